@@ -0,0 +1,486 @@
+// Package audit maintains the local append-only audit log of actions performed
+// on behalf of remote parties. Every entry carries the hash of its previous
+// entry, so any modification, removal or reordering of entries breaks the
+// chain and is detected by Verify. The chain continues across rotated files,
+// and the link to entries removed by rotation is kept in the anchor file.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/common/filelock"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+const (
+	AuditLogFilename = "audit.log"
+
+	// Hash of the virtual entry before the first entry
+	GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	lockTimeout = 5 * time.Second
+	// Maximum size of a single entry when looking for the last entry
+	maxEntrySize = 1024 * 1024
+	// Audit log is rotated into <path>.1, <path>.2, ... when exceeding the
+	// size, and the oldest rotated file is removed beyond the count
+	maxAuditLogSize     = 10 * 1024 * 1024
+	maxRotatedAuditLogs = 9
+	auditLockFileSuffix = ".lock"
+	// Anchor file records where the chain continues after the oldest rotated
+	// file is removed
+	auditAnchorFileSuffix = ".anchor"
+)
+
+// Actions recorded in audit log
+const (
+	ActionRunTask          = "run_task"
+	ActionStopTask         = "stop_task"
+	ActionSendFile         = "send_file"
+	ActionSessionStart     = "session_start"
+	ActionSessionEnd       = "session_end"
+	ActionPortForwardStart = "port_forward_start"
+	ActionPortForwardEnd   = "port_forward_end"
+	ActionKickVm           = "kick_vm"
+	ActionPluginExecute    = "plugin_execute"
+	ActionIpcDenied        = "ipc_denied"
+	ActionSetLogLevel      = "set_log_level"
+	// Task refused before or while running, e.g., due to invalid content,
+	// signature or execution policy
+	ActionTaskRejected = "task_rejected"
+)
+
+// Triggers describing who or what requests the action
+const (
+	TriggerServer = "server"
+	TriggerKick   = "kick"
+	TriggerLocal  = "local"
+)
+
+var (
+	ErrChainBroken = errors.New("audit log hash chain is broken")
+
+	// Replaced in tests
+	getAuditLogPath            = DefaultAuditLogPath
+	auditLogRotateSize   int64 = maxAuditLogSize
+	auditLogRotatedCount       = maxRotatedAuditLogs
+)
+
+// Event describes an action to be recorded
+type Event struct {
+	Action  string
+	Trigger string
+	// Identifier of the object acted on, e.g., task id, session id or plugin
+	// name
+	Subject string
+	Details map[string]string
+	// Digests of content involved in action, see Digest()
+	Digests map[string]string
+}
+
+// Entry is one line of audit log in JSON format
+type Entry struct {
+	Seq      uint64            `json:"seq"`
+	Time     string            `json:"time"`
+	Pid      int               `json:"pid"`
+	Action   string            `json:"action"`
+	Trigger  string            `json:"trigger"`
+	Subject  string            `json:"subject"`
+	Details  map[string]string `json:"details,omitempty"`
+	Digests  map[string]string `json:"digests,omitempty"`
+	PrevHash string            `json:"prevHash"`
+	Hash     string            `json:"hash"`
+}
+
+// Anchor is the expected sequence number and previous hash of the oldest
+// retained entry, i.e., the link to the last entry removed by rotation
+type Anchor struct {
+	Seq      uint64 `json:"seq"`
+	PrevHash string `json:"prevHash"`
+}
+
+// DefaultAuditLogPath returns path of audit log shared across versions
+func DefaultAuditLogPath() (string, error) {
+	auditDir, err := pathutil.GetCrossVersionAuditPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(auditDir, AuditLogFilename), nil
+}
+
+// Digest returns SHA-256 digest of content in "sha256:<hex>" format
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// computeHash returns hash of entry, which covers all fields except the hash
+// itself. encoding/json emits struct fields in declaration order and map keys
+// in sorted order, thus the serialization is stable.
+func computeHash(entry Entry) (string, error) {
+	entry.Hash = ""
+	serialized, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(serialized)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Record appends event to audit log. Failure of auditing is logged but never
+// interrupts the action being audited.
+func Record(event Event) {
+	if err := record(event); err != nil {
+		log.GetLogger().WithError(err).WithFields(logrus.Fields{
+			"action":  event.Action,
+			"subject": event.Subject,
+		}).Errorln("Failed to record audit log")
+	}
+}
+
+func record(event Event) error {
+	auditLogPath, err := getAuditLogPath()
+	if err != nil {
+		return err
+	}
+	return appendEntry(auditLogPath, event, time.Now())
+}
+
+func appendEntry(auditLogPath string, event Event, now time.Time) error {
+	// Audit log is shared with other processes like acs-plugin-manager. The
+	// lock is held on a separate file since audit log itself is renamed when
+	// rotated.
+	lockFile, err := os.OpenFile(auditLogPath+auditLockFileSuffix, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer lockFile.Close()
+	if err := filelock.Lock(lockFile, lockTimeout); err != nil {
+		return err
+	}
+	defer filelock.Unlock(lockFile)
+
+	if err := rotateIfNeeded(auditLogPath); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(auditLogPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entry := Entry{
+		Seq:      1,
+		Time:     now.Format(time.RFC3339Nano),
+		Pid:      os.Getpid(),
+		Action:   event.Action,
+		Trigger:  event.Trigger,
+		Subject:  event.Subject,
+		Details:  event.Details,
+		Digests:  event.Digests,
+		PrevHash: GenesisHash,
+	}
+	lastLine, err := readLastLine(f)
+	if err != nil {
+		return err
+	}
+	// The first entry of new audit log after rotation continues the chain
+	// from the last entry of rotated one
+	if len(lastLine) == 0 {
+		if lastLine, err = readLastLineOfFile(rotatedPath(auditLogPath, 1)); err != nil {
+			return err
+		}
+	}
+	if len(lastLine) > 0 {
+		var last Entry
+		if err := json.Unmarshal(lastLine, &last); err != nil {
+			return fmt.Errorf("%w: invalid last entry: %v", ErrChainBroken, err)
+		}
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+	}
+	if entry.Hash, err = computeHash(entry); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// rotateIfNeeded renames audit log exceeding the rotation size to <path>.1,
+// after shifting existing rotated files and removing the oldest one. Caller
+// must hold the lock of audit log.
+func rotateIfNeeded(auditLogPath string) error {
+	fileInfo, err := os.Stat(auditLogPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if fileInfo.Size() < auditLogRotateSize {
+		return nil
+	}
+
+	if err := removeOldest(auditLogPath); err != nil {
+		return err
+	}
+	for i := auditLogRotatedCount - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedPath(auditLogPath, i), rotatedPath(auditLogPath, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(auditLogPath, rotatedPath(auditLogPath, 1))
+}
+
+// removeOldest removes the oldest rotated file after anchoring the chain at
+// its last entry, so that verification could tell removal by rotation from
+// removal of other entries. Caller must hold the lock of audit log.
+func removeOldest(auditLogPath string) error {
+	oldestPath := rotatedPath(auditLogPath, auditLogRotatedCount)
+	lastLine, err := readLastLineOfFile(oldestPath)
+	if err != nil {
+		return err
+	}
+	if len(lastLine) > 0 {
+		var last Entry
+		if err := json.Unmarshal(lastLine, &last); err != nil {
+			return fmt.Errorf("%w: invalid last entry of %s: %v", ErrChainBroken, oldestPath, err)
+		}
+		if err := writeAnchor(auditLogPath, Anchor{Seq: last.Seq + 1, PrevHash: last.Hash}); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(oldestPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// writeAnchor replaces anchor file atomically
+func writeAnchor(auditLogPath string, anchor Anchor) error {
+	content, err := json.Marshal(anchor)
+	if err != nil {
+		return err
+	}
+	anchorPath := auditLogPath + auditAnchorFileSuffix
+	tempPath := anchorPath + ".tmp"
+	if err := os.WriteFile(tempPath, content, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, anchorPath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// readAnchor returns nil when no file has been removed by rotation. Anchor
+// file which could be modified by non-root users is refused, since it decides
+// where the chain may start.
+func readAnchor(auditLogPath string) (*Anchor, error) {
+	anchorPath := auditLogPath + auditAnchorFileSuffix
+	if _, err := os.Stat(anchorPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := pathutil.CheckFileProtected(anchorPath); err != nil {
+		return nil, fmt.Errorf("%w: refuse anchor file: %v", ErrChainBroken, err)
+	}
+	content, err := os.ReadFile(anchorPath)
+	if err != nil {
+		return nil, err
+	}
+	anchor := &Anchor{}
+	if err := json.Unmarshal(content, anchor); err != nil {
+		return nil, fmt.Errorf("%w: invalid anchor file: %v", ErrChainBroken, err)
+	}
+	return anchor, nil
+}
+
+func rotatedPath(auditLogPath string, index int) string {
+	return fmt.Sprintf("%s.%d", auditLogPath, index)
+}
+
+// readLastLineOfFile is like readLastLine, and returns nil if the file does not
+// exist
+func readLastLineOfFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return readLastLine(f)
+}
+
+// readLastLine returns the last non-empty line of file without trailing
+// newline, or nil for empty file.
+func readLastLine(f *os.File) ([]byte, error) {
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := fileInfo.Size()
+	if size == 0 {
+		return nil, nil
+	}
+
+	readSize := int64(maxEntrySize)
+	if readSize > size {
+		readSize = size
+	}
+	buffer := make([]byte, readSize)
+	if _, err := f.ReadAt(buffer, size-readSize); err != nil && err != io.EOF {
+		return nil, err
+	}
+	buffer = bytes.TrimRight(buffer, "\n")
+	if index := bytes.LastIndexByte(buffer, '\n'); index >= 0 {
+		return buffer[index+1:], nil
+	}
+	if readSize < size {
+		return nil, fmt.Errorf("%w: last entry exceeds %d bytes", ErrChainBroken, maxEntrySize)
+	}
+	return buffer, nil
+}
+
+// Files returns paths of existing audit log files from the oldest rotated one
+// to the current one, i.e., in the order of the chain
+func Files(auditLogPath string) ([]string, error) {
+	var files []string
+	for i := auditLogRotatedCount; i >= 1; i-- {
+		path := rotatedPath(auditLogPath, i)
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		files = append(files, path)
+	}
+	if _, err := os.Stat(auditLogPath); err != nil {
+		if os.IsNotExist(err) && len(files) > 0 {
+			return files, nil
+		}
+		return nil, err
+	}
+	return append(files, auditLogPath), nil
+}
+
+// chainVerifier checks sequence numbers and hash chain of entries one by one
+type chainVerifier struct {
+	verified int
+	prevHash string
+	prevSeq  uint64
+	// Anchor recorded when rotation removed the oldest entries, or nil
+	anchor *Anchor
+}
+
+func (v *chainVerifier) verify(entry Entry) error {
+	// The chain starts from the genesis, or from the anchor when the oldest
+	// entries have been removed by rotation. Any other start means entries
+	// have been removed otherwise. The genesis is still accepted with anchor
+	// present, in case removal of rotated file was interrupted.
+	if v.verified == 0 && entry.PrevHash != GenesisHash {
+		if v.anchor == nil || entry.Seq != v.anchor.Seq || entry.PrevHash != v.anchor.PrevHash {
+			return fmt.Errorf("%w: entries before entry %d have been removed", ErrChainBroken, entry.Seq)
+		}
+		v.prevHash = entry.PrevHash
+		v.prevSeq = entry.Seq - 1
+	}
+	if entry.Seq != v.prevSeq+1 {
+		return fmt.Errorf("%w: entry %d has sequence number %d, expected %d", ErrChainBroken, v.verified+1, entry.Seq, v.prevSeq+1)
+	}
+	if entry.PrevHash != v.prevHash {
+		return fmt.Errorf("%w: entry %d does not link to previous entry", ErrChainBroken, entry.Seq)
+	}
+	hash, err := computeHash(entry)
+	if err != nil {
+		return err
+	}
+	if hash != entry.Hash {
+		return fmt.Errorf("%w: entry %d has been modified", ErrChainBroken, entry.Seq)
+	}
+	v.prevHash = entry.Hash
+	v.prevSeq = entry.Seq
+	v.verified++
+	return nil
+}
+
+// Verify streams entries in audit log and its rotated files from the oldest,
+// verifies the chain, and passes each verified entry to onEntry, which may be
+// nil. The number of entries verified before the first broken one is
+// returned. When the oldest entries have been removed by rotation, the chain
+// is verified from the anchor recorded by rotation.
+func Verify(auditLogPath string, onEntry func(Entry) error) (int, error) {
+	files, err := Files(auditLogPath)
+	if err != nil {
+		return 0, err
+	}
+	anchor, err := readAnchor(auditLogPath)
+	if err != nil {
+		return 0, err
+	}
+
+	verifier := &chainVerifier{prevHash: GenesisHash, anchor: anchor}
+	for _, path := range files {
+		if err := verifyFile(path, verifier, onEntry); err != nil {
+			return verifier.verified, err
+		}
+	}
+	return verifier.verified, nil
+}
+
+func verifyFile(path string, verifier *chainVerifier, onEntry func(Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxEntrySize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("%w: invalid entry at line %d of %s: %v", ErrChainBroken, lineNumber, path, err)
+		}
+		if err := verifier.verify(entry); err != nil {
+			return err
+		}
+		if onEntry != nil {
+			if err := onEntry(entry); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: failed to read %s: %v", ErrChainBroken, path, err)
+	}
+	return nil
+}
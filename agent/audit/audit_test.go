@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readVerified(auditLogPath string) ([]Entry, error) {
+	var entries []Entry
+	verified, err := Verify(auditLogPath, func(entry Entry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if verified != len(entries) {
+		return nil, errors.New("number of verified entries mismatches")
+	}
+	return entries, err
+}
+
+func TestAppendAndVerify(t *testing.T) {
+	auditLogPath := filepath.Join(t.TempDir(), AuditLogFilename)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []Event{
+		{Action: ActionRunTask, Trigger: TriggerServer, Subject: "t-1", Digests: map[string]string{"content": Digest([]byte("echo 1"))}},
+		{Action: ActionSendFile, Trigger: TriggerServer, Subject: "t-2", Details: map[string]string{"filePath": "/root/a.txt"}},
+		{Action: ActionKickVm, Trigger: TriggerKick, Subject: "agent stop"},
+	}
+	for _, event := range events {
+		assert.NoError(t, appendEntry(auditLogPath, event, now))
+	}
+
+	entries, err := readVerified(auditLogPath)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, uint64(1), entries[0].Seq)
+		assert.Equal(t, GenesisHash, entries[0].PrevHash)
+		assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+		assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+		assert.Equal(t, Digest([]byte("echo 1")), entries[0].Digests["content"])
+		assert.Equal(t, "/root/a.txt", entries[1].Details["filePath"])
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	auditLogPath := filepath.Join(t.TempDir(), AuditLogFilename)
+	now := time.Now()
+	for _, subject := range []string{"t-1", "t-2", "t-3"} {
+		assert.NoError(t, appendEntry(auditLogPath, Event{Action: ActionRunTask, Trigger: TriggerServer, Subject: subject}, now))
+	}
+	content, err := os.ReadFile(auditLogPath)
+	assert.NoError(t, err)
+	lines := strings.SplitAfter(string(content), "\n")
+
+	// Modified entry
+	modified := strings.Replace(string(content), `"subject":"t-2"`, `"subject":"t-9"`, 1)
+	assert.NoError(t, os.WriteFile(auditLogPath, []byte(modified), 0600))
+	entries, err := readVerified(auditLogPath)
+	assert.True(t, errors.Is(err, ErrChainBroken))
+	assert.Len(t, entries, 1)
+
+	// Removed entry
+	assert.NoError(t, os.WriteFile(auditLogPath, []byte(lines[0]+lines[2]), 0600))
+	entries, err = readVerified(auditLogPath)
+	assert.True(t, errors.Is(err, ErrChainBroken))
+	assert.Len(t, entries, 1)
+
+	// Truncated tail could not be detected by chain itself, but appending
+	// continues the chain from the remaining last entry
+	assert.NoError(t, os.WriteFile(auditLogPath, []byte(lines[0]+lines[1]), 0600))
+	assert.NoError(t, appendEntry(auditLogPath, Event{Action: ActionStopTask, Trigger: TriggerServer, Subject: "t-1"}, now))
+	entries, err = readVerified(auditLogPath)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, uint64(3), entries[2].Seq)
+	}
+}
+
+func TestRotateAndVerify(t *testing.T) {
+	auditLogPath := filepath.Join(t.TempDir(), AuditLogFilename)
+	originalRotateSize, originalRotatedCount := auditLogRotateSize, auditLogRotatedCount
+	auditLogRotateSize, auditLogRotatedCount = 1, 2
+	defer func() {
+		auditLogRotateSize, auditLogRotatedCount = originalRotateSize, originalRotatedCount
+	}()
+
+	// Every entry is rotated into its own file, and only the latest three
+	// files are retained
+	now := time.Now()
+	for _, subject := range []string{"t-1", "t-2", "t-3", "t-4"} {
+		assert.NoError(t, appendEntry(auditLogPath, Event{Action: ActionRunTask, Trigger: TriggerServer, Subject: subject}, now))
+	}
+	files, err := Files(auditLogPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{auditLogPath + ".2", auditLogPath + ".1", auditLogPath}, files)
+
+	// Chain continues across rotated files, and is anchored where the oldest
+	// rotated file was removed
+	entries, err := readVerified(auditLogPath)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "t-2", entries[0].Subject)
+		assert.Equal(t, uint64(2), entries[0].Seq)
+		assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+		assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+	}
+
+	// Removing a rotated file in the middle breaks the chain
+	assert.NoError(t, os.Remove(auditLogPath+".1"))
+	entries, err = readVerified(auditLogPath)
+	assert.True(t, errors.Is(err, ErrChainBroken))
+	assert.Len(t, entries, 1)
+}
+
+func TestVerifyRemovedOldest(t *testing.T) {
+	auditLogPath := filepath.Join(t.TempDir(), AuditLogFilename)
+	originalRotateSize, originalRotatedCount := auditLogRotateSize, auditLogRotatedCount
+	auditLogRotateSize, auditLogRotatedCount = 1, 2
+	defer func() {
+		auditLogRotateSize, auditLogRotatedCount = originalRotateSize, originalRotatedCount
+	}()
+
+	now := time.Now()
+	for _, subject := range []string{"t-1", "t-2", "t-3"} {
+		assert.NoError(t, appendEntry(auditLogPath, Event{Action: ActionRunTask, Trigger: TriggerServer, Subject: subject}, now))
+	}
+	// No file has been removed by rotation yet, so removing the oldest one is
+	// detected without anchor
+	_, err := os.Stat(auditLogPath + auditAnchorFileSuffix)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, os.Remove(auditLogPath+".2"))
+	entries, err := readVerified(auditLogPath)
+	assert.True(t, errors.Is(err, ErrChainBroken))
+	assert.Len(t, entries, 0)
+
+	// Removing the oldest retained file beyond the anchor is detected too
+	assert.NoError(t, appendEntry(auditLogPath, Event{Action: ActionRunTask, Trigger: TriggerServer, Subject: "t-4"}, now))
+	assert.NoError(t, appendEntry(auditLogPath, Event{Action: ActionRunTask, Trigger: TriggerServer, Subject: "t-5"}, now))
+	_, err = readVerified(auditLogPath)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Remove(auditLogPath+".2"))
+	entries, err = readVerified(auditLogPath)
+	assert.True(t, errors.Is(err, ErrChainBroken))
+	assert.Len(t, entries, 0)
+
+	// Anchor file modifiable by non-root users is refused
+	if runtime.GOOS != "windows" {
+		assert.NoError(t, os.Chmod(auditLogPath+auditAnchorFileSuffix, 0666))
+		_, err = readVerified(auditLogPath)
+		assert.True(t, errors.Is(err, ErrChainBroken))
+	}
+}
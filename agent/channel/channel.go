@@ -8,6 +8,7 @@ const (
 	ChannelNone          = 0
 	ChannelGshellType    = 1
 	ChannelWebsocketType = 2
+	ChannelLongPollType  = 3
 )
 
 type OnReceiveMsg func(Msg string, ChannelType int) string
@@ -46,6 +47,8 @@ func ChannelTypeStr(channelType int) string {
 		return "gshell"
 	case ChannelWebsocketType:
 		return "websocket"
+	case ChannelLongPollType:
+		return "longpoll"
 	default:
 		return "unknown"
 	}
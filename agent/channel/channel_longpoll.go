@@ -0,0 +1,262 @@
+package channel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/clientreport"
+	"github.com/aliyun/aliyun_assist_client/agent/flagging"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/metrics"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+)
+
+var (
+	// Seconds the server holds a poll request when no message is pending
+	longPollTimeout = 60
+	// Extra seconds waited for the response beyond longPollTimeout
+	longPollResponseMargin = 15
+	// Result of support probe is reused within the interval, since
+	// IsSupported is frequently called when selecting channels
+	longPollProbeInterval = 10 * time.Minute
+	longPollRetryInterval = 5 * time.Second
+
+	// detectLongPollChannelEnabled is replaced in tests
+	detectLongPollChannelEnabled = flagging.DetectLongPollChannelEnabled
+)
+
+type longPollMessage struct {
+	Id      string `json:"id"`
+	Content string `json:"content"`
+}
+
+type longPollResponse struct {
+	Cursor   string            `json:"cursor"`
+	Messages []longPollMessage `json:"messages"`
+}
+
+type longPollReply struct {
+	Id    string `json:"id"`
+	Reply string `json:"reply"`
+}
+
+// LongPollChannel receives kick messages by HTTP long-polling against the API
+// server, which works when websocket upgrade is blocked by middleboxes. The
+// notify endpoints polled are not provided by API servers generally, thus the
+// channel is never selected unless enabled by flag file.
+type LongPollChannel struct {
+	*Channel
+	lock sync.Mutex
+	// generation is increased on every start and stop, thus the polling loop
+	// of a stopped channel exits even if it is restarted immediately
+	generation      uint64
+	cursor          string
+	supported       bool
+	probeValidUntil time.Time
+}
+
+func (c *LongPollChannel) IsSupported() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if time.Now().Before(c.probeValidUntil) {
+		return c.supported
+	}
+
+	enabled, err := detectLongPollChannelEnabled()
+	if err != nil {
+		log.GetLogger().WithError(err).Errorln("Failed to detect longpoll channel flag")
+	}
+	c.supported = enabled && c.probe()
+	c.probeValidUntil = time.Now().Add(longPollProbeInterval)
+	return c.supported
+}
+
+// probe sends a poll request returning immediately to check whether long-poll
+// endpoint is served by the API server and reachable
+func (c *LongPollChannel) probe() bool {
+	host := util.GetServerHost()
+	if host == "" {
+		metrics.GetChannelFailEvent(
+			metrics.EVENT_SUBCATEGORY_CHANNEL_LONGPOLL,
+			"errormsg", "longpoll channel not supported",
+			"type", ChannelTypeStr(c.ChannelType),
+		).ReportEvent()
+		log.GetLogger().Error("longpoll channel not supported")
+		return false
+	}
+	if err, _ := util.HttpGetWithTimeout(longPollUrl(0, ""), 10, true); err != nil {
+		metrics.GetChannelFailEvent(
+			metrics.EVENT_SUBCATEGORY_CHANNEL_LONGPOLL,
+			"errormsg", fmt.Sprintf("longpoll channel probe error:%s", err.Error()),
+			"type", ChannelTypeStr(c.ChannelType),
+		).ReportEvent()
+		log.GetLogger().WithError(err).Error("longpoll channel not supported")
+		return false
+	}
+	return true
+}
+
+func (c *LongPollChannel) StartChannel() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.Working.IsSet() {
+		return nil
+	}
+	if util.GetServerHost() == "" {
+		metrics.GetChannelFailEvent(
+			metrics.EVENT_SUBCATEGORY_CHANNEL_LONGPOLL,
+			"errmsg", "No available host",
+			"type", ChannelTypeStr(c.ChannelType),
+		).ReportEvent()
+		return errors.New("No available host")
+	}
+
+	c.generation++
+	generation := c.generation
+	c.Working.Set()
+	log.GetLogger().Infoln("Start longpoll channel ok! url:", util.GetLongPollService())
+	go c.pollLoop(generation)
+	return nil
+}
+
+func (c *LongPollChannel) StopChannel() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.Working.IsSet() {
+		c.generation++
+		c.Working.Clear()
+		log.GetLogger().Println("close longpoll channel")
+	}
+	return nil
+}
+
+func (c *LongPollChannel) isCurrent(generation uint64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Working.IsSet() && c.generation == generation
+}
+
+func (c *LongPollChannel) pollLoop(generation uint64) {
+	logger := log.GetLogger().WithField("channel", "longpoll")
+	defer func() {
+		if msg := recover(); msg != nil {
+			logger.Errorf("LongPollChannel run panic: %v", msg)
+			logger.Errorf("%s: %s", msg, debug.Stack())
+		}
+	}()
+
+	retryCount := 0
+	for c.isCurrent(generation) {
+		response, err := c.poll()
+		// Cursor is not advanced for messages polled by a stopped channel,
+		// thus they are delivered again when the channel is started later
+		if !c.isCurrent(generation) {
+			break
+		}
+		if err != nil {
+			retryCount++
+			if retryCount >= MAX_RETRY_COUNT {
+				c.lock.Lock()
+				if c.generation == generation {
+					c.generation++
+					c.Working.Clear()
+				}
+				c.lock.Unlock()
+				logger.Errorf("Reach the retry limit for polling messages. Error: %v", err.Error())
+				report := clientreport.ClientReport{
+					ReportType: "switch_channel_in_longpoll",
+					Info:       fmt.Sprintf("start:" + err.Error()),
+				}
+				clientreport.SendReport(report)
+				go switchChannel("switch_channel_in_longpoll")
+				break
+			}
+			logger.Errorf("An error happened when polling messages. Retried times: %d, Error: %s", retryCount, err.Error())
+			time.Sleep(longPollRetryInterval)
+			continue
+		}
+		retryCount = 0
+
+		if response == nil {
+			continue
+		}
+		for _, message := range response.Messages {
+			logger.Infof("longpoll recv: %s", message.Content)
+			content := c.CallBack(message.Content, ChannelLongPollType)
+			if content != "" {
+				if err := c.reply(message.Id, content); err != nil {
+					metrics.GetChannelFailEvent(
+						metrics.EVENT_SUBCATEGORY_CHANNEL_LONGPOLL,
+						"errormsg", fmt.Sprintf("longpoll reply err:%s, content=%s", err.Error(), content),
+						"type", ChannelTypeStr(c.ChannelType),
+					).ReportEvent()
+				}
+			}
+		}
+		if response.Cursor != "" {
+			c.lock.Lock()
+			c.cursor = response.Cursor
+			c.lock.Unlock()
+		}
+	}
+	logger.Infoln("longpoll channel is closed")
+}
+
+// poll waits for messages pending after the cursor. Nil response is returned
+// when no message is pending until timeout
+func (c *LongPollChannel) poll() (*longPollResponse, error) {
+	c.lock.Lock()
+	cursor := c.cursor
+	c.lock.Unlock()
+	// Timeout of HttpGetWithTimeout is in seconds
+	timeout := time.Duration(longPollTimeout + longPollResponseMargin)
+	err, content := util.HttpGetWithTimeout(longPollUrl(longPollTimeout, cursor), timeout, true)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" {
+		return nil, nil
+	}
+	var response longPollResponse
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		return nil, fmt.Errorf("invalid longpoll response: %w", err)
+	}
+	return &response, nil
+}
+
+func (c *LongPollChannel) reply(id string, content string) error {
+	data, err := json.Marshal(longPollReply{
+		Id:    id,
+		Reply: content,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = util.HttpPost(util.GetLongPollReplyService(), string(data), "")
+	return err
+}
+
+func longPollUrl(timeout int, cursor string) string {
+	query := url.Values{}
+	query.Set("timeout", fmt.Sprint(timeout))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	return util.GetLongPollService() + "?" + query.Encode()
+}
+
+func NewLongPollChannel(CallBack OnReceiveMsg) IChannel {
+	l := &LongPollChannel{
+		Channel: &Channel{
+			CallBack:    CallBack,
+			ChannelType: ChannelLongPollType,
+		},
+	}
+	l.Working.Clear()
+	return l
+}
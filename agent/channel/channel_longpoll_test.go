@@ -0,0 +1,143 @@
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/flagging"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/internal/testutil"
+)
+
+func TestLongPollChannel(t *testing.T) {
+	httpmock.Activate()
+	util.NilRequest.Set()
+	defer util.NilRequest.Clear()
+	defer httpmock.DeactivateAndReset()
+	const mockRegion = "cn-test100"
+	testutil.MockMetaServer(mockRegion)
+	guard := monkey.Patch(util.GetServerHost, func() string {
+		return mockRegion + ".axt.aliyun.com"
+	})
+	defer guard.Unpatch()
+
+	var lock sync.Mutex
+	var cursors []string
+	replies := map[string]string{}
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://%s.axt.aliyun.com/luban/api/v1/notify/poll", mockRegion),
+		func(h *http.Request) (*http.Response, error) {
+			cursor := h.URL.Query().Get("cursor")
+			lock.Lock()
+			cursors = append(cursors, cursor)
+			lock.Unlock()
+			if h.URL.Query().Get("timeout") == "0" || cursor != "" {
+				time.Sleep(10 * time.Millisecond)
+				return httpmock.NewStringResponse(200, ""), nil
+			}
+			response, _ := json.Marshal(&longPollResponse{
+				Cursor: "c1",
+				Messages: []longPollMessage{
+					{Id: "m1", Content: "kick_vm"},
+				},
+			})
+			return httpmock.NewStringResponse(200, string(response)), nil
+		})
+	httpmock.RegisterResponder("POST",
+		fmt.Sprintf("https://%s.axt.aliyun.com/luban/api/v1/notify/reply", mockRegion),
+		func(h *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(h.Body)
+			var reply longPollReply
+			json.Unmarshal(body, &reply)
+			lock.Lock()
+			replies[reply.Id] = reply.Reply
+			lock.Unlock()
+			return httpmock.NewStringResponse(200, "success"), nil
+		})
+	httpmock.RegisterResponder("POST",
+		fmt.Sprintf("https://%s.axt.aliyun.com/luban/api/metrics", mockRegion),
+		func(h *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, "success"), nil
+		})
+
+	detectLongPollChannelEnabled = func() (bool, error) { return true, nil }
+	defer func() { detectLongPollChannelEnabled = flagging.DetectLongPollChannelEnabled }()
+
+	received := make(chan string, 1)
+	channel := NewLongPollChannel(func(msg string, channelType int) string {
+		assert.Equal(t, ChannelLongPollType, channelType)
+		received <- msg
+		return "accept:" + msg
+	})
+	assert.True(t, channel.IsSupported())
+	assert.NoError(t, channel.StartChannel())
+	assert.True(t, channel.IsWorking())
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "kick_vm", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, channel.StopChannel())
+	assert.False(t, channel.IsWorking())
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, "accept:kick_vm", replies["m1"])
+	assert.Contains(t, cursors, "c1")
+}
+
+func TestLongPollChannelDisabledByDefault(t *testing.T) {
+	detectLongPollChannelEnabled = func() (bool, error) { return false, nil }
+	defer func() { detectLongPollChannelEnabled = flagging.DetectLongPollChannelEnabled }()
+	guard := monkey.Patch(util.GetServerHost, func() string {
+		t.Fatal("disabled longpoll channel should not probe the server")
+		return ""
+	})
+	defer guard.Unpatch()
+
+	channel := NewLongPollChannel(func(msg string, channelType int) string { return "" })
+	assert.False(t, channel.IsSupported())
+}
+
+func TestLongPollTimeout(t *testing.T) {
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-stalled
+	}))
+	defer server.Close()
+	defer close(stalled)
+	guard := monkey.Patch(util.GetLongPollService, func() string {
+		return server.URL
+	})
+	defer guard.Unpatch()
+	originalTimeout, originalMargin := longPollTimeout, longPollResponseMargin
+	longPollTimeout, longPollResponseMargin = 0, 1
+	defer func() { longPollTimeout, longPollResponseMargin = originalTimeout, originalMargin }()
+
+	// Hung poll must fail in time, thus failover could be triggered
+	channel := NewLongPollChannel(func(msg string, channelType int) string { return "" }).(*LongPollChannel)
+	done := make(chan error, 1)
+	go func() {
+		_, err := channel.poll()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("stalled poll never timed out")
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/tidwall/gjson"
 
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
 	"github.com/aliyun/aliyun_assist_client/agent/clientreport"
 	"github.com/aliyun/aliyun_assist_client/agent/hybrid"
 	"github.com/aliyun/aliyun_assist_client/agent/kickvmhandle"
@@ -17,16 +18,20 @@ import (
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine"
 	"github.com/aliyun/aliyun_assist_client/agent/update"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
 	"github.com/aliyun/aliyun_assist_client/agent/util/powerutil"
 	"github.com/aliyun/aliyun_assist_client/common/apiserver"
 )
 
 var _gshellChannel IChannel = nil
 
+// unRegisterAgent is replaced in tests
+var unRegisterAgent = hybrid.UnRegister
+
 //manage all channels
 type ChannelMgr struct {
 	ActiveChannel   IChannel   //current used channel
-	AllChannel      []IChannel //the first one is GshellChannel
+	AllChannel      []IChannel //GshellChannel, WebsocketChannel and LongPollChannel in order
 	StopChanelEvent chan struct{}
 	WaitCheckDone   sync.WaitGroup
 	ChannelSetLock  sync.Mutex
@@ -43,12 +48,24 @@ func (m *ChannelMgr) checkChannelWorker() bool {
 	m.ChannelSetLock.Lock()
 	defer m.ChannelSetLock.Unlock()
 	if m.AllChannel[0].IsSupported() && m.AllChannel[0].IsWorking() {
-		if m.AllChannel[1].IsWorking() {
-			m.AllChannel[1].StopChannel()
+		for _, item := range m.AllChannel[1:] {
+			if item.IsWorking() {
+				item.StopChannel()
+			}
 		}
 		m.ActiveChannel = m.AllChannel[0]
 		return true
 	}
+	// Long-poll channel is only a fallback, thus try to switch back to
+	// websocket channel
+	if m.ActiveChannel != nil && m.ActiveChannel.GetChannelType() == ChannelLongPollType {
+		wsChannel := m.AllChannel[1]
+		if wsChannel.IsSupported() && wsChannel.StartChannel() == nil {
+			m.ActiveChannel.StopChannel()
+			m.ActiveChannel = wsChannel
+			return true
+		}
+	}
 	return false
 }
 
@@ -116,7 +133,7 @@ func (m *ChannelMgr) Init(CallBack OnReceiveMsg) error {
 	}()
 	m.ChannelSetLock.Lock()
 	defer m.ChannelSetLock.Unlock()
-	m.AllChannel = append(m.AllChannel, _gshellChannel, NewWebsocketChannel(CallBack))
+	m.AllChannel = append(m.AllChannel, _gshellChannel, NewWebsocketChannel(CallBack), NewLongPollChannel(CallBack))
 	for _, item := range m.AllChannel {
 		if item.IsSupported() {
 			if e := item.StartChannel(); e == nil {
@@ -147,11 +164,25 @@ func (m *ChannelMgr) GetCurrentChannelType() int {
 	return m.ActiveChannel.GetChannelType()
 }
 
+// GetCurrentChannelState returns type of active channel and whether it is
+// still working
+func (m *ChannelMgr) GetCurrentChannelState() (channelType int, working bool) {
+	m.ChannelSetLock.Lock()
+	defer m.ChannelSetLock.Unlock()
+	if m.ActiveChannel == nil {
+		return ChannelNone, false
+	}
+	return m.ActiveChannel.GetChannelType(), m.ActiveChannel.IsWorking()
+}
+
 func InitChannelMgr(CallBack OnReceiveMsg) error {
+	util.SetAPIForwarder(channelAPIForwarder{})
 	return G_ChannelMgr.Init(CallBack)
 }
 
 func StopChannelMgr() error {
+	stopNetworkWatcher()
+	util.SetAPIForwarder(nil)
 	G_ChannelMgr.Uninit()
 	return nil
 }
@@ -160,6 +191,10 @@ func GetCurrentChannelType() int {
 	return G_ChannelMgr.GetCurrentChannelType()
 }
 
+func GetCurrentChannelState() (channelType int, working bool) {
+	return G_ChannelMgr.GetCurrentChannelState()
+}
+
 func TryStartGshellChannel() {
 	_gshellChannel = NewGshellChannel(OnRecvMsg)
 	if apiserver.IsHybrid() == false {
@@ -180,6 +215,7 @@ func StartChannelMgr() {
 			"errormsg", err.Error(),
 		).ReportEvent()
 	}
+	startNetworkWatcher()
 }
 
 type GshellInvalid struct {
@@ -231,30 +267,75 @@ func BuildInvalidRet(desc string) string {
 	return string(retStr)
 }
 
+func buildRateLimitedGshellRet() string {
+	gshellCmdReply := GshellCmdReply{}
+	gshellCmdReply.Return.Result = 7
+	gshellCmdReply.Return.CmdOutput = errKickRateLimited.Error()
+	retStr, _ := json.Marshal(gshellCmdReply)
+	return string(retStr)
+}
+
+func fetchTasksOnKick() error {
+	taskengine.Fetch(true, "", taskengine.NormalTaskType)
+	return nil
+}
+
+// recordKickAudit records kick_vm commands performing actions other than
+// fetching tasks, which are recorded when tasks are run.
+func recordKickAudit(kickCmd string, channelType int) {
+	fields := strings.Fields(kickCmd)
+	subject := strings.Join(fields[1:], " ")
+	if len(fields) > 3 {
+		subject = strings.Join(fields[1:3], " ")
+	}
+	audit.Record(audit.Event{
+		Action:  audit.ActionKickVm,
+		Trigger: audit.TriggerKick,
+		Subject: subject,
+		Details: map[string]string{
+			"channel": ChannelTypeStr(channelType),
+			"command": kickCmd,
+		},
+	})
+}
+
 func OnRecvMsg(Msg string, ChannelType int) string {
 	log.GetLogger().Infoln("kick msg:", Msg)
+	Msg, err := verifyKickMessage(Msg, ChannelType)
+	if err != nil {
+		return buildUnauthenticatedRet(err, ChannelType)
+	}
 
-	// legacy code for websocket kick data proc.
-	if ChannelType == ChannelWebsocketType {
+	// legacy code for websocket kick data proc, which is shared by long-poll
+	// channel.
+	if ChannelType == ChannelWebsocketType || ChannelType == ChannelLongPollType {
+		if isKickRequest(Msg) {
+			return handleKickRequest(Msg, ChannelType).String()
+		}
 		if update.IsCriticalActionRunning() {
 			return "reject:" + Msg
 		}
 		if Msg == "kick_vm" {
-			go func() {
-				taskengine.Fetch(true, "", taskengine.NormalTaskType)			}()
+			if dispatchKick(Msg, fetchTasksOnKick, nil) == kickRateLimited {
+				return "reject:" + Msg
+			}
 			return "accept:" + Msg
-		} else if strings.Contains(Msg, "kick_vm agent deregister") {
-			hybrid.UnRegister(true)
+		} else if ChannelType == ChannelWebsocketType && strings.Contains(Msg, "kick_vm agent deregister") {
+			// Deregistration is only accepted from websocket channel, and is
+			// done before replying
+			recordKickAudit(Msg, ChannelType)
+			unRegisterAgent(true)
 		}
 
 		handle := kickvmhandle.ParseOption(Msg)
 		valid_cmd := false
 		if handle != nil {
 			if handle.CheckAction() == true {
+				if dispatchKick(Msg, handle.DoAction, nil) == kickRateLimited {
+					return "reject:" + Msg
+				}
 				valid_cmd = true
-				go func() {
-					handle.DoAction()
-				}()
+				recordKickAudit(Msg, ChannelType)
 			}
 		}
 		if valid_cmd == false {
@@ -286,6 +367,20 @@ func OnRecvMsg(Msg string, ChannelType int) string {
 		if err != nil {
 			return BuildInvalidRet("invalid guest-command json: " + err.Error())
 		}
+		if isKickRequest(gshellCmd.Arguments.Cmd) {
+			ack := handleKickRequest(gshellCmd.Arguments.Cmd, ChannelType)
+			gshellCmdReply := GshellCmdReply{}
+			gshellCmdReply.Return.CmdOutput = ack.String()
+			if ack.Status == kickvmhandle.AckAccepted {
+				gshellCmdReply.Return.Result = 8
+			} else if ack.Error == errAgentBusy.Error() || ack.Error == errKickRateLimited.Error() {
+				gshellCmdReply.Return.Result = 7
+			} else {
+				gshellCmdReply.Return.Result = 6
+			}
+			retStr, _ := json.Marshal(gshellCmdReply)
+			return string(retStr)
+		}
 		if update.IsCriticalActionRunning() {
 			gshellCmdReply := GshellCmdReply{}
 			gshellCmdReply.Return.Result = 7
@@ -294,8 +389,9 @@ func OnRecvMsg(Msg string, ChannelType int) string {
 			return string(retStr)
 		}
 		if gshellCmd.Arguments.Cmd == "kick_vm" {
-			go func() {
-				taskengine.Fetch(true, "", taskengine.NormalTaskType)			}()
+			if dispatchKick(gshellCmd.Arguments.Cmd, fetchTasksOnKick, nil) == kickRateLimited {
+				return buildRateLimitedGshellRet()
+			}
 			gshellCmdReply := GshellCmdReply{}
 			gshellCmdReply.Return.Result = 8
 			gshellCmdReply.Return.CmdOutput = "execute kick_vm success"
@@ -307,10 +403,11 @@ func OnRecvMsg(Msg string, ChannelType int) string {
 			valid_cmd := false
 			if handle != nil {
 				if handle.CheckAction() == true {
+					if dispatchKick(gshellCmd.Arguments.Cmd, handle.DoAction, nil) == kickRateLimited {
+						return buildRateLimitedGshellRet()
+					}
 					valid_cmd = true
-					go func() {
-						handle.DoAction()
-					}()
+					recordKickAudit(gshellCmd.Arguments.Cmd, ChannelType)
 				}
 			}
 			if valid_cmd == false {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"bou.ke/monkey"
+	"github.com/stretchr/testify/assert"
 	"github.com/aliyun/aliyun_assist_client/agent/kickvmhandle"
 	"github.com/aliyun/aliyun_assist_client/agent/update"
 	"github.com/aliyun/aliyun_assist_client/agent/util"
@@ -141,3 +142,27 @@ func TestOnRecvMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestDeregisterOnlyFromWebsocket(t *testing.T) {
+	guard := monkey.Patch(update.IsCriticalActionRunning, func() bool { return false })
+	defer guard.Unpatch()
+	unRegistered := 0
+	originalUnRegister := unRegisterAgent
+	defer func() { unRegisterAgent = originalUnRegister }()
+	unRegisterAgent = func(bool) bool {
+		unRegistered++
+		return true
+	}
+
+	OnRecvMsg("kick_vm agent deregister", ChannelLongPollType)
+	gshellCmd := GshellCmd{Execute: "guest-command"}
+	gshellCmd.Arguments.Cmd = "kick_vm agent deregister"
+	content, _ := json.Marshal(&gshellCmd)
+	OnRecvMsg(string(content), ChannelGshellType)
+	OnRecvMsg(`{"version":1,"requestId":"r-1","type":"agent","action":"deregister"}`, ChannelWebsocketType)
+	assert.Equal(t, 0, unRegistered)
+
+	// Deregistration is done synchronously before replying
+	OnRecvMsg("kick_vm agent deregister", ChannelWebsocketType)
+	assert.Equal(t, 1, unRegistered)
+}
@@ -1,13 +1,14 @@
 package channel
 
 import (
-	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -37,6 +38,8 @@ type WebSocketChannel struct {
 	writeLock                sync.Mutex
 	consecutiveConnectFailed int
 	calmDownUntil            time.Time
+	// RPC client over current connection
+	rpc atomic.Pointer[wsRPCClient]
 }
 
 func (c *WebSocketChannel) IsSupported() bool {
@@ -96,9 +99,7 @@ func (c *WebSocketChannel) StartChannel() error {
 	var MyDialer = &websocket.Dialer{
 		Proxy:            requester.GetProxyFunc(logger),
 		HandshakeTimeout: 45 * time.Second,
-		TLSClientConfig: &tls.Config{
-			RootCAs: requester.GetRootCAs(logger),
-		},
+		TLSClientConfig:  requester.NewTLSConfig(logger, requester.GetRootCAs(logger)),
 	}
 	var dialErr error
 	var conn *websocket.Conn
@@ -133,6 +134,12 @@ func (c *WebSocketChannel) StartChannel() error {
 	}
 	c.consecutiveConnectFailed = 0
 	c.wskConn = conn
+	rpc := newWSRPCClient(func(data []byte) error {
+		c.writeLock.Lock()
+		defer c.writeLock.Unlock()
+		return conn.WriteMessage(websocket.TextMessage, data)
+	})
+	c.rpc.Store(rpc)
 	logger.Infoln("Start websocket channel ok! url:", url)
 	c.Working.Set()
 	c.StartPings(time.Second * 60)
@@ -158,6 +165,7 @@ func (c *WebSocketChannel) StartChannel() error {
 					defer c.lock.Unlock()
 					c.wskConn.Close()
 					c.Working.Clear()
+					rpc.close()
 					logger.Errorf("Reach the retry limit for receive messages. Error: %v", err.Error())
 					report := clientreport.ClientReport{
 						ReportType: "switch_channel_in_wsk",
@@ -175,6 +183,13 @@ func (c *WebSocketChannel) StartChannel() error {
 			} else if messageType != websocket.TextMessage && messageType != websocket.BinaryMessage {
 				logger.Errorf("Invalid message type %d. ", messageType)
 
+			} else if frame, isRPC, err := parseRPCMessage(message); isRPC {
+				if err != nil {
+					rejectKickMessage(string(message), ChannelWebsocketType, err)
+				} else {
+					rpc.handleFrame(frame)
+				}
+				retryCount = 0
 			} else {
 				logger.Infof("wsk recv: %s", string(message))
 
@@ -200,17 +215,23 @@ func (c *WebSocketChannel) StartChannel() error {
 }
 
 func (c *WebSocketChannel) SwitchChannel() error {
+	return switchChannel("switch_channel_in_wsk")
+}
+
+// switchChannel selects another available channel after the current one is
+// broken, and reports the result as reportType
+func switchChannel(reportType string) error {
 	time.Sleep(time.Duration(1) * time.Second)
 	for i := 0; i < 5; i++ {
 		if G_ChannelMgr.SelectAvailableChannel(ChannelNone) == nil {
 			metrics.GetChannelSwitchEvent(
 				"type", ChannelTypeStr(G_ChannelMgr.GetCurrentChannelType()),
-				"reportType", "switch_channel_in_wsk",
+				"reportType", reportType,
 				"info", fmt.Sprintf("success: Current channel is %d", G_ChannelMgr.GetCurrentChannelType()),
 			).ReportEvent()
 
 			report := clientreport.ClientReport{
-				ReportType: "switch_channel_in_wsk",
+				ReportType: reportType,
 				Info:       fmt.Sprintf("success: Current channel is %d", G_ChannelMgr.GetCurrentChannelType()),
 			}
 			clientreport.SendReport(report)
@@ -220,12 +241,12 @@ func (c *WebSocketChannel) SwitchChannel() error {
 	}
 	metrics.GetChannelSwitchEvent(
 		"type", ChannelTypeStr(G_ChannelMgr.GetCurrentChannelType()),
-		"reportType", "switch_channel_in_wsk",
+		"reportType", reportType,
 		"info", fmt.Sprintf("fail: no available channel"),
 	).ReportEvent()
 
 	report := clientreport.ClientReport{
-		ReportType: "switch_channel_in_wsk",
+		ReportType: reportType,
 		Info:       fmt.Sprintf("fail: no available channel"),
 	}
 	clientreport.SendReport(report)
@@ -238,6 +259,9 @@ func (c *WebSocketChannel) StopChannel() error {
 	if c.Working.IsSet() {
 		c.Working.Clear()
 		log.GetLogger().Println("close websocket channel")
+		if rpc := c.rpc.Load(); rpc != nil {
+			rpc.close()
+		}
 		err := c.wskConn.Close()
 		if err != nil {
 			metrics.GetChannelFailEvent(
@@ -251,6 +275,37 @@ func (c *WebSocketChannel) StopChannel() error {
 	return nil
 }
 
+// SendMessage sends message to the server actively rather than as the reply
+// of received message
+func (c *WebSocketChannel) SendMessage(msg string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.Working.IsSet() {
+		return errors.New("websocket channel is not working")
+	}
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return c.wskConn.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+// LocalAddr returns local address of current connection
+func (c *WebSocketChannel) LocalAddr() net.Addr {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.wskConn == nil {
+		return nil
+	}
+	return c.wskConn.LocalAddr()
+}
+
+func (c *WebSocketChannel) getRPCClient() *wsRPCClient {
+	rpc := c.rpc.Load()
+	if rpc == nil || !rpc.isEnabled() {
+		return nil
+	}
+	return rpc
+}
+
 func (c *WebSocketChannel) StartPings(pingInterval time.Duration) {
 
 	go func() {
@@ -0,0 +1,230 @@
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/metrics"
+)
+
+type kickDispatchResult int
+
+const (
+	kickDispatched kickDispatchResult = iota
+	// Identical kick is in flight, and the action would be executed once more
+	// after it finishes for all coalesced kicks
+	kickCoalesced
+	kickRateLimited
+)
+
+const (
+	kickClassFetch = "fetch"
+
+	kickThrottledReportInterval = time.Minute
+)
+
+// kickRateLimit configures token bucket of a kind of kick
+type kickRateLimit struct {
+	// Tokens refilled per second
+	rate  float64
+	burst float64
+}
+
+var (
+	errKickRateLimited = errors.New("kick is rate limited")
+
+	defaultKickRateLimit = kickRateLimit{rate: 1, burst: 10}
+	// Keyed by kick type, or kickClassFetch for fetching tasks
+	kickRateLimits = map[string]kickRateLimit{
+		kickClassFetch: {rate: 1, burst: 5},
+		"task":         {rate: 5, burst: 20},
+		"file":         {rate: 5, burst: 20},
+		"session":      {rate: 5, burst: 20},
+		"agent":        {rate: 1.0 / 60, burst: 3},
+		"status":       {rate: 1.0 / 10, burst: 3},
+	}
+
+	_kickDispatcher = newKickDispatcher(time.Now)
+)
+
+// KickDispatchCounters counts kicks of an action class since agent started
+type KickDispatchCounters struct {
+	Dispatched  uint64 `json:"dispatched"`
+	Coalesced   uint64 `json:"coalesced"`
+	RateLimited uint64 `json:"rateLimited"`
+}
+
+type tokenBucket struct {
+	limit  kickRateLimit
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.rate
+	if b.tokens > b.limit.burst {
+		b.tokens = b.limit.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+type inflightKick struct {
+	rerun bool
+	// Callbacks of kicks coalesced into the rerun
+	waiters []func(error)
+}
+
+// kickDispatcher bounds concurrency and rate of actions triggered by kicks
+type kickDispatcher struct {
+	lock         sync.Mutex
+	now          func() time.Time
+	buckets      map[string]*tokenBucket
+	inflight     map[string]*inflightKick
+	counters     map[string]*KickDispatchCounters
+	lastReported map[string]time.Time
+}
+
+func newKickDispatcher(now func() time.Time) *kickDispatcher {
+	return &kickDispatcher{
+		now:          now,
+		buckets:      make(map[string]*tokenBucket),
+		inflight:     make(map[string]*inflightKick),
+		counters:     make(map[string]*KickDispatchCounters),
+		lastReported: make(map[string]time.Time),
+	}
+}
+
+// dispatchKick executes action of the kick in a new goroutine unless an
+// identical kick is in flight or its rate limit is exceeded. onDone is
+// called with the result of the execution covering the kick, if not nil.
+func dispatchKick(kick string, action func() error, onDone func(error)) kickDispatchResult {
+	return _kickDispatcher.dispatch(kick, action, onDone)
+}
+
+// KickDispatchStats returns counters of kicks by action class
+func KickDispatchStats() map[string]KickDispatchCounters {
+	return _kickDispatcher.stats()
+}
+
+func (d *kickDispatcher) dispatch(kick string, action func() error, onDone func(error)) kickDispatchResult {
+	key, class, limitKey := classifyKick(kick)
+
+	d.lock.Lock()
+	counters := d.counters[class]
+	if counters == nil {
+		counters = &KickDispatchCounters{}
+		d.counters[class] = counters
+	}
+	if inflight, ok := d.inflight[key]; ok {
+		inflight.rerun = true
+		if onDone != nil {
+			inflight.waiters = append(inflight.waiters, onDone)
+		}
+		counters.Coalesced++
+		d.lock.Unlock()
+		return kickCoalesced
+	}
+
+	now := d.now()
+	bucket := d.buckets[class]
+	if bucket == nil {
+		limit, ok := kickRateLimits[limitKey]
+		if !ok {
+			limit = defaultKickRateLimit
+		}
+		bucket = &tokenBucket{limit: limit, tokens: limit.burst, last: now}
+		d.buckets[class] = bucket
+	}
+	if !bucket.take(now) {
+		counters.RateLimited++
+		shouldReport := now.Sub(d.lastReported[class]) >= kickThrottledReportInterval
+		if shouldReport {
+			d.lastReported[class] = now
+		}
+		snapshot := *counters
+		d.lock.Unlock()
+
+		log.GetLogger().Warningln("Kick is rate limited:", kick)
+		if shouldReport {
+			metrics.GetKickThrottledEvent(
+				"action", class,
+				"dispatched", fmt.Sprint(snapshot.Dispatched),
+				"coalesced", fmt.Sprint(snapshot.Coalesced),
+				"rateLimited", fmt.Sprint(snapshot.RateLimited),
+			).ReportEvent()
+		}
+		return kickRateLimited
+	}
+	counters.Dispatched++
+	d.inflight[key] = &inflightKick{}
+	d.lock.Unlock()
+
+	go d.run(key, action, onDone)
+	return kickDispatched
+}
+
+func (d *kickDispatcher) run(key string, action func() error, onDone func(error)) {
+	waiters := []func(error){}
+	if onDone != nil {
+		waiters = append(waiters, onDone)
+	}
+	for {
+		err := func() (err error) {
+			defer func() {
+				if msg := recover(); msg != nil {
+					err = fmt.Errorf("kick action panic: %v", msg)
+				}
+			}()
+			return action()
+		}()
+		for _, waiter := range waiters {
+			waiter(err)
+		}
+
+		d.lock.Lock()
+		inflight := d.inflight[key]
+		if !inflight.rerun {
+			delete(d.inflight, key)
+			d.lock.Unlock()
+			return
+		}
+		// Coalesced kicks may arrive after the in-flight action has, e.g.,
+		// fetched tasks from server, thus the action is executed once more
+		waiters = inflight.waiters
+		d.inflight[key] = &inflightKick{}
+		d.lock.Unlock()
+	}
+}
+
+func (d *kickDispatcher) stats() map[string]KickDispatchCounters {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	stats := make(map[string]KickDispatchCounters, len(d.counters))
+	for class, counters := range d.counters {
+		stats[class] = *counters
+	}
+	return stats
+}
+
+// classifyKick returns the key identifying identical kicks, the action class
+// sharing a token bucket and the key of its rate limit configuration
+func classifyKick(kick string) (string, string, string) {
+	fields := strings.Fields(kick)
+	if len(fields) <= 1 || (len(fields) == 3 && fields[1] == "task" && fields[2] == "fetch") {
+		return "kick_vm", kickClassFetch, kickClassFetch
+	}
+	key := strings.Join(fields, " ")
+	if len(fields) == 2 {
+		return key, fields[1], fields[1]
+	}
+	return key, fields[1] + " " + fields[2], fields[1]
+}
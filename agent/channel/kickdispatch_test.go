@@ -0,0 +1,89 @@
+package channel
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKickDispatchCoalesce(t *testing.T) {
+	now := time.Now()
+	dispatcher := newKickDispatcher(func() time.Time { return now })
+
+	var executed int32
+	release := make(chan struct{})
+	action := func() error {
+		atomic.AddInt32(&executed, 1)
+		<-release
+		return nil
+	}
+	var done sync.WaitGroup
+	done.Add(6)
+	onDone := func(err error) {
+		assert.NoError(t, err)
+		done.Done()
+	}
+
+	assert.Equal(t, kickDispatched, dispatcher.dispatch("kick_vm", action, onDone))
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, kickCoalesced, dispatcher.dispatch("kick_vm task fetch", action, onDone))
+	}
+	close(release)
+	done.Wait()
+	// One in-flight execution and one rerun for all coalesced kicks
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executed))
+
+	stats := dispatcher.stats()
+	assert.Equal(t, KickDispatchCounters{Dispatched: 1, Coalesced: 5}, stats[kickClassFetch])
+}
+
+func TestKickDispatchRateLimit(t *testing.T) {
+	now := time.Now()
+	dispatcher := newKickDispatcher(func() time.Time { return now })
+	var done sync.WaitGroup
+	action := func() error {
+		done.Done()
+		return nil
+	}
+
+	limit := kickRateLimits["agent"]
+	done.Add(int(limit.burst))
+	for i := 0; i < int(limit.burst); i++ {
+		// Wait for previous one finishing to avoid being coalesced
+		assert.Equal(t, kickDispatched, dispatcher.dispatch("kick_vm agent update", action, nil))
+		for {
+			dispatcher.lock.Lock()
+			_, inflight := dispatcher.inflight["kick_vm agent update"]
+			dispatcher.lock.Unlock()
+			if !inflight {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	done.Wait()
+	assert.Equal(t, kickRateLimited, dispatcher.dispatch("kick_vm agent update", action, nil))
+	// Other actions have their own buckets
+	done.Add(1)
+	assert.Equal(t, kickDispatched, dispatcher.dispatch("kick_vm task run t-1", action, nil))
+
+	done.Add(1)
+	now = now.Add(time.Minute)
+	assert.Equal(t, kickDispatched, dispatcher.dispatch("kick_vm agent update", action, nil))
+	done.Wait()
+	assert.Equal(t, uint64(1), dispatcher.stats()["agent update"].RateLimited)
+}
+
+func TestClassifyKick(t *testing.T) {
+	key, class, limitKey := classifyKick("kick_vm task run t-1")
+	assert.Equal(t, "kick_vm task run t-1", key)
+	assert.Equal(t, "task run", class)
+	assert.Equal(t, "task", limitKey)
+
+	key, class, _ = classifyKick("kick_vm noop")
+	assert.Equal(t, "kick_vm noop", key)
+	assert.Equal(t, "noop", class)
+}
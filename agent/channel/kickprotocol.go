@@ -0,0 +1,108 @@
+package channel
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/aliyun/aliyun_assist_client/agent/kickvmhandle"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/update"
+)
+
+var (
+	errAgentBusy          = errors.New("agent is busy")
+	errSendingUnsupported = errors.New("active channel does not support sending message")
+)
+
+// messageSender is implemented by channels able to send messages to the
+// server actively
+type messageSender interface {
+	SendMessage(msg string) error
+}
+
+// isKickRequest distinguishes JSON kick envelope from legacy kick string
+func isKickRequest(msg string) bool {
+	return strings.HasPrefix(strings.TrimSpace(msg), "{") && gjson.Valid(msg) &&
+		gjson.Get(msg, "version").Exists() && gjson.Get(msg, "requestId").Exists()
+}
+
+// handleKickRequest handles JSON kick envelope and returns the synchronous
+// acknowledgement. Result of accepted kick is acknowledged asynchronously
+// through the active channel.
+func handleKickRequest(msg string, channelType int) *kickvmhandle.KickAck {
+	request, err := kickvmhandle.ParseRequest(msg)
+	if err != nil {
+		requestId := ""
+		if request != nil {
+			requestId = request.RequestId
+		}
+		return kickvmhandle.NewKickAck(requestId, kickvmhandle.AckRejected, err)
+	}
+	logger := log.GetLogger().WithFields(logrus.Fields{
+		"requestId": request.RequestId,
+		"kick":      request.String(),
+	})
+
+	if update.IsCriticalActionRunning() {
+		return kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckRejected, errAgentBusy)
+	}
+	if request.Expired(time.Now()) {
+		logger.Warningln("Kick request expired before received")
+		return kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckExpired, nil)
+	}
+	handle, err := request.Handle()
+	if err != nil {
+		return kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckRejected, err)
+	}
+
+	onDone := func(err error) {
+		var ack *kickvmhandle.KickAck
+		if err != nil {
+			logger.WithError(err).Errorln("Failed to execute kick request")
+			ack = kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckFailed, err)
+		} else {
+			ack = kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckSucceeded, nil)
+		}
+		sendKickAck(ack)
+	}
+	if dispatchKick(request.String(), handle.DoAction, onDone) == kickRateLimited {
+		return kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckRejected, errKickRateLimited)
+	}
+	recordKickAudit(request.String(), channelType)
+	return kickvmhandle.NewKickAck(request.RequestId, kickvmhandle.AckAccepted, nil)
+}
+
+// sendKickAck sends asynchronous acknowledgement through the active channel.
+// Acknowledgement is dropped when the channel could not send messages
+// actively, and server relies on the synchronous one then.
+func sendKickAck(ack *kickvmhandle.KickAck) {
+	logger := log.GetLogger().WithFields(logrus.Fields{
+		"requestId": ack.RequestId,
+		"status":    ack.Status,
+	})
+	if err := G_ChannelMgr.sendMessage(ack.String()); err != nil {
+		if errors.Is(err, errSendingUnsupported) {
+			logger.Infoln("Kick acknowledgement dropped since active channel could not send messages")
+		} else {
+			logger.WithError(err).Warningln("Failed to send kick acknowledgement through channel")
+		}
+	}
+}
+
+func (m *ChannelMgr) sendMessage(msg string) error {
+	m.ChannelSetLock.Lock()
+	activeChannel := m.ActiveChannel
+	m.ChannelSetLock.Unlock()
+	if activeChannel == nil || !activeChannel.IsWorking() {
+		return errSendingUnsupported
+	}
+	sender, ok := activeChannel.(messageSender)
+	if !ok {
+		return errSendingUnsupported
+	}
+	return sender.SendMessage(msg)
+}
@@ -0,0 +1,129 @@
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/aliyun/aliyun_assist_client/agent/kickauth"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/metrics"
+)
+
+// kickRejections aggregates rejected kick messages not reported yet
+type kickRejections struct {
+	count       int
+	channelType int
+	reason      string
+}
+
+var (
+	// Count of kick messages rejected by authentication since agent started
+	rejectedKickCount uint64
+
+	// Rejections are reported at most once per interval, and those in between
+	// are aggregated into one report, thus a flood of forged kicks never turns
+	// into a flood of outbound requests
+	kickRejectReportInterval = time.Minute
+	// sendKickRejectedEvent is replaced in tests
+	sendKickRejectedEvent = defaultSendKickRejectedEvent
+
+	_kickRejectLock       sync.Mutex
+	_kickRejectLastReport time.Time
+	// Rejections waiting for the scheduled report, nil when none scheduled
+	_kickRejectPending *kickRejections
+)
+
+// verifyKickMessage authenticates received message before any dispatch, and
+// returns the original message unwrapped from authentication information
+func verifyKickMessage(msg string, channelType int) (string, error) {
+	original, authenticated, err := kickauth.Verify(msg)
+	if err == nil {
+		if authenticated {
+			log.GetLogger().Infoln("kick msg authenticated:", original)
+		}
+		return original, nil
+	}
+	// guest-sync handshake of gshell channel performs nothing but echoes the
+	// id, which keeps the channel working before authenticated messages
+	// are sent
+	if errors.Is(err, kickauth.ErrAuthenticationRequired) && channelType == ChannelGshellType &&
+		gjson.Valid(msg) && gjson.Get(msg, "execute").String() == "guest-sync" {
+		return msg, nil
+	}
+
+	rejectKickMessage(msg, channelType, err)
+	return "", err
+}
+
+// rejectKickMessage counts and reports message failing authentication
+func rejectKickMessage(msg string, channelType int, err error) {
+	atomic.AddUint64(&rejectedKickCount, 1)
+	log.GetLogger().WithError(err).Errorln("Reject kick msg failing authentication:", msg)
+	reportKickRejected(channelType, err.Error())
+}
+
+// reportKickRejected reports the rejection immediately when no report has been
+// sent within the interval, otherwise aggregates it into the report scheduled
+// at the end of the interval
+func reportKickRejected(channelType int, reason string) {
+	_kickRejectLock.Lock()
+	if _kickRejectPending != nil {
+		_kickRejectPending.count++
+		_kickRejectPending.channelType = channelType
+		_kickRejectPending.reason = reason
+		_kickRejectLock.Unlock()
+		return
+	}
+	wait := kickRejectReportInterval - time.Since(_kickRejectLastReport)
+	if wait <= 0 {
+		_kickRejectLastReport = time.Now()
+		_kickRejectLock.Unlock()
+		sendKickRejectedEvent(&kickRejections{
+			count:       1,
+			channelType: channelType,
+			reason:      reason,
+		})
+		return
+	}
+	_kickRejectPending = &kickRejections{
+		count:       1,
+		channelType: channelType,
+		reason:      reason,
+	}
+	_kickRejectLock.Unlock()
+	time.AfterFunc(wait, flushKickRejected)
+}
+
+func flushKickRejected() {
+	_kickRejectLock.Lock()
+	pending := _kickRejectPending
+	_kickRejectPending = nil
+	_kickRejectLastReport = time.Now()
+	_kickRejectLock.Unlock()
+	if pending != nil {
+		sendKickRejectedEvent(pending)
+	}
+}
+
+func defaultSendKickRejectedEvent(rejections *kickRejections) {
+	metrics.GetKickRejectedEvent(
+		"type", ChannelTypeStr(rejections.channelType),
+		"reason", rejections.reason,
+		"aggregatedCount", fmt.Sprint(rejections.count),
+		"rejectedCount", fmt.Sprint(atomic.LoadUint64(&rejectedKickCount)),
+	).ReportEvent()
+}
+
+// buildUnauthenticatedRet builds reply for rejected message in the format of
+// the channel
+func buildUnauthenticatedRet(err error, channelType int) string {
+	if channelType == ChannelGshellType {
+		return BuildInvalidRet("unauthenticated: " + err.Error())
+	}
+	return "reject:unauthenticated: " + err.Error()
+}
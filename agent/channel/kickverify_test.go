@@ -0,0 +1,42 @@
+package channel
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportKickRejectedAggregated(t *testing.T) {
+	var lock sync.Mutex
+	var reported []kickRejections
+	sendKickRejectedEvent = func(rejections *kickRejections) {
+		lock.Lock()
+		defer lock.Unlock()
+		reported = append(reported, *rejections)
+	}
+	kickRejectReportInterval = 200 * time.Millisecond
+	_kickRejectLock.Lock()
+	_kickRejectLastReport = time.Time{}
+	_kickRejectLock.Unlock()
+	defer func() {
+		sendKickRejectedEvent = defaultSendKickRejectedEvent
+		kickRejectReportInterval = time.Minute
+	}()
+
+	for i := 0; i < 100; i++ {
+		reportKickRejected(ChannelWebsocketType, "kick message is not authenticated")
+	}
+	lock.Lock()
+	assert.Len(t, reported, 1)
+	assert.Equal(t, 1, reported[0].count)
+	lock.Unlock()
+
+	time.Sleep(400 * time.Millisecond)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Len(t, reported, 2)
+	assert.Equal(t, 99, reported[1].count)
+	assert.Equal(t, "kick message is not authenticated", reported[1].reason)
+}
@@ -4,10 +4,15 @@ import (
 	"github.com/aliyun/aliyun_assist_client/agent/checknet"
 )
 
-// NetcheckReply represents
+// NetcheckReply represents the most recent network diagnostic result carried
+// in reply of gshell command
 type NetcheckReply struct {
 	Result int `json:"result"`
 	Timestamp int64 `json:"timestamp"`
+	// Detailed code of the first failed step, see checknet.Diagnostic* constants
+	DiagnosticCode int `json:"diagnosticCode"`
+	FailedStep string `json:"failedStep,omitempty"`
+	Steps []checknet.CheckStep `json:"steps,omitempty"`
 }
 
 func LastNetcheckReply() *NetcheckReply {
@@ -19,6 +24,9 @@ func LastNetcheckReply() *NetcheckReply {
 	reply := NetcheckReply{
 		Result: report.Result,
 		Timestamp: report.FinishedTime.Local().Unix(),
+		DiagnosticCode: report.DiagnosticCode,
+		FailedStep: report.FailedStep(),
+		Steps: report.Steps,
 	}
 	return &reply
 }
@@ -0,0 +1,135 @@
+package channel
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/aliyun/aliyun_assist_client/agent/checknet"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/metrics"
+	"github.com/aliyun/aliyun_assist_client/agent/netwatch"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/networkcategory"
+)
+
+var (
+	_networkWatcher     *netwatch.Watcher
+	_networkWatcherLock sync.Mutex
+
+	// interfaceAddrs is replaced in tests
+	interfaceAddrs = net.InterfaceAddrs
+)
+
+// localAddrProvider is implemented by channels holding a persistent
+// connection to the server
+type localAddrProvider interface {
+	LocalAddr() net.Addr
+}
+
+func startNetworkWatcher() {
+	_networkWatcherLock.Lock()
+	defer _networkWatcherLock.Unlock()
+	if _networkWatcher != nil {
+		return
+	}
+	watcher, err := netwatch.Start(recoverOnNetworkChange)
+	if err != nil {
+		if errors.Is(err, netwatch.ErrUnsupported) {
+			log.GetLogger().Infoln("Network change watcher is not supported")
+		} else {
+			log.GetLogger().WithError(err).Errorln("Failed to start network change watcher")
+		}
+		return
+	}
+	_networkWatcher = watcher
+}
+
+func stopNetworkWatcher() {
+	_networkWatcherLock.Lock()
+	defer _networkWatcherLock.Unlock()
+	if _networkWatcher != nil {
+		_networkWatcher.Stop()
+		_networkWatcher = nil
+	}
+}
+
+// recoverOnNetworkChange detects the network environment again after network
+// configuration changed, reconnects the channel if its connection would be
+// broken, and fetches tasks which may be missed during the change.
+func recoverOnNetworkChange(changes netwatch.Changes) {
+	logger := log.GetLogger().WithField("changes", changes.String())
+	logger.Infoln("Recover from network changes")
+
+	host, hostChanged, err := util.RedetectServerHost()
+	if err != nil {
+		logger.WithError(err).Warningln("Failed to detect API server domain again")
+	} else if hostChanged {
+		logger.Infoln("API server domain changed to", host)
+	}
+	checknet.DeclareNetworkCategory(networkcategory.Get())
+	checknet.RequestNetcheck(checknet.NetcheckRequestForceOnce)
+
+	if G_ChannelMgr.restartStaleChannel(hostChanged) {
+		err := G_ChannelMgr.SelectAvailableChannel(ChannelNone)
+		info := "success: Current channel is " + ChannelTypeStr(G_ChannelMgr.GetCurrentChannelType())
+		if err != nil {
+			info = "fail: " + err.Error()
+		}
+		metrics.GetChannelSwitchEvent(
+			"type", ChannelTypeStr(G_ChannelMgr.GetCurrentChannelType()),
+			"reportType", "switch_channel_on_network_change",
+			"info", info,
+		).ReportEvent()
+	}
+
+	// Fetching is dispatched like a kick_vm request, thus coalesced with
+	// kicks in flight and subject to the same rate limit
+	if dispatchKick("kick_vm", fetchTasksOnKick, nil) == kickRateLimited {
+		logger.Warningln("Fetching tasks after network changes is rate limited")
+	}
+}
+
+// restartStaleChannel stops the active channel when its connection would not
+// survive network changes, and reports whether it is stopped. Gshell channel
+// does not depend on the network.
+func (m *ChannelMgr) restartStaleChannel(hostChanged bool) bool {
+	// Stopping channel may wait for its connection to close, which must not
+	// block channel selection of others holding the lock
+	m.ChannelSetLock.Lock()
+	activeChannel := m.ActiveChannel
+	m.ChannelSetLock.Unlock()
+	if activeChannel == nil || activeChannel.GetChannelType() == ChannelGshellType {
+		return false
+	}
+	if activeChannel.IsWorking() && !hostChanged {
+		// Requests of long-poll channel are cheap to restart, while
+		// websocket connection is kept when it is still bound to an
+		// assigned address
+		provider, ok := activeChannel.(localAddrProvider)
+		if ok && isLocalAddrAssigned(provider.LocalAddr()) {
+			return false
+		}
+	}
+	log.GetLogger().Infoln("Restart channel after network changes:", ChannelTypeStr(activeChannel.GetChannelType()))
+	activeChannel.StopChannel()
+	return true
+}
+
+func isLocalAddrAssigned(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok || tcpAddr == nil {
+		return false
+	}
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		log.GetLogger().WithError(err).Errorln("Failed to list interface addresses")
+		return false
+	}
+	for _, a := range addrs {
+		if ipNet, ok := a.(*net.IPNet); ok && ipNet.IP.Equal(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package channel
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLocalAddrAssigned(t *testing.T) {
+	defer func(original func() ([]net.Addr, error)) { interfaceAddrs = original }(interfaceAddrs)
+	interfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{
+			&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+			&net.IPNet{IP: net.ParseIP("192.168.0.10"), Mask: net.CIDRMask(24, 32)},
+		}, nil
+	}
+
+	assert.True(t, isLocalAddrAssigned(&net.TCPAddr{IP: net.ParseIP("192.168.0.10"), Port: 50000}))
+	assert.False(t, isLocalAddrAssigned(&net.TCPAddr{IP: net.ParseIP("192.168.0.11"), Port: 50000}))
+	assert.False(t, isLocalAddrAssigned(nil))
+
+	interfaceAddrs = func() ([]net.Addr, error) { return nil, errors.New("failed") }
+	assert.False(t, isLocalAddrAssigned(&net.TCPAddr{IP: net.ParseIP("192.168.0.10"), Port: 50000}))
+}
+
+type lockCheckingChannel struct {
+	mgr          *ChannelMgr
+	lockReleased bool
+	stopped      bool
+}
+
+func (c *lockCheckingChannel) IsWorking() bool     { return false }
+func (c *lockCheckingChannel) IsSupported() bool   { return true }
+func (c *lockCheckingChannel) GetChannelType() int { return ChannelWebsocketType }
+func (c *lockCheckingChannel) StartChannel() error { return nil }
+func (c *lockCheckingChannel) StopChannel() error {
+	c.stopped = true
+	if c.mgr.ChannelSetLock.TryLock() {
+		c.lockReleased = true
+		c.mgr.ChannelSetLock.Unlock()
+	}
+	return nil
+}
+
+func TestRestartStaleChannelReleasesLock(t *testing.T) {
+	mgr := &ChannelMgr{}
+	channel := &lockCheckingChannel{mgr: mgr}
+	mgr.ActiveChannel = channel
+
+	assert.True(t, mgr.restartStaleChannel(false))
+	assert.True(t, channel.stopped)
+	assert.True(t, channel.lockReleased)
+}
@@ -0,0 +1,234 @@
+package channel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/aliyun/aliyun_assist_client/agent/kickauth"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+)
+
+const (
+	// Sent by the server when it supports the RPC layer over the connection
+	rpcFrameHello    = "rpc_hello"
+	rpcFrameRequest  = "rpc_request"
+	rpcFrameResponse = "rpc_response"
+
+	rpcProtocolVersion = 1
+)
+
+var (
+	errRPCConnectionClosed = errors.New("websocket connection closed before RPC response")
+	errRPCTimeout          = errors.New("RPC request timed out")
+
+	// kickAuthRequired and verifyRPCMessage are replaced in tests
+	kickAuthRequired = kickauth.Required
+	verifyRPCMessage = kickauth.Verify
+)
+
+// rpcFrame is a request or response multiplexed over websocket connection,
+// correlated by id. Requests are API server requests with the path relative
+// to the API server.
+type rpcFrame struct {
+	Type    string            `json:"type"`
+	Id      uint64            `json:"id,omitempty"`
+	Version int               `json:"version,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Path    string            `json:"path,omitempty"`
+	Header  map[string]string `json:"header,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	Status  int               `json:"status,omitempty"`
+	// Set when the server failed to handle the request, and the request
+	// should be retried over HTTP
+	Error string `json:"error,omitempty"`
+}
+
+// parseRPCFrame distinguishes RPC frames from kick messages received through
+// websocket connection
+func parseRPCFrame(message []byte) (*rpcFrame, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(string(message)), "{") || !gjson.ValidBytes(message) {
+		return nil, false
+	}
+	if !strings.HasPrefix(gjson.GetBytes(message, "type").String(), "rpc_") {
+		return nil, false
+	}
+	frame := &rpcFrame{}
+	if err := json.Unmarshal(message, frame); err != nil {
+		return nil, false
+	}
+	return frame, true
+}
+
+// parseRPCMessage distinguishes RPC frames, either as they are or wrapped in
+// authenticated message like kick messages, from kick messages received
+// through websocket connection. Responses of forwarded requests deliver tasks
+// and sessions, thus RPC frames must be authenticated when kick
+// authentication is required. Error is returned for RPC frame failing
+// authentication.
+func parseRPCMessage(message []byte) (*rpcFrame, bool, error) {
+	if frame, ok := parseRPCFrame(message); ok {
+		if kickAuthRequired() {
+			return nil, true, kickauth.ErrAuthenticationRequired
+		}
+		return frame, true, nil
+	}
+	if !strings.HasPrefix(strings.TrimSpace(string(message)), "{") || !gjson.ValidBytes(message) {
+		return nil, false, nil
+	}
+	wrapped := gjson.GetBytes(message, "message")
+	if wrapped.Type != gjson.String {
+		return nil, false, nil
+	}
+	if _, ok := parseRPCFrame([]byte(wrapped.String())); !ok {
+		return nil, false, nil
+	}
+	original, _, err := verifyRPCMessage(string(message))
+	if err != nil {
+		return nil, true, err
+	}
+	frame, ok := parseRPCFrame([]byte(original))
+	if !ok {
+		return nil, true, fmt.Errorf("invalid RPC frame: %s", original)
+	}
+	return frame, true, nil
+}
+
+// wsRPCClient sends API requests over a websocket connection. It is disabled
+// until the server announces support by hello frame, and is closed along
+// with the connection.
+type wsRPCClient struct {
+	lock    sync.Mutex
+	send    func([]byte) error
+	nextId  uint64
+	enabled bool
+	closed  bool
+	pending map[uint64]chan *rpcFrame
+}
+
+func newWSRPCClient(send func([]byte) error) *wsRPCClient {
+	return &wsRPCClient{
+		send:    send,
+		pending: make(map[uint64]chan *rpcFrame),
+	}
+}
+
+// handleFrame is called by the reading loop of the connection, thus it must
+// not block
+func (r *wsRPCClient) handleFrame(frame *rpcFrame) {
+	switch frame.Type {
+	case rpcFrameHello:
+		r.lock.Lock()
+		r.enabled = !r.closed && frame.Version >= rpcProtocolVersion
+		r.lock.Unlock()
+		log.GetLogger().Infoln("RPC over websocket announced by server, version:", frame.Version)
+	case rpcFrameResponse:
+		r.lock.Lock()
+		ch, ok := r.pending[frame.Id]
+		delete(r.pending, frame.Id)
+		r.lock.Unlock()
+		if !ok {
+			log.GetLogger().Warningln("Drop RPC response of unknown or timed out request:", frame.Id)
+			return
+		}
+		ch <- frame
+	default:
+		log.GetLogger().Warningln("Unsupported RPC frame type:", frame.Type)
+	}
+}
+
+func (r *wsRPCClient) isEnabled() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.enabled && !r.closed
+}
+
+func (r *wsRPCClient) call(method string, path string, header map[string]string, body string, timeout time.Duration) (*rpcFrame, error) {
+	r.lock.Lock()
+	if !r.enabled || r.closed {
+		r.lock.Unlock()
+		return nil, util.ErrForwarderUnavailable
+	}
+	r.nextId++
+	id := r.nextId
+	ch := make(chan *rpcFrame, 1)
+	r.pending[id] = ch
+	r.lock.Unlock()
+
+	request, _ := json.Marshal(&rpcFrame{
+		Type:    rpcFrameRequest,
+		Id:      id,
+		Version: rpcProtocolVersion,
+		Method:  method,
+		Path:    path,
+		Header:  header,
+		Body:    body,
+	})
+	if err := r.send(request); err != nil {
+		r.removePending(id)
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case response, ok := <-ch:
+		if !ok {
+			return nil, errRPCConnectionClosed
+		}
+		if response.Error != "" {
+			return nil, fmt.Errorf("RPC request %d failed: %s", id, response.Error)
+		}
+		return response, nil
+	case <-timer.C:
+		r.removePending(id)
+		return nil, errRPCTimeout
+	}
+}
+
+func (r *wsRPCClient) removePending(id uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.pending, id)
+}
+
+// close fails all pending requests, and disables the client
+func (r *wsRPCClient) close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.closed = true
+	r.enabled = false
+	for id, ch := range r.pending {
+		close(ch)
+		delete(r.pending, id)
+	}
+}
+
+// channelAPIForwarder forwards API requests over the active websocket
+// channel when the server supports RPC over it
+type channelAPIForwarder struct{}
+
+func (channelAPIForwarder) Forward(method string, path string, header map[string]string, body string, timeout time.Duration) (string, int, error) {
+	G_ChannelMgr.ChannelSetLock.Lock()
+	activeChannel := G_ChannelMgr.ActiveChannel
+	G_ChannelMgr.ChannelSetLock.Unlock()
+	wsChannel, ok := activeChannel.(*WebSocketChannel)
+	if !ok || !wsChannel.IsWorking() {
+		return "", 0, util.ErrForwarderUnavailable
+	}
+	rpc := wsChannel.getRPCClient()
+	if rpc == nil {
+		return "", 0, util.ErrForwarderUnavailable
+	}
+	response, err := rpc.call(method, path, header, body, timeout)
+	if err != nil {
+		return "", 0, err
+	}
+	return response.Body, response.Status, nil
+}
@@ -0,0 +1,163 @@
+package channel
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/kickauth"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+)
+
+func TestParseRPCFrame(t *testing.T) {
+	frame, ok := parseRPCFrame([]byte(`{"type":"rpc_response","id":3,"status":200,"body":"ok"}`))
+	assert.True(t, ok)
+	assert.Equal(t, rpcFrameResponse, frame.Type)
+	assert.Equal(t, uint64(3), frame.Id)
+	assert.Equal(t, 200, frame.Status)
+
+	for _, msg := range []string{
+		"kick_vm",
+		`{"version":1,"requestId":"r-1","type":"task","action":"fetch"}`,
+		`{"type":"rpc_response"`,
+	} {
+		_, ok = parseRPCFrame([]byte(msg))
+		assert.False(t, ok, msg)
+	}
+}
+
+func TestParseRPCMessageAuthenticated(t *testing.T) {
+	required := false
+	kickAuthRequired = func() bool { return required }
+	// Only the wrapped message signed by "trusted" is authenticated
+	verifyRPCMessage = func(msg string) (string, bool, error) {
+		var authMsg kickauth.AuthenticatedMessage
+		json.Unmarshal([]byte(msg), &authMsg)
+		if authMsg.Signature != "trusted" {
+			return "", true, kickauth.ErrInvalidMAC
+		}
+		return authMsg.Message, true, nil
+	}
+	defer func() {
+		kickAuthRequired = kickauth.Required
+		verifyRPCMessage = kickauth.Verify
+	}()
+	plain := `{"type":"rpc_response","id":3,"status":200,"body":"ok"}`
+	wrap := func(signature string) []byte {
+		msg, _ := json.Marshal(&kickauth.AuthenticatedMessage{
+			Message:   plain,
+			Timestamp: time.Now().UnixMilli(),
+			Nonce:     "nonce-0001",
+			Signature: signature,
+		})
+		return msg
+	}
+
+	frame, isRPC, err := parseRPCMessage([]byte(plain))
+	assert.True(t, isRPC)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), frame.Id)
+
+	// Forged frames could not answer forwarded requests once kick
+	// authentication is required
+	required = true
+	_, isRPC, err = parseRPCMessage([]byte(plain))
+	assert.True(t, isRPC)
+	assert.True(t, errors.Is(err, kickauth.ErrAuthenticationRequired))
+	_, isRPC, err = parseRPCMessage(wrap("forged"))
+	assert.True(t, isRPC)
+	assert.True(t, errors.Is(err, kickauth.ErrInvalidMAC))
+	frame, isRPC, err = parseRPCMessage(wrap("trusted"))
+	assert.True(t, isRPC)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, frame.Status)
+
+	// Authenticated kick messages are left to kick verification
+	kick, _ := json.Marshal(&kickauth.AuthenticatedMessage{
+		Message:   "kick_vm task run t-1",
+		Signature: "trusted",
+	})
+	_, isRPC, _ = parseRPCMessage(kick)
+	assert.False(t, isRPC)
+	_, isRPC, _ = parseRPCMessage([]byte("kick_vm task run t-1"))
+	assert.False(t, isRPC)
+}
+
+func TestWSRPCClientCall(t *testing.T) {
+	var rpc *wsRPCClient
+	var lock sync.Mutex
+	var requests []*rpcFrame
+	// Responds to requests in reverse order to verify correlation by id
+	rpc = newWSRPCClient(func(data []byte) error {
+		frame := &rpcFrame{}
+		if err := json.Unmarshal(data, frame); err != nil {
+			return err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		requests = append(requests, frame)
+		if len(requests) == 2 {
+			for i := len(requests) - 1; i >= 0; i-- {
+				rpc.handleFrame(&rpcFrame{
+					Type:   rpcFrameResponse,
+					Id:     requests[i].Id,
+					Status: 200,
+					Body:   requests[i].Path,
+				})
+			}
+		}
+		return nil
+	})
+
+	_, err := rpc.call("GET", "/luban/api/heart-beat", nil, "", time.Second)
+	assert.ErrorIs(t, err, util.ErrForwarderUnavailable)
+
+	rpc.handleFrame(&rpcFrame{Type: rpcFrameHello, Version: rpcProtocolVersion})
+	assert.True(t, rpc.isEnabled())
+
+	var wg sync.WaitGroup
+	for _, path := range []string{"/luban/api/heart-beat", "/luban/api/v1/task/list"} {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			response, err := rpc.call("POST", path, nil, "", time.Second)
+			if assert.NoError(t, err) {
+				assert.Equal(t, 200, response.Status)
+				assert.Equal(t, path, response.Body)
+			}
+		}(path)
+	}
+	wg.Wait()
+	assert.Equal(t, rpcFrameRequest, requests[0].Type)
+	assert.NotEqual(t, requests[0].Id, requests[1].Id)
+	assert.Empty(t, rpc.pending)
+}
+
+func TestWSRPCClientTimeoutAndClose(t *testing.T) {
+	rpc := newWSRPCClient(func(data []byte) error { return nil })
+	rpc.handleFrame(&rpcFrame{Type: rpcFrameHello, Version: rpcProtocolVersion})
+
+	_, err := rpc.call("GET", "/luban/api/heart-beat", nil, "", 10*time.Millisecond)
+	assert.ErrorIs(t, err, errRPCTimeout)
+	assert.Empty(t, rpc.pending)
+
+	done := make(chan error)
+	go func() {
+		_, err := rpc.call("GET", "/luban/api/heart-beat", nil, "", time.Minute)
+		done <- err
+	}()
+	assert.Eventually(t, func() bool {
+		rpc.lock.Lock()
+		defer rpc.lock.Unlock()
+		return len(rpc.pending) == 1
+	}, time.Second, time.Millisecond)
+	rpc.close()
+	assert.ErrorIs(t, <-done, errRPCConnectionClosed)
+
+	_, err = rpc.call("GET", "/luban/api/heart-beat", nil, "", time.Second)
+	assert.ErrorIs(t, err, util.ErrForwarderUnavailable)
+}
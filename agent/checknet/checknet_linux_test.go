@@ -2,6 +2,7 @@ package checknet
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -39,12 +40,28 @@ func TestNetWorkCheck(t *testing.T) {
 	})
 	defer guard_1.Unpatch()
 
+	// API server domain is not determined, and metaserver is served locally
+	defer func(original func() string) { getServerHost = original }(getServerHost)
+	getServerHost = func() string { return "" }
+	metaserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("cn-hangzhou"))
+	}))
+	defer metaserver.Close()
+	defer func(original string) { metaserverUrl = original }(metaserverUrl)
+	metaserverUrl = metaserver.URL
+
 	RequestNetcheck("-")
 	RequestNetcheck(NetcheckRequestNormal)	
 	RequestNetcheck(NetcheckRequestForceOnce)
 	_doNetcheck(NetcheckRequestNormal)
 	_doNetcheck(NetcheckRequestForceOnce)
-	RecentReport()
+	report := RecentReport()
+	if report == nil {
+		t.Fatal("no network diagnostic report")
+	}
+	if report.Result != ResultFailed || report.DiagnosticCode != DiagnosticDNSFailed {
+		t.Errorf("unexpected result %d and diagnostic code %d", report.Result, report.DiagnosticCode)
+	}
 
 	httpmock.Activate()
 	util.NilRequest.Set()
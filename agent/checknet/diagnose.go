@@ -0,0 +1,343 @@
+package checknet
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/metrics"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/networkcategory"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+const (
+	StepDNS        = "dns"
+	StepProxy      = "proxy"
+	StepTCP        = "tcp"
+	StepTLS        = "tls"
+	StepClock      = "clock"
+	StepMetaserver = "metaserver"
+
+	diagnoseTimeout = 3 * time.Minute
+	stepTimeout     = 10 * time.Second
+	// Kick authentication and certificate verification would fail beyond
+	maxClockSkew = 5 * time.Minute
+
+	connectionDetectPath = "/luban/api/connection_detect"
+)
+
+var (
+	errServerDomainUndetermined = errors.New("API server domain is not determined")
+	errPreviousStepFailed       = errors.New("previous step failed")
+
+	stepDiagnosticCodes = map[string]int{
+		StepDNS:        DiagnosticDNSFailed,
+		StepProxy:      DiagnosticProxyFailed,
+		StepTCP:        DiagnosticTCPFailed,
+		StepTLS:        DiagnosticTLSFailed,
+		StepClock:      DiagnosticClockSkewed,
+		StepMetaserver: DiagnosticMetaserverFailed,
+	}
+
+	// Following are replaced in tests
+	getServerHost = util.GetServerHost
+	getProxyFunc  = requester.GetProxyFunc
+	getRootCAs    = requester.GetRootCAs
+	lookupHost    = net.DefaultResolver.LookupHost
+	dialContext   = (&net.Dialer{}).DialContext
+	serverPort    = "443"
+	metaserverUrl = "http://100.100.100.200/latest/meta-data/region-id"
+	timeNow       = time.Now
+)
+
+type diagnosis struct {
+	logger logrus.FieldLogger
+	report *CheckReport
+}
+
+// Diagnose checks step by step whether the API server is reachable: DNS
+// resolution of the server domain, proxy reachability, TCP connection, TLS
+// handshake and certificate chain against bundled CA, and clock skew from the
+// server. Metaserver access is also checked in VPC network.
+func Diagnose(ctx context.Context) *CheckReport {
+	d := &diagnosis{
+		logger: log.GetLogger().WithField("module", "checknet"),
+		report: &CheckReport{},
+	}
+	d.checkServer(ctx)
+	d.checkMetaserver(ctx)
+
+	d.report.FinishedTime = timeNow()
+	if failedStep := d.report.FailedStep(); failedStep != "" {
+		d.report.Result = ResultFailed
+		d.report.DiagnosticCode = stepDiagnosticCodes[failedStep]
+	}
+	return d.report
+}
+
+// step runs the check with timeout and records its result
+func (d *diagnosis) step(ctx context.Context, name string, check func(ctx context.Context) (string, error)) bool {
+	stepCtx, cancel := context.WithTimeout(ctx, stepTimeout)
+	defer cancel()
+	start := time.Now()
+	detail, err := check(stepCtx)
+	result := CheckStep{
+		Name:      name,
+		Status:    StepStatusOK,
+		Detail:    detail,
+		ElapsedMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StepStatusFailed
+		result.Error = err.Error()
+		d.logger.WithError(err).Warningf("Network diagnostic step %s failed", name)
+	}
+	d.report.Steps = append(d.report.Steps, result)
+	return err == nil
+}
+
+func (d *diagnosis) skip(name string, reason string) {
+	d.report.Steps = append(d.report.Steps, CheckStep{
+		Name:   name,
+		Status: StepStatusSkipped,
+		Detail: reason,
+	})
+}
+
+func (d *diagnosis) checkServer(ctx context.Context) {
+	domain := getServerHost()
+	if domain == "" {
+		d.step(ctx, StepDNS, func(context.Context) (string, error) {
+			return "", errServerDomainUndetermined
+		})
+		for _, name := range []string{StepProxy, StepTCP, StepTLS, StepClock} {
+			d.skip(name, errPreviousStepFailed.Error())
+		}
+		return
+	}
+	address := net.JoinHostPort(domain, serverPort)
+
+	var proxyUrl *url.URL
+	var proxyErr error
+	if proxyFunc := getProxyFunc(d.logger); proxyFunc != nil {
+		request, _ := http.NewRequest(http.MethodGet, "https://"+domain, nil)
+		proxyUrl, proxyErr = proxyFunc(request)
+	}
+
+	var conn net.Conn
+	if proxyUrl == nil && proxyErr == nil {
+		ok := d.step(ctx, StepDNS, func(ctx context.Context) (string, error) {
+			addrs, err := lookupHost(ctx, domain)
+			if err != nil {
+				return "", err
+			}
+			return domain + ": " + strings.Join(addrs, ","), nil
+		})
+		d.skip(StepProxy, "no proxy is configured")
+		if ok {
+			d.step(ctx, StepTCP, func(ctx context.Context) (string, error) {
+				var err error
+				conn, err = dialContext(ctx, "tcp", address)
+				if err != nil {
+					return "", err
+				}
+				return "connected to " + conn.RemoteAddr().String(), nil
+			})
+		} else {
+			d.skip(StepTCP, errPreviousStepFailed.Error())
+		}
+	} else {
+		d.skip(StepDNS, "server domain is resolved by proxy")
+		d.step(ctx, StepProxy, func(ctx context.Context) (string, error) {
+			if proxyErr != nil {
+				return "", proxyErr
+			}
+			var err error
+			conn, err = dialViaProxy(ctx, proxyUrl, address)
+			if err != nil {
+				return "", err
+			}
+			return "tunnel to " + address + " established through " + proxyUrl.Redacted(), nil
+		})
+		d.skip(StepTCP, "connected through proxy")
+	}
+	if conn == nil {
+		d.skip(StepTLS, errPreviousStepFailed.Error())
+		d.skip(StepClock, errPreviousStepFailed.Error())
+		return
+	}
+	defer conn.Close()
+
+	tlsConfig := requester.NewTLSConfig(d.logger, getRootCAs(d.logger))
+	tlsConfig.ServerName = domain
+	tlsConn := tls.Client(conn, tlsConfig)
+	ok := d.step(ctx, StepTLS, func(ctx context.Context) (string, error) {
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return "", err
+		}
+		certs := tlsConn.ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return "", errors.New("no certificate is presented by server")
+		}
+		return fmt.Sprintf("subject=%s issuer=%s notAfter=%s", certs[0].Subject.CommonName,
+			certs[0].Issuer.CommonName, certs[0].NotAfter.Format(time.RFC3339)), nil
+	})
+	if !ok {
+		d.skip(StepClock, errPreviousStepFailed.Error())
+		return
+	}
+
+	d.step(ctx, StepClock, func(ctx context.Context) (string, error) {
+		serverTime, err := requestServerTime(ctx, tlsConn, domain)
+		if err != nil {
+			return "", err
+		}
+		skew := timeNow().Sub(serverTime)
+		detail := fmt.Sprintf("local clock differs from server by %s", skew.Round(time.Second))
+		if skew > maxClockSkew || skew < -maxClockSkew {
+			return detail, fmt.Errorf("clock skew %s exceeds %s", skew.Round(time.Second), maxClockSkew)
+		}
+		return detail, nil
+	})
+}
+
+func (d *diagnosis) checkMetaserver(ctx context.Context) {
+	category := networkCategoryCache.Get()
+	if category != networkcategory.NetworkVPC && category != networkcategory.NetworkWithMetaserver {
+		d.skip(StepMetaserver, "metaserver is only accessible in VPC network, current network: "+string(category))
+		return
+	}
+	d.step(ctx, StepMetaserver, func(ctx context.Context) (string, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, metaserverUrl, nil)
+		if err != nil {
+			return "", err
+		}
+		// Metaserver is never accessed through proxy
+		client := &http.Client{Transport: &http.Transport{Proxy: nil}}
+		response, err := client.Do(request)
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("unexpected status %d", response.StatusCode)
+		}
+		return "region-id accessible", nil
+	})
+}
+
+// dialViaProxy establishes a tunnel to address through HTTP CONNECT method
+func dialViaProxy(ctx context.Context, proxyUrl *url.URL, address string) (net.Conn, error) {
+	proxyAddress := proxyUrl.Host
+	if proxyUrl.Port() == "" {
+		switch proxyUrl.Scheme {
+		case "https":
+			proxyAddress = net.JoinHostPort(proxyUrl.Hostname(), "443")
+		default:
+			proxyAddress = net.JoinHostPort(proxyUrl.Hostname(), "80")
+		}
+	}
+	conn, err := dialContext(ctx, "tcp", proxyAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect proxy: %w", err)
+	}
+	switch proxyUrl.Scheme {
+	case "http":
+	case "https":
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyUrl.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to handshake with proxy: %w", err)
+		}
+		conn = tlsConn
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", proxyUrl.Scheme)
+	}
+
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if proxyUrl.User != nil {
+		password, _ := proxyUrl.User.Password()
+		credential := base64.StdEncoding.EncodeToString([]byte(proxyUrl.User.Username() + ":" + password))
+		request.Header.Set("Proxy-Authorization", "Basic "+credential)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	if err := request.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT request to proxy: %w", err)
+	}
+	response, err := http.ReadResponse(bufio.NewReader(conn), request)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused tunnel: %s", response.Status)
+	}
+	return conn, nil
+}
+
+// requestServerTime sends connection detection request over established
+// connection and parses Date header of the response
+func requestServerTime(ctx context.Context, conn net.Conn, domain string) (time.Time, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	request, err := http.NewRequest(http.MethodGet, "https://"+domain+connectionDetectPath, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	request.Header.Set(requester.UserAgentHeader, requester.UserAgentValue)
+	request.Close = true
+	if err := request.Write(conn); err != nil {
+		return time.Time{}, err
+	}
+	response, err := http.ReadResponse(bufio.NewReader(conn), request)
+	if err != nil {
+		return time.Time{}, err
+	}
+	response.Body.Close()
+	date := response.Header.Get("Date")
+	if date == "" {
+		return time.Time{}, errors.New("no Date header in server response")
+	}
+	return http.ParseTime(date)
+}
+
+// reportDiagnostic sends diagnostic report to server as metrics event
+func reportDiagnostic(report *CheckReport) {
+	content, err := json.Marshal(report)
+	if err != nil {
+		return
+	}
+	metrics.GetNetworkDiagnosticEvent(
+		"result", fmt.Sprint(report.Result),
+		"diagnosticCode", fmt.Sprint(report.DiagnosticCode),
+		"failedStep", report.FailedStep(),
+		"report", string(content),
+	).ReportEvent()
+}
@@ -0,0 +1,203 @@
+package checknet
+
+import (
+	"bufio"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/common/networkcategory"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+const testServerDomain = "example.com"
+
+// mockDiagnoseHooks directs diagnostic to the test server and restores hooks
+// when the test finishes
+func mockDiagnoseHooks(t *testing.T, server *httptest.Server) {
+	originalGetServerHost, originalGetProxyFunc, originalGetRootCAs := getServerHost, getProxyFunc, getRootCAs
+	originalLookupHost, originalDialContext := lookupHost, dialContext
+	originalMetaserverUrl, originalTimeNow := metaserverUrl, timeNow
+	t.Cleanup(func() {
+		getServerHost, getProxyFunc, getRootCAs = originalGetServerHost, originalGetProxyFunc, originalGetRootCAs
+		lookupHost, dialContext = originalLookupHost, originalDialContext
+		metaserverUrl, timeNow = originalMetaserverUrl, originalTimeNow
+		networkCategoryCache.Set(networkcategory.NetworkCategoryUnknown)
+	})
+
+	serverAddress := server.Listener.Addr().String()
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	getServerHost = func() string { return testServerDomain }
+	getProxyFunc = func(logrus.FieldLogger) func(*http.Request) (*url.URL, error) { return nil }
+	getRootCAs = func(logrus.FieldLogger) *x509.CertPool { return pool }
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		if host != testServerDomain {
+			return nil, fmt.Errorf("no such host: %s", host)
+		}
+		return []string{"127.0.0.1"}, nil
+	}
+	dialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, serverAddress)
+	}
+	metaserverUrl = server.URL
+	networkCategoryCache.Set(networkcategory.NetworkClassic)
+}
+
+func stepStatuses(report *CheckReport) map[string]string {
+	statuses := make(map[string]string)
+	for _, step := range report.Steps {
+		statuses[step.Name] = step.Status
+	}
+	return statuses
+}
+
+func TestDiagnoseOK(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, connectionDetectPath, r.URL.Path)
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+	mockDiagnoseHooks(t, server)
+
+	report := Diagnose(context.Background())
+	assert.Equal(t, ResultOK, report.Result)
+	assert.Equal(t, DiagnosticOK, report.DiagnosticCode)
+	assert.Equal(t, "", report.FailedStep())
+	assert.Equal(t, map[string]string{
+		StepDNS:        StepStatusOK,
+		StepProxy:      StepStatusSkipped,
+		StepTCP:        StepStatusOK,
+		StepTLS:        StepStatusOK,
+		StepClock:      StepStatusOK,
+		StepMetaserver: StepStatusSkipped,
+	}, stepStatuses(report))
+}
+
+func TestDiagnoseFailures(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	t.Run("dns", func(t *testing.T) {
+		mockDiagnoseHooks(t, server)
+		getServerHost = func() string { return "unknown.example.com" }
+		report := Diagnose(context.Background())
+		assert.Equal(t, ResultFailed, report.Result)
+		assert.Equal(t, DiagnosticDNSFailed, report.DiagnosticCode)
+		assert.Equal(t, StepStatusSkipped, stepStatuses(report)[StepTLS])
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		mockDiagnoseHooks(t, server)
+		// Certificate of test server is not trusted by system CAs
+		getRootCAs = func(logrus.FieldLogger) *x509.CertPool { return x509.NewCertPool() }
+		report := Diagnose(context.Background())
+		assert.Equal(t, ResultFailed, report.Result)
+		assert.Equal(t, DiagnosticTLSFailed, report.DiagnosticCode)
+		assert.Equal(t, StepTLS, report.FailedStep())
+	})
+
+	t.Run("clock", func(t *testing.T) {
+		mockDiagnoseHooks(t, server)
+		timeNow = func() time.Time { return time.Now().Add(time.Hour) }
+		report := Diagnose(context.Background())
+		assert.Equal(t, ResultFailed, report.Result)
+		assert.Equal(t, DiagnosticClockSkewed, report.DiagnosticCode)
+	})
+
+	t.Run("metaserver", func(t *testing.T) {
+		metaserver := httptest.NewServer(http.NotFoundHandler())
+		defer metaserver.Close()
+		mockDiagnoseHooks(t, server)
+		metaserverUrl = metaserver.URL
+		networkCategoryCache.Set(networkcategory.NetworkVPC)
+		report := Diagnose(context.Background())
+		assert.Equal(t, ResultFailed, report.Result)
+		assert.Equal(t, DiagnosticMetaserverFailed, report.DiagnosticCode)
+	})
+}
+
+func TestDiagnoseThroughProxy(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer proxyListener.Close()
+	go func() {
+		for {
+			conn, err := proxyListener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				request, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil {
+					return
+				}
+				if request.Method != http.MethodConnect || request.Header.Get("Proxy-Authorization") == "" {
+					fmt.Fprint(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+					return
+				}
+				upstream, err := net.Dial("tcp", server.Listener.Addr().String())
+				if err != nil {
+					return
+				}
+				defer upstream.Close()
+				fmt.Fprint(conn, "HTTP/1.1 200 Connection Established\r\n\r\n")
+				go io.Copy(upstream, conn)
+				io.Copy(conn, upstream)
+			}(conn)
+		}
+	}()
+
+	mockDiagnoseHooks(t, server)
+	// Connections other than to the proxy are refused
+	dialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address != proxyListener.Addr().String() {
+			return nil, fmt.Errorf("unexpected address %s", address)
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+	proxyUrl := &url.URL{Scheme: "http", Host: proxyListener.Addr().String()}
+	getProxyFunc = func(logrus.FieldLogger) func(*http.Request) (*url.URL, error) {
+		return func(*http.Request) (*url.URL, error) { return proxyUrl, nil }
+	}
+
+	report := Diagnose(context.Background())
+	assert.Equal(t, ResultFailed, report.Result)
+	assert.Equal(t, DiagnosticProxyFailed, report.DiagnosticCode)
+	for _, step := range report.Steps {
+		if step.Name == StepProxy {
+			assert.True(t, strings.Contains(step.Error, "407"), step.Error)
+		}
+	}
+
+	proxyUrl.User = url.UserPassword("user", "password")
+	report = Diagnose(context.Background())
+	assert.Equal(t, ResultOK, report.Result, report.Steps)
+	assert.Equal(t, DiagnosticOK, report.DiagnosticCode)
+	assert.Equal(t, map[string]string{
+		StepDNS:        StepStatusSkipped,
+		StepProxy:      StepStatusOK,
+		StepTCP:        StepStatusSkipped,
+		StepTLS:        StepStatusOK,
+		StepClock:      StepStatusOK,
+		StepMetaserver: StepStatusSkipped,
+	}, stepStatuses(report))
+}
@@ -8,9 +8,56 @@ const (
 	_refreshNetcheckTimeThreshold = time.Duration(15) * time.Minute
 )
 
+// Result of network diagnostic, which keeps the meaning of exit code of
+// netcheck program for existing consumers: zero when the network is available
+const (
+	ResultOK     = 0
+	ResultFailed = 1
+)
+
+// Diagnostic codes of network diagnostic, indicating the first failed step
+const (
+	DiagnosticOK               = 0
+	DiagnosticDNSFailed        = 1
+	DiagnosticProxyFailed      = 2
+	DiagnosticTCPFailed        = 3
+	DiagnosticTLSFailed        = 4
+	DiagnosticClockSkewed      = 5
+	DiagnosticMetaserverFailed = 6
+)
+
+const (
+	StepStatusOK      = "ok"
+	StepStatusFailed  = "failed"
+	StepStatusSkipped = "skipped"
+)
+
 type CheckReport struct {
-	Result int
-	FinishedTime time.Time
+	Result         int         `json:"result"`
+	DiagnosticCode int         `json:"diagnosticCode"`
+	FinishedTime   time.Time   `json:"finishedTime"`
+	Steps          []CheckStep `json:"steps"`
+}
+
+// CheckStep is the result of one step in network diagnostic
+type CheckStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Human-readable information like resolved addresses and certificate
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+	ElapsedMs int64  `json:"elapsedMs"`
+}
+
+// FailedStep returns name of the first failed step, or empty string when all
+// steps passed
+func (r *CheckReport) FailedStep() string {
+	for _, step := range r.Steps {
+		if step.Status == StepStatusFailed {
+			return step.Name
+		}
+	}
+	return ""
 }
 
 func isReportOutdated(reportedTime time.Time) bool {
@@ -1,7 +1,162 @@
 package checknet
 
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+	heavylock "github.com/viney-shih/go-lock"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util/atomicutil"
+	"github.com/aliyun/aliyun_assist_client/agent/util/wrapgo"
+	"github.com/aliyun/aliyun_assist_client/common/networkcategory"
+)
+
 type NetcheckRequestType string
 const (
 	NetcheckRequestNormal NetcheckRequestType = "normal"
 	NetcheckRequestForceOnce NetcheckRequestType = "forceOnce"
 )
+
+var (
+	// Atomic value for boolean indicator of whether it is need to report network
+	// diagnostic result
+	_needToReport atomicutil.AtomicBoolean
+	// Atomic counter of how many times the network diagnostic result must be
+	// reported due to force-to-report request
+	_forceToReportResponses atomicutil.AtomicInt32
+
+	// Atomic value for pointer of last network diagnostic report
+	_neverDirectRW_atomic_lastReportPtr atomic.Value
+	// _refreshingReportLock indicates whether one goroutine is running netcheck
+	_refreshingReportLock heavylock.CASMutex
+)
+
+func init() {
+	// _needToReport and _forceToReportOnce are automatically statically
+	// initialized with zero-value of atomicutil.AtomicBoolean type.
+
+	var nilCheckReportPtr *CheckReport = nil
+	_neverDirectRW_atomic_lastReportPtr.Store(nilCheckReportPtr)
+
+	_refreshingReportLock = heavylock.NewCASMutex()
+}
+
+// RequestNetcheck would asynchronously run network diagnostic, when no other
+// network diagnostic is running or the last diagnostic report has outdated.
+func RequestNetcheck(requestType NetcheckRequestType) {
+	logger := log.GetLogger().WithFields(logrus.Fields{
+		"module": "checknet",
+	})
+
+	switch requestType {
+	case NetcheckRequestNormal:
+		_needToReport.Set()
+		wrapgo.GoWithDefaultPanicHandler(func() {
+			_doNetcheck(NetcheckRequestNormal)
+		})
+	case NetcheckRequestForceOnce:
+		wrapgo.GoWithDefaultPanicHandler(func() {
+			_doNetcheck(NetcheckRequestForceOnce)
+		})
+	default:
+		logger.WithFields(logrus.Fields{
+			"requestType": requestType,
+		}).Errorln("Invalid netcheck request type")
+		return
+	}
+}
+
+func _doNetcheck(requestType NetcheckRequestType) {
+	if !_refreshingReportLock.TryLock() {
+		return
+	}
+	defer _refreshingReportLock.Unlock()
+
+	logger := log.GetLogger().WithFields(logrus.Fields{
+		"module": "checknet",
+	})
+	// Only check cache validity when processing normal netcheck request
+	if requestType == NetcheckRequestNormal {
+		reportPtr, ok := _neverDirectRW_atomic_lastReportPtr.Load().(*CheckReport)
+		if !ok {
+			return
+		}
+		if reportPtr != nil {
+			if !isReportOutdated(reportPtr.FinishedTime) {
+				return
+			}
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"requestType": requestType,
+	}).Infoln("Run network diagnostic in response to checknet request")
+	ctx, cancel := context.WithTimeout(context.Background(), diagnoseTimeout)
+	defer cancel()
+	newReportPtr := Diagnose(ctx)
+
+	_neverDirectRW_atomic_lastReportPtr.Store(newReportPtr)
+	// Only increase force-to-report response counter when processing
+	// force-to-report netcheck request
+	if requestType == NetcheckRequestForceOnce {
+		_forceToReportResponses.Add(1)
+		// Report is also sent to server since channels other than gshell
+		// could not carry it
+		reportDiagnostic(newReportPtr)
+	}
+	logger.WithFields(logrus.Fields{
+		"result":       newReportPtr.Result,
+		"failedStep":   newReportPtr.FailedStep(),
+		"finishedTime": newReportPtr.FinishedTime.Format(time.RFC3339),
+	}).Infoln("Finished network diagnostic")
+}
+
+// RecentReport would return the most recent available network diagnostic report,
+// or nil pointer if the report has not been generated. When the report has been
+// outdated, it would call RequestNetcheck to refresh netcheck report.
+func RecentReport() *CheckReport {
+	isForceToReportOnce := _forceToReportResponses.Load() > 0
+	isNeedToReport := _needToReport.IsSet()
+	if !isForceToReportOnce && !isNeedToReport {
+		return nil
+	}
+
+	reportPtr, ok := _neverDirectRW_atomic_lastReportPtr.Load().(*CheckReport)
+	if !ok {
+		return nil
+	}
+	if reportPtr == nil {
+		return nil
+	}
+
+	// NOTE: Thanks to to serial feature of gshell channel, RecentReport() would
+	// never be called concurrently and _forceToReportResponses counter should
+	// never become less than zero due to parallel decreasing actions more than
+	// available response count.
+	if isForceToReportOnce {
+		_forceToReportResponses.Add(-1)
+	}
+	// Only when it is needed to report by automatic detection via heart-beat,
+	// it is needed to check whether current report is out-of-date.
+	if isNeedToReport && isReportOutdated(reportPtr.FinishedTime) {
+		RequestNetcheck(NetcheckRequestNormal)
+	}
+
+	return reportPtr
+}
+
+// DeclareNetworkCategory sets the network category in cache of this module,
+// which is used to specify the network environment when running network
+// diagnostic.
+func DeclareNetworkCategory(category networkcategory.NetworkCategory) {
+	networkCategoryCache.Set(category)
+}
+
+// clearNeedToReport simply set that it is not needed to report network
+// diagnostic result.
+func clearNeedToReport() {
+	_needToReport.Clear()
+}
@@ -0,0 +1,82 @@
+package containerd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/defaults"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/namespaces"
+)
+
+// Container runtime containerd without CRI plugin enabled can only be accessed
+// via its native gRPC API, where containers are isolated in namespaces.
+
+var (
+	ErrInvalidIdentifier = errors.New("container identifier should be in format [<namespace>/]<container-id>")
+
+	// Replaced in tests
+	Endpoint = defaults.DefaultAddress
+)
+
+// NewClient connects to the native API of containerd
+func NewClient(timeout time.Duration) (*containerd.Client, error) {
+	client, err := containerd.New(Endpoint, containerd.WithTimeout(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to containerd at %s: %w", Endpoint, err)
+	}
+	return client, nil
+}
+
+// ParseIdentifier splits container identifier in format
+// [<namespace>/]<container-id> into namespace and container id
+func ParseIdentifier(identifier string) (namespace string, containerId string, err error) {
+	containerId = identifier
+	if slashIndex := strings.LastIndex(identifier, "/"); slashIndex != -1 {
+		namespace = identifier[:slashIndex]
+		containerId = identifier[slashIndex+1:]
+		if namespace == "" {
+			return "", "", ErrInvalidIdentifier
+		}
+	}
+	if containerId == "" {
+		return "", "", ErrInvalidIdentifier
+	}
+	return namespace, containerId, nil
+}
+
+// WithNamespace returns context whose requests are in specified namespace
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return namespaces.WithNamespace(ctx, namespace)
+}
+
+// TaskState returns state of the task, i.e., the running process, of container
+// in uppercase like "RUNNING", and StateCreated for container without task
+func TaskState(ctx context.Context, container containerd.Container) (string, error) {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return StateCreated, nil
+		}
+		return "", err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return StateCreated, nil
+		}
+		return "", err
+	}
+	return normalizeState(status.Status), nil
+}
+
+func normalizeState(status containerd.ProcessStatus) string {
+	if status == "" || status == containerd.Unknown {
+		return StateUnknown
+	}
+	return strings.ToUpper(string(status))
+}
@@ -0,0 +1,41 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier  string
+		namespace   string
+		containerId string
+		wantErr     bool
+	}{
+		{"abc", "", "abc", false},
+		{"k8s.io/abc", "k8s.io", "abc", false},
+		{"a/b/abc", "a/b", "abc", false},
+		{"", "", "", true},
+		{"k8s.io/", "", "", true},
+		{"/abc", "", "", true},
+	}
+	for _, tt := range tests {
+		namespace, containerId, err := ParseIdentifier(tt.identifier)
+		if tt.wantErr {
+			assert.Equal(t, ErrInvalidIdentifier, err, tt.identifier)
+			continue
+		}
+		assert.NoError(t, err, tt.identifier)
+		assert.Equal(t, tt.namespace, namespace, tt.identifier)
+		assert.Equal(t, tt.containerId, containerId, tt.identifier)
+	}
+}
+
+func TestNormalizeState(t *testing.T) {
+	assert.Equal(t, StateRunning, normalizeState(containerd.Running))
+	assert.Equal(t, "STOPPED", normalizeState(containerd.Stopped))
+	assert.Equal(t, StateUnknown, normalizeState(containerd.Unknown))
+	assert.Equal(t, StateUnknown, normalizeState(""))
+}
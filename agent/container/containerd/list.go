@@ -0,0 +1,104 @@
+package containerd
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd"
+
+	"github.com/aliyun/aliyun_assist_client/agent/container/model"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+const (
+	// Containers without any task, i.e., created but never started or whose
+	// task has been deleted, are considered in this state.
+	StateCreated = "CREATED"
+	StateRunning = "RUNNING"
+	StateUnknown = "UNKNOWN"
+)
+
+func ListContainers(timeout time.Duration, showAllContainers bool) ([]model.Container, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := NewClient(timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	namespaces, err := client.NamespaceService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []model.Container
+	for _, namespace := range namespaces {
+		oneNamespaceContainers, err := listNamespacedContainers(ctx, client, namespace, "")
+		if err != nil {
+			log.GetLogger().WithError(err).Errorf("Failed to list containers in namespace %s of containerd", namespace)
+			continue
+		}
+
+		for _, container := range oneNamespaceContainers {
+			if !showAllContainers && container.State != StateRunning {
+				continue
+			}
+			containers = append(containers, container)
+		}
+	}
+	return containers, nil
+}
+
+// FindContainers searches container with specified id in the namespace, or in
+// all namespaces when namespace is empty. Multiple containers may be returned
+// since id of container is only unique in one namespace.
+func FindContainers(ctx context.Context, client *containerd.Client, namespace string, containerId string) ([]model.Container, error) {
+	namespaces := []string{namespace}
+	if namespace == "" {
+		var err error
+		namespaces, err = client.NamespaceService().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var containers []model.Container
+	for _, namespace := range namespaces {
+		oneNamespaceContainers, err := listNamespacedContainers(ctx, client, namespace, containerId)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, oneNamespaceContainers...)
+	}
+	return containers, nil
+}
+
+func listNamespacedContainers(ctx context.Context, client *containerd.Client, namespace string, containerId string) ([]model.Container, error) {
+	ctx = WithNamespace(ctx, namespace)
+	var filters []string
+	if containerId != "" {
+		filters = append(filters, "id=="+containerId)
+	}
+	found, err := client.Containers(ctx, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []model.Container
+	for _, container := range found {
+		state, err := TaskState(ctx, container)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, model.Container{
+			Id:          container.ID(),
+			Namespace:   namespace,
+			RuntimeName: "containerd",
+			State:       state,
+			DataSource:  model.ViaContainerd,
+		})
+	}
+	return containers, nil
+}
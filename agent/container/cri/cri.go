@@ -1,5 +1,18 @@
 package cri
 
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	runtimeapiV1alpha2 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/kubernetes/pkg/kubelet/cri/remote/util"
+)
+
 type RuntimeEndpoint struct{
 	RuntimeName string
 	Endpoint string
@@ -15,3 +28,58 @@ func init() {
 		RuntimeName2Endpoints[endpoint.RuntimeName] = append(RuntimeName2Endpoints[endpoint.RuntimeName], endpoint)
 	}
 }
+
+// CheckRuntimeService checks whether CRI service of the specified container
+// runtime is available via any of its known endpoints.
+func CheckRuntimeService(runtimeName string, connectTimeout time.Duration) error {
+	endpoints, ok := RuntimeName2Endpoints[runtimeName]
+	if !ok {
+		return fmt.Errorf("Unknown container runtime %s for CRI", runtimeName)
+	}
+
+	var lastErr error
+	for _, endpoint := range endpoints {
+		if err := checkEndpoint(endpoint.Endpoint, connectTimeout); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// checkEndpoint calls Version of CRI runtime service via endpoint. Runtime
+// service of neither v1 nor v1alpha2 being served, e.g., containerd without
+// CRI plugin enabled, is reported as unavailable.
+func checkEndpoint(endpoint string, timeout time.Duration) error {
+	addr, dialer, err := util.GetAddressAndDialer(endpoint)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithContextDialer(dialer))
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", endpoint, err)
+	}
+	defer conn.Close()
+
+	var runtimeName string
+	resp, err := runtimeapi.NewRuntimeServiceClient(conn).Version(ctx, &runtimeapi.VersionRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		var respV1alpha2 *runtimeapiV1alpha2.VersionResponse
+		respV1alpha2, err = runtimeapiV1alpha2.NewRuntimeServiceClient(conn).Version(ctx, &runtimeapiV1alpha2.VersionRequest{})
+		if err == nil {
+			runtimeName = respV1alpha2.RuntimeName
+		}
+	} else if err == nil {
+		runtimeName = resp.RuntimeName
+	}
+	if err != nil {
+		return fmt.Errorf("CRI runtime service is not available at %s: %w", endpoint, err)
+	}
+	if runtimeName == "" {
+		return fmt.Errorf("CRI runtime service at %s reports no runtime name", endpoint)
+	}
+	return nil
+}
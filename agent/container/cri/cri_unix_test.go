@@ -0,0 +1,57 @@
+//go:build !windows
+// +build !windows
+
+package cri
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	runtimeapiV1alpha2 "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+type fakeRuntimeServiceV1alpha2 struct {
+	*runtimeapiV1alpha2.UnimplementedRuntimeServiceServer
+}
+
+func (*fakeRuntimeServiceV1alpha2) Version(ctx context.Context, req *runtimeapiV1alpha2.VersionRequest) (*runtimeapiV1alpha2.VersionResponse, error) {
+	return &runtimeapiV1alpha2.VersionResponse{RuntimeName: "containerd"}, nil
+}
+
+// serveRuntimeEndpoint serves gRPC server via unix socket as the only
+// endpoint of containerd
+func serveRuntimeEndpoint(t *testing.T, server *grpc.Server) {
+	socketPath := filepath.Join(t.TempDir(), "containerd.sock")
+	lis, err := net.Listen("unix", socketPath)
+	assert.NoError(t, err)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	original := RuntimeName2Endpoints
+	t.Cleanup(func() { RuntimeName2Endpoints = original })
+	RuntimeName2Endpoints = map[string][]RuntimeEndpoint{
+		"containerd": {{"containerd", "unix://" + socketPath}},
+	}
+}
+
+func TestCheckRuntimeServiceWithoutCRI(t *testing.T) {
+	// Like containerd with CRI plugin disabled, which serves no CRI service
+	serveRuntimeEndpoint(t, grpc.NewServer())
+	assert.Error(t, CheckRuntimeService("containerd", time.Second))
+}
+
+func TestCheckRuntimeServiceV1alpha2(t *testing.T) {
+	server := grpc.NewServer()
+	runtimeapiV1alpha2.RegisterRuntimeServiceServer(server, &fakeRuntimeServiceV1alpha2{})
+	serveRuntimeEndpoint(t, server)
+	assert.NoError(t, CheckRuntimeService("containerd", time.Second))
+}
+
+func TestCheckRuntimeServiceUnknown(t *testing.T) {
+	assert.Error(t, CheckRuntimeService("unknown", time.Second))
+}
@@ -13,7 +13,28 @@ import (
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 )
 
+// RuntimeContainers holds containers listed from one container runtime, along
+// with the connection to its runtime service for further operations.
+type RuntimeContainers struct {
+	Endpoint   RuntimeEndpoint
+	Service    criapis.RuntimeService
+	Containers []model.Container
+}
+
 func ListContainers(connectTimeout time.Duration, showAllContainers bool) ([]model.Container, error) {
+	runtimeContainersList, err := ListRuntimeContainers(connectTimeout, showAllContainers)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := []model.Container{}
+	for _, runtimeContainers := range runtimeContainersList {
+		containers = append(containers, runtimeContainers.Containers...)
+	}
+	return containers, nil
+}
+
+func ListRuntimeContainers(connectTimeout time.Duration, showAllContainers bool) ([]RuntimeContainers, error) {
 	containerFilter := &runtimeapis.ContainerFilter{}
 	if !showAllContainers {
 		containerFilter.State = &runtimeapis.ContainerStateValue{
@@ -21,7 +42,7 @@ func ListContainers(connectTimeout time.Duration, showAllContainers bool) ([]mod
 		}
 	}
 
-	containers := []model.Container{}
+	runtimeContainersList := []RuntimeContainers{}
 	// Since both old dockershim inside kubelet and new standalone cri-docker
 	// provide CRI to docker, runtime endpoints need to be deduplicated based on
 	// runtime name.
@@ -50,14 +71,18 @@ func ListContainers(connectTimeout time.Duration, showAllContainers bool) ([]mod
 				return oneRuntimeContainers[i].Id < oneRuntimeContainers[j].Id
 			})
 
-			containers = append(containers, oneRuntimeContainers...)
+			runtimeContainersList = append(runtimeContainersList, RuntimeContainers{
+				Endpoint:   endpoint,
+				Service:    service,
+				Containers: oneRuntimeContainers,
+			})
 			// Only one available runtime endpoint is needed for the container
 			// runtime
 			break
 		}
 	}
 
-	return containers, nil
+	return runtimeContainersList, nil
 }
 
 func listCRIContainers(service criapis.RuntimeService, runtimeName string, containerFilter *runtimeapis.ContainerFilter) ([]model.Container, error) {
@@ -65,11 +90,9 @@ func listCRIContainers(service criapis.RuntimeService, runtimeName string, conta
 	if err != nil {
 		return nil, err
 	}
-	podSandboxId2Names := make(map[string]string, len(criPodSandboxes))
+	podSandboxes := make(map[string]*runtimeapis.PodSandbox, len(criPodSandboxes))
 	for _, podSandbox := range criPodSandboxes {
-		if podSandbox.Metadata != nil && podSandbox.Metadata.Name != "" {
-			podSandboxId2Names[podSandbox.Id] = podSandbox.Metadata.Name
-		}
+		podSandboxes[podSandbox.Id] = podSandbox
 	}
 
 	criContainers, err := service.ListContainers(containerFilter)
@@ -89,8 +112,12 @@ func listCRIContainers(service criapis.RuntimeService, runtimeName string, conta
 		if criContainer.Metadata != nil {
 			container.Name = criContainer.Metadata.Name
 		}
-		if podSandboxName, ok := podSandboxId2Names[container.PodId]; ok {
-			container.PodName = podSandboxName
+		if podSandbox, ok := podSandboxes[container.PodId]; ok {
+			if podSandbox.Metadata != nil {
+				container.PodName = podSandbox.Metadata.Name
+				container.PodNamespace = podSandbox.Metadata.Namespace
+			}
+			container.PodLabels = podSandbox.Labels
 		}
 		containers = append(containers, container)
 	}
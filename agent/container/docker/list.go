@@ -14,6 +14,18 @@ import (
 )
 
 func ListContainers(timeout time.Duration, showAllContainers bool) ([]model.Container, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+
+	return ListContainersWithClient(cli, timeout, showAllContainers, "docker", model.ViaDocker)
+}
+
+// ListContainersWithClient lists containers through any service compatible
+// with Docker Engine API, e.g., the REST service of podman, and tags them with
+// specified runtime name and data source.
+func ListContainersWithClient(cli *client.Client, timeout time.Duration, showAllContainers bool, runtimeName string, dataSource model.DataSourceName) ([]model.Container, error) {
 	containerListOptions := types.ContainerListOptions{
 		All: showAllContainers,
 	}
@@ -24,11 +36,6 @@ func ListContainers(timeout time.Duration, showAllContainers bool) ([]model.Cont
 		})
 	}
 
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
-	if err != nil {
-		return nil, err
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	dockerContainers, err := cli.ContainerList(ctx, containerListOptions)
@@ -47,10 +54,10 @@ func ListContainers(timeout time.Duration, showAllContainers bool) ([]model.Cont
 	for _, dockerContainer := range dockerContainers {
 		container := model.Container{
 			Id: dockerContainer.ID,
-			RuntimeName: "docker",
+			RuntimeName: runtimeName,
 			// TODO: FIXME: Use uniform container state representation
 			State: strings.ToUpper(dockerContainer.State),
-			DataSource: model.ViaDocker,
+			DataSource: dataSource,
 		}
 		if len(dockerContainer.Names) > 0 {
 			container.Name = StripAndSelectName(dockerContainer.Names)
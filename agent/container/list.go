@@ -5,9 +5,11 @@ import (
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 
+	"github.com/aliyun/aliyun_assist_client/agent/container/containerd"
 	libcri "github.com/aliyun/aliyun_assist_client/agent/container/cri"
 	"github.com/aliyun/aliyun_assist_client/agent/container/docker"
 	"github.com/aliyun/aliyun_assist_client/agent/container/model"
+	"github.com/aliyun/aliyun_assist_client/agent/container/podman"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 )
 
@@ -15,12 +17,15 @@ type ListContainersOptions struct {
 	ConnectTimeout    time.Duration
 	DataSourceName    string
 	ShowAllContainers bool
+	// Only containers in pods matching the selector are listed if specified
+	PodSelector *model.PodSelector
 }
 
 func ListContainers(opts ListContainersOptions) ([]model.Container, error) {
 	log.GetLogger().WithFields(logrus.Fields{
 		"connectTimeout": opts.ConnectTimeout,
 		"dataSourceName": opts.DataSourceName,
+		"podSelector":    opts.PodSelector,
 	}).Infoln("Would retrieve container list from specified data source in limited time")
 
 	var containers []model.Container
@@ -49,8 +54,36 @@ func ListContainers(opts ListContainersOptions) ([]model.Container, error) {
 		}
 	}
 
-	// Deduplicate containers with the same ID, but retrieved from both CRI and
-	// docker
+	if opts.DataSourceName == "all" || opts.DataSourceName == "podman" {
+		containersFromPodman, errFromPodman := podman.ListContainers(opts.ConnectTimeout, opts.ShowAllContainers)
+		if errFromPodman != nil {
+			log.GetLogger().WithError(errFromPodman).Errorln("Failed to retrieve container list from podman")
+			// Keep previous error encountered
+			if err == nil {
+				err = errFromPodman
+			}
+		}
+		if len(containersFromPodman) > 0 {
+			containers = append(containers, containersFromPodman...)
+		}
+	}
+	if opts.DataSourceName == "all" || opts.DataSourceName == "containerd" {
+		containersFromContainerd, errFromContainerd := containerd.ListContainers(opts.ConnectTimeout, opts.ShowAllContainers)
+		if errFromContainerd != nil {
+			log.GetLogger().WithError(errFromContainerd).Errorln("Failed to retrieve container list from containerd")
+			// Keep previous error encountered
+			if err == nil {
+				err = errFromContainerd
+			}
+		}
+		if len(containersFromContainerd) > 0 {
+			containers = append(containers, containersFromContainerd...)
+		}
+	}
+
+	// Deduplicate containers with the same ID, but retrieved from more than
+	// one data source, e.g., containers managed by kubelet are visible via both
+	// CRI and the native API of containerd.
 	if len(containers) > 0 {
 		containerIdSet := make(map[string]struct{}, len(containers))
 
@@ -64,5 +97,9 @@ func ListContainers(opts ListContainersOptions) ([]model.Container, error) {
 		containers = uniqueContainers
 	}
 
+	if opts.PodSelector != nil {
+		containers = opts.PodSelector.Select(containers)
+	}
+
 	return containers, err
 }
@@ -4,13 +4,18 @@ type DataSourceName string
 const (
 	ViaCRI DataSourceName = "CRI"
 	ViaDocker DataSourceName = "docker"
+	ViaPodman DataSourceName = "podman"
+	ViaContainerd DataSourceName = "containerd"
 )
 
 type Container struct {
 	Id string `json:"id"`
 	Name string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
 	PodId string `json:"podId,omitempty"`
 	PodName string `json:"podName,omitempty"`
+	PodNamespace string `json:"podNamespace,omitempty"`
+	PodLabels map[string]string `json:"podLabels,omitempty"`
 	RuntimeName string `json:"runtimeName,omitempty"`
 	State string `json:"state"`
 	DataSource DataSourceName `json:"dataSource"`
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	DefaultPodNamespace = "default"
+)
+
+type labelOperator int
+
+const (
+	labelEquals labelOperator = iota
+	labelNotEquals
+	labelExists
+	labelNotExists
+)
+
+type labelRequirement struct {
+	key      string
+	operator labelOperator
+	value    string
+}
+
+// LabelSelector is a simplified implementation of equality-based label
+// selector of Kubernetes, i.e., comma-separated requirements in forms of
+// key=value, key==value, key!=value, key and !key.
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+func ParseLabelSelector(selector string) (*LabelSelector, error) {
+	parsed := &LabelSelector{}
+	for _, segment := range strings.Split(selector, ",") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+
+		var requirement labelRequirement
+		if index := strings.Index(segment, "!="); index != -1 {
+			requirement = labelRequirement{segment[:index], labelNotEquals, segment[index+2:]}
+		} else if index := strings.Index(segment, "=="); index != -1 {
+			requirement = labelRequirement{segment[:index], labelEquals, segment[index+2:]}
+		} else if index := strings.Index(segment, "="); index != -1 {
+			requirement = labelRequirement{segment[:index], labelEquals, segment[index+1:]}
+		} else if strings.HasPrefix(segment, "!") {
+			requirement = labelRequirement{segment[1:], labelNotExists, ""}
+		} else {
+			requirement = labelRequirement{segment, labelExists, ""}
+		}
+		requirement.key = strings.TrimSpace(requirement.key)
+		requirement.value = strings.TrimSpace(requirement.value)
+		if requirement.key == "" || strings.ContainsAny(requirement.key, "=! ") || strings.ContainsAny(requirement.value, "=! ") {
+			return nil, fmt.Errorf("Invalid label selector requirement: %s", segment)
+		}
+		parsed.requirements = append(parsed.requirements, requirement)
+	}
+	return parsed, nil
+}
+
+func (s *LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range s.requirements {
+		value, ok := labels[requirement.key]
+		switch requirement.operator {
+		case labelEquals:
+			if !ok || value != requirement.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == requirement.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *LabelSelector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// PodSelector selects containers on Kubernetes nodes by metadata of pods they
+// belong to, which are retrieved via CRI.
+type PodSelector struct {
+	Namespace     string
+	PodName       string
+	LabelSelector string
+	ContainerName string
+
+	labels *LabelSelector
+}
+
+// IsPodSelectorSpecified reports whether any pod-related condition is set
+func IsPodSelectorSpecified(namespace string, podName string, labelSelector string) bool {
+	return namespace != "" || podName != "" || labelSelector != ""
+}
+
+// Validate checks the selector and parses the label selector in it
+func (s *PodSelector) Validate() error {
+	if s.PodName != "" && s.LabelSelector != "" {
+		return fmt.Errorf("Pod name and label selector cannot be specified at the same time")
+	}
+
+	labels, err := ParseLabelSelector(s.LabelSelector)
+	if err != nil {
+		return err
+	}
+	s.labels = labels
+	return nil
+}
+
+// EffectiveNamespace returns the namespace containers are selected in. Like
+// kubectl, the default namespace is assumed when selecting one pod by name.
+func (s *PodSelector) EffectiveNamespace() string {
+	if s.Namespace == "" && s.PodName != "" {
+		return DefaultPodNamespace
+	}
+	return s.Namespace
+}
+
+// Matches reports whether the container satisfies all specified conditions.
+// Validate() MUST be called before.
+func (s *PodSelector) Matches(container *Container) bool {
+	if container.PodId == "" {
+		return false
+	}
+	if namespace := s.EffectiveNamespace(); namespace != "" && container.PodNamespace != namespace {
+		return false
+	}
+	if s.PodName != "" && container.PodName != s.PodName {
+		return false
+	}
+	if s.ContainerName != "" && container.Name != s.ContainerName {
+		return false
+	}
+	if s.labels != nil && !s.labels.Matches(container.PodLabels) {
+		return false
+	}
+	return true
+}
+
+// Select filters containers satisfying the selector
+func (s *PodSelector) Select(containers []Container) []Container {
+	selected := make([]Container, 0, len(containers))
+	for i := range containers {
+		if s.Matches(&containers[i]) {
+			selected = append(selected, containers[i])
+		}
+	}
+	return selected
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := ParseLabelSelector("app=nginx, tier!=cache,env==prod,canary,!legacy")
+	assert.NoError(t, err)
+	assert.True(t, selector.Matches(map[string]string{"app": "nginx", "env": "prod", "canary": ""}))
+	assert.False(t, selector.Matches(map[string]string{"app": "nginx", "env": "prod", "canary": "", "tier": "cache"}))
+	assert.False(t, selector.Matches(map[string]string{"app": "nginx", "env": "prod", "canary": "", "legacy": "true"}))
+	assert.False(t, selector.Matches(map[string]string{"app": "nginx", "env": "prod"}))
+
+	selector, err = ParseLabelSelector("")
+	assert.NoError(t, err)
+	assert.True(t, selector.Empty())
+
+	_, err = ParseLabelSelector("=nginx")
+	assert.Error(t, err)
+	_, err = ParseLabelSelector("app=a=b")
+	assert.Error(t, err)
+}
+
+func TestPodSelector(t *testing.T) {
+	containers := []Container{
+		{Id: "c1", Name: "web", PodId: "p1", PodName: "nginx-1", PodNamespace: "default", PodLabels: map[string]string{"app": "nginx"}},
+		{Id: "c2", Name: "sidecar", PodId: "p1", PodName: "nginx-1", PodNamespace: "default", PodLabels: map[string]string{"app": "nginx"}},
+		{Id: "c3", Name: "web", PodId: "p2", PodName: "nginx-2", PodNamespace: "prod", PodLabels: map[string]string{"app": "nginx"}},
+		{Id: "c4", Name: "standalone"},
+	}
+
+	selector := &PodSelector{PodName: "nginx-1"}
+	assert.NoError(t, selector.Validate())
+	assert.Len(t, selector.Select(containers), 2)
+
+	selector = &PodSelector{PodName: "nginx-1", ContainerName: "web"}
+	assert.NoError(t, selector.Validate())
+	assert.Equal(t, "c1", selector.Select(containers)[0].Id)
+
+	selector = &PodSelector{LabelSelector: "app=nginx", ContainerName: "web"}
+	assert.NoError(t, selector.Validate())
+	assert.Len(t, selector.Select(containers), 2)
+
+	selector = &PodSelector{Namespace: "prod", LabelSelector: "app=nginx"}
+	assert.NoError(t, selector.Validate())
+	assert.Equal(t, "c3", selector.Select(containers)[0].Id)
+
+	selector = &PodSelector{PodName: "nginx-1", LabelSelector: "app=nginx"}
+	assert.Error(t, selector.Validate())
+}
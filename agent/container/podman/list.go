@@ -0,0 +1,22 @@
+package podman
+
+import (
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/container/docker"
+	"github.com/aliyun/aliyun_assist_client/agent/container/model"
+)
+
+func ListContainers(timeout time.Duration, showAllContainers bool) ([]model.Container, error) {
+	endpoint, err := FindEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := NewClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return docker.ListContainersWithClient(cli, timeout, showAllContainers, "podman", model.ViaPodman)
+}
@@ -0,0 +1,38 @@
+package podman
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	dockerclient "github.com/docker/docker/client"
+)
+
+var (
+	ErrNoAvailableEndpoint = errors.New("No available podman REST service endpoint")
+)
+
+// FindEndpoint returns the first endpoint of podman REST service existing on
+// the host. Podman does not run a daemon like docker, and its REST service is
+// usually activated by systemd socket unit podman.socket, so the existence of
+// socket file is a cheap but good enough indicator.
+func FindEndpoint() (string, error) {
+	for _, endpoint := range PrecedentEndpoints {
+		socketPath := strings.TrimPrefix(endpoint, "unix://")
+		if socketPath == endpoint {
+			// Named pipes on Windows cannot be detected in the same way, let
+			// the client try it.
+			return endpoint, nil
+		}
+		if _, err := os.Stat(socketPath); err == nil {
+			return endpoint, nil
+		}
+	}
+	return "", ErrNoAvailableEndpoint
+}
+
+// NewClient creates client of the Docker-compatible API served by podman REST
+// service, which is enough for listing containers and running exec sessions.
+func NewClient(endpoint string) (*dockerclient.Client, error) {
+	return dockerclient.NewClientWithOpts(dockerclient.WithHost(endpoint), dockerclient.WithAPIVersionNegotiation())
+}
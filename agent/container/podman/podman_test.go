@@ -0,0 +1,32 @@
+package podman
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("named pipes are not detected")
+	}
+	originalEndpoints := PrecedentEndpoints
+	defer func() { PrecedentEndpoints = originalEndpoints }()
+
+	dir := t.TempDir()
+	missing := "unix://" + filepath.Join(dir, "missing.sock")
+	existing := "unix://" + filepath.Join(dir, "podman.sock")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "podman.sock"), nil, 0600))
+
+	PrecedentEndpoints = []string{missing}
+	_, err := FindEndpoint()
+	assert.Equal(t, ErrNoAvailableEndpoint, err)
+
+	PrecedentEndpoints = []string{missing, existing}
+	endpoint, err := FindEndpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, existing, endpoint)
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package podman
+
+var (
+	PrecedentEndpoints = []string{
+		"unix:///run/podman/podman.sock",
+		"unix:///var/run/podman/podman.sock",
+	}
+)
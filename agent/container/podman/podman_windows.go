@@ -0,0 +1,7 @@
+package podman
+
+var (
+	PrecedentEndpoints = []string{
+		"npipe:////./pipe/podman-machine-default",
+	}
+)
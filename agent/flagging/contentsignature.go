@@ -0,0 +1,18 @@
+package flagging
+
+import (
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+const requireContentSignatureFlagFilename = "require_content_signature"
+
+// DetectContentSignatureRequired reports whether command content must carry
+// a valid detached signature from keys in the local keyring.
+func DetectContentSignatureRequired() (bool, error) {
+	flagPath, err := findFlagFile(requireContentSignatureFlagFilename)
+	if err != nil || flagPath == "" {
+		return false, err
+	}
+	log.GetLogger().Infof("Detected requiring content signature flag %s", flagPath)
+	return true, nil
+}
@@ -0,0 +1,19 @@
+package flagging
+
+import (
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+const requireKickAuthenticationFlagFilename = "require_kick_authentication"
+
+// DetectKickAuthenticationRequired reports whether kick messages must be
+// authenticated even if no kick key has been provisioned, i.e., signed by
+// keys in the local kick keyring.
+func DetectKickAuthenticationRequired() (bool, error) {
+	flagPath, err := findFlagFile(requireKickAuthenticationFlagFilename)
+	if err != nil || flagPath == "" {
+		return false, err
+	}
+	log.GetLogger().Infof("Detected requiring kick authentication flag %s", flagPath)
+	return true, nil
+}
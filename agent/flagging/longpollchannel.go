@@ -0,0 +1,20 @@
+package flagging
+
+import (
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+const enableLongPollChannelFlagFilename = "enable_longpoll_channel"
+
+// DetectLongPollChannelEnabled reports whether the HTTP long-poll channel may
+// be selected. It is disabled by default, since its notify endpoints are not
+// served by API servers generally and are only available on servers
+// explicitly providing them.
+func DetectLongPollChannelEnabled() (bool, error) {
+	flagPath, err := findFlagFile(enableLongPollChannelFlagFilename)
+	if err != nil || flagPath == "" {
+		return false, err
+	}
+	log.GetLogger().Infof("Detected enabling longpoll channel flag %s", flagPath)
+	return true, nil
+}
@@ -0,0 +1,94 @@
+package flagging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	enableEnvParameterFlagFilename  = "enable_env_parameter"
+	enableFileParameterFlagFilename = "enable_file_parameter"
+)
+
+// DetectEnvParameterEnabled reports whether {{env::VAR}} parameters in command
+// content are allowed to be resolved from environment variables of agent. Each
+// non-empty line in the flag file is an allowed variable name, and no variable
+// is allowed if no name is specified, since environment of agent may contain
+// secrets.
+func DetectEnvParameterEnabled() (bool, []string, error) {
+	flagPath, err := findFlagFile(enableEnvParameterFlagFilename)
+	if err != nil || flagPath == "" {
+		return false, nil, err
+	}
+	log.GetLogger().Infof("Detected enabling env parameter flag %s", flagPath)
+
+	allowedNames, err := readFlagFileLines(flagPath)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, allowedNames, nil
+}
+
+// DetectFileParameterEnabled reports whether {{file::/path}} parameters in
+// command content are allowed to be resolved from content of local files. Each
+// non-empty line in the flag file is an allowed path prefix, and no path is
+// allowed if no prefix is specified.
+func DetectFileParameterEnabled() (bool, []string, error) {
+	flagPath, err := findFlagFile(enableFileParameterFlagFilename)
+	if err != nil || flagPath == "" {
+		return false, nil, err
+	}
+	log.GetLogger().Infof("Detected enabling file parameter flag %s", flagPath)
+
+	allowedPrefixes, err := readFlagFileLines(flagPath)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, allowedPrefixes, nil
+}
+
+// readFlagFileLines returns non-empty lines in the flag file, except comments
+// beginning with #
+func readFlagFileLines(flagPath string) ([]string, error) {
+	content, err := os.ReadFile(flagPath)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// findFlagFile returns path of the flag file across all installed versions or
+// in this installed version, or empty string if not found.
+func findFlagFile(filename string) (string, error) {
+	crossVersionConfigDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	crossVersionFlagPath := filepath.Join(crossVersionConfigDir, filename)
+	if util.CheckFileIsExist(crossVersionFlagPath) {
+		return crossVersionFlagPath, nil
+	}
+
+	currentVersionConfigDir, err := pathutil.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	currentVersionFlagPath := filepath.Join(currentVersionConfigDir, filename)
+	if util.CheckFileIsExist(currentVersionFlagPath) {
+		return currentVersionFlagPath, nil
+	}
+
+	return "", nil
+}
@@ -60,8 +60,18 @@ var (
 	// if _useFullFields is true ping hear-beat with full fields,
 	// otherwise use the reduced fields
 	_useFullFields atomic.Bool
+
+	_lastPingResult     PingResult
+	_lastPingResultLock sync.Mutex
 )
 
+// PingResult is the result of last heart-beat
+type PingResult struct {
+	Time      time.Time
+	Succeeded bool
+	Error     string
+}
+
 func init() {
 	_retryCounter = 0
 	_retryMutex = &sync.Mutex{}
@@ -360,22 +370,46 @@ func buildFullFieldsPingParams(sendCounter uint64) (querystring string) {
 }
 
 func PingwithRetries(retryCount int) {
+	var err error
 	for i := 0; i < retryCount; i++ {
-		if err := doPing(); err == nil {
+		if err = doPing(); err == nil {
 			_acknowledgeCounter++
 			break
 		}
 	}
 	_sendCounter++
+	recordPingResult(err)
 }
 
 func pingWithoutRetry() {
 	// Error(s) encountered during heart-beating has been logged internally,
 	// simply ignore it here.
-	if err := doPing(); err == nil {
+	err := doPing()
+	if err == nil {
 		_acknowledgeCounter++
 	}
 	_sendCounter++
+	recordPingResult(err)
+}
+
+func recordPingResult(err error) {
+	_lastPingResultLock.Lock()
+	defer _lastPingResultLock.Unlock()
+	_lastPingResult = PingResult{
+		Time:      time.Now(),
+		Succeeded: err == nil,
+	}
+	if err != nil {
+		_lastPingResult.Error = err.Error()
+	}
+}
+
+// GetLastPingResult returns the result of last heart-beat, whose Time is zero
+// when no heart-beat has been sent
+func GetLastPingResult() PingResult {
+	_lastPingResultLock.Lock()
+	defer _lastPingResultLock.Unlock()
+	return _lastPingResult
 }
 
 func InitHeartbeatTimer() error {
@@ -522,3 +522,16 @@ func Test_doPing(t *testing.T) {
 	err = doPing()
 	assert.ErrorIs(t, err, nil)
 }
+
+func TestRecordPingResult(t *testing.T) {
+	recordPingResult(errors.New("connection refused"))
+	result := GetLastPingResult()
+	assert.False(t, result.Succeeded)
+	assert.Equal(t, "connection refused", result.Error)
+	assert.WithinDuration(t, time.Now(), result.Time, time.Minute)
+
+	recordPingResult(nil)
+	result = GetLastPingResult()
+	assert.True(t, result.Succeeded)
+	assert.Empty(t, result.Error)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/tidwall/gjson"
 
+	"github.com/aliyun/aliyun_assist_client/agent/kickauth"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
 	"github.com/aliyun/aliyun_assist_client/agent/util"
@@ -49,6 +50,8 @@ type Tag struct {
 type registerResponse struct {
 	Code       int    `json:"code"`
 	InstanceId string `json:"instanceId"`
+	// Base64-encoded HMAC key authenticating kick messages, optional
+	KickKey string `json:"kickKey"`
 }
 
 type unregisterResponse struct {
@@ -136,6 +139,11 @@ func Register(region string, code string, id string, name string, networkmode st
 			util.WriteStringToFile(path+"/region-id", region)
 			util.WriteStringToFile(path+"/instance-id", register_response.InstanceId)
 			util.WriteStringToFile(path+"/machine-id", mid)
+			if register_response.KickKey != "" {
+				if err := kickauth.SaveKickKey(register_response.KickKey); err != nil {
+					log.GetLogger().WithError(err).Errorln("Failed to save kick key")
+				}
+			}
 		} else {
 			ret = false
 		}
@@ -256,6 +264,7 @@ func clean_unregister_data(need_restart bool) {
 	os.Remove(path + "/region-id")
 	os.Remove(path + "/instance-id")
 	os.Remove(path + "/machine-id")
+	kickauth.RemoveKickKey()
 
 	if need_restart {
 		restartService()
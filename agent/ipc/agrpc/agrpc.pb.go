@@ -747,6 +747,1993 @@ func (m *CreateSecretParamResp) GetSecretParam() *SecretParamInfo {
 	return nil
 }
 
+// GetAgentStatus api
+type ChannelStatus struct {
+	ChannelType          string   `protobuf:"bytes,1,opt,name=channelType,proto3" json:"channelType,omitempty"`
+	Working              bool     `protobuf:"varint,2,opt,name=working,proto3" json:"working,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelStatus) Reset()         { *m = ChannelStatus{} }
+func (m *ChannelStatus) String() string { return proto.CompactTextString(m) }
+func (*ChannelStatus) ProtoMessage()    {}
+func (*ChannelStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{15}
+}
+
+func (m *ChannelStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelStatus.Unmarshal(m, b)
+}
+func (m *ChannelStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelStatus.Marshal(b, m, deterministic)
+}
+func (m *ChannelStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelStatus.Merge(m, src)
+}
+func (m *ChannelStatus) XXX_Size() int {
+	return xxx_messageInfo_ChannelStatus.Size(m)
+}
+func (m *ChannelStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelStatus proto.InternalMessageInfo
+
+func (m *ChannelStatus) GetChannelType() string {
+	if m != nil {
+		return m.ChannelType
+	}
+	return ""
+}
+
+func (m *ChannelStatus) GetWorking() bool {
+	if m != nil {
+		return m.Working
+	}
+	return false
+}
+
+type HeartbeatStatus struct {
+	// zero when no heart-beat has been sent
+	LastTimestamp        int64    `protobuf:"varint,1,opt,name=lastTimestamp,proto3" json:"lastTimestamp,omitempty"`
+	Succeeded            bool     `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	ErrMessage           string   `protobuf:"bytes,3,opt,name=errMessage,proto3" json:"errMessage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeartbeatStatus) Reset()         { *m = HeartbeatStatus{} }
+func (m *HeartbeatStatus) String() string { return proto.CompactTextString(m) }
+func (*HeartbeatStatus) ProtoMessage()    {}
+func (*HeartbeatStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{16}
+}
+
+func (m *HeartbeatStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeartbeatStatus.Unmarshal(m, b)
+}
+func (m *HeartbeatStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeartbeatStatus.Marshal(b, m, deterministic)
+}
+func (m *HeartbeatStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeartbeatStatus.Merge(m, src)
+}
+func (m *HeartbeatStatus) XXX_Size() int {
+	return xxx_messageInfo_HeartbeatStatus.Size(m)
+}
+func (m *HeartbeatStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeartbeatStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeartbeatStatus proto.InternalMessageInfo
+
+func (m *HeartbeatStatus) GetLastTimestamp() int64 {
+	if m != nil {
+		return m.LastTimestamp
+	}
+	return 0
+}
+
+func (m *HeartbeatStatus) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *HeartbeatStatus) GetErrMessage() string {
+	if m != nil {
+		return m.ErrMessage
+	}
+	return ""
+}
+
+type GetAgentStatusReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAgentStatusReq) Reset()         { *m = GetAgentStatusReq{} }
+func (m *GetAgentStatusReq) String() string { return proto.CompactTextString(m) }
+func (*GetAgentStatusReq) ProtoMessage()    {}
+func (*GetAgentStatusReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{17}
+}
+
+func (m *GetAgentStatusReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAgentStatusReq.Unmarshal(m, b)
+}
+func (m *GetAgentStatusReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAgentStatusReq.Marshal(b, m, deterministic)
+}
+func (m *GetAgentStatusReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAgentStatusReq.Merge(m, src)
+}
+func (m *GetAgentStatusReq) XXX_Size() int {
+	return xxx_messageInfo_GetAgentStatusReq.Size(m)
+}
+func (m *GetAgentStatusReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAgentStatusReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAgentStatusReq proto.InternalMessageInfo
+
+type GetAgentStatusResp struct {
+	Status               *RespStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Version              string           `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	GitCommitHash        string           `protobuf:"bytes,3,opt,name=gitCommitHash,proto3" json:"gitCommitHash,omitempty"`
+	Pid                  int32            `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	StartTimestamp       int64            `protobuf:"varint,5,opt,name=startTimestamp,proto3" json:"startTimestamp,omitempty"`
+	UptimeSeconds        int64            `protobuf:"varint,6,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"`
+	Channel              *ChannelStatus   `protobuf:"bytes,7,opt,name=channel,proto3" json:"channel,omitempty"`
+	Heartbeat            *HeartbeatStatus `protobuf:"bytes,8,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetAgentStatusResp) Reset()         { *m = GetAgentStatusResp{} }
+func (m *GetAgentStatusResp) String() string { return proto.CompactTextString(m) }
+func (*GetAgentStatusResp) ProtoMessage()    {}
+func (*GetAgentStatusResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{18}
+}
+
+func (m *GetAgentStatusResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAgentStatusResp.Unmarshal(m, b)
+}
+func (m *GetAgentStatusResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAgentStatusResp.Marshal(b, m, deterministic)
+}
+func (m *GetAgentStatusResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAgentStatusResp.Merge(m, src)
+}
+func (m *GetAgentStatusResp) XXX_Size() int {
+	return xxx_messageInfo_GetAgentStatusResp.Size(m)
+}
+func (m *GetAgentStatusResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAgentStatusResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAgentStatusResp proto.InternalMessageInfo
+
+func (m *GetAgentStatusResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *GetAgentStatusResp) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetAgentStatusResp) GetGitCommitHash() string {
+	if m != nil {
+		return m.GitCommitHash
+	}
+	return ""
+}
+
+func (m *GetAgentStatusResp) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *GetAgentStatusResp) GetStartTimestamp() int64 {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return 0
+}
+
+func (m *GetAgentStatusResp) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *GetAgentStatusResp) GetChannel() *ChannelStatus {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *GetAgentStatusResp) GetHeartbeat() *HeartbeatStatus {
+	if m != nil {
+		return m.Heartbeat
+	}
+	return nil
+}
+
+// ListInvocations api
+type InvocationStatus struct {
+	TaskId        string `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	InvokeVersion int32  `protobuf:"varint,2,opt,name=invokeVersion,proto3" json:"invokeVersion,omitempty"`
+	CommandName   string `protobuf:"bytes,3,opt,name=commandName,proto3" json:"commandName,omitempty"`
+	Repeat        string `protobuf:"bytes,4,opt,name=repeat,proto3" json:"repeat,omitempty"`
+	// running or queued
+	State string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	// zero when queued
+	StartTimestamp       int64    `protobuf:"varint,6,opt,name=startTimestamp,proto3" json:"startTimestamp,omitempty"`
+	ElapsedSeconds       int64    `protobuf:"varint,7,opt,name=elapsedSeconds,proto3" json:"elapsedSeconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvocationStatus) Reset()         { *m = InvocationStatus{} }
+func (m *InvocationStatus) String() string { return proto.CompactTextString(m) }
+func (*InvocationStatus) ProtoMessage()    {}
+func (*InvocationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{19}
+}
+
+func (m *InvocationStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvocationStatus.Unmarshal(m, b)
+}
+func (m *InvocationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvocationStatus.Marshal(b, m, deterministic)
+}
+func (m *InvocationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvocationStatus.Merge(m, src)
+}
+func (m *InvocationStatus) XXX_Size() int {
+	return xxx_messageInfo_InvocationStatus.Size(m)
+}
+func (m *InvocationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvocationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvocationStatus proto.InternalMessageInfo
+
+func (m *InvocationStatus) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *InvocationStatus) GetInvokeVersion() int32 {
+	if m != nil {
+		return m.InvokeVersion
+	}
+	return 0
+}
+
+func (m *InvocationStatus) GetCommandName() string {
+	if m != nil {
+		return m.CommandName
+	}
+	return ""
+}
+
+func (m *InvocationStatus) GetRepeat() string {
+	if m != nil {
+		return m.Repeat
+	}
+	return ""
+}
+
+func (m *InvocationStatus) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *InvocationStatus) GetStartTimestamp() int64 {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return 0
+}
+
+func (m *InvocationStatus) GetElapsedSeconds() int64 {
+	if m != nil {
+		return m.ElapsedSeconds
+	}
+	return 0
+}
+
+type ListInvocationsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListInvocationsReq) Reset()         { *m = ListInvocationsReq{} }
+func (m *ListInvocationsReq) String() string { return proto.CompactTextString(m) }
+func (*ListInvocationsReq) ProtoMessage()    {}
+func (*ListInvocationsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{20}
+}
+
+func (m *ListInvocationsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInvocationsReq.Unmarshal(m, b)
+}
+func (m *ListInvocationsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInvocationsReq.Marshal(b, m, deterministic)
+}
+func (m *ListInvocationsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInvocationsReq.Merge(m, src)
+}
+func (m *ListInvocationsReq) XXX_Size() int {
+	return xxx_messageInfo_ListInvocationsReq.Size(m)
+}
+func (m *ListInvocationsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInvocationsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInvocationsReq proto.InternalMessageInfo
+
+type ListInvocationsResp struct {
+	Status               *RespStatus         `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Invocations          []*InvocationStatus `protobuf:"bytes,2,rep,name=invocations,proto3" json:"invocations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListInvocationsResp) Reset()         { *m = ListInvocationsResp{} }
+func (m *ListInvocationsResp) String() string { return proto.CompactTextString(m) }
+func (*ListInvocationsResp) ProtoMessage()    {}
+func (*ListInvocationsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{21}
+}
+
+func (m *ListInvocationsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInvocationsResp.Unmarshal(m, b)
+}
+func (m *ListInvocationsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInvocationsResp.Marshal(b, m, deterministic)
+}
+func (m *ListInvocationsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInvocationsResp.Merge(m, src)
+}
+func (m *ListInvocationsResp) XXX_Size() int {
+	return xxx_messageInfo_ListInvocationsResp.Size(m)
+}
+func (m *ListInvocationsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInvocationsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInvocationsResp proto.InternalMessageInfo
+
+func (m *ListInvocationsResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListInvocationsResp) GetInvocations() []*InvocationStatus {
+	if m != nil {
+		return m.Invocations
+	}
+	return nil
+}
+
+// ListPeriodicTasks api
+type PeriodicTaskStatus struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	InvokeVersion        int32    `protobuf:"varint,2,opt,name=invokeVersion,proto3" json:"invokeVersion,omitempty"`
+	CommandName          string   `protobuf:"bytes,3,opt,name=commandName,proto3" json:"commandName,omitempty"`
+	Repeat               string   `protobuf:"bytes,4,opt,name=repeat,proto3" json:"repeat,omitempty"`
+	Expression           string   `protobuf:"bytes,5,opt,name=expression,proto3" json:"expression,omitempty"`
+	CreationTimestamp    int64    `protobuf:"varint,6,opt,name=creationTimestamp,proto3" json:"creationTimestamp,omitempty"`
+	Running              bool     `protobuf:"varint,7,opt,name=running,proto3" json:"running,omitempty"`
+	LastRunTimestamp     int64    `protobuf:"varint,8,opt,name=lastRunTimestamp,proto3" json:"lastRunTimestamp,omitempty"`
+	NextRunTimestamp     int64    `protobuf:"varint,9,opt,name=nextRunTimestamp,proto3" json:"nextRunTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeriodicTaskStatus) Reset()         { *m = PeriodicTaskStatus{} }
+func (m *PeriodicTaskStatus) String() string { return proto.CompactTextString(m) }
+func (*PeriodicTaskStatus) ProtoMessage()    {}
+func (*PeriodicTaskStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{22}
+}
+
+func (m *PeriodicTaskStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeriodicTaskStatus.Unmarshal(m, b)
+}
+func (m *PeriodicTaskStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeriodicTaskStatus.Marshal(b, m, deterministic)
+}
+func (m *PeriodicTaskStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeriodicTaskStatus.Merge(m, src)
+}
+func (m *PeriodicTaskStatus) XXX_Size() int {
+	return xxx_messageInfo_PeriodicTaskStatus.Size(m)
+}
+func (m *PeriodicTaskStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeriodicTaskStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeriodicTaskStatus proto.InternalMessageInfo
+
+func (m *PeriodicTaskStatus) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *PeriodicTaskStatus) GetInvokeVersion() int32 {
+	if m != nil {
+		return m.InvokeVersion
+	}
+	return 0
+}
+
+func (m *PeriodicTaskStatus) GetCommandName() string {
+	if m != nil {
+		return m.CommandName
+	}
+	return ""
+}
+
+func (m *PeriodicTaskStatus) GetRepeat() string {
+	if m != nil {
+		return m.Repeat
+	}
+	return ""
+}
+
+func (m *PeriodicTaskStatus) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *PeriodicTaskStatus) GetCreationTimestamp() int64 {
+	if m != nil {
+		return m.CreationTimestamp
+	}
+	return 0
+}
+
+func (m *PeriodicTaskStatus) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *PeriodicTaskStatus) GetLastRunTimestamp() int64 {
+	if m != nil {
+		return m.LastRunTimestamp
+	}
+	return 0
+}
+
+func (m *PeriodicTaskStatus) GetNextRunTimestamp() int64 {
+	if m != nil {
+		return m.NextRunTimestamp
+	}
+	return 0
+}
+
+type ListPeriodicTasksReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPeriodicTasksReq) Reset()         { *m = ListPeriodicTasksReq{} }
+func (m *ListPeriodicTasksReq) String() string { return proto.CompactTextString(m) }
+func (*ListPeriodicTasksReq) ProtoMessage()    {}
+func (*ListPeriodicTasksReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{23}
+}
+
+func (m *ListPeriodicTasksReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPeriodicTasksReq.Unmarshal(m, b)
+}
+func (m *ListPeriodicTasksReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPeriodicTasksReq.Marshal(b, m, deterministic)
+}
+func (m *ListPeriodicTasksReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPeriodicTasksReq.Merge(m, src)
+}
+func (m *ListPeriodicTasksReq) XXX_Size() int {
+	return xxx_messageInfo_ListPeriodicTasksReq.Size(m)
+}
+func (m *ListPeriodicTasksReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPeriodicTasksReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPeriodicTasksReq proto.InternalMessageInfo
+
+type ListPeriodicTasksResp struct {
+	Status               *RespStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PeriodicTasks        []*PeriodicTaskStatus `protobuf:"bytes,2,rep,name=periodicTasks,proto3" json:"periodicTasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListPeriodicTasksResp) Reset()         { *m = ListPeriodicTasksResp{} }
+func (m *ListPeriodicTasksResp) String() string { return proto.CompactTextString(m) }
+func (*ListPeriodicTasksResp) ProtoMessage()    {}
+func (*ListPeriodicTasksResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{24}
+}
+
+func (m *ListPeriodicTasksResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPeriodicTasksResp.Unmarshal(m, b)
+}
+func (m *ListPeriodicTasksResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPeriodicTasksResp.Marshal(b, m, deterministic)
+}
+func (m *ListPeriodicTasksResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPeriodicTasksResp.Merge(m, src)
+}
+func (m *ListPeriodicTasksResp) XXX_Size() int {
+	return xxx_messageInfo_ListPeriodicTasksResp.Size(m)
+}
+func (m *ListPeriodicTasksResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPeriodicTasksResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPeriodicTasksResp proto.InternalMessageInfo
+
+func (m *ListPeriodicTasksResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListPeriodicTasksResp) GetPeriodicTasks() []*PeriodicTaskStatus {
+	if m != nil {
+		return m.PeriodicTasks
+	}
+	return nil
+}
+
+// ListSessions api
+type SessionStatus struct {
+	SessionId            string   `protobuf:"bytes,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	TaskId               string   `protobuf:"bytes,2,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	Username             string   `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	TargetHost           string   `protobuf:"bytes,4,opt,name=targetHost,proto3" json:"targetHost,omitempty"`
+	PortNumber           string   `protobuf:"bytes,5,opt,name=portNumber,proto3" json:"portNumber,omitempty"`
+	ContainerId          string   `protobuf:"bytes,6,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	ContainerName        string   `protobuf:"bytes,7,opt,name=containerName,proto3" json:"containerName,omitempty"`
+	StartTimestamp       int64    `protobuf:"varint,8,opt,name=startTimestamp,proto3" json:"startTimestamp,omitempty"`
+	ElapsedSeconds       int64    `protobuf:"varint,9,opt,name=elapsedSeconds,proto3" json:"elapsedSeconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionStatus) Reset()         { *m = SessionStatus{} }
+func (m *SessionStatus) String() string { return proto.CompactTextString(m) }
+func (*SessionStatus) ProtoMessage()    {}
+func (*SessionStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{25}
+}
+
+func (m *SessionStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionStatus.Unmarshal(m, b)
+}
+func (m *SessionStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionStatus.Marshal(b, m, deterministic)
+}
+func (m *SessionStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionStatus.Merge(m, src)
+}
+func (m *SessionStatus) XXX_Size() int {
+	return xxx_messageInfo_SessionStatus.Size(m)
+}
+func (m *SessionStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionStatus proto.InternalMessageInfo
+
+func (m *SessionStatus) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetTargetHost() string {
+	if m != nil {
+		return m.TargetHost
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetPortNumber() string {
+	if m != nil {
+		return m.PortNumber
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetContainerName() string {
+	if m != nil {
+		return m.ContainerName
+	}
+	return ""
+}
+
+func (m *SessionStatus) GetStartTimestamp() int64 {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return 0
+}
+
+func (m *SessionStatus) GetElapsedSeconds() int64 {
+	if m != nil {
+		return m.ElapsedSeconds
+	}
+	return 0
+}
+
+type ListSessionsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSessionsReq) Reset()         { *m = ListSessionsReq{} }
+func (m *ListSessionsReq) String() string { return proto.CompactTextString(m) }
+func (*ListSessionsReq) ProtoMessage()    {}
+func (*ListSessionsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{26}
+}
+
+func (m *ListSessionsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSessionsReq.Unmarshal(m, b)
+}
+func (m *ListSessionsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSessionsReq.Marshal(b, m, deterministic)
+}
+func (m *ListSessionsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSessionsReq.Merge(m, src)
+}
+func (m *ListSessionsReq) XXX_Size() int {
+	return xxx_messageInfo_ListSessionsReq.Size(m)
+}
+func (m *ListSessionsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSessionsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSessionsReq proto.InternalMessageInfo
+
+type ListSessionsResp struct {
+	Status               *RespStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Sessions             []*SessionStatus `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListSessionsResp) Reset()         { *m = ListSessionsResp{} }
+func (m *ListSessionsResp) String() string { return proto.CompactTextString(m) }
+func (*ListSessionsResp) ProtoMessage()    {}
+func (*ListSessionsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{27}
+}
+
+func (m *ListSessionsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSessionsResp.Unmarshal(m, b)
+}
+func (m *ListSessionsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSessionsResp.Marshal(b, m, deterministic)
+}
+func (m *ListSessionsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSessionsResp.Merge(m, src)
+}
+func (m *ListSessionsResp) XXX_Size() int {
+	return xxx_messageInfo_ListSessionsResp.Size(m)
+}
+func (m *ListSessionsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSessionsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSessionsResp proto.InternalMessageInfo
+
+func (m *ListSessionsResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListSessionsResp) GetSessions() []*SessionStatus {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+// ListPlugins api
+type PluginStatus struct {
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version    string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	PluginType string `protobuf:"bytes,3,opt,name=pluginType,proto3" json:"pluginType,omitempty"`
+	// empty when not checked yet
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CheckTimestamp       int64    `protobuf:"varint,5,opt,name=checkTimestamp,proto3" json:"checkTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PluginStatus) Reset()         { *m = PluginStatus{} }
+func (m *PluginStatus) String() string { return proto.CompactTextString(m) }
+func (*PluginStatus) ProtoMessage()    {}
+func (*PluginStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{28}
+}
+
+func (m *PluginStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PluginStatus.Unmarshal(m, b)
+}
+func (m *PluginStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PluginStatus.Marshal(b, m, deterministic)
+}
+func (m *PluginStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PluginStatus.Merge(m, src)
+}
+func (m *PluginStatus) XXX_Size() int {
+	return xxx_messageInfo_PluginStatus.Size(m)
+}
+func (m *PluginStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PluginStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PluginStatus proto.InternalMessageInfo
+
+func (m *PluginStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PluginStatus) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *PluginStatus) GetPluginType() string {
+	if m != nil {
+		return m.PluginType
+	}
+	return ""
+}
+
+func (m *PluginStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *PluginStatus) GetCheckTimestamp() int64 {
+	if m != nil {
+		return m.CheckTimestamp
+	}
+	return 0
+}
+
+type ListPluginsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPluginsReq) Reset()         { *m = ListPluginsReq{} }
+func (m *ListPluginsReq) String() string { return proto.CompactTextString(m) }
+func (*ListPluginsReq) ProtoMessage()    {}
+func (*ListPluginsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{29}
+}
+
+func (m *ListPluginsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPluginsReq.Unmarshal(m, b)
+}
+func (m *ListPluginsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPluginsReq.Marshal(b, m, deterministic)
+}
+func (m *ListPluginsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPluginsReq.Merge(m, src)
+}
+func (m *ListPluginsReq) XXX_Size() int {
+	return xxx_messageInfo_ListPluginsReq.Size(m)
+}
+func (m *ListPluginsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPluginsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPluginsReq proto.InternalMessageInfo
+
+type ListPluginsResp struct {
+	Status               *RespStatus     `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Plugins              []*PluginStatus `protobuf:"bytes,2,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListPluginsResp) Reset()         { *m = ListPluginsResp{} }
+func (m *ListPluginsResp) String() string { return proto.CompactTextString(m) }
+func (*ListPluginsResp) ProtoMessage()    {}
+func (*ListPluginsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{30}
+}
+
+func (m *ListPluginsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPluginsResp.Unmarshal(m, b)
+}
+func (m *ListPluginsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPluginsResp.Marshal(b, m, deterministic)
+}
+func (m *ListPluginsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPluginsResp.Merge(m, src)
+}
+func (m *ListPluginsResp) XXX_Size() int {
+	return xxx_messageInfo_ListPluginsResp.Size(m)
+}
+func (m *ListPluginsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPluginsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPluginsResp proto.InternalMessageInfo
+
+func (m *ListPluginsResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListPluginsResp) GetPlugins() []*PluginStatus {
+	if m != nil {
+		return m.Plugins
+	}
+	return nil
+}
+
+// ListStateConfigs api
+type StateConfigStatus struct {
+	StateConfigurationId string   `protobuf:"bytes,1,opt,name=stateConfigurationId,proto3" json:"stateConfigurationId,omitempty"`
+	TemplateName         string   `protobuf:"bytes,2,opt,name=templateName,proto3" json:"templateName,omitempty"`
+	TemplateVersion      string   `protobuf:"bytes,3,opt,name=templateVersion,proto3" json:"templateVersion,omitempty"`
+	ConfigureMode        string   `protobuf:"bytes,4,opt,name=configureMode,proto3" json:"configureMode,omitempty"`
+	ScheduleType         string   `protobuf:"bytes,5,opt,name=scheduleType,proto3" json:"scheduleType,omitempty"`
+	ScheduleExpression   string   `protobuf:"bytes,6,opt,name=scheduleExpression,proto3" json:"scheduleExpression,omitempty"`
+	SuccessfulApplyTime  string   `protobuf:"bytes,7,opt,name=successfulApplyTime,proto3" json:"successfulApplyTime,omitempty"`
+	LastStatus           string   `protobuf:"bytes,8,opt,name=lastStatus,proto3" json:"lastStatus,omitempty"`
+	LastEnforceTimestamp int64    `protobuf:"varint,9,opt,name=lastEnforceTimestamp,proto3" json:"lastEnforceTimestamp,omitempty"`
+	NextRunTimestamp     int64    `protobuf:"varint,10,opt,name=nextRunTimestamp,proto3" json:"nextRunTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateConfigStatus) Reset()         { *m = StateConfigStatus{} }
+func (m *StateConfigStatus) String() string { return proto.CompactTextString(m) }
+func (*StateConfigStatus) ProtoMessage()    {}
+func (*StateConfigStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{31}
+}
+
+func (m *StateConfigStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateConfigStatus.Unmarshal(m, b)
+}
+func (m *StateConfigStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateConfigStatus.Marshal(b, m, deterministic)
+}
+func (m *StateConfigStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateConfigStatus.Merge(m, src)
+}
+func (m *StateConfigStatus) XXX_Size() int {
+	return xxx_messageInfo_StateConfigStatus.Size(m)
+}
+func (m *StateConfigStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateConfigStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateConfigStatus proto.InternalMessageInfo
+
+func (m *StateConfigStatus) GetStateConfigurationId() string {
+	if m != nil {
+		return m.StateConfigurationId
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetTemplateName() string {
+	if m != nil {
+		return m.TemplateName
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetTemplateVersion() string {
+	if m != nil {
+		return m.TemplateVersion
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetConfigureMode() string {
+	if m != nil {
+		return m.ConfigureMode
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetScheduleType() string {
+	if m != nil {
+		return m.ScheduleType
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetScheduleExpression() string {
+	if m != nil {
+		return m.ScheduleExpression
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetSuccessfulApplyTime() string {
+	if m != nil {
+		return m.SuccessfulApplyTime
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetLastStatus() string {
+	if m != nil {
+		return m.LastStatus
+	}
+	return ""
+}
+
+func (m *StateConfigStatus) GetLastEnforceTimestamp() int64 {
+	if m != nil {
+		return m.LastEnforceTimestamp
+	}
+	return 0
+}
+
+func (m *StateConfigStatus) GetNextRunTimestamp() int64 {
+	if m != nil {
+		return m.NextRunTimestamp
+	}
+	return 0
+}
+
+type ListStateConfigsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStateConfigsReq) Reset()         { *m = ListStateConfigsReq{} }
+func (m *ListStateConfigsReq) String() string { return proto.CompactTextString(m) }
+func (*ListStateConfigsReq) ProtoMessage()    {}
+func (*ListStateConfigsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{32}
+}
+
+func (m *ListStateConfigsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStateConfigsReq.Unmarshal(m, b)
+}
+func (m *ListStateConfigsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStateConfigsReq.Marshal(b, m, deterministic)
+}
+func (m *ListStateConfigsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStateConfigsReq.Merge(m, src)
+}
+func (m *ListStateConfigsReq) XXX_Size() int {
+	return xxx_messageInfo_ListStateConfigsReq.Size(m)
+}
+func (m *ListStateConfigsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStateConfigsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStateConfigsReq proto.InternalMessageInfo
+
+type ListStateConfigsResp struct {
+	Status               *RespStatus          `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	StateConfigs         []*StateConfigStatus `protobuf:"bytes,2,rep,name=stateConfigs,proto3" json:"stateConfigs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListStateConfigsResp) Reset()         { *m = ListStateConfigsResp{} }
+func (m *ListStateConfigsResp) String() string { return proto.CompactTextString(m) }
+func (*ListStateConfigsResp) ProtoMessage()    {}
+func (*ListStateConfigsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{33}
+}
+
+func (m *ListStateConfigsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStateConfigsResp.Unmarshal(m, b)
+}
+func (m *ListStateConfigsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStateConfigsResp.Marshal(b, m, deterministic)
+}
+func (m *ListStateConfigsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStateConfigsResp.Merge(m, src)
+}
+func (m *ListStateConfigsResp) XXX_Size() int {
+	return xxx_messageInfo_ListStateConfigsResp.Size(m)
+}
+func (m *ListStateConfigsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStateConfigsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStateConfigsResp proto.InternalMessageInfo
+
+func (m *ListStateConfigsResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListStateConfigsResp) GetStateConfigs() []*StateConfigStatus {
+	if m != nil {
+		return m.StateConfigs
+	}
+	return nil
+}
+
+// RunCommand api
+type RunCommandReq struct {
+	// RunShellScript, RunBatScript or RunPowerShellScript
+	CommandType string `protobuf:"bytes,1,opt,name=commandType,proto3" json:"commandType,omitempty"`
+	CommandName string `protobuf:"bytes,2,opt,name=commandName,proto3" json:"commandName,omitempty"`
+	// plain text of script
+	Content    string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	WorkingDir string `protobuf:"bytes,4,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	Username   string `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	LoginShell bool   `protobuf:"varint,6,opt,name=loginShell,proto3" json:"loginShell,omitempty"`
+	// in seconds, default 3600
+	Timeout               int32  `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	ContainerId           string `protobuf:"bytes,8,opt,name=containerId,proto3" json:"containerId,omitempty"`
+	ContainerName         string `protobuf:"bytes,9,opt,name=containerName,proto3" json:"containerName,omitempty"`
+	PodNamespace          string `protobuf:"bytes,10,opt,name=podNamespace,proto3" json:"podNamespace,omitempty"`
+	PodName               string `protobuf:"bytes,11,opt,name=podName,proto3" json:"podName,omitempty"`
+	PodLabelSelector      string `protobuf:"bytes,12,opt,name=podLabelSelector,proto3" json:"podLabelSelector,omitempty"`
+	ContentSignature      string `protobuf:"bytes,13,opt,name=contentSignature,proto3" json:"contentSignature,omitempty"`
+	ContentSignatureKeyId string `protobuf:"bytes,14,opt,name=contentSignatureKeyId,proto3" json:"contentSignatureKeyId,omitempty"`
+	// unix time in seconds after which content signature expires
+	ContentSignatureExpireTime int64    `protobuf:"varint,15,opt,name=contentSignatureExpireTime,proto3" json:"contentSignatureExpireTime,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
+	XXX_unrecognized           []byte   `json:"-"`
+	XXX_sizecache              int32    `json:"-"`
+}
+
+func (m *RunCommandReq) Reset()         { *m = RunCommandReq{} }
+func (m *RunCommandReq) String() string { return proto.CompactTextString(m) }
+func (*RunCommandReq) ProtoMessage()    {}
+func (*RunCommandReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{34}
+}
+
+func (m *RunCommandReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunCommandReq.Unmarshal(m, b)
+}
+func (m *RunCommandReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunCommandReq.Marshal(b, m, deterministic)
+}
+func (m *RunCommandReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunCommandReq.Merge(m, src)
+}
+func (m *RunCommandReq) XXX_Size() int {
+	return xxx_messageInfo_RunCommandReq.Size(m)
+}
+func (m *RunCommandReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunCommandReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunCommandReq proto.InternalMessageInfo
+
+func (m *RunCommandReq) GetCommandType() string {
+	if m != nil {
+		return m.CommandType
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetCommandName() string {
+	if m != nil {
+		return m.CommandName
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetWorkingDir() string {
+	if m != nil {
+		return m.WorkingDir
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetLoginShell() bool {
+	if m != nil {
+		return m.LoginShell
+	}
+	return false
+}
+
+func (m *RunCommandReq) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *RunCommandReq) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetContainerName() string {
+	if m != nil {
+		return m.ContainerName
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetPodNamespace() string {
+	if m != nil {
+		return m.PodNamespace
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetPodLabelSelector() string {
+	if m != nil {
+		return m.PodLabelSelector
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetContentSignature() string {
+	if m != nil {
+		return m.ContentSignature
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetContentSignatureKeyId() string {
+	if m != nil {
+		return m.ContentSignatureKeyId
+	}
+	return ""
+}
+
+func (m *RunCommandReq) GetContentSignatureExpireTime() int64 {
+	if m != nil {
+		return m.ContentSignatureExpireTime
+	}
+	return 0
+}
+
+type RunCommandResp struct {
+	Status               *RespStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	TaskId               string      `protobuf:"bytes,2,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RunCommandResp) Reset()         { *m = RunCommandResp{} }
+func (m *RunCommandResp) String() string { return proto.CompactTextString(m) }
+func (*RunCommandResp) ProtoMessage()    {}
+func (*RunCommandResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{35}
+}
+
+func (m *RunCommandResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunCommandResp.Unmarshal(m, b)
+}
+func (m *RunCommandResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunCommandResp.Marshal(b, m, deterministic)
+}
+func (m *RunCommandResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunCommandResp.Merge(m, src)
+}
+func (m *RunCommandResp) XXX_Size() int {
+	return xxx_messageInfo_RunCommandResp.Size(m)
+}
+func (m *RunCommandResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunCommandResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunCommandResp proto.InternalMessageInfo
+
+func (m *RunCommandResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *RunCommandResp) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+// StopCommand api
+type StopCommandReq struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopCommandReq) Reset()         { *m = StopCommandReq{} }
+func (m *StopCommandReq) String() string { return proto.CompactTextString(m) }
+func (*StopCommandReq) ProtoMessage()    {}
+func (*StopCommandReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{36}
+}
+
+func (m *StopCommandReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopCommandReq.Unmarshal(m, b)
+}
+func (m *StopCommandReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopCommandReq.Marshal(b, m, deterministic)
+}
+func (m *StopCommandReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopCommandReq.Merge(m, src)
+}
+func (m *StopCommandReq) XXX_Size() int {
+	return xxx_messageInfo_StopCommandReq.Size(m)
+}
+func (m *StopCommandReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopCommandReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopCommandReq proto.InternalMessageInfo
+
+func (m *StopCommandReq) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+type StopCommandResp struct {
+	Status               *RespStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *StopCommandResp) Reset()         { *m = StopCommandResp{} }
+func (m *StopCommandResp) String() string { return proto.CompactTextString(m) }
+func (*StopCommandResp) ProtoMessage()    {}
+func (*StopCommandResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{37}
+}
+
+func (m *StopCommandResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopCommandResp.Unmarshal(m, b)
+}
+func (m *StopCommandResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopCommandResp.Marshal(b, m, deterministic)
+}
+func (m *StopCommandResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopCommandResp.Merge(m, src)
+}
+func (m *StopCommandResp) XXX_Size() int {
+	return xxx_messageInfo_StopCommandResp.Size(m)
+}
+func (m *StopCommandResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopCommandResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopCommandResp proto.InternalMessageInfo
+
+func (m *StopCommandResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// WatchCommand api
+type WatchCommandReq struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchCommandReq) Reset()         { *m = WatchCommandReq{} }
+func (m *WatchCommandReq) String() string { return proto.CompactTextString(m) }
+func (*WatchCommandReq) ProtoMessage()    {}
+func (*WatchCommandReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{38}
+}
+
+func (m *WatchCommandReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchCommandReq.Unmarshal(m, b)
+}
+func (m *WatchCommandReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchCommandReq.Marshal(b, m, deterministic)
+}
+func (m *WatchCommandReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchCommandReq.Merge(m, src)
+}
+func (m *WatchCommandReq) XXX_Size() int {
+	return xxx_messageInfo_WatchCommandReq.Size(m)
+}
+func (m *WatchCommandReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchCommandReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchCommandReq proto.InternalMessageInfo
+
+func (m *WatchCommandReq) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+type CommandEvent struct {
+	// started, output or finished
+	Type      string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp int64  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Output    string `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// finished, timeout, failed, canceled or invalid, only set when finished
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	ExitCode             int32    `protobuf:"varint,5,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	ErrCode              string   `protobuf:"bytes,6,opt,name=errCode,proto3" json:"errCode,omitempty"`
+	ErrMessage           string   `protobuf:"bytes,7,opt,name=errMessage,proto3" json:"errMessage,omitempty"`
+	Dropped              int64    `protobuf:"varint,8,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandEvent) Reset()         { *m = CommandEvent{} }
+func (m *CommandEvent) String() string { return proto.CompactTextString(m) }
+func (*CommandEvent) ProtoMessage()    {}
+func (*CommandEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{39}
+}
+
+func (m *CommandEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandEvent.Unmarshal(m, b)
+}
+func (m *CommandEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandEvent.Marshal(b, m, deterministic)
+}
+func (m *CommandEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandEvent.Merge(m, src)
+}
+func (m *CommandEvent) XXX_Size() int {
+	return xxx_messageInfo_CommandEvent.Size(m)
+}
+func (m *CommandEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandEvent proto.InternalMessageInfo
+
+func (m *CommandEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CommandEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *CommandEvent) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *CommandEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CommandEvent) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *CommandEvent) GetErrCode() string {
+	if m != nil {
+		return m.ErrCode
+	}
+	return ""
+}
+
+func (m *CommandEvent) GetErrMessage() string {
+	if m != nil {
+		return m.ErrMessage
+	}
+	return ""
+}
+
+func (m *CommandEvent) GetDropped() int64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+// StreamLogs api
+type StreamLogsReq struct {
+	// panic, fatal, error, warning, info, debug or trace, default info
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// package path under agent like "taskengine", covering its sub-packages
+	Module               string   `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	TaskId               string   `protobuf:"bytes,3,opt,name=taskId,proto3" json:"taskId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamLogsReq) Reset()         { *m = StreamLogsReq{} }
+func (m *StreamLogsReq) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsReq) ProtoMessage()    {}
+func (*StreamLogsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{40}
+}
+
+func (m *StreamLogsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsReq.Unmarshal(m, b)
+}
+func (m *StreamLogsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsReq.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsReq.Merge(m, src)
+}
+func (m *StreamLogsReq) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsReq.Size(m)
+}
+func (m *StreamLogsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsReq proto.InternalMessageInfo
+
+func (m *StreamLogsReq) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *StreamLogsReq) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *StreamLogsReq) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+type LogEntry struct {
+	// in milliseconds
+	Timestamp int64             `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Level     string            `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Module    string            `protobuf:"bytes,3,opt,name=module,proto3" json:"module,omitempty"`
+	Message   string            `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Fields    map[string]string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// entries dropped before this one since the receiver fell behind
+	Dropped              int64    `protobuf:"varint,6,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{41}
+}
+
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (m *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(m, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *LogEntry) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *LogEntry) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *LogEntry) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *LogEntry) GetFields() map[string]string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *LogEntry) GetDropped() int64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+// SetLogLevel api
+type SetLogLevelReq struct {
+	// empty module covers the whole agent
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Level  string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// in seconds, default 1800
+	Ttl                  int32    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetLogLevelReq) Reset()         { *m = SetLogLevelReq{} }
+func (m *SetLogLevelReq) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelReq) ProtoMessage()    {}
+func (*SetLogLevelReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{42}
+}
+
+func (m *SetLogLevelReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelReq.Unmarshal(m, b)
+}
+func (m *SetLogLevelReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelReq.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelReq.Merge(m, src)
+}
+func (m *SetLogLevelReq) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelReq.Size(m)
+}
+func (m *SetLogLevelReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelReq proto.InternalMessageInfo
+
+func (m *SetLogLevelReq) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *SetLogLevelReq) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *SetLogLevelReq) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type SetLogLevelResp struct {
+	Status               *RespStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ExpireTimestamp      int64       `protobuf:"varint,2,opt,name=expireTimestamp,proto3" json:"expireTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SetLogLevelResp) Reset()         { *m = SetLogLevelResp{} }
+func (m *SetLogLevelResp) String() string { return proto.CompactTextString(m) }
+func (*SetLogLevelResp) ProtoMessage()    {}
+func (*SetLogLevelResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{43}
+}
+
+func (m *SetLogLevelResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetLogLevelResp.Unmarshal(m, b)
+}
+func (m *SetLogLevelResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetLogLevelResp.Marshal(b, m, deterministic)
+}
+func (m *SetLogLevelResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetLogLevelResp.Merge(m, src)
+}
+func (m *SetLogLevelResp) XXX_Size() int {
+	return xxx_messageInfo_SetLogLevelResp.Size(m)
+}
+func (m *SetLogLevelResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetLogLevelResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetLogLevelResp proto.InternalMessageInfo
+
+func (m *SetLogLevelResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *SetLogLevelResp) GetExpireTimestamp() int64 {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return 0
+}
+
+// ResetLogLevel api
+type ResetLogLevelReq struct {
+	Module               string   `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetLogLevelReq) Reset()         { *m = ResetLogLevelReq{} }
+func (m *ResetLogLevelReq) String() string { return proto.CompactTextString(m) }
+func (*ResetLogLevelReq) ProtoMessage()    {}
+func (*ResetLogLevelReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{44}
+}
+
+func (m *ResetLogLevelReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetLogLevelReq.Unmarshal(m, b)
+}
+func (m *ResetLogLevelReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetLogLevelReq.Marshal(b, m, deterministic)
+}
+func (m *ResetLogLevelReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetLogLevelReq.Merge(m, src)
+}
+func (m *ResetLogLevelReq) XXX_Size() int {
+	return xxx_messageInfo_ResetLogLevelReq.Size(m)
+}
+func (m *ResetLogLevelReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetLogLevelReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetLogLevelReq proto.InternalMessageInfo
+
+func (m *ResetLogLevelReq) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+type ResetLogLevelResp struct {
+	Status               *RespStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ResetLogLevelResp) Reset()         { *m = ResetLogLevelResp{} }
+func (m *ResetLogLevelResp) String() string { return proto.CompactTextString(m) }
+func (*ResetLogLevelResp) ProtoMessage()    {}
+func (*ResetLogLevelResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{45}
+}
+
+func (m *ResetLogLevelResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetLogLevelResp.Unmarshal(m, b)
+}
+func (m *ResetLogLevelResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetLogLevelResp.Marshal(b, m, deterministic)
+}
+func (m *ResetLogLevelResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetLogLevelResp.Merge(m, src)
+}
+func (m *ResetLogLevelResp) XXX_Size() int {
+	return xxx_messageInfo_ResetLogLevelResp.Size(m)
+}
+func (m *ResetLogLevelResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetLogLevelResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetLogLevelResp proto.InternalMessageInfo
+
+func (m *ResetLogLevelResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// ListLogLevels api
+type ModuleLogLevel struct {
+	Module               string   `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Level                string   `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	ExpireTimestamp      int64    `protobuf:"varint,3,opt,name=expireTimestamp,proto3" json:"expireTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModuleLogLevel) Reset()         { *m = ModuleLogLevel{} }
+func (m *ModuleLogLevel) String() string { return proto.CompactTextString(m) }
+func (*ModuleLogLevel) ProtoMessage()    {}
+func (*ModuleLogLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{46}
+}
+
+func (m *ModuleLogLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModuleLogLevel.Unmarshal(m, b)
+}
+func (m *ModuleLogLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModuleLogLevel.Marshal(b, m, deterministic)
+}
+func (m *ModuleLogLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModuleLogLevel.Merge(m, src)
+}
+func (m *ModuleLogLevel) XXX_Size() int {
+	return xxx_messageInfo_ModuleLogLevel.Size(m)
+}
+func (m *ModuleLogLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModuleLogLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModuleLogLevel proto.InternalMessageInfo
+
+func (m *ModuleLogLevel) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *ModuleLogLevel) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *ModuleLogLevel) GetExpireTimestamp() int64 {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return 0
+}
+
+type ListLogLevelsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLogLevelsReq) Reset()         { *m = ListLogLevelsReq{} }
+func (m *ListLogLevelsReq) String() string { return proto.CompactTextString(m) }
+func (*ListLogLevelsReq) ProtoMessage()    {}
+func (*ListLogLevelsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{47}
+}
+
+func (m *ListLogLevelsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLogLevelsReq.Unmarshal(m, b)
+}
+func (m *ListLogLevelsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLogLevelsReq.Marshal(b, m, deterministic)
+}
+func (m *ListLogLevelsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLogLevelsReq.Merge(m, src)
+}
+func (m *ListLogLevelsReq) XXX_Size() int {
+	return xxx_messageInfo_ListLogLevelsReq.Size(m)
+}
+func (m *ListLogLevelsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLogLevelsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLogLevelsReq proto.InternalMessageInfo
+
+type ListLogLevelsResp struct {
+	Status               *RespStatus       `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	DefaultLevel         string            `protobuf:"bytes,2,opt,name=defaultLevel,proto3" json:"defaultLevel,omitempty"`
+	Levels               []*ModuleLogLevel `protobuf:"bytes,3,rep,name=levels,proto3" json:"levels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListLogLevelsResp) Reset()         { *m = ListLogLevelsResp{} }
+func (m *ListLogLevelsResp) String() string { return proto.CompactTextString(m) }
+func (*ListLogLevelsResp) ProtoMessage()    {}
+func (*ListLogLevelsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9840c882bb9a7ad, []int{48}
+}
+
+func (m *ListLogLevelsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLogLevelsResp.Unmarshal(m, b)
+}
+func (m *ListLogLevelsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLogLevelsResp.Marshal(b, m, deterministic)
+}
+func (m *ListLogLevelsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLogLevelsResp.Merge(m, src)
+}
+func (m *ListLogLevelsResp) XXX_Size() int {
+	return xxx_messageInfo_ListLogLevelsResp.Size(m)
+}
+func (m *ListLogLevelsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLogLevelsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLogLevelsResp proto.InternalMessageInfo
+
+func (m *ListLogLevelsResp) GetStatus() *RespStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListLogLevelsResp) GetDefaultLevel() string {
+	if m != nil {
+		return m.DefaultLevel
+	}
+	return ""
+}
+
+func (m *ListLogLevelsResp) GetLevels() []*ModuleLogLevel {
+	if m != nil {
+		return m.Levels
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RespStatus)(nil), "protos.RespStatus")
 	proto.RegisterType((*KeyInfo)(nil), "protos.KeyInfo")
@@ -763,6 +2750,41 @@ func init() {
 	proto.RegisterType((*SecretParamInfo)(nil), "protos.SecretParamInfo")
 	proto.RegisterType((*CreateSecretParamReq)(nil), "protos.CreateSecretParamReq")
 	proto.RegisterType((*CreateSecretParamResp)(nil), "protos.CreateSecretParamResp")
+	proto.RegisterType((*ChannelStatus)(nil), "protos.ChannelStatus")
+	proto.RegisterType((*HeartbeatStatus)(nil), "protos.HeartbeatStatus")
+	proto.RegisterType((*GetAgentStatusReq)(nil), "protos.GetAgentStatusReq")
+	proto.RegisterType((*GetAgentStatusResp)(nil), "protos.GetAgentStatusResp")
+	proto.RegisterType((*InvocationStatus)(nil), "protos.InvocationStatus")
+	proto.RegisterType((*ListInvocationsReq)(nil), "protos.ListInvocationsReq")
+	proto.RegisterType((*ListInvocationsResp)(nil), "protos.ListInvocationsResp")
+	proto.RegisterType((*PeriodicTaskStatus)(nil), "protos.PeriodicTaskStatus")
+	proto.RegisterType((*ListPeriodicTasksReq)(nil), "protos.ListPeriodicTasksReq")
+	proto.RegisterType((*ListPeriodicTasksResp)(nil), "protos.ListPeriodicTasksResp")
+	proto.RegisterType((*SessionStatus)(nil), "protos.SessionStatus")
+	proto.RegisterType((*ListSessionsReq)(nil), "protos.ListSessionsReq")
+	proto.RegisterType((*ListSessionsResp)(nil), "protos.ListSessionsResp")
+	proto.RegisterType((*PluginStatus)(nil), "protos.PluginStatus")
+	proto.RegisterType((*ListPluginsReq)(nil), "protos.ListPluginsReq")
+	proto.RegisterType((*ListPluginsResp)(nil), "protos.ListPluginsResp")
+	proto.RegisterType((*StateConfigStatus)(nil), "protos.StateConfigStatus")
+	proto.RegisterType((*ListStateConfigsReq)(nil), "protos.ListStateConfigsReq")
+	proto.RegisterType((*ListStateConfigsResp)(nil), "protos.ListStateConfigsResp")
+	proto.RegisterType((*RunCommandReq)(nil), "protos.RunCommandReq")
+	proto.RegisterType((*RunCommandResp)(nil), "protos.RunCommandResp")
+	proto.RegisterType((*StopCommandReq)(nil), "protos.StopCommandReq")
+	proto.RegisterType((*StopCommandResp)(nil), "protos.StopCommandResp")
+	proto.RegisterType((*WatchCommandReq)(nil), "protos.WatchCommandReq")
+	proto.RegisterType((*CommandEvent)(nil), "protos.CommandEvent")
+	proto.RegisterType((*StreamLogsReq)(nil), "protos.StreamLogsReq")
+	proto.RegisterType((*LogEntry)(nil), "protos.LogEntry")
+	proto.RegisterMapType((map[string]string)(nil), "protos.LogEntry.FieldsEntry")
+	proto.RegisterType((*SetLogLevelReq)(nil), "protos.SetLogLevelReq")
+	proto.RegisterType((*SetLogLevelResp)(nil), "protos.SetLogLevelResp")
+	proto.RegisterType((*ResetLogLevelReq)(nil), "protos.ResetLogLevelReq")
+	proto.RegisterType((*ResetLogLevelResp)(nil), "protos.ResetLogLevelResp")
+	proto.RegisterType((*ModuleLogLevel)(nil), "protos.ModuleLogLevel")
+	proto.RegisterType((*ListLogLevelsReq)(nil), "protos.ListLogLevelsReq")
+	proto.RegisterType((*ListLogLevelsResp)(nil), "protos.ListLogLevelsResp")
 }
 
 func init() {
@@ -770,44 +2792,142 @@ func init() {
 }
 
 var fileDescriptor_d9840c882bb9a7ad = []byte{
-	// 616 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5d, 0x4f, 0xd4, 0x40,
-	0x14, 0xa5, 0x94, 0xcf, 0x5b, 0x0c, 0x30, 0x60, 0xac, 0x2b, 0x92, 0xcd, 0x3c, 0xa1, 0x24, 0x6c,
-	0x02, 0x89, 0xe2, 0x23, 0xe0, 0x17, 0xac, 0x1a, 0x32, 0x90, 0x18, 0x7d, 0x21, 0x43, 0xb9, 0x2e,
-	0x23, 0xdb, 0x0f, 0x3b, 0xb3, 0x86, 0xbe, 0xf8, 0xee, 0x8b, 0xff, 0xc0, 0x27, 0xff, 0xa8, 0xe9,
-	0xf4, 0x6b, 0xd8, 0x2e, 0xac, 0x8d, 0x4f, 0xdd, 0x3d, 0xf7, 0xde, 0x93, 0x33, 0xe7, 0xf6, 0x4c,
-	0xc1, 0xe1, 0xbd, 0x38, 0xf2, 0xb6, 0xa2, 0x38, 0x54, 0x21, 0x99, 0xd1, 0x0f, 0x49, 0xdf, 0x01,
-	0x30, 0x94, 0xd1, 0x89, 0xe2, 0x6a, 0x20, 0xc9, 0x3a, 0x80, 0xd4, 0xbf, 0x0e, 0xc2, 0x0b, 0x74,
-	0xad, 0xb6, 0xb5, 0x31, 0xcd, 0x0c, 0x24, 0xad, 0x63, 0x1c, 0xbf, 0x47, 0x29, 0x79, 0x0f, 0xdd,
-	0xc9, 0xb6, 0xb5, 0x31, 0xcf, 0x0c, 0x84, 0xfe, 0xb6, 0x60, 0xb6, 0x8b, 0xc9, 0x61, 0xf0, 0x25,
-	0x24, 0x6b, 0x30, 0x7f, 0x85, 0xc9, 0x31, 0x17, 0xf1, 0xe1, 0x85, 0xa6, 0x9a, 0x67, 0x15, 0x90,
-	0x56, 0xa3, 0xc1, 0x79, 0x5f, 0x78, 0x5d, 0x4c, 0x72, 0xa2, 0x0a, 0x20, 0x4f, 0x61, 0xc9, 0x8b,
-	0x91, 0x2b, 0xbc, 0x38, 0x15, 0x3e, 0x4a, 0xc5, 0xfd, 0xc8, 0xb5, 0xdb, 0xd6, 0x86, 0xcd, 0x6a,
-	0x78, 0xda, 0x8b, 0xd7, 0x91, 0x88, 0xcd, 0xde, 0xa9, 0xac, 0x77, 0x18, 0xa7, 0x47, 0xb0, 0xf4,
-	0x06, 0x03, 0x26, 0x79, 0x37, 0x13, 0xc2, 0xf0, 0xdb, 0x18, 0x9d, 0x2e, 0xcc, 0x2a, 0xe1, 0x63,
-	0x38, 0x50, 0x5a, 0xe5, 0x34, 0x2b, 0xfe, 0xd2, 0xaf, 0xb0, 0x3c, 0xc4, 0x25, 0x53, 0x31, 0x33,
-	0x99, 0x5d, 0x9a, 0xc9, 0xd9, 0x26, 0x99, 0xdd, 0x72, 0xab, 0x32, 0x99, 0xe5, 0x1d, 0xe4, 0x09,
-	0xcc, 0x5e, 0x65, 0x5e, 0x69, 0x6a, 0x67, 0x7b, 0xb1, 0x68, 0xce, 0x2d, 0x64, 0x45, 0x9d, 0xee,
-	0xc0, 0x0a, 0x43, 0x3f, 0xfc, 0x8e, 0x0d, 0xa4, 0xd3, 0x7d, 0x58, 0xad, 0x0f, 0x35, 0xd3, 0x48,
-	0xdf, 0x02, 0xbc, 0x0a, 0xbc, 0x38, 0x89, 0xd4, 0x78, 0xab, 0xd2, 0x95, 0xf6, 0xb9, 0x08, 0x4e,
-	0xf1, 0x5a, 0x95, 0x2b, 0x2d, 0x00, 0xfa, 0x09, 0x9c, 0x92, 0xa9, 0xa1, 0x51, 0xeb, 0x00, 0x9e,
-	0x88, 0x2e, 0x31, 0x36, 0x98, 0x0d, 0x84, 0x1e, 0x01, 0xbc, 0xc4, 0x7f, 0x14, 0x39, 0x8e, 0xeb,
-	0x23, 0x38, 0x25, 0x57, 0x43, 0x99, 0x77, 0x9f, 0x7f, 0x13, 0x9c, 0x83, 0x4b, 0xf4, 0xae, 0xba,
-	0x98, 0x8c, 0x5f, 0x5d, 0x0f, 0x16, 0xaa, 0xe6, 0x86, 0x32, 0x36, 0x61, 0x2e, 0x7f, 0x6d, 0xa4,
-	0x3b, 0xd9, 0xb6, 0x47, 0xbd, 0x57, 0x65, 0x03, 0xfd, 0x69, 0xc1, 0xe2, 0x09, 0x7a, 0x31, 0xaa,
-	0x63, 0x1e, 0x73, 0x5f, 0x07, 0x37, 0xbd, 0x04, 0x34, 0xf4, 0x81, 0xfb, 0x98, 0x6b, 0x33, 0x90,
-	0x91, 0xe1, 0x9c, 0x6c, 0x10, 0x4e, 0xfb, 0x96, 0x70, 0xfe, 0xb2, 0x60, 0xf5, 0x40, 0x13, 0x18,
-	0x8a, 0xfe, 0x7b, 0xa3, 0x43, 0xc7, 0xb1, 0x6b, 0xc7, 0x31, 0x12, 0x3e, 0x75, 0x33, 0xe1, 0x3f,
-	0xe0, 0xfe, 0x08, 0x3d, 0x0d, 0xd7, 0xf1, 0x02, 0x1c, 0x59, 0x8d, 0xe7, 0x49, 0x7f, 0x50, 0x0c,
-	0x0c, 0x79, 0xcf, 0xcc, 0xde, 0xed, 0x3f, 0x36, 0x38, 0x7b, 0x52, 0x0a, 0xa9, 0xf6, 0x7a, 0x18,
-	0x28, 0xf2, 0x1a, 0xee, 0xdd, 0xb8, 0x71, 0x88, 0x5b, 0xd0, 0x0c, 0x5f, 0x6a, 0xad, 0x87, 0xb7,
-	0x54, 0x64, 0x44, 0x27, 0x48, 0x17, 0x16, 0x98, 0x6f, 0xd0, 0x3c, 0xaa, 0xe4, 0xd7, 0xee, 0x98,
-	0xd6, 0xda, 0xed, 0x45, 0x4d, 0xb6, 0x5b, 0xe6, 0x5a, 0xbb, 0x5d, 0x5a, 0x51, 0x5d, 0x1b, 0xad,
-	0x95, 0x1a, 0x56, 0x4c, 0xe6, 0x51, 0xbb, 0x39, 0x59, 0x65, 0xb9, 0x9a, 0x34, 0x32, 0x49, 0x27,
-	0xc8, 0x73, 0x98, 0x2b, 0xe2, 0x41, 0xca, 0x16, 0x23, 0x5d, 0xad, 0xd5, 0x3a, 0xa8, 0x07, 0x19,
-	0x2c, 0xd7, 0x36, 0x4a, 0xca, 0x13, 0x8e, 0x7a, 0xf9, 0x5a, 0x8f, 0xef, 0xa8, 0xa6, 0x9c, 0xfb,
-	0xbb, 0x9f, 0x9f, 0xf5, 0x84, 0xba, 0x1c, 0x9c, 0x6f, 0x79, 0xa1, 0xdf, 0xe1, 0x7d, 0x91, 0x0c,
-	0x82, 0xfc, 0x71, 0xc6, 0xf5, 0xf6, 0xce, 0xbc, 0xbe, 0xc0, 0x40, 0x75, 0x78, 0xba, 0xc5, 0x8e,
-	0x88, 0xbc, 0x8e, 0xfe, 0x12, 0x9f, 0x67, 0xdf, 0xe0, 0x9d, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xf6, 0xde, 0x84, 0x5e, 0x99, 0x07, 0x00, 0x00,
+	// 2181 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4f, 0x73, 0xe3, 0x48,
+	0x15, 0x5f, 0xdb, 0x71, 0x6c, 0x3f, 0x3b, 0x76, 0xd2, 0xf9, 0xe7, 0xd1, 0xce, 0x6e, 0xa5, 0x54,
+	0xd4, 0x54, 0x76, 0x97, 0x4a, 0x96, 0xd9, 0x05, 0x66, 0xb6, 0x6a, 0x60, 0x67, 0x93, 0x0c, 0x33,
+	0x9b, 0xcc, 0x92, 0x92, 0x03, 0x5b, 0x70, 0xd9, 0x52, 0xe4, 0x17, 0x5b, 0x44, 0x96, 0x34, 0x52,
+	0x2b, 0xc4, 0x07, 0xa8, 0xe2, 0xc0, 0x81, 0x0b, 0x17, 0xae, 0xf0, 0x39, 0xa8, 0x3d, 0x72, 0xe3,
+	0xc0, 0x87, 0xe0, 0x23, 0x70, 0xe7, 0x42, 0x75, 0xab, 0x5b, 0xea, 0x96, 0xe4, 0x4c, 0x0c, 0x07,
+	0x4e, 0x71, 0xff, 0xfa, 0xf5, 0xd3, 0x7b, 0xbf, 0xf7, 0xa7, 0xff, 0x04, 0xba, 0xf6, 0x24, 0x0a,
+	0x9d, 0x83, 0x30, 0x0a, 0x68, 0x40, 0x56, 0xf9, 0x9f, 0xd8, 0x3c, 0x03, 0xb0, 0x30, 0x0e, 0x47,
+	0xd4, 0xa6, 0x49, 0x4c, 0xde, 0x07, 0x88, 0xf9, 0xaf, 0xa3, 0x60, 0x8c, 0xc3, 0xda, 0x5e, 0x6d,
+	0xbf, 0x69, 0x29, 0x08, 0x9b, 0xc7, 0x28, 0x7a, 0x8d, 0x71, 0x6c, 0x4f, 0x70, 0x58, 0xdf, 0xab,
+	0xed, 0x77, 0x2c, 0x05, 0x31, 0xff, 0x52, 0x83, 0xd6, 0x29, 0xce, 0x5f, 0xf9, 0x57, 0x01, 0x79,
+	0x08, 0x9d, 0x6b, 0x9c, 0x9f, 0xdb, 0x6e, 0xf4, 0x6a, 0xcc, 0x55, 0x75, 0xac, 0x1c, 0x60, 0xb3,
+	0x61, 0x72, 0xe9, 0xb9, 0xce, 0x29, 0xce, 0x85, 0xa2, 0x1c, 0x20, 0x1f, 0xc2, 0xba, 0x13, 0xa1,
+	0x4d, 0x71, 0x7c, 0xe1, 0xce, 0x30, 0xa6, 0xf6, 0x2c, 0x1c, 0x36, 0xf6, 0x6a, 0xfb, 0x0d, 0xab,
+	0x84, 0x33, 0x59, 0xbc, 0x0d, 0xdd, 0x48, 0x95, 0x5d, 0x49, 0x65, 0x8b, 0xb8, 0xf9, 0x25, 0xac,
+	0xff, 0x04, 0x7d, 0x2b, 0xb6, 0x4f, 0x53, 0x43, 0x2c, 0x7c, 0xf3, 0x16, 0x3b, 0x87, 0xd0, 0xa2,
+	0xee, 0x0c, 0x83, 0x84, 0x72, 0x2b, 0x9b, 0x96, 0x1c, 0x9a, 0xbf, 0x82, 0x8d, 0x82, 0xae, 0x98,
+	0x19, 0xb3, 0x9a, 0xd2, 0xc5, 0x35, 0x75, 0x1f, 0x93, 0x94, 0xee, 0xf8, 0x20, 0x27, 0xd9, 0x12,
+	0x12, 0xe4, 0x03, 0x68, 0x5d, 0xa7, 0x5c, 0x71, 0xd5, 0xdd, 0xc7, 0x03, 0x29, 0x2c, 0x28, 0xb4,
+	0xe4, 0xbc, 0xf9, 0x09, 0x6c, 0x5a, 0x38, 0x0b, 0x6e, 0x70, 0x09, 0xd3, 0xcd, 0x2f, 0x60, 0xab,
+	0xbc, 0x68, 0x39, 0x1b, 0xcd, 0x97, 0x00, 0x27, 0xbe, 0x13, 0xcd, 0x43, 0xfa, 0x76, 0xaa, 0x58,
+	0x48, 0x3d, 0xdb, 0xf5, 0x2f, 0xf0, 0x96, 0x66, 0x21, 0x95, 0x80, 0xf9, 0x0b, 0xe8, 0x66, 0x9a,
+	0x96, 0x24, 0xea, 0x7d, 0x00, 0xc7, 0x0d, 0xa7, 0x18, 0x29, 0x9a, 0x15, 0xc4, 0xfc, 0x12, 0xe0,
+	0x18, 0xef, 0x69, 0xe4, 0xdb, 0x74, 0x7d, 0x0d, 0xdd, 0x63, 0xfc, 0xef, 0xcc, 0xbc, 0xdb, 0xff,
+	0x8f, 0xa0, 0x7b, 0x34, 0x45, 0xe7, 0xfa, 0x14, 0xe7, 0x6f, 0x0f, 0xdd, 0x04, 0x7a, 0xb9, 0xf0,
+	0x92, 0x66, 0x7c, 0x04, 0x6d, 0x91, 0x36, 0xf1, 0xb0, 0xbe, 0xd7, 0xa8, 0xca, 0xab, 0x4c, 0xc0,
+	0xfc, 0x43, 0x0d, 0x06, 0x23, 0x74, 0x22, 0xa4, 0xe7, 0x76, 0x64, 0xcf, 0x18, 0xc8, 0x9b, 0x00,
+	0x87, 0xbe, 0xb2, 0x67, 0x28, 0x6c, 0x53, 0x90, 0xca, 0xe2, 0xac, 0x2f, 0x51, 0x9c, 0x8d, 0x05,
+	0xc5, 0xf9, 0xc7, 0x1a, 0x6c, 0x1d, 0x71, 0x05, 0x8a, 0x45, 0xff, 0x73, 0x44, 0x0b, 0xee, 0x34,
+	0x4a, 0xee, 0x28, 0x15, 0xbe, 0xa2, 0x57, 0xf8, 0x6f, 0x61, 0xbb, 0xc2, 0x9e, 0x25, 0xc3, 0xf1,
+	0x14, 0xba, 0x71, 0xbe, 0x5c, 0x54, 0xfa, 0xae, 0x5c, 0x50, 0xe0, 0xde, 0x52, 0x65, 0xcd, 0x53,
+	0x58, 0x3b, 0x9a, 0xda, 0xbe, 0x8f, 0x9e, 0x68, 0xcf, 0x7b, 0xd0, 0x75, 0x52, 0xe0, 0x62, 0x1e,
+	0xca, 0xd0, 0xa8, 0x10, 0x73, 0xe6, 0xd7, 0x41, 0x74, 0xed, 0xfa, 0x13, 0xfe, 0xa5, 0xb6, 0x25,
+	0x87, 0x66, 0x02, 0x83, 0x97, 0x68, 0x47, 0xf4, 0x12, 0x6d, 0x2a, 0xd4, 0x7d, 0x07, 0xd6, 0x3c,
+	0x3b, 0xa6, 0x79, 0x64, 0x6a, 0x3c, 0x32, 0x3a, 0xc8, 0xd8, 0x8f, 0x13, 0xc7, 0x41, 0x1c, 0xe3,
+	0x58, 0x28, 0xcd, 0x81, 0xc2, 0x8e, 0xd0, 0x28, 0xed, 0x08, 0x9b, 0xac, 0x4b, 0xd2, 0xe7, 0x13,
+	0xf4, 0xc5, 0x57, 0x2d, 0x7c, 0x63, 0xfe, 0xbd, 0x0e, 0xa4, 0x88, 0x2e, 0x49, 0xeb, 0x10, 0x5a,
+	0x37, 0x18, 0xc5, 0x6e, 0xe0, 0x8b, 0x90, 0xcb, 0x21, 0xf3, 0x6a, 0xe2, 0xd2, 0xa3, 0x60, 0x36,
+	0x73, 0xe9, 0x4b, 0x3b, 0x9e, 0x0a, 0xa3, 0x74, 0x90, 0xac, 0x43, 0x23, 0x74, 0xc7, 0x22, 0xe2,
+	0xec, 0x27, 0x79, 0x04, 0xfd, 0x98, 0xda, 0x91, 0x42, 0x47, 0x93, 0xd3, 0x51, 0x40, 0x99, 0xfe,
+	0x24, 0x64, 0x29, 0x32, 0x42, 0x27, 0xf0, 0xc7, 0xf1, 0x70, 0x35, 0x65, 0x4d, 0x03, 0xc9, 0x21,
+	0xb4, 0x44, 0x5c, 0x86, 0x2d, 0xee, 0xcc, 0xb6, 0x74, 0x46, 0x0b, 0xa9, 0x25, 0xa5, 0xc8, 0xf7,
+	0xa1, 0x33, 0x95, 0xf1, 0x19, 0xb6, 0xf5, 0x2c, 0x29, 0x04, 0xce, 0xca, 0x25, 0xcd, 0x7f, 0xd5,
+	0x60, 0xfd, 0x95, 0x7f, 0x13, 0x38, 0x36, 0x75, 0x03, 0x5f, 0x04, 0x76, 0x07, 0x56, 0xa9, 0x1d,
+	0x5f, 0x67, 0xd5, 0x22, 0x46, 0xcc, 0x74, 0xd7, 0xbf, 0x09, 0xae, 0xf1, 0xe7, 0x0a, 0x75, 0x4d,
+	0x4b, 0x07, 0x79, 0x96, 0x05, 0xb3, 0x99, 0xed, 0x8f, 0x95, 0x8a, 0x51, 0x21, 0xa6, 0x3f, 0xc2,
+	0x90, 0x19, 0xba, 0x92, 0xea, 0x4f, 0x47, 0x64, 0x0b, 0x9a, 0x2c, 0x3c, 0xc8, 0x99, 0xeb, 0x58,
+	0xe9, 0xa0, 0x82, 0xd8, 0xd5, 0x4a, 0x62, 0x1f, 0x41, 0x1f, 0x3d, 0x3b, 0x8c, 0x71, 0x2c, 0x99,
+	0x6d, 0xa5, 0x72, 0x3a, 0x6a, 0x6e, 0x01, 0x39, 0x73, 0x63, 0x9a, 0x7b, 0xcd, 0x73, 0xea, 0x37,
+	0xb0, 0x59, 0x42, 0x97, 0xcc, 0xa9, 0xcf, 0xa0, 0xeb, 0xe6, 0xcb, 0x45, 0xf3, 0x1c, 0xca, 0x05,
+	0x45, 0x96, 0x2d, 0x55, 0xd8, 0xfc, 0x47, 0x1d, 0xc8, 0x39, 0x46, 0x6e, 0x30, 0x76, 0x9d, 0x0b,
+	0x3b, 0xbe, 0xfe, 0x3f, 0x47, 0x82, 0x95, 0xe5, 0x6d, 0x18, 0x61, 0xcc, 0x95, 0x37, 0x45, 0x59,
+	0x66, 0x08, 0xf9, 0x2e, 0x6c, 0xf0, 0x5e, 0xed, 0x06, 0x7e, 0x31, 0x2c, 0xe5, 0x09, 0x56, 0x6c,
+	0x51, 0xe2, 0xfb, 0xac, 0xab, 0xb4, 0xd2, 0xae, 0x22, 0x86, 0xac, 0xbf, 0xb3, 0x6e, 0x61, 0x25,
+	0x8a, 0x9a, 0x76, 0xda, 0xdf, 0x8b, 0x38, 0x93, 0xf5, 0xf1, 0x56, 0x97, 0xed, 0xa4, 0xb2, 0x45,
+	0xdc, 0xdc, 0x81, 0x2d, 0x16, 0x4d, 0x95, 0x51, 0x1e, 0xe5, 0xdf, 0xd7, 0x60, 0xbb, 0x62, 0x62,
+	0xc9, 0x40, 0x7f, 0x0e, 0x6b, 0xa1, 0xaa, 0x40, 0x84, 0xda, 0x90, 0x4b, 0xca, 0x81, 0xb4, 0xf4,
+	0x05, 0xe6, 0xb7, 0x75, 0x58, 0x1b, 0xa5, 0x5c, 0x8e, 0xb2, 0xdd, 0x3f, 0x4e, 0x81, 0x7c, 0x93,
+	0xca, 0x00, 0x25, 0x0f, 0xea, 0x5a, 0x1e, 0x18, 0xd0, 0x4e, 0x62, 0x8c, 0xfc, 0x3c, 0xbc, 0xd9,
+	0x98, 0xc5, 0x90, 0xda, 0xd1, 0x04, 0xe9, 0xcb, 0x20, 0x96, 0xf1, 0x55, 0x10, 0x36, 0x1f, 0x06,
+	0x11, 0xfd, 0x2a, 0x99, 0x5d, 0x62, 0x24, 0x63, 0x9c, 0x23, 0x69, 0xf6, 0xf8, 0xd4, 0x76, 0x7d,
+	0x64, 0x1b, 0xe7, 0xaa, 0xcc, 0x9e, 0x0c, 0x62, 0x59, 0x98, 0x0d, 0x79, 0x86, 0xb5, 0xd2, 0x56,
+	0xa9, 0x81, 0x15, 0xf5, 0xdb, 0xbe, 0x67, 0xfd, 0x76, 0x2a, 0xeb, 0x77, 0x03, 0x06, 0x2c, 0x84,
+	0x82, 0x3e, 0x1e, 0xd6, 0x37, 0xb0, 0xae, 0x43, 0x4b, 0x06, 0xf4, 0x7b, 0xd0, 0x16, 0x5c, 0xcb,
+	0x58, 0x6e, 0xe7, 0x3b, 0xac, 0x12, 0x25, 0x2b, 0x13, 0x33, 0xff, 0x5c, 0x83, 0xde, 0xb9, 0x97,
+	0x4c, 0x5c, 0x19, 0x40, 0x02, 0x2b, 0x7e, 0x7e, 0xe0, 0xe1, 0xbf, 0xef, 0xd8, 0x65, 0x18, 0xf9,
+	0x7c, 0x35, 0xdf, 0x89, 0xc5, 0xbe, 0x97, 0x23, 0x2c, 0xe0, 0xc2, 0x7a, 0x51, 0x98, 0xc2, 0xd2,
+	0x47, 0xd0, 0x77, 0xd8, 0xc9, 0xae, 0xb4, 0xcb, 0xe8, 0xa8, 0xb9, 0x0e, 0x7d, 0x9e, 0xe7, 0x5c,
+	0x23, 0xe7, 0x68, 0x06, 0x03, 0x0d, 0x59, 0x92, 0xa2, 0x03, 0x68, 0xa5, 0xe6, 0x49, 0x86, 0xb6,
+	0xb2, 0x6c, 0x57, 0x58, 0xb0, 0xa4, 0x90, 0xf9, 0xb7, 0x06, 0x6c, 0x30, 0x0c, 0x8f, 0x02, 0xff,
+	0xca, 0x9d, 0x08, 0x92, 0x1e, 0xc3, 0x56, 0x9c, 0x83, 0x49, 0x64, 0x53, 0x35, 0xe1, 0x2b, 0xe7,
+	0x88, 0x09, 0x3d, 0x8a, 0xb3, 0xd0, 0xb3, 0x29, 0xf2, 0x24, 0x4b, 0x99, 0xd4, 0x30, 0xb2, 0x0f,
+	0x03, 0x39, 0x96, 0x1d, 0x31, 0xe5, 0xb4, 0x08, 0x8b, 0x9c, 0xe5, 0x1f, 0xc0, 0xd7, 0xec, 0x96,
+	0xba, 0x92, 0xe5, 0x6c, 0x0e, 0xb2, 0x6f, 0xc6, 0xce, 0x14, 0xc7, 0x89, 0x87, 0x3c, 0x40, 0x69,
+	0x75, 0x68, 0x18, 0x39, 0x00, 0x22, 0xc7, 0x27, 0x79, 0xaf, 0x4c, 0xcb, 0xa4, 0x62, 0x86, 0x7c,
+	0x0c, 0x9b, 0xfc, 0xdc, 0x13, 0xc7, 0x57, 0x89, 0xf7, 0x3c, 0x0c, 0xbd, 0xf9, 0x85, 0x9b, 0xd5,
+	0x4c, 0xd5, 0x14, 0x4b, 0x12, 0xd6, 0x05, 0x53, 0xee, 0x78, 0xd5, 0x74, 0x2c, 0x05, 0x61, 0x6c,
+	0xb2, 0xd1, 0x89, 0x7f, 0x15, 0x44, 0x0e, 0x16, 0xbb, 0x62, 0xe5, 0x5c, 0x65, 0x17, 0x85, 0x05,
+	0x5d, 0x74, 0x3b, 0xdd, 0x13, 0x95, 0x30, 0xf2, 0x4c, 0xfa, 0x5d, 0x0d, 0xb6, 0xca, 0xf8, 0x92,
+	0xf9, 0xf4, 0x0c, 0x7a, 0x4a, 0xb4, 0x65, 0x52, 0x3d, 0xc8, 0xca, 0xae, 0x98, 0x3a, 0x96, 0x26,
+	0x6e, 0xfe, 0x75, 0x05, 0xd6, 0xac, 0xc4, 0x3f, 0x4a, 0xf7, 0x32, 0x76, 0xca, 0xcf, 0x37, 0x3b,
+	0xed, 0x70, 0x9b, 0x43, 0xc5, 0xed, 0xb0, 0x5e, 0xde, 0x0e, 0x87, 0xd0, 0x62, 0xbd, 0x0b, 0x7d,
+	0x2a, 0xd2, 0x47, 0x0e, 0x59, 0x28, 0xc4, 0x49, 0xf8, 0xd8, 0x8d, 0x64, 0x33, 0xcd, 0x11, 0xad,
+	0x11, 0x37, 0xcb, 0x8d, 0xd8, 0x0b, 0x58, 0x89, 0x4c, 0xd1, 0xf3, 0x78, 0x82, 0xb4, 0x2d, 0x05,
+	0x51, 0x6f, 0x10, 0x2d, 0xed, 0x06, 0x51, 0x6c, 0xc1, 0xed, 0x7b, 0xb4, 0xe0, 0x4e, 0x55, 0x0b,
+	0x36, 0xa1, 0x17, 0x06, 0xdc, 0xc5, 0x38, 0xb4, 0x1d, 0xe4, 0x01, 0xef, 0x58, 0x1a, 0xc6, 0xac,
+	0x10, 0xe3, 0x61, 0x37, 0xf5, 0x5d, 0x0c, 0x59, 0xca, 0x84, 0xc1, 0xf8, 0xcc, 0xbe, 0x44, 0x6f,
+	0x84, 0x1e, 0x3a, 0x34, 0x88, 0x86, 0x3d, 0x2e, 0x52, 0xc2, 0x99, 0xac, 0xa0, 0x6c, 0xe4, 0x4e,
+	0x7c, 0x9b, 0x26, 0x11, 0x0e, 0xd7, 0x52, 0xd9, 0x22, 0x4e, 0x3e, 0x85, 0xed, 0x22, 0xc6, 0x6e,
+	0x98, 0xe3, 0x61, 0x9f, 0x2f, 0xa8, 0x9e, 0x24, 0x3f, 0x02, 0xa3, 0x38, 0x71, 0xc2, 0xaf, 0x82,
+	0xbc, 0x9a, 0x06, 0x3c, 0x95, 0xef, 0x90, 0x30, 0x2f, 0xa0, 0xaf, 0x26, 0xce, 0x92, 0x69, 0xbb,
+	0x60, 0x23, 0x36, 0xf7, 0xa1, 0x3f, 0xa2, 0x41, 0xa8, 0xe4, 0xe3, 0x82, 0xa3, 0x9b, 0xf9, 0x0c,
+	0x06, 0x9a, 0xe4, 0x92, 0x2f, 0x2a, 0x1f, 0xc0, 0xe0, 0x6b, 0x9b, 0x3a, 0xd3, 0x7b, 0x7c, 0xe9,
+	0x9f, 0x35, 0xe8, 0x09, 0xb1, 0x93, 0x1b, 0x96, 0xc4, 0x04, 0x56, 0x68, 0x5e, 0x1b, 0xfc, 0x37,
+	0x3b, 0x77, 0xd0, 0xc2, 0x35, 0x3c, 0x07, 0x98, 0xea, 0x20, 0xa1, 0x61, 0x22, 0xeb, 0x41, 0x8c,
+	0x16, 0x6e, 0x4f, 0x06, 0xb4, 0xf1, 0x96, 0x5d, 0x94, 0xc6, 0x69, 0x19, 0x34, 0xad, 0x6c, 0xcc,
+	0x12, 0x0c, 0xa3, 0x88, 0x4f, 0xa5, 0x4d, 0x52, 0x0e, 0x0b, 0x97, 0xc0, 0x56, 0xf1, 0x12, 0xc8,
+	0x56, 0x8e, 0xa3, 0x20, 0x0c, 0x71, 0x2c, 0x8e, 0x0e, 0x72, 0x68, 0xfe, 0x0c, 0xd6, 0x46, 0x34,
+	0x42, 0x7b, 0x76, 0x16, 0xf0, 0xde, 0xc4, 0xae, 0x10, 0x1e, 0xde, 0xa0, 0x27, 0x7c, 0x4c, 0x07,
+	0xcc, 0xdc, 0x59, 0xc0, 0xda, 0xb1, 0x8c, 0x5a, 0x3a, 0x52, 0x98, 0x6b, 0x68, 0xcc, 0xfd, 0xbb,
+	0x06, 0xed, 0xb3, 0x60, 0x72, 0xe2, 0xd3, 0x68, 0xae, 0x33, 0x54, 0x2b, 0x32, 0x94, 0x7d, 0xb0,
+	0x5e, 0xfd, 0xc1, 0x86, 0xf6, 0xc1, 0x21, 0xb4, 0x66, 0xc2, 0xcd, 0x94, 0x38, 0x39, 0x24, 0x9f,
+	0xc2, 0xea, 0x95, 0x8b, 0xde, 0x38, 0x1e, 0x36, 0x79, 0x27, 0x7c, 0x28, 0x73, 0x40, 0xda, 0x71,
+	0xf0, 0x82, 0x4f, 0xf3, 0xdf, 0x96, 0x90, 0x55, 0x99, 0x59, 0xd5, 0x98, 0x31, 0x9e, 0x42, 0x57,
+	0x59, 0xc0, 0xee, 0xab, 0xd7, 0x38, 0x17, 0xac, 0xb0, 0x9f, 0xcc, 0xf0, 0x1b, 0xdb, 0x4b, 0x24,
+	0x25, 0xe9, 0xe0, 0xb3, 0xfa, 0x93, 0x9a, 0x79, 0x0e, 0xfd, 0x11, 0xd2, 0xb3, 0x60, 0x72, 0xc6,
+	0x7c, 0x11, 0x19, 0x26, 0xdc, 0xa9, 0x69, 0xee, 0x54, 0x3b, 0xbf, 0x0e, 0x0d, 0x4a, 0x3d, 0xee,
+	0x79, 0xd3, 0x62, 0x3f, 0xcd, 0x09, 0x0c, 0x34, 0x8d, 0x4b, 0x16, 0xdd, 0x3e, 0x0c, 0x30, 0x2b,
+	0x60, 0x35, 0x53, 0x8b, 0xb0, 0xf9, 0x21, 0xac, 0x5b, 0x18, 0xdf, 0xcb, 0x78, 0xf3, 0xc7, 0xb0,
+	0x51, 0x90, 0x5d, 0xb2, 0x14, 0xa7, 0xd0, 0x7f, 0xcd, 0x55, 0x49, 0x0d, 0x4b, 0xf2, 0x54, 0xe1,
+	0x56, 0xa3, 0xda, 0x2d, 0x92, 0x9e, 0x6f, 0xe5, 0x77, 0xf8, 0x2e, 0xfc, 0xa7, 0x1a, 0x6c, 0x14,
+	0xc0, 0x25, 0x69, 0x35, 0xa1, 0x37, 0xc6, 0x2b, 0x3b, 0xf1, 0xe8, 0x99, 0x62, 0x9c, 0x86, 0x91,
+	0x03, 0x58, 0xe5, 0xc6, 0xc6, 0xc3, 0x06, 0x4f, 0xcb, 0x1d, 0xa9, 0x4f, 0xf7, 0xdc, 0x12, 0x52,
+	0x8f, 0xbf, 0x05, 0xe8, 0x3e, 0x8f, 0x63, 0x37, 0x4e, 0x5f, 0x67, 0xc8, 0x0b, 0x58, 0xd3, 0x5e,
+	0xb9, 0x49, 0x76, 0x1f, 0x2e, 0x3e, 0xa4, 0x1b, 0x0f, 0x16, 0xcc, 0xc4, 0xa1, 0xf9, 0x0e, 0x39,
+	0x85, 0x9e, 0x35, 0x53, 0xd4, 0xbc, 0x9b, 0xfb, 0x55, 0x7a, 0xd7, 0x36, 0x1e, 0x2e, 0x9e, 0xe4,
+	0xca, 0x9e, 0x64, 0x6f, 0xc9, 0xfc, 0x85, 0x2f, 0xe3, 0x28, 0x7f, 0xaa, 0x36, 0x36, 0x4b, 0x98,
+	0x5c, 0x79, 0x8c, 0x15, 0x2b, 0x8f, 0xb1, 0xbc, 0xf2, 0x18, 0xd5, 0x95, 0x3f, 0x84, 0xb6, 0x7c,
+	0x92, 0x25, 0x9b, 0xf9, 0x5b, 0x4e, 0xf6, 0xa2, 0x6b, 0x6c, 0x95, 0x41, 0xbe, 0xd0, 0x82, 0x8d,
+	0xd2, 0x2b, 0x22, 0xc9, 0x3c, 0xac, 0x7a, 0xf0, 0x34, 0xde, 0xbb, 0x63, 0x96, 0xeb, 0x7c, 0x05,
+	0x7d, 0xfd, 0xfd, 0x8c, 0x28, 0xe4, 0x17, 0x5e, 0xdb, 0x0c, 0x63, 0xd1, 0x14, 0x57, 0x75, 0x96,
+	0x5e, 0x2b, 0x94, 0x77, 0x13, 0x92, 0x2d, 0x28, 0x3f, 0xb3, 0x18, 0xef, 0x2e, 0x9c, 0x93, 0xce,
+	0x96, 0xae, 0xe7, 0xb9, 0xb3, 0x55, 0x57, 0x7a, 0xe3, 0xbd, 0x3b, 0x66, 0xb9, 0xce, 0x23, 0xe8,
+	0xa9, 0x97, 0x43, 0xb2, 0xab, 0x2e, 0x50, 0x6e, 0x91, 0xc6, 0xb0, 0x7a, 0x82, 0x2b, 0xf9, 0x1c,
+	0xba, 0xca, 0xed, 0x89, 0xec, 0x68, 0x1f, 0xcd, 0x2e, 0x59, 0xc6, 0x6e, 0x25, 0xce, 0x35, 0xfc,
+	0x54, 0xdc, 0x51, 0x95, 0x53, 0x2c, 0xd1, 0xd8, 0x28, 0x1c, 0xb3, 0x8d, 0x87, 0x8b, 0x27, 0xb9,
+	0xc2, 0x67, 0x00, 0xf9, 0x41, 0x86, 0x64, 0x17, 0x56, 0xed, 0x54, 0x6c, 0xec, 0x54, 0xc1, 0xd2,
+	0x23, 0xe5, 0x1c, 0x92, 0x7b, 0xa4, 0x1f, 0x63, 0x8c, 0xdd, 0x4a, 0x9c, 0x6b, 0x78, 0x0e, 0x3d,
+	0xf5, 0x28, 0x92, 0x13, 0x5b, 0x38, 0xa0, 0x28, 0xa9, 0xad, 0x9c, 0x46, 0xcc, 0x77, 0x3e, 0xae,
+	0x91, 0xa7, 0x00, 0xf9, 0xfe, 0x9d, 0xfb, 0xa0, 0xed, 0xe9, 0xc6, 0x7a, 0x71, 0x2b, 0xe4, 0x4b,
+	0x99, 0xfd, 0x79, 0xf3, 0x56, 0xec, 0xd7, 0xba, 0xbf, 0xb1, 0x5b, 0x89, 0x73, 0xfb, 0x5f, 0xc0,
+	0x9a, 0xb6, 0x01, 0xe4, 0xbd, 0xa9, 0xb8, 0x87, 0x18, 0x0f, 0x16, 0xcc, 0x48, 0x3d, 0x5a, 0x23,
+	0x26, 0x5a, 0x22, 0xa9, 0x4d, 0xdb, 0x78, 0xb0, 0x60, 0x86, 0xe9, 0xf9, 0xe2, 0xc9, 0x2f, 0x7f,
+	0x30, 0x71, 0xe9, 0x34, 0xb9, 0x3c, 0x70, 0x82, 0xd9, 0xa1, 0xed, 0xb9, 0xf3, 0xc4, 0x17, 0x7f,
+	0xbe, 0xb1, 0x79, 0x4f, 0xfd, 0xc6, 0xf1, 0x5c, 0xf4, 0xe9, 0xa1, 0xcd, 0xca, 0xf0, 0xd0, 0x0d,
+	0x9d, 0x43, 0xfe, 0x3f, 0xd9, 0xcb, 0xf4, 0xbf, 0xb1, 0x9f, 0xfc, 0x67, 0x00, 0x8c, 0x4f, 0xb9,
+	0x5e, 0xa3, 0x1d, 0x00, 0x00,
 }
@@ -28,6 +28,19 @@ type AssistAgentClient interface {
 	DecryptText(ctx context.Context, in *DecryptReq, opts ...grpc.CallOption) (*DecryptResp, error)
 	CheckKey(ctx context.Context, in *CheckKeyReq, opts ...grpc.CallOption) (*CheckKeyResp, error)
 	CreateSecretParam(ctx context.Context, in *CreateSecretParamReq, opts ...grpc.CallOption) (*CreateSecretParamResp, error)
+	GetAgentStatus(ctx context.Context, in *GetAgentStatusReq, opts ...grpc.CallOption) (*GetAgentStatusResp, error)
+	ListInvocations(ctx context.Context, in *ListInvocationsReq, opts ...grpc.CallOption) (*ListInvocationsResp, error)
+	ListPeriodicTasks(ctx context.Context, in *ListPeriodicTasksReq, opts ...grpc.CallOption) (*ListPeriodicTasksResp, error)
+	ListSessions(ctx context.Context, in *ListSessionsReq, opts ...grpc.CallOption) (*ListSessionsResp, error)
+	ListPlugins(ctx context.Context, in *ListPluginsReq, opts ...grpc.CallOption) (*ListPluginsResp, error)
+	ListStateConfigs(ctx context.Context, in *ListStateConfigsReq, opts ...grpc.CallOption) (*ListStateConfigsResp, error)
+	RunCommand(ctx context.Context, in *RunCommandReq, opts ...grpc.CallOption) (*RunCommandResp, error)
+	StopCommand(ctx context.Context, in *StopCommandReq, opts ...grpc.CallOption) (*StopCommandResp, error)
+	WatchCommand(ctx context.Context, in *WatchCommandReq, opts ...grpc.CallOption) (AssistAgent_WatchCommandClient, error)
+	StreamLogs(ctx context.Context, in *StreamLogsReq, opts ...grpc.CallOption) (AssistAgent_StreamLogsClient, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelReq, opts ...grpc.CallOption) (*SetLogLevelResp, error)
+	ResetLogLevel(ctx context.Context, in *ResetLogLevelReq, opts ...grpc.CallOption) (*ResetLogLevelResp, error)
+	ListLogLevels(ctx context.Context, in *ListLogLevelsReq, opts ...grpc.CallOption) (*ListLogLevelsResp, error)
 }
 
 type assistAgentClient struct {
@@ -92,6 +105,169 @@ func (c *assistAgentClient) CreateSecretParam(ctx context.Context, in *CreateSec
 	return out, nil
 }
 
+func (c *assistAgentClient) GetAgentStatus(ctx context.Context, in *GetAgentStatusReq, opts ...grpc.CallOption) (*GetAgentStatusResp, error) {
+	out := new(GetAgentStatusResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/GetAgentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ListInvocations(ctx context.Context, in *ListInvocationsReq, opts ...grpc.CallOption) (*ListInvocationsResp, error) {
+	out := new(ListInvocationsResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ListInvocations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ListPeriodicTasks(ctx context.Context, in *ListPeriodicTasksReq, opts ...grpc.CallOption) (*ListPeriodicTasksResp, error) {
+	out := new(ListPeriodicTasksResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ListPeriodicTasks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ListSessions(ctx context.Context, in *ListSessionsReq, opts ...grpc.CallOption) (*ListSessionsResp, error) {
+	out := new(ListSessionsResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ListPlugins(ctx context.Context, in *ListPluginsReq, opts ...grpc.CallOption) (*ListPluginsResp, error) {
+	out := new(ListPluginsResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ListPlugins", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ListStateConfigs(ctx context.Context, in *ListStateConfigsReq, opts ...grpc.CallOption) (*ListStateConfigsResp, error) {
+	out := new(ListStateConfigsResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ListStateConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) RunCommand(ctx context.Context, in *RunCommandReq, opts ...grpc.CallOption) (*RunCommandResp, error) {
+	out := new(RunCommandResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/RunCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) StopCommand(ctx context.Context, in *StopCommandReq, opts ...grpc.CallOption) (*StopCommandResp, error) {
+	out := new(StopCommandResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/StopCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) WatchCommand(ctx context.Context, in *WatchCommandReq, opts ...grpc.CallOption) (AssistAgent_WatchCommandClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AssistAgent_ServiceDesc.Streams[0], "/protos.AssistAgent/WatchCommand", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &assistAgentWatchCommandClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AssistAgent_WatchCommandClient interface {
+	Recv() (*CommandEvent, error)
+	grpc.ClientStream
+}
+
+type assistAgentWatchCommandClient struct {
+	grpc.ClientStream
+}
+
+func (x *assistAgentWatchCommandClient) Recv() (*CommandEvent, error) {
+	m := new(CommandEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *assistAgentClient) StreamLogs(ctx context.Context, in *StreamLogsReq, opts ...grpc.CallOption) (AssistAgent_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AssistAgent_ServiceDesc.Streams[1], "/protos.AssistAgent/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &assistAgentStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AssistAgent_StreamLogsClient interface {
+	Recv() (*LogEntry, error)
+	grpc.ClientStream
+}
+
+type assistAgentStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *assistAgentStreamLogsClient) Recv() (*LogEntry, error) {
+	m := new(LogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *assistAgentClient) SetLogLevel(ctx context.Context, in *SetLogLevelReq, opts ...grpc.CallOption) (*SetLogLevelResp, error) {
+	out := new(SetLogLevelResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ResetLogLevel(ctx context.Context, in *ResetLogLevelReq, opts ...grpc.CallOption) (*ResetLogLevelResp, error) {
+	out := new(ResetLogLevelResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ResetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assistAgentClient) ListLogLevels(ctx context.Context, in *ListLogLevelsReq, opts ...grpc.CallOption) (*ListLogLevelsResp, error) {
+	out := new(ListLogLevelsResp)
+	err := c.cc.Invoke(ctx, "/protos.AssistAgent/ListLogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AssistAgentServer is the server API for AssistAgent service.
 // All implementations must embed UnimplementedAssistAgentServer
 // for forward compatibility
@@ -102,6 +278,19 @@ type AssistAgentServer interface {
 	DecryptText(context.Context, *DecryptReq) (*DecryptResp, error)
 	CheckKey(context.Context, *CheckKeyReq) (*CheckKeyResp, error)
 	CreateSecretParam(context.Context, *CreateSecretParamReq) (*CreateSecretParamResp, error)
+	GetAgentStatus(context.Context, *GetAgentStatusReq) (*GetAgentStatusResp, error)
+	ListInvocations(context.Context, *ListInvocationsReq) (*ListInvocationsResp, error)
+	ListPeriodicTasks(context.Context, *ListPeriodicTasksReq) (*ListPeriodicTasksResp, error)
+	ListSessions(context.Context, *ListSessionsReq) (*ListSessionsResp, error)
+	ListPlugins(context.Context, *ListPluginsReq) (*ListPluginsResp, error)
+	ListStateConfigs(context.Context, *ListStateConfigsReq) (*ListStateConfigsResp, error)
+	RunCommand(context.Context, *RunCommandReq) (*RunCommandResp, error)
+	StopCommand(context.Context, *StopCommandReq) (*StopCommandResp, error)
+	WatchCommand(*WatchCommandReq, AssistAgent_WatchCommandServer) error
+	StreamLogs(*StreamLogsReq, AssistAgent_StreamLogsServer) error
+	SetLogLevel(context.Context, *SetLogLevelReq) (*SetLogLevelResp, error)
+	ResetLogLevel(context.Context, *ResetLogLevelReq) (*ResetLogLevelResp, error)
+	ListLogLevels(context.Context, *ListLogLevelsReq) (*ListLogLevelsResp, error)
 	mustEmbedUnimplementedAssistAgentServer()
 }
 
@@ -127,6 +316,45 @@ func (UnimplementedAssistAgentServer) CheckKey(context.Context, *CheckKeyReq) (*
 func (UnimplementedAssistAgentServer) CreateSecretParam(context.Context, *CreateSecretParamReq) (*CreateSecretParamResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSecretParam not implemented")
 }
+func (UnimplementedAssistAgentServer) GetAgentStatus(context.Context, *GetAgentStatusReq) (*GetAgentStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentStatus not implemented")
+}
+func (UnimplementedAssistAgentServer) ListInvocations(context.Context, *ListInvocationsReq) (*ListInvocationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvocations not implemented")
+}
+func (UnimplementedAssistAgentServer) ListPeriodicTasks(context.Context, *ListPeriodicTasksReq) (*ListPeriodicTasksResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeriodicTasks not implemented")
+}
+func (UnimplementedAssistAgentServer) ListSessions(context.Context, *ListSessionsReq) (*ListSessionsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAssistAgentServer) ListPlugins(context.Context, *ListPluginsReq) (*ListPluginsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlugins not implemented")
+}
+func (UnimplementedAssistAgentServer) ListStateConfigs(context.Context, *ListStateConfigsReq) (*ListStateConfigsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStateConfigs not implemented")
+}
+func (UnimplementedAssistAgentServer) RunCommand(context.Context, *RunCommandReq) (*RunCommandResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunCommand not implemented")
+}
+func (UnimplementedAssistAgentServer) StopCommand(context.Context, *StopCommandReq) (*StopCommandResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopCommand not implemented")
+}
+func (UnimplementedAssistAgentServer) WatchCommand(*WatchCommandReq, AssistAgent_WatchCommandServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCommand not implemented")
+}
+func (UnimplementedAssistAgentServer) StreamLogs(*StreamLogsReq, AssistAgent_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedAssistAgentServer) SetLogLevel(context.Context, *SetLogLevelReq) (*SetLogLevelResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAssistAgentServer) ResetLogLevel(context.Context, *ResetLogLevelReq) (*ResetLogLevelResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetLogLevel not implemented")
+}
+func (UnimplementedAssistAgentServer) ListLogLevels(context.Context, *ListLogLevelsReq) (*ListLogLevelsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLogLevels not implemented")
+}
 func (UnimplementedAssistAgentServer) mustEmbedUnimplementedAssistAgentServer() {}
 
 // UnsafeAssistAgentServer may be embedded to opt out of forward compatibility for this service.
@@ -248,6 +476,246 @@ func _AssistAgent_CreateSecretParam_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AssistAgent_GetAgentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).GetAgentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/GetAgentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).GetAgentStatus(ctx, req.(*GetAgentStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ListInvocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvocationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ListInvocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ListInvocations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ListInvocations(ctx, req.(*ListInvocationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ListPeriodicTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeriodicTasksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ListPeriodicTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ListPeriodicTasks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ListPeriodicTasks(ctx, req.(*ListPeriodicTasksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ListSessions(ctx, req.(*ListSessionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ListPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPluginsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ListPlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ListPlugins",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ListPlugins(ctx, req.(*ListPluginsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ListStateConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStateConfigsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ListStateConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ListStateConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ListStateConfigs(ctx, req.(*ListStateConfigsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_RunCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCommandReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).RunCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/RunCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).RunCommand(ctx, req.(*RunCommandReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_StopCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopCommandReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).StopCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/StopCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).StopCommand(ctx, req.(*StopCommandReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_WatchCommand_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCommandReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AssistAgentServer).WatchCommand(m, &assistAgentWatchCommandServer{stream})
+}
+
+type AssistAgent_WatchCommandServer interface {
+	Send(*CommandEvent) error
+	grpc.ServerStream
+}
+
+type assistAgentWatchCommandServer struct {
+	grpc.ServerStream
+}
+
+func (x *assistAgentWatchCommandServer) Send(m *CommandEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AssistAgent_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AssistAgentServer).StreamLogs(m, &assistAgentStreamLogsServer{stream})
+}
+
+type AssistAgent_StreamLogsServer interface {
+	Send(*LogEntry) error
+	grpc.ServerStream
+}
+
+type assistAgentStreamLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *assistAgentStreamLogsServer) Send(m *LogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AssistAgent_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).SetLogLevel(ctx, req.(*SetLogLevelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ResetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetLogLevelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ResetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ResetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ResetLogLevel(ctx, req.(*ResetLogLevelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssistAgent_ListLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLogLevelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssistAgentServer).ListLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.AssistAgent/ListLogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssistAgentServer).ListLogLevels(ctx, req.(*ListLogLevelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AssistAgent_ServiceDesc is the grpc.ServiceDesc for AssistAgent service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -279,7 +747,62 @@ var AssistAgent_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSecretParam",
 			Handler:    _AssistAgent_CreateSecretParam_Handler,
 		},
+		{
+			MethodName: "GetAgentStatus",
+			Handler:    _AssistAgent_GetAgentStatus_Handler,
+		},
+		{
+			MethodName: "ListInvocations",
+			Handler:    _AssistAgent_ListInvocations_Handler,
+		},
+		{
+			MethodName: "ListPeriodicTasks",
+			Handler:    _AssistAgent_ListPeriodicTasks_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AssistAgent_ListSessions_Handler,
+		},
+		{
+			MethodName: "ListPlugins",
+			Handler:    _AssistAgent_ListPlugins_Handler,
+		},
+		{
+			MethodName: "ListStateConfigs",
+			Handler:    _AssistAgent_ListStateConfigs_Handler,
+		},
+		{
+			MethodName: "RunCommand",
+			Handler:    _AssistAgent_RunCommand_Handler,
+		},
+		{
+			MethodName: "StopCommand",
+			Handler:    _AssistAgent_StopCommand_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AssistAgent_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "ResetLogLevel",
+			Handler:    _AssistAgent_ResetLogLevel_Handler,
+		},
+		{
+			MethodName: "ListLogLevels",
+			Handler:    _AssistAgent_ListLogLevels_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCommand",
+			Handler:       _AssistAgent_WatchCommand_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _AssistAgent_StreamLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "agrpc.proto",
 }
@@ -105,7 +105,7 @@ func RmRsaKeyPair(keyId string) (errCode int32, err error) {
 	var resp *pb.RemoveRsaKeyPairResp
 	resp, err = client.Client.RmRsaKeyPair(client.Ctx, req)
 	if err != nil {
-		log.GetLogger().Errorf("RmRsaKeyPair failed, keyPairId[%s], StatusCode[%d], errMsg[%s]: ", keyId, resp.Status.StatusCode, resp.Status.ErrMessage)
+		log.GetLogger().Error("Client request RmRsaKeyPair failed: ", err)
 		return
 	}
 	errCode = resp.Status.StatusCode
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+// RunCommand submits command to agent and returns TaskId of the local
+// invocation
+func RunCommand(req *pb.RunCommandReq) (taskId string, err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	resp, err := client.Client.RunCommand(client.Ctx, req)
+	if err != nil {
+		log.GetLogger().Error("Client request RunCommand failed: ", err)
+		return "", err
+	}
+	if err = checkRespStatus("RunCommand", resp.Status); err != nil {
+		return "", err
+	}
+	log.GetLogger().Infof("RunCommand success, taskId[%s]", resp.TaskId)
+	return resp.TaskId, nil
+}
+
+// StopCommand cancels pending or running local invocation
+func StopCommand(taskId string) (err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	resp, err := client.Client.StopCommand(client.Ctx, &pb.StopCommandReq{
+		TaskId: taskId,
+	})
+	if err != nil {
+		log.GetLogger().Error("Client request StopCommand failed: ", err)
+		return err
+	}
+	if err = checkRespStatus("StopCommand", resp.Status); err != nil {
+		return err
+	}
+	log.GetLogger().Infof("StopCommand success, taskId[%s]", taskId)
+	return nil
+}
+
+// WatchCommand calls onEvent for every event of local invocation from the
+// beginning, and returns after the finished event or when ctx is done
+func WatchCommand(ctx context.Context, taskId string, onEvent func(*pb.CommandEvent)) (err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	// Invocation may run longer than the timeout of unary calls
+	stream, err := client.Client.WatchCommand(ctx, &pb.WatchCommandReq{
+		TaskId: taskId,
+	})
+	if err != nil {
+		log.GetLogger().Error("Client request WatchCommand failed: ", err)
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.GetLogger().Error("WatchCommand receive failed: ", err)
+			return err
+		}
+		onEvent(event)
+	}
+}
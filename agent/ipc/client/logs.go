@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+// StreamLogs calls onEntry for every live log entry of agent matching req,
+// until ctx is done or connection is broken
+func StreamLogs(ctx context.Context, req *pb.StreamLogsReq, onEntry func(*pb.LogEntry)) (err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	// Streaming lasts longer than the timeout of unary calls
+	stream, err := client.Client.StreamLogs(ctx, req)
+	if err != nil {
+		log.GetLogger().Error("Client request StreamLogs failed: ", err)
+		return err
+	}
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			log.GetLogger().Error("StreamLogs receive failed: ", err)
+			return err
+		}
+		onEntry(entry)
+	}
+}
+
+// SetLogLevel changes log level of module in agent for ttlSeconds, and
+// returns the timestamp when the level is reverted
+func SetLogLevel(module string, level string, ttlSeconds int) (expireTimestamp int64, err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	resp, err := client.Client.SetLogLevel(client.Ctx, &pb.SetLogLevelReq{
+		Module: module,
+		Level:  level,
+		Ttl:    int32(ttlSeconds),
+	})
+	if err != nil {
+		log.GetLogger().Error("Client request SetLogLevel failed: ", err)
+		return 0, err
+	}
+	if err = checkRespStatus("SetLogLevel", resp.Status); err != nil {
+		return 0, err
+	}
+	return resp.ExpireTimestamp, nil
+}
+
+// ResetLogLevel reverts log level of module in agent immediately
+func ResetLogLevel(module string) (err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	resp, err := client.Client.ResetLogLevel(client.Ctx, &pb.ResetLogLevelReq{
+		Module: module,
+	})
+	if err != nil {
+		log.GetLogger().Error("Client request ResetLogLevel failed: ", err)
+		return err
+	}
+	return checkRespStatus("ResetLogLevel", resp.Status)
+}
+
+// ListLogLevels returns default log level of agent and levels changed at
+// runtime
+func ListLogLevels() (resp *pb.ListLogLevelsResp, err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+	resp, err = client.Client.ListLogLevels(client.Ctx, &pb.ListLogLevelsReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request ListLogLevels failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("ListLogLevels", resp.Status); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
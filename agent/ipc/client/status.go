@@ -0,0 +1,117 @@
+package client
+
+import (
+	"errors"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+// AgentStatus is the runtime state of agent collected through IPC
+type AgentStatus struct {
+	Version        string                   `json:"version"`
+	GitCommitHash  string                   `json:"gitCommitHash"`
+	Pid            int32                    `json:"pid"`
+	StartTimestamp int64                    `json:"startTimestamp"`
+	UptimeSeconds  int64                    `json:"uptimeSeconds"`
+	Channel        *pb.ChannelStatus        `json:"channel"`
+	Heartbeat      *pb.HeartbeatStatus      `json:"heartbeat"`
+	Invocations    []*pb.InvocationStatus   `json:"invocations"`
+	PeriodicTasks  []*pb.PeriodicTaskStatus `json:"periodicTasks"`
+	Sessions       []*pb.SessionStatus      `json:"sessions"`
+	Plugins        []*pb.PluginStatus       `json:"plugins"`
+	StateConfigs   []*pb.StateConfigStatus  `json:"stateConfigs"`
+}
+
+func checkRespStatus(api string, status *pb.RespStatus) error {
+	if status != nil && status.StatusCode != 0 {
+		log.GetLogger().Errorf("%s failed, StatusCode[%d], errMsg[%s]", api, status.StatusCode, status.ErrMessage)
+		return errors.New(status.ErrMessage)
+	}
+	return nil
+}
+
+// GetAgentStatus queries all runtime state of agent in one connection
+func GetAgentStatus() (status *AgentStatus, err error) {
+	var client *agentClient
+	client, err = newClient()
+	if err != nil {
+		log.GetLogger().Error("Create client failed: ", err)
+		return
+	}
+	defer func() {
+		client.Conn.Close()
+		client.Cancel()
+	}()
+
+	agentResp, err := client.Client.GetAgentStatus(client.Ctx, &pb.GetAgentStatusReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request GetAgentStatus failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("GetAgentStatus", agentResp.Status); err != nil {
+		return nil, err
+	}
+	status = &AgentStatus{
+		Version:        agentResp.Version,
+		GitCommitHash:  agentResp.GitCommitHash,
+		Pid:            agentResp.Pid,
+		StartTimestamp: agentResp.StartTimestamp,
+		UptimeSeconds:  agentResp.UptimeSeconds,
+		Channel:        agentResp.Channel,
+		Heartbeat:      agentResp.Heartbeat,
+	}
+
+	invocationsResp, err := client.Client.ListInvocations(client.Ctx, &pb.ListInvocationsReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request ListInvocations failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("ListInvocations", invocationsResp.Status); err != nil {
+		return nil, err
+	}
+	status.Invocations = invocationsResp.Invocations
+
+	periodicTasksResp, err := client.Client.ListPeriodicTasks(client.Ctx, &pb.ListPeriodicTasksReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request ListPeriodicTasks failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("ListPeriodicTasks", periodicTasksResp.Status); err != nil {
+		return nil, err
+	}
+	status.PeriodicTasks = periodicTasksResp.PeriodicTasks
+
+	sessionsResp, err := client.Client.ListSessions(client.Ctx, &pb.ListSessionsReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request ListSessions failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("ListSessions", sessionsResp.Status); err != nil {
+		return nil, err
+	}
+	status.Sessions = sessionsResp.Sessions
+
+	pluginsResp, err := client.Client.ListPlugins(client.Ctx, &pb.ListPluginsReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request ListPlugins failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("ListPlugins", pluginsResp.Status); err != nil {
+		return nil, err
+	}
+	status.Plugins = pluginsResp.Plugins
+
+	stateConfigsResp, err := client.Client.ListStateConfigs(client.Ctx, &pb.ListStateConfigsReq{})
+	if err != nil {
+		log.GetLogger().Error("Client request ListStateConfigs failed: ", err)
+		return nil, err
+	}
+	if err = checkRespStatus("ListStateConfigs", stateConfigsResp.Status); err != nil {
+		return nil, err
+	}
+	status.StateConfigs = stateConfigsResp.StateConfigs
+
+	log.GetLogger().Info("GetAgentStatus success")
+	return status, nil
+}
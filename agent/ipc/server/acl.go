@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	// ACLConfigFilename is the file in cross-version config directory
+	// specifying which local users are allowed to call each RPC
+	ACLConfigFilename = "ipc-acl.json"
+
+	peerCredentialsAuthType = "peercred"
+	anyUser                 = "*"
+)
+
+var (
+	// Read-only RPCs exposing no secret are allowed for all local users by
+	// default, while others, including RPCs added later, are allowed for root
+	// only unless granted in ACL config file
+	publicMethods = map[string]bool{
+		"GetAgentStatus":    true,
+		"ListInvocations":   true,
+		"ListPeriodicTasks": true,
+		"ListSessions":      true,
+		"ListPlugins":       true,
+		"ListStateConfigs":  true,
+		"ListLogLevels":     true,
+	}
+
+	errPeerCredentialsUnsupported = errors.New("peer credentials are not supported on this platform")
+
+	// Replaced in tests
+	getACLConfigPath = defaultACLConfigPath
+	lookupGroupIds   = defaultLookupGroupIds
+	recordAudit      = audit.Record
+)
+
+// ACLRule specifies local users allowed to call an RPC. Root is always
+// allowed.
+type ACLRule struct {
+	// User names or uids, "*" allows all users
+	Users []string `json:"users,omitempty"`
+	// Group names or gids, whose members are allowed
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ACLConfig specifies rules by RPC name like "DecryptText", which replace
+// default rules of listed RPCs. The config file must be only writable by root.
+type ACLConfig struct {
+	Rules map[string]ACLRule `json:"rules"`
+}
+
+// PeerCredentials identifies the local process connected to IPC socket
+type PeerCredentials struct {
+	Uid uint32
+	Gid uint32
+	Pid int32
+}
+
+// AuthType implements credentials.AuthInfo
+func (c *PeerCredentials) AuthType() string {
+	return peerCredentialsAuthType
+}
+
+type resolvedRule struct {
+	anyUser bool
+	uids    map[uint32]bool
+	gids    map[uint32]bool
+}
+
+type accessControl struct {
+	rules map[string]*resolvedRule
+}
+
+// LoadACLConfig reads ACL config file, and returns empty config when the file
+// does not exist
+func LoadACLConfig() (*ACLConfig, error) {
+	configPath, err := getACLConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		if os.IsNotExist(err) {
+			return &ACLConfig{}, nil
+		}
+		return nil, err
+	}
+	if err := util.CheckFileProtected(configPath); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &ACLConfig{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid IPC ACL config file %s: %w", configPath, err)
+	}
+	return config, nil
+}
+
+// newAccessControl builds rules of all RPCs from config over defaults.
+// Unresolvable users or groups are skipped, so they never grant access.
+func newAccessControl(config *ACLConfig) *accessControl {
+	ac := &accessControl{
+		rules: make(map[string]*resolvedRule),
+	}
+	for method := range publicMethods {
+		ac.rules[method] = &resolvedRule{anyUser: true}
+	}
+	for method, rule := range config.Rules {
+		resolved := &resolvedRule{
+			uids: make(map[uint32]bool),
+			gids: make(map[uint32]bool),
+		}
+		for _, name := range rule.Users {
+			if name == anyUser {
+				resolved.anyUser = true
+				continue
+			}
+			uid, err := resolveId(name, func(name string) (string, error) {
+				u, err := user.Lookup(name)
+				if err != nil {
+					return "", err
+				}
+				return u.Uid, nil
+			})
+			if err != nil {
+				log.GetLogger().WithError(err).Errorf("Skip unknown user %s in IPC ACL of %s", name, method)
+				continue
+			}
+			resolved.uids[uid] = true
+		}
+		for _, name := range rule.Groups {
+			gid, err := resolveId(name, func(name string) (string, error) {
+				g, err := user.LookupGroup(name)
+				if err != nil {
+					return "", err
+				}
+				return g.Gid, nil
+			})
+			if err != nil {
+				log.GetLogger().WithError(err).Errorf("Skip unknown group %s in IPC ACL of %s", name, method)
+				continue
+			}
+			resolved.gids[gid] = true
+		}
+		ac.rules[method] = resolved
+	}
+	return ac
+}
+
+func resolveId(name string, lookup func(string) (string, error)) (uint32, error) {
+	if id, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+	id, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := strconv.ParseUint(id, 10, 32)
+	return uint32(parsed), err
+}
+
+func (ac *accessControl) allowed(method string, creds *PeerCredentials) bool {
+	if creds.Uid == 0 {
+		return true
+	}
+	rule, ok := ac.rules[method]
+	if !ok {
+		return false
+	}
+	if rule.anyUser || rule.uids[creds.Uid] {
+		return true
+	}
+	if len(rule.gids) == 0 {
+		return false
+	}
+	if rule.gids[creds.Gid] {
+		return true
+	}
+	// Supplementary groups are not carried by peer credentials
+	gids, err := lookupGroupIds(creds.Uid)
+	if err != nil {
+		log.GetLogger().WithError(err).Warningf("Failed to lookup groups of uid %d", creds.Uid)
+		return false
+	}
+	for _, gid := range gids {
+		if rule.gids[gid] {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize checks whether the peer of ctx is allowed to call fullMethod
+func (ac *accessControl) authorize(ctx context.Context, fullMethod string) error {
+	method := path.Base(fullMethod)
+	creds := peerCredentialsFromContext(ctx)
+	if creds == nil {
+		// Named pipe on Windows is only accessible by SYSTEM and
+		// administrators due to its security descriptor, see listen()
+		if !peerCredentialsSupported {
+			return nil
+		}
+		recordDenied(method, nil)
+		return status.Error(codes.PermissionDenied, "peer credentials unavailable")
+	}
+	if !ac.allowed(method, creds) {
+		recordDenied(method, creds)
+		return status.Errorf(codes.PermissionDenied, "uid %d is not allowed to call %s", creds.Uid, method)
+	}
+	return nil
+}
+
+// peerCredentialsFromContext returns credentials of the caller, or nil when
+// they are unavailable
+func peerCredentialsFromContext(ctx context.Context) *PeerCredentials {
+	p, _ := peer.FromContext(ctx)
+	if p == nil {
+		return nil
+	}
+	creds, _ := p.AuthInfo.(*PeerCredentials)
+	return creds
+}
+
+func (ac *accessControl) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := ac.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (ac *accessControl) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := ac.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func recordDenied(method string, creds *PeerCredentials) {
+	details := map[string]string{}
+	if creds != nil {
+		details["uid"] = strconv.FormatUint(uint64(creds.Uid), 10)
+		details["gid"] = strconv.FormatUint(uint64(creds.Gid), 10)
+		details["pid"] = strconv.Itoa(int(creds.Pid))
+	}
+	log.GetLogger().WithField("method", method).Warningf("Denied IPC call with peer credentials %v", details)
+	recordAudit(audit.Event{
+		Action:  audit.ActionIpcDenied,
+		Trigger: audit.TriggerLocal,
+		Subject: method,
+		Details: details,
+	})
+}
+
+// peerCredentials is the transport credentials of IPC server, which reads
+// credentials of peer process instead of securing the local connection
+type peerCredentials struct{}
+
+func (peerCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+func (peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	creds, err := getPeerCredentials(conn)
+	if err != nil {
+		if err == errPeerCredentialsUnsupported {
+			return conn, nil, nil
+		}
+		log.GetLogger().WithError(err).Errorln("Failed to get peer credentials of IPC connection")
+		return nil, nil, err
+	}
+	return conn, creds, nil
+}
+
+func (peerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: peerCredentialsAuthType,
+	}
+}
+
+func (c peerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (peerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+func defaultACLConfigPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, ACLConfigFilename), nil
+}
+
+func defaultLookupGroupIds(uid uint32) ([]uint32, error) {
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return nil, err
+	}
+	groupIds, err := u.GroupIds()
+	if err != nil {
+		return nil, err
+	}
+	gids := make([]uint32, 0, len(groupIds))
+	for _, groupId := range groupIds {
+		if gid, err := strconv.ParseUint(groupId, 10, 32); err == nil {
+			gids = append(gids, uint32(gid))
+		}
+	}
+	return gids, nil
+}
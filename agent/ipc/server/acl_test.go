@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+)
+
+func TestAccessControlDefaults(t *testing.T) {
+	ac := newAccessControl(&ACLConfig{})
+	root := &PeerCredentials{Uid: 0, Gid: 0}
+	normal := &PeerCredentials{Uid: 1000, Gid: 1000}
+
+	assert.True(t, ac.allowed("DecryptText", root))
+	assert.False(t, ac.allowed("DecryptText", normal))
+	assert.False(t, ac.allowed("GenRsaKeyPair", normal))
+	assert.False(t, ac.allowed("CreateSecretParam", normal))
+	assert.True(t, ac.allowed("GetAgentStatus", normal))
+	assert.True(t, ac.allowed("ListLogLevels", normal))
+	assert.False(t, ac.allowed("RunCommand", normal))
+	// RPCs not listed are denied by default
+	assert.False(t, ac.allowed("NewMethodForTest", normal))
+	assert.True(t, ac.allowed("NewMethodForTest", root))
+}
+
+func TestAccessControlRules(t *testing.T) {
+	originalLookupGroupIds := lookupGroupIds
+	defer func() { lookupGroupIds = originalLookupGroupIds }()
+	lookupGroupIds = func(uid uint32) ([]uint32, error) {
+		if uid == 1002 {
+			return []uint32{1002, 2000}, nil
+		}
+		return nil, errors.New("unknown uid")
+	}
+
+	ac := newAccessControl(&ACLConfig{
+		Rules: map[string]ACLRule{
+			"DecryptText":    {Users: []string{"1000"}, Groups: []string{"2000"}},
+			"EncryptText":    {Users: []string{"*"}},
+			"GetAgentStatus": {Users: []string{"no-such-user-for-test"}},
+		},
+	})
+
+	assert.True(t, ac.allowed("DecryptText", &PeerCredentials{Uid: 1000, Gid: 1000}))
+	assert.True(t, ac.allowed("DecryptText", &PeerCredentials{Uid: 1001, Gid: 2000}), "primary group")
+	assert.True(t, ac.allowed("DecryptText", &PeerCredentials{Uid: 1002, Gid: 1002}), "supplementary group")
+	assert.False(t, ac.allowed("DecryptText", &PeerCredentials{Uid: 1003, Gid: 1003}))
+	assert.True(t, ac.allowed("EncryptText", &PeerCredentials{Uid: 1003, Gid: 1003}))
+	// Unresolvable user never grants access
+	assert.False(t, ac.allowed("GetAgentStatus", &PeerCredentials{Uid: 1003, Gid: 1003}))
+	assert.True(t, ac.allowed("GetAgentStatus", &PeerCredentials{Uid: 0, Gid: 0}))
+	// Default rules of other RPCs remain
+	assert.False(t, ac.allowed("GenRsaKeyPair", &PeerCredentials{Uid: 1000, Gid: 1000}))
+}
+
+func TestAuthorize(t *testing.T) {
+	originalRecordAudit := recordAudit
+	defer func() { recordAudit = originalRecordAudit }()
+	var events []audit.Event
+	recordAudit = func(event audit.Event) {
+		events = append(events, event)
+	}
+
+	ac := newAccessControl(&ACLConfig{})
+	const method = "/protos.AssistAgent/DecryptText"
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &PeerCredentials{Uid: 1000, Gid: 1000, Pid: 42},
+	})
+	err := ac.authorize(ctx, method)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Len(t, events, 1)
+	assert.Equal(t, audit.ActionIpcDenied, events[0].Action)
+	assert.Equal(t, audit.TriggerLocal, events[0].Trigger)
+	assert.Equal(t, "DecryptText", events[0].Subject)
+	assert.Equal(t, map[string]string{"uid": "1000", "gid": "1000", "pid": "42"}, events[0].Details)
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: &PeerCredentials{Uid: 0, Gid: 0, Pid: 1},
+	})
+	assert.NoError(t, ac.authorize(ctx, method))
+	assert.Len(t, events, 1)
+
+	err = ac.authorize(context.Background(), method)
+	if peerCredentialsSupported {
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Len(t, events, 2)
+	} else {
+		assert.NoError(t, err)
+	}
+}
+
+func TestLoadACLConfig(t *testing.T) {
+	originalGetACLConfigPath := getACLConfigPath
+	defer func() { getACLConfigPath = originalGetACLConfigPath }()
+	configPath := filepath.Join(t.TempDir(), ACLConfigFilename)
+	getACLConfigPath = func() (string, error) {
+		return configPath, nil
+	}
+
+	config, err := LoadACLConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, config.Rules)
+
+	assert.NoError(t, os.WriteFile(configPath, []byte(`{"rules":{"DecryptText":{"users":["1000"],"groups":["wheel"]}}}`), 0600))
+	config, err = LoadACLConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, ACLRule{Users: []string{"1000"}, Groups: []string{"wheel"}}, config.Rules["DecryptText"])
+
+	assert.NoError(t, os.WriteFile(configPath, []byte(`{"rules":`), 0600))
+	_, err = LoadACLConfig()
+	assert.Error(t, err)
+
+	if runtime.GOOS != "windows" {
+		// Config file writable by others is not trusted
+		assert.NoError(t, os.WriteFile(configPath, []byte(`{"rules":{}}`), 0600))
+		assert.NoError(t, os.Chmod(configPath, 0666))
+		_, err = LoadACLConfig()
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/user"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine"
+)
+
+var (
+	errContainerNotAllowed = errors.New("commands in containers are only allowed for root")
+
+	// lookupUsername is replaced in tests
+	lookupUsername = defaultLookupUsername
+)
+
+func (s *agentServer) RunCommand(ctx context.Context, req *pb.RunCommandReq) (*pb.RunCommandResp, error) {
+	resp := &pb.RunCommandResp{
+		Status: newRespStatus(),
+	}
+	creds := peerCredentialsFromContext(ctx)
+	username := req.Username
+	defer func() {
+		log.GetLogger().Infof("RunCommand taskId[%s] commandType[%s] username[%s] caller[%v] statusCode[%d] errMsg[%s]", resp.TaskId, req.CommandType, username, creds, resp.Status.StatusCode, resp.Status.ErrMessage)
+	}()
+	caller := localCaller(creds)
+	if creds != nil {
+		var err error
+		if username, err = localRunUsername(req, creds); err != nil {
+			resp.Status.StatusCode = 1
+			resp.Status.ErrMessage = err.Error()
+			return resp, nil
+		}
+	}
+	invocation, err := taskengine.RunLocalCommand(taskengine.LocalCommand{
+		CommandType:                req.CommandType,
+		CommandName:                req.CommandName,
+		Content:                    req.Content,
+		WorkingDir:                 req.WorkingDir,
+		Username:                   username,
+		LoginShell:                 req.LoginShell,
+		TimeoutSeconds:             int(req.Timeout),
+		ContainerId:                req.ContainerId,
+		ContainerName:              req.ContainerName,
+		PodNamespace:               req.PodNamespace,
+		PodName:                    req.PodName,
+		PodLabelSelector:           req.PodLabelSelector,
+		ContentSignature:           req.ContentSignature,
+		ContentSignatureKeyId:      req.ContentSignatureKeyId,
+		ContentSignatureExpireTime: req.ContentSignatureExpireTime,
+		Caller:                     caller,
+	})
+	if err != nil {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = err.Error()
+		return resp, nil
+	}
+	resp.TaskId = invocation.TaskId
+	return resp, nil
+}
+
+// localRunUsername returns the user running command for the caller. Callers
+// other than root, which may be granted RunCommand in ACL config, could only
+// run commands as themselves and never in containers, otherwise they would
+// gain privileges of root.
+func localRunUsername(req *pb.RunCommandReq, creds *PeerCredentials) (string, error) {
+	if creds.Uid == 0 {
+		return req.Username, nil
+	}
+	if req.ContainerId != "" || req.ContainerName != "" || req.PodName != "" || req.PodLabelSelector != "" {
+		return "", errContainerNotAllowed
+	}
+	callerName, err := lookupUsername(creds.Uid)
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup user of uid %d: %w", creds.Uid, err)
+	}
+	if req.Username != "" && req.Username != callerName && req.Username != strconv.FormatUint(uint64(creds.Uid), 10) {
+		return "", fmt.Errorf("uid %d is only allowed to run commands as %s", creds.Uid, callerName)
+	}
+	return callerName, nil
+}
+
+// localCaller converts peer credentials for taskengine, and returns nil when
+// they are unavailable
+func localCaller(creds *PeerCredentials) *taskengine.LocalCaller {
+	if creds == nil {
+		return nil
+	}
+	return &taskengine.LocalCaller{
+		Uid: creds.Uid,
+		Gid: creds.Gid,
+		Pid: creds.Pid,
+	}
+}
+
+func defaultLookupUsername(uid uint32) (string, error) {
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+func (s *agentServer) StopCommand(ctx context.Context, req *pb.StopCommandReq) (*pb.StopCommandResp, error) {
+	resp := &pb.StopCommandResp{
+		Status: newRespStatus(),
+	}
+	creds := peerCredentialsFromContext(ctx)
+	defer func() {
+		log.GetLogger().Infof("StopCommand taskId[%s] caller[%v] statusCode[%d] errMsg[%s]", req.TaskId, creds, resp.Status.StatusCode, resp.Status.ErrMessage)
+	}()
+	if err := taskengine.StopLocalCommand(req.TaskId, localCaller(creds)); err != nil {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = err.Error()
+	}
+	return resp, nil
+}
+
+func (s *agentServer) WatchCommand(req *pb.WatchCommandReq, stream pb.AssistAgent_WatchCommandServer) error {
+	creds := peerCredentialsFromContext(stream.Context())
+	invocation, ok := taskengine.GetLocalInvocation(req.TaskId)
+	if !ok || !invocation.AccessibleBy(localCaller(creds)) {
+		return status.Error(codes.NotFound, taskengine.ErrLocalInvocationNotFound.Error())
+	}
+	log.GetLogger().Infof("WatchCommand taskId[%s] caller[%v]", req.TaskId, creds)
+
+	next := 0
+	for {
+		events, updated := invocation.Events(next)
+		for _, event := range events {
+			if err := stream.Send(&pb.CommandEvent{
+				Type:       event.Type,
+				Timestamp:  event.Time.Unix(),
+				Output:     event.Output,
+				Status:     event.Status,
+				ExitCode:   int32(event.ExitCode),
+				ErrCode:    event.ErrorCode,
+				ErrMessage: event.ErrorMessage,
+				Dropped:    event.Dropped,
+			}); err != nil {
+				return err
+			}
+			if event.Type == taskengine.LocalEventFinished {
+				return nil
+			}
+		}
+		next += len(events)
+
+		select {
+		case <-updated:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+)
+
+func TestStopCommandNotFound(t *testing.T) {
+	resp, err := newTestClient(t).StopCommand(context.Background(), &pb.StopCommandReq{TaskId: "local-unknown"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, int32(0), resp.Status.StatusCode)
+	assert.NotEmpty(t, resp.Status.ErrMessage)
+}
+
+func TestWatchCommandNotFound(t *testing.T) {
+	stream, err := newTestClient(t).WatchCommand(context.Background(), &pb.WatchCommandReq{TaskId: "local-unknown"})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestLocalRunUsername(t *testing.T) {
+	originalLookup := lookupUsername
+	defer func() { lookupUsername = originalLookup }()
+	lookupUsername = func(uid uint32) (string, error) {
+		if uid == 1000 {
+			return "alice", nil
+		}
+		return "", errors.New("unknown uid")
+	}
+
+	root := &PeerCredentials{Uid: 0}
+	username, err := localRunUsername(&pb.RunCommandReq{}, root)
+	assert.NoError(t, err)
+	assert.Equal(t, "", username)
+	username, err = localRunUsername(&pb.RunCommandReq{Username: "bob", ContainerId: "abc"}, root)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", username)
+
+	alice := &PeerCredentials{Uid: 1000, Gid: 1000}
+	for _, requested := range []string{"", "alice", "1000"} {
+		username, err = localRunUsername(&pb.RunCommandReq{Username: requested}, alice)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", username)
+	}
+	_, err = localRunUsername(&pb.RunCommandReq{Username: "root"}, alice)
+	assert.Error(t, err)
+	_, err = localRunUsername(&pb.RunCommandReq{ContainerId: "abc"}, alice)
+	assert.ErrorIs(t, err, errContainerNotAllowed)
+	_, err = localRunUsername(&pb.RunCommandReq{}, &PeerCredentials{Uid: 1001})
+	assert.Error(t, err)
+}
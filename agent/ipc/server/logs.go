@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+// parseLevel accepts level names of logrus, and returns defaultLevel for
+// empty name
+func parseLevel(name string, defaultLevel logrus.Level) (logrus.Level, error) {
+	if name == "" {
+		return defaultLevel, nil
+	}
+	return logrus.ParseLevel(strings.ToLower(name))
+}
+
+func (s *agentServer) StreamLogs(req *pb.StreamLogsReq, stream pb.AssistAgent_StreamLogsServer) error {
+	level, err := parseLevel(req.Level, logrus.InfoLevel)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Not logged for each entry sent, which would be streamed again
+	log.GetLogger().Infof("StreamLogs level[%s] module[%s] taskId[%s] started", level, req.Module, req.TaskId)
+	subscription := log.Subscribe(log.StreamFilter{
+		Level:  level,
+		Module: strings.Trim(req.Module, "/"),
+		TaskId: req.TaskId,
+	})
+	defer func() {
+		subscription.Close()
+		log.GetLogger().Infof("StreamLogs level[%s] module[%s] taskId[%s] stopped", level, req.Module, req.TaskId)
+	}()
+
+	for {
+		select {
+		case entry := <-subscription.C:
+			if err := stream.Send(&pb.LogEntry{
+				Timestamp: entry.Time.UnixMilli(),
+				Level:     entry.Level.String(),
+				Module:    entry.Module,
+				Message:   entry.Message,
+				Fields:    entry.Fields,
+				Dropped:   entry.Dropped,
+			}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *agentServer) SetLogLevel(ctx context.Context, req *pb.SetLogLevelReq) (*pb.SetLogLevelResp, error) {
+	resp := &pb.SetLogLevelResp{
+		Status: newRespStatus(),
+	}
+	defer func() {
+		log.GetLogger().Infof("SetLogLevel module[%s] level[%s] ttl[%d] statusCode[%d] errMsg[%s]", req.Module, req.Level, req.Ttl, resp.Status.StatusCode, resp.Status.ErrMessage)
+	}()
+	if req.Level == "" {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = "level is required"
+		return resp, nil
+	}
+	level, err := parseLevel(req.Level, log.DefaultLevel())
+	if err != nil {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = err.Error()
+		return resp, nil
+	}
+	expireTime, err := log.SetModuleLevel(req.Module, level, time.Duration(req.Ttl)*time.Second)
+	if err != nil {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = err.Error()
+		return resp, nil
+	}
+	recordAudit(audit.Event{
+		Action:  audit.ActionSetLogLevel,
+		Trigger: audit.TriggerLocal,
+		Subject: req.Module,
+		Details: map[string]string{
+			"level":      level.String(),
+			"expireTime": expireTime.Format(time.RFC3339),
+		},
+	})
+	resp.ExpireTimestamp = toTimestamp(expireTime)
+	return resp, nil
+}
+
+func (s *agentServer) ResetLogLevel(ctx context.Context, req *pb.ResetLogLevelReq) (*pb.ResetLogLevelResp, error) {
+	resp := &pb.ResetLogLevelResp{
+		Status: newRespStatus(),
+	}
+	defer func() {
+		log.GetLogger().Infof("ResetLogLevel module[%s] statusCode[%d] errMsg[%s]", req.Module, resp.Status.StatusCode, resp.Status.ErrMessage)
+	}()
+	if !log.ResetModuleLevel(req.Module) {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = "log level of module is not changed"
+		return resp, nil
+	}
+	recordAudit(audit.Event{
+		Action:  audit.ActionSetLogLevel,
+		Trigger: audit.TriggerLocal,
+		Subject: req.Module,
+		Details: map[string]string{
+			"level": "reset",
+		},
+	})
+	return resp, nil
+}
+
+func (s *agentServer) ListLogLevels(ctx context.Context, req *pb.ListLogLevelsReq) (*pb.ListLogLevelsResp, error) {
+	resp := &pb.ListLogLevelsResp{
+		Status:       newRespStatus(),
+		DefaultLevel: log.DefaultLevel().String(),
+	}
+	for _, moduleLevel := range log.ListModuleLevels() {
+		resp.Levels = append(resp.Levels, &pb.ModuleLogLevel{
+			Module:          moduleLevel.Module,
+			Level:           moduleLevel.Level.String(),
+			ExpireTimestamp: toTimestamp(moduleLevel.ExpireTime),
+		})
+	}
+	return resp, nil
+}
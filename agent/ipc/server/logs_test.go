@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	originalRecordAudit := recordAudit
+	defer func() { recordAudit = originalRecordAudit }()
+	var events []audit.Event
+	recordAudit = func(event audit.Event) {
+		events = append(events, event)
+	}
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	resp, err := client.SetLogLevel(ctx, &pb.SetLogLevelReq{Module: "taskengine", Level: "verbose"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, int32(0), resp.Status.StatusCode)
+
+	resp, err = client.SetLogLevel(ctx, &pb.SetLogLevelReq{Module: "taskengine", Level: "DEBUG", Ttl: 60})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resp.Status.StatusCode)
+	assert.InDelta(t, time.Now().Add(time.Minute).Unix(), resp.ExpireTimestamp, 2)
+	assert.Len(t, events, 1)
+	assert.Equal(t, audit.ActionSetLogLevel, events[0].Action)
+	assert.Equal(t, "taskengine", events[0].Subject)
+
+	listResp, err := client.ListLogLevels(ctx, &pb.ListLogLevelsReq{})
+	assert.NoError(t, err)
+	assert.Equal(t, log.DefaultLevel().String(), listResp.DefaultLevel)
+	assert.Len(t, listResp.Levels, 1)
+	assert.Equal(t, "taskengine", listResp.Levels[0].Module)
+	assert.Equal(t, "debug", listResp.Levels[0].Level)
+
+	resetResp, err := client.ResetLogLevel(ctx, &pb.ResetLogLevelReq{Module: "taskengine"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resetResp.Status.StatusCode)
+	resetResp, err = client.ResetLogLevel(ctx, &pb.ResetLogLevelReq{Module: "taskengine"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, int32(0), resetResp.Status.StatusCode)
+	assert.Empty(t, log.ListModuleLevels())
+}
+
+func TestStreamLogs(t *testing.T) {
+	client := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamLogs(ctx, &pb.StreamLogsReq{Level: "debug", TaskId: "t-stream"})
+	assert.NoError(t, err)
+	// Wait for subscription, which lowers logger level
+	assert.Eventually(t, func() bool {
+		return log.GetLogger().IsLevelEnabled(log.DefaultLevel() + 1)
+	}, time.Second, 10*time.Millisecond)
+	log.GetLogger().WithField("TaskId", "t-stream").Debug("streamed entry")
+
+	entry, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "streamed entry", entry.Message)
+	assert.Equal(t, "debug", entry.Level)
+	assert.Equal(t, "t-stream", entry.Fields["TaskId"])
+}
+
+func TestStreamLogsInvalidLevel(t *testing.T) {
+	stream, err := newTestClient(t).StreamLogs(context.Background(), &pb.StreamLogsReq{Level: "verbose"})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+)
+
+func TestGetPeerCredentials(t *testing.T) {
+	lis, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	assert.NoError(t, err)
+	defer lis.Close()
+
+	go func() {
+		if conn, err := net.Dial("unix", lis.Addr().String()); err == nil {
+			defer conn.Close()
+			buf := make([]byte, 1)
+			conn.Read(buf)
+		}
+	}()
+	conn, err := lis.Accept()
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	creds, err := getPeerCredentials(conn)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(os.Getuid()), creds.Uid)
+	assert.Equal(t, uint32(os.Getgid()), creds.Gid)
+	assert.Equal(t, int32(os.Getpid()), creds.Pid)
+
+	tcpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer tcpLis.Close()
+	go func() {
+		if conn, err := net.Dial("tcp", tcpLis.Addr().String()); err == nil {
+			conn.Close()
+		}
+	}()
+	tcpConn, err := tcpLis.Accept()
+	assert.NoError(t, err)
+	defer tcpConn.Close()
+	_, err = getPeerCredentials(tcpConn)
+	assert.Error(t, err)
+}
+
+func TestPeerCredentialsOverGrpc(t *testing.T) {
+	originalRecordAudit := recordAudit
+	defer func() { recordAudit = originalRecordAudit }()
+	var events []audit.Event
+	recordAudit = func(event audit.Event) {
+		events = append(events, event)
+	}
+
+	sockPath := filepath.Join(t.TempDir(), "test.sock")
+	lis, err := net.Listen("unix", sockPath)
+	assert.NoError(t, err)
+	// Non-root caller is denied for every RPC, while root is always allowed
+	ac := newAccessControl(&ACLConfig{
+		Rules: map[string]ACLRule{"GetAgentStatus": {}},
+	})
+	server := grpc.NewServer(
+		grpc.Creds(peerCredentials{}),
+		grpc.UnaryInterceptor(ac.unaryInterceptor),
+	)
+	pb.RegisterAssistAgentServer(server, newServer())
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(sockPath, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.Dial("unix", addr)
+		}))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	_, err = pb.NewAssistAgentClient(conn).GetAgentStatus(context.Background(), &pb.GetAgentStatusReq{})
+	if os.Getuid() == 0 {
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	} else {
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Len(t, events, 1)
+	}
+}
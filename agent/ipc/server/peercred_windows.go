@@ -0,0 +1,11 @@
+package server
+
+import (
+	"net"
+)
+
+const peerCredentialsSupported = false
+
+func getPeerCredentials(conn net.Conn) (*PeerCredentials, error) {
+	return nil, errPeerCredentialsUnsupported
+}
@@ -36,7 +36,17 @@ func StartService() {
 		log.GetLogger().Errorf("StartService failed to listen: %v", err)
 		return
 	}
-	grpcServer = grpc.NewServer()
+	config, err := LoadACLConfig()
+	if err != nil {
+		log.GetLogger().WithError(err).Errorln("Failed to load IPC ACL config, use default rules")
+		config = &ACLConfig{}
+	}
+	ac := newAccessControl(config)
+	grpcServer = grpc.NewServer(
+		grpc.Creds(peerCredentials{}),
+		grpc.UnaryInterceptor(ac.unaryInterceptor),
+		grpc.StreamInterceptor(ac.streamInterceptor),
+	)
 	pb.RegisterAssistAgentServer(grpcServer, newServer())
 	go func () {
 		if err := grpcServer.Serve(lis); err != nil {
@@ -7,8 +7,13 @@ import (
 	"github.com/Microsoft/go-winio"
 )
 
+// pipeSecurityDescriptor grants full access to SYSTEM and administrators only,
+// since peer credentials of named pipe are not checked by IPC ACL
+const pipeSecurityDescriptor = "D:P(A;;GA;;;SY)(A;;GA;;;BA)"
+
 func listen() (net.Listener, error) {
 	pipConfig := &winio.PipeConfig{
+		SecurityDescriptor: pipeSecurityDescriptor,
 		MessageMode: false,
 		InputBufferSize: 512,
 		OutputBufferSize: 512,
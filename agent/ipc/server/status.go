@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/channel"
+	"github.com/aliyun/aliyun_assist_client/agent/heartbeat"
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/pluginmanager"
+	"github.com/aliyun/aliyun_assist_client/agent/statemanager"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine"
+	"github.com/aliyun/aliyun_assist_client/agent/version"
+)
+
+const (
+	invocationStateRunning = "running"
+	invocationStateQueued  = "queued"
+)
+
+var (
+	processStartTime = time.Now()
+
+	// Replaced in tests
+	statusNow = time.Now
+)
+
+func toTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func elapsedSeconds(since time.Time) int64 {
+	if since.IsZero() {
+		return 0
+	}
+	return int64(statusNow().Sub(since) / time.Second)
+}
+
+func (s *agentServer) GetAgentStatus(ctx context.Context, req *pb.GetAgentStatusReq) (*pb.GetAgentStatusResp, error) {
+	channelType, working := channel.GetCurrentChannelState()
+	pingResult := heartbeat.GetLastPingResult()
+	resp := &pb.GetAgentStatusResp{
+		Status:         newRespStatus(),
+		Version:        version.AssistVersion,
+		GitCommitHash:  version.GitCommitHash,
+		Pid:            int32(os.Getpid()),
+		StartTimestamp: processStartTime.Unix(),
+		UptimeSeconds:  elapsedSeconds(processStartTime),
+		Channel: &pb.ChannelStatus{
+			ChannelType: channel.ChannelTypeStr(channelType),
+			Working:     working,
+		},
+		Heartbeat: &pb.HeartbeatStatus{
+			LastTimestamp: toTimestamp(pingResult.Time),
+			Succeeded:     pingResult.Succeeded,
+			ErrMessage:    pingResult.Error,
+		},
+	}
+	if channelType == channel.ChannelNone {
+		resp.Channel.ChannelType = "none"
+	}
+	log.GetLogger().Infof("GetAgentStatus statusCode[%d] errMsg[%s]", resp.Status.StatusCode, resp.Status.ErrMessage)
+	return resp, nil
+}
+
+func (s *agentServer) ListInvocations(ctx context.Context, req *pb.ListInvocationsReq) (*pb.ListInvocationsResp, error) {
+	resp := &pb.ListInvocationsResp{
+		Status: newRespStatus(),
+	}
+	for _, invocation := range taskengine.GetTaskFactory().ListInvocations() {
+		state := invocationStateQueued
+		if invocation.Running {
+			state = invocationStateRunning
+		}
+		resp.Invocations = append(resp.Invocations, &pb.InvocationStatus{
+			TaskId:         invocation.TaskId,
+			InvokeVersion:  int32(invocation.InvokeVersion),
+			CommandName:    invocation.CommandName,
+			Repeat:         invocation.Repeat,
+			State:          state,
+			StartTimestamp: toTimestamp(invocation.StartTime),
+			ElapsedSeconds: elapsedSeconds(invocation.StartTime),
+		})
+	}
+	log.GetLogger().Infof("ListInvocations count[%d] statusCode[%d] errMsg[%s]", len(resp.Invocations), resp.Status.StatusCode, resp.Status.ErrMessage)
+	return resp, nil
+}
+
+func (s *agentServer) ListPeriodicTasks(ctx context.Context, req *pb.ListPeriodicTasksReq) (*pb.ListPeriodicTasksResp, error) {
+	resp := &pb.ListPeriodicTasksResp{
+		Status: newRespStatus(),
+	}
+	for _, periodicTask := range taskengine.ListPeriodicTasks() {
+		resp.PeriodicTasks = append(resp.PeriodicTasks, &pb.PeriodicTaskStatus{
+			TaskId:            periodicTask.TaskId,
+			InvokeVersion:     int32(periodicTask.InvokeVersion),
+			CommandName:       periodicTask.CommandName,
+			Repeat:            periodicTask.Repeat,
+			Expression:        periodicTask.Cronat,
+			CreationTimestamp: periodicTask.CreationTime / 1000,
+			Running:           periodicTask.Running,
+			LastRunTimestamp:  toTimestamp(periodicTask.LastRunTime),
+			NextRunTimestamp:  toTimestamp(periodicTask.NextRunTime),
+		})
+	}
+	log.GetLogger().Infof("ListPeriodicTasks count[%d] statusCode[%d] errMsg[%s]", len(resp.PeriodicTasks), resp.Status.StatusCode, resp.Status.ErrMessage)
+	return resp, nil
+}
+
+func (s *agentServer) ListSessions(ctx context.Context, req *pb.ListSessionsReq) (*pb.ListSessionsResp, error) {
+	resp := &pb.ListSessionsResp{
+		Status: newRespStatus(),
+	}
+	for _, session := range taskengine.GetSessionFactory().ListSessions() {
+		resp.Sessions = append(resp.Sessions, &pb.SessionStatus{
+			SessionId:      session.SessionId,
+			TaskId:         session.TaskId,
+			Username:       session.Username,
+			TargetHost:     session.TargetHost,
+			PortNumber:     session.PortNumber,
+			ContainerId:    session.ContainerId,
+			ContainerName:  session.ContainerName,
+			StartTimestamp: toTimestamp(session.StartTime),
+			ElapsedSeconds: elapsedSeconds(session.StartTime),
+		})
+	}
+	log.GetLogger().Infof("ListSessions count[%d] statusCode[%d] errMsg[%s]", len(resp.Sessions), resp.Status.StatusCode, resp.Status.ErrMessage)
+	return resp, nil
+}
+
+func (s *agentServer) ListPlugins(ctx context.Context, req *pb.ListPluginsReq) (*pb.ListPluginsResp, error) {
+	resp := &pb.ListPluginsResp{
+		Status: newRespStatus(),
+	}
+	defer func() {
+		log.GetLogger().Infof("ListPlugins count[%d] statusCode[%d] errMsg[%s]", len(resp.Plugins), resp.Status.StatusCode, resp.Status.ErrMessage)
+	}()
+	pluginStates, err := pluginmanager.ListPluginStates()
+	if err != nil {
+		resp.Status.StatusCode = 1
+		resp.Status.ErrMessage = err.Error()
+		return resp, nil
+	}
+	for _, pluginState := range pluginStates {
+		resp.Plugins = append(resp.Plugins, &pb.PluginStatus{
+			Name:           pluginState.Name,
+			Version:        pluginState.Version,
+			PluginType:     pluginState.PluginType,
+			Status:         pluginState.Status,
+			CheckTimestamp: toTimestamp(pluginState.CheckTime),
+		})
+	}
+	return resp, nil
+}
+
+func (s *agentServer) ListStateConfigs(ctx context.Context, req *pb.ListStateConfigsReq) (*pb.ListStateConfigsResp, error) {
+	resp := &pb.ListStateConfigsResp{
+		Status: newRespStatus(),
+	}
+	for _, stateConfig := range statemanager.ListStateConfigs() {
+		resp.StateConfigs = append(resp.StateConfigs, &pb.StateConfigStatus{
+			StateConfigurationId: stateConfig.StateConfigurationId,
+			TemplateName:         stateConfig.TemplateName,
+			TemplateVersion:      stateConfig.TemplateVersion,
+			ConfigureMode:        stateConfig.ConfigureMode,
+			ScheduleType:         stateConfig.ScheduleType,
+			ScheduleExpression:   stateConfig.ScheduleExpression,
+			SuccessfulApplyTime:  stateConfig.SuccessfulApplyTime,
+			LastStatus:           stateConfig.LastStatus,
+			LastEnforceTimestamp: toTimestamp(stateConfig.LastEnforceTime),
+			NextRunTimestamp:     toTimestamp(stateConfig.NextRunTime),
+		})
+	}
+	log.GetLogger().Infof("ListStateConfigs count[%d] statusCode[%d] errMsg[%s]", len(resp.StateConfigs), resp.Status.StatusCode, resp.Status.ErrMessage)
+	return resp, nil
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/version"
+)
+
+func newTestClient(t *testing.T) pb.AssistAgentClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	pb.RegisterAssistAgentServer(server, newServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewAssistAgentClient(conn)
+}
+
+func TestGetAgentStatus(t *testing.T) {
+	originalNow := statusNow
+	defer func() { statusNow = originalNow }()
+	statusNow = func() time.Time {
+		return processStartTime.Add(90 * time.Second)
+	}
+
+	resp, err := newTestClient(t).GetAgentStatus(context.Background(), &pb.GetAgentStatusReq{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resp.Status.StatusCode)
+	assert.Equal(t, version.AssistVersion, resp.Version)
+	assert.Equal(t, int32(os.Getpid()), resp.Pid)
+	assert.Equal(t, int64(90), resp.UptimeSeconds)
+	assert.Equal(t, "none", resp.Channel.ChannelType)
+	assert.False(t, resp.Channel.Working)
+	assert.Equal(t, int64(0), resp.Heartbeat.LastTimestamp)
+}
+
+func TestListInvocations(t *testing.T) {
+	resp, err := newTestClient(t).ListInvocations(context.Background(), &pb.ListInvocationsReq{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resp.Status.StatusCode)
+	assert.Empty(t, resp.Invocations)
+}
+
+func TestToTimestamp(t *testing.T) {
+	assert.Equal(t, int64(0), toTimestamp(time.Time{}))
+	assert.Equal(t, int64(1700000000), toTimestamp(time.Unix(1700000000, 0)))
+	assert.Equal(t, int64(0), elapsedSeconds(time.Time{}))
+}
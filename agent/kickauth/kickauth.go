@@ -0,0 +1,384 @@
+// Package kickauth authenticates kick messages received from channels and
+// protects them from being replayed.
+package kickauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/flagging"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/signature"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	// KickKeyFilename is the file in hybrid directory storing base64-encoded
+	// HMAC key provisioned at registration
+	KickKeyFilename = "kick-key"
+	// AcceptedTimestampFilename is the file in cross-version config directory
+	// storing the latest timestamp of accepted messages, which survives
+	// restart of agent unlike remembered nonces
+	AcceptedTimestampFilename = "kick-accepted-timestamp"
+
+	AlgorithmHmacSha256 = "hmac-sha256"
+	// Signature by a key in the kick keyring
+	AlgorithmSignature = "signature"
+
+	// Maximum difference between timestamp of message and local clock
+	maxClockSkew = 5 * time.Minute
+	// Nonce of accepted message is remembered until its timestamp falls out
+	// of the acceptable window. Oldest nonces are evicted beyond the limit
+	maxNonceCount  = 100000
+	minNonceLength = 8
+)
+
+var (
+	ErrAuthenticationRequired = errors.New("kick message is not authenticated")
+	ErrUnsupportedAlgorithm   = errors.New("unsupported kick authentication algorithm")
+	ErrTimestampOutOfWindow   = errors.New("timestamp of kick message is out of acceptable window")
+	ErrInvalidNonce           = errors.New("nonce of kick message is too short")
+	ErrReplayed               = errors.New("kick message is replayed")
+	ErrInvalidMAC             = errors.New("HMAC of kick message is invalid")
+	ErrNoKickKey              = errors.New("no kick key is provisioned")
+	ErrInstanceMismatch       = errors.New("kick message is not for this instance")
+
+	// getKickKeyPath is replaced in tests
+	getKickKeyPath = defaultKickKeyPath
+	// getAcceptedTimestampPath is replaced in tests
+	getAcceptedTimestampPath = defaultAcceptedTimestampPath
+	// detectRequired is replaced in tests
+	detectRequired = defaultDetectRequired
+	// getInstanceId is replaced in tests
+	getInstanceId = defaultInstanceId
+	// verifySignature is replaced in tests
+	verifySignature = signature.VerifyKick
+	timeNow         = time.Now
+
+	_defaultVerifier = NewVerifier()
+)
+
+// AuthenticatedMessage wraps original kick message, in legacy string format,
+// JSON kick envelope or gshell command, with authentication information
+type AuthenticatedMessage struct {
+	Message string `json:"message"`
+	// Id of the instance the message is sent to, thus message signed by keys
+	// trusted across instances could not be replayed on other instances
+	InstanceId string `json:"instanceId"`
+	// Unix timestamp in milliseconds
+	Timestamp int64  `json:"timestamp"`
+	Nonce     string `json:"nonce"`
+	Algorithm string `json:"algorithm"`
+	// Id of the key in keyring for signature algorithm
+	KeyId string `json:"keyId,omitempty"`
+	// Base64-encoded HMAC or signature of SignedPayload()
+	Signature string `json:"signature"`
+}
+
+// SignedPayload joins instance id, timestamp, nonce and message with newline
+func (m *AuthenticatedMessage) SignedPayload() []byte {
+	return []byte(m.InstanceId + "\n" + strconv.FormatInt(m.Timestamp, 10) + "\n" + m.Nonce + "\n" + m.Message)
+}
+
+// Verifier verifies authenticated messages and remembers nonces of accepted
+// messages. Nonces are only remembered in memory, thus messages not newer than
+// the latest one accepted before agent started are refused as replayed.
+type Verifier struct {
+	lock   sync.Mutex
+	nonces map[string]int64
+	order  []string
+
+	acceptedLock sync.Mutex
+	// Latest timestamp accepted before agent started, loaded at first use
+	startupAccepted int64
+	latestAccepted  int64
+	acceptedLoaded  bool
+}
+
+func NewVerifier() *Verifier {
+	return &Verifier{
+		nonces: make(map[string]int64),
+	}
+}
+
+// Verify unwraps authenticated message and returns the original message.
+// Unauthenticated message is returned as is unless authentication is
+// required, which is when a kick key has been provisioned or the requiring
+// flag file exists.
+func Verify(msg string) (string, bool, error) {
+	return _defaultVerifier.Verify(msg)
+}
+
+func (v *Verifier) Verify(msg string) (string, bool, error) {
+	authMsg, ok := parseAuthenticatedMessage(msg)
+	if !ok {
+		if Required() {
+			return "", false, ErrAuthenticationRequired
+		}
+		return msg, false, nil
+	}
+
+	now := timeNow()
+	timestamp := time.UnixMilli(authMsg.Timestamp)
+	if timestamp.Before(now.Add(-maxClockSkew)) || timestamp.After(now.Add(maxClockSkew)) {
+		return "", true, ErrTimestampOutOfWindow
+	}
+	if len(authMsg.Nonce) < minNonceLength {
+		return "", true, ErrInvalidNonce
+	}
+	if instanceId := getInstanceId(); instanceId == "" || authMsg.InstanceId != instanceId {
+		return "", true, ErrInstanceMismatch
+	}
+	if err := verifyMessage(authMsg); err != nil {
+		return "", true, err
+	}
+	if v.acceptedBeforeStartup(authMsg.Timestamp) {
+		return "", true, ErrReplayed
+	}
+	// Nonce is remembered only after the message is authenticated, thus
+	// forged messages could not occupy nonces of legitimate ones
+	if !v.rememberNonce(authMsg.Nonce, authMsg.Timestamp, now) {
+		return "", true, ErrReplayed
+	}
+	v.recordAccepted(authMsg.Timestamp)
+	return authMsg.Message, true, nil
+}
+
+// acceptedBeforeStartup reports whether message of the timestamp could have
+// been accepted before agent started, whose nonce has been forgotten
+func (v *Verifier) acceptedBeforeStartup(timestamp int64) bool {
+	v.acceptedLock.Lock()
+	defer v.acceptedLock.Unlock()
+	v.loadAcceptedLocked()
+	return timestamp <= v.startupAccepted
+}
+
+// recordAccepted persists the timestamp when it is the latest accepted one
+func (v *Verifier) recordAccepted(timestamp int64) {
+	v.acceptedLock.Lock()
+	defer v.acceptedLock.Unlock()
+	v.loadAcceptedLocked()
+	if timestamp <= v.latestAccepted {
+		return
+	}
+	v.latestAccepted = timestamp
+	if err := saveAcceptedTimestamp(timestamp); err != nil {
+		log.GetLogger().WithError(err).Warningln("Failed to persist timestamp of accepted kick message")
+	}
+}
+
+func (v *Verifier) loadAcceptedLocked() {
+	if v.acceptedLoaded {
+		return
+	}
+	v.acceptedLoaded = true
+	timestamp, err := loadAcceptedTimestamp()
+	if err != nil {
+		log.GetLogger().WithError(err).Warningln("Failed to load timestamp of accepted kick message")
+		return
+	}
+	v.startupAccepted = timestamp
+	v.latestAccepted = timestamp
+}
+
+func (v *Verifier) rememberNonce(nonce string, timestamp int64, now time.Time) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	// Evict nonces whose messages could no longer pass timestamp check
+	expiredBefore := now.Add(-maxClockSkew).UnixMilli()
+	evicted := 0
+	for _, n := range v.order {
+		if v.nonces[n] >= expiredBefore && len(v.order)-evicted < maxNonceCount {
+			break
+		}
+		delete(v.nonces, n)
+		evicted++
+	}
+	v.order = v.order[evicted:]
+
+	if _, ok := v.nonces[nonce]; ok {
+		return false
+	}
+	v.nonces[nonce] = timestamp
+	v.order = append(v.order, nonce)
+	return true
+}
+
+func parseAuthenticatedMessage(msg string) (*AuthenticatedMessage, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(msg), "{") {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(msg), &fields); err != nil {
+		return nil, false
+	}
+	for _, name := range []string{"message", "signature", "nonce", "timestamp"} {
+		if _, ok := fields[name]; !ok {
+			return nil, false
+		}
+	}
+	authMsg := &AuthenticatedMessage{}
+	if err := json.Unmarshal([]byte(msg), authMsg); err != nil {
+		return nil, false
+	}
+	return authMsg, true
+}
+
+func verifyMessage(authMsg *AuthenticatedMessage) error {
+	switch authMsg.Algorithm {
+	case AlgorithmHmacSha256:
+		key, err := loadKickKey()
+		if err != nil {
+			return err
+		}
+		mac, err := base64.StdEncoding.DecodeString(authMsg.Signature)
+		if err != nil {
+			return ErrInvalidMAC
+		}
+		h := hmac.New(sha256.New, key)
+		h.Write(authMsg.SignedPayload())
+		if !hmac.Equal(mac, h.Sum(nil)) {
+			return ErrInvalidMAC
+		}
+		return nil
+	case AlgorithmSignature:
+		_, err := verifySignature(authMsg.SignedPayload(), authMsg.Signature, authMsg.KeyId)
+		return err
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, authMsg.Algorithm)
+	}
+}
+
+// Required reports whether kick messages must be authenticated, which is when
+// a kick key has been provisioned or the requiring flag file exists
+func Required() bool {
+	if keyPath, err := getKickKeyPath(); err == nil {
+		if _, err := os.Stat(keyPath); err == nil {
+			return true
+		}
+	}
+	isRequired, err := detectRequired()
+	if err != nil {
+		log.GetLogger().WithError(err).Errorln("Failed to detect kick authentication flag")
+	}
+	return isRequired
+}
+
+func loadKickKey() ([]byte, error) {
+	keyPath, err := getKickKeyPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoKickKey
+		}
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("invalid kick key in %s", keyPath)
+	}
+	return key, nil
+}
+
+// SaveKickKey stores base64-encoded kick key provisioned at registration,
+// which is only readable by the agent
+func SaveKickKey(encodedKey string) error {
+	if _, err := base64.StdEncoding.DecodeString(encodedKey); err != nil {
+		return fmt.Errorf("invalid kick key: %w", err)
+	}
+	keyPath, err := getKickKeyPath()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(keyPath, []byte(encodedKey), 0600); err != nil {
+		return err
+	}
+	// Mode of existing file is not changed by os.WriteFile
+	return os.Chmod(keyPath, 0600)
+}
+
+// RemoveKickKey removes provisioned kick key at deregistration
+func RemoveKickKey() {
+	if keyPath, err := getKickKeyPath(); err == nil {
+		os.Remove(keyPath)
+	}
+}
+
+func defaultKickKeyPath() (string, error) {
+	hybridDir, err := pathutil.GetHybridPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(hybridDir, KickKeyFilename), nil
+}
+
+func loadAcceptedTimestamp() (int64, error) {
+	statePath, err := getAcceptedTimestampPath()
+	if err != nil {
+		return 0, err
+	}
+	content, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
+
+// saveAcceptedTimestamp replaces the state file atomically, thus a crash
+// during writing never leaves it truncated
+func saveAcceptedTimestamp(timestamp int64) error {
+	statePath, err := getAcceptedTimestampPath()
+	if err != nil {
+		return err
+	}
+	tempPath := statePath + ".tmp"
+	if err := os.WriteFile(tempPath, []byte(strconv.FormatInt(timestamp, 10)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, statePath)
+}
+
+func defaultAcceptedTimestampPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, AcceptedTimestampFilename), nil
+}
+
+// defaultInstanceId returns id of the managed instance for hybrid mode, or
+// id of the ECS instance from meta server otherwise. Empty string is returned
+// when it is unknown.
+func defaultInstanceId() string {
+	if hybridDir, err := pathutil.GetHybridPath(); err == nil {
+		if content, err := os.ReadFile(filepath.Join(hybridDir, "instance-id")); err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+	if instanceId := util.GetInstanceId(); instanceId != "unknown" {
+		return instanceId
+	}
+	return ""
+}
+
+func defaultDetectRequired() (bool, error) {
+	return flagging.DetectKickAuthenticationRequired()
+}
@@ -0,0 +1,160 @@
+package kickauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+const testInstanceId = "i-test"
+
+func useKickKey(t *testing.T, provisioned bool) {
+	stateDir := t.TempDir()
+	keyPath := filepath.Join(stateDir, KickKeyFilename)
+	statePath := filepath.Join(stateDir, AcceptedTimestampFilename)
+	getKickKeyPath = func() (string, error) { return keyPath, nil }
+	getAcceptedTimestampPath = func() (string, error) { return statePath, nil }
+	detectRequired = func() (bool, error) { return false, nil }
+	getInstanceId = func() string { return testInstanceId }
+	t.Cleanup(func() {
+		getKickKeyPath = defaultKickKeyPath
+		getAcceptedTimestampPath = defaultAcceptedTimestampPath
+		detectRequired = defaultDetectRequired
+		getInstanceId = defaultInstanceId
+	})
+	if provisioned {
+		assert.NoError(t, SaveKickKey(base64.StdEncoding.EncodeToString(testKey)))
+	}
+}
+
+func signedMessage(msg string, timestamp time.Time, nonce string, key []byte) string {
+	return signedMessageFor(testInstanceId, msg, timestamp, nonce, key)
+}
+
+func signedMessageFor(instanceId string, msg string, timestamp time.Time, nonce string, key []byte) string {
+	authMsg := &AuthenticatedMessage{
+		Message:    msg,
+		InstanceId: instanceId,
+		Timestamp:  timestamp.UnixMilli(),
+		Nonce:      nonce,
+		Algorithm:  AlgorithmHmacSha256,
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(authMsg.SignedPayload())
+	authMsg.Signature = base64.StdEncoding.EncodeToString(h.Sum(nil))
+	msgBytes, _ := json.Marshal(authMsg)
+	return string(msgBytes)
+}
+
+func TestVerifyNotRequired(t *testing.T) {
+	useKickKey(t, false)
+	verifier := NewVerifier()
+	msg, authenticated, err := verifier.Verify("kick_vm task run t-xx")
+	assert.NoError(t, err)
+	assert.False(t, authenticated)
+	assert.Equal(t, "kick_vm task run t-xx", msg)
+
+	detectRequired = func() (bool, error) { return true, nil }
+	_, _, err = verifier.Verify("kick_vm task run t-xx")
+	assert.True(t, errors.Is(err, ErrAuthenticationRequired))
+}
+
+func TestVerifyHmac(t *testing.T) {
+	useKickKey(t, true)
+	verifier := NewVerifier()
+	now := time.Now()
+
+	_, _, err := verifier.Verify("kick_vm agent stop")
+	assert.True(t, errors.Is(err, ErrAuthenticationRequired))
+
+	signed := signedMessage("kick_vm agent stop", now, "nonce-0001", testKey)
+	msg, authenticated, err := verifier.Verify(signed)
+	assert.NoError(t, err)
+	assert.True(t, authenticated)
+	assert.Equal(t, "kick_vm agent stop", msg)
+
+	// Replayed
+	_, _, err = verifier.Verify(signed)
+	assert.True(t, errors.Is(err, ErrReplayed))
+
+	// Expired
+	_, _, err = verifier.Verify(signedMessage("kick_vm agent stop", now.Add(-10*time.Minute), "nonce-0002", testKey))
+	assert.True(t, errors.Is(err, ErrTimestampOutOfWindow))
+
+	// Forged, whose nonce is not remembered
+	_, _, err = verifier.Verify(signedMessage("kick_vm agent stop", now, "nonce-0003", []byte("wrong key")))
+	assert.True(t, errors.Is(err, ErrInvalidMAC))
+	_, _, err = verifier.Verify(signedMessage("kick_vm agent stop", now, "nonce-0003", testKey))
+	assert.NoError(t, err)
+
+	// Tampered message
+	var authMsg AuthenticatedMessage
+	json.Unmarshal([]byte(signedMessage("kick_vm noop", now, "nonce-0004", testKey)), &authMsg)
+	authMsg.Message = "kick_vm agent remove"
+	tampered, _ := json.Marshal(&authMsg)
+	_, _, err = verifier.Verify(string(tampered))
+	assert.True(t, errors.Is(err, ErrInvalidMAC))
+
+	// Message for another instance, or re-targeted to this instance
+	_, _, err = verifier.Verify(signedMessageFor("i-other", "kick_vm agent stop", now, "nonce-0005", testKey))
+	assert.True(t, errors.Is(err, ErrInstanceMismatch))
+	json.Unmarshal([]byte(signedMessageFor("i-other", "kick_vm agent stop", now, "nonce-0006", testKey)), &authMsg)
+	authMsg.InstanceId = testInstanceId
+	retargeted, _ := json.Marshal(&authMsg)
+	_, _, err = verifier.Verify(string(retargeted))
+	assert.True(t, errors.Is(err, ErrInvalidMAC))
+}
+
+func TestRememberNonceEviction(t *testing.T) {
+	verifier := NewVerifier()
+	now := time.Now()
+	assert.True(t, verifier.rememberNonce("nonce-old", now.Add(-6*time.Minute).UnixMilli(), now.Add(-6*time.Minute)))
+	assert.True(t, verifier.rememberNonce("nonce-new", now.UnixMilli(), now))
+	assert.Len(t, verifier.nonces, 1)
+	assert.False(t, verifier.rememberNonce("nonce-new", now.UnixMilli(), now))
+}
+
+func TestVerifyReplayedAfterRestart(t *testing.T) {
+	useKickKey(t, true)
+	now := time.Now()
+	earlier := signedMessage("kick_vm agent stop", now.Add(-time.Minute), "nonce-0001", testKey)
+	later := signedMessage("kick_vm agent stop", now, "nonce-0002", testKey)
+	verifier := NewVerifier()
+	_, _, err := verifier.Verify(later)
+	assert.NoError(t, err)
+	// Messages out of order are accepted until agent restarts
+	_, _, err = verifier.Verify(earlier)
+	assert.NoError(t, err)
+
+	restarted := NewVerifier()
+	_, _, err = restarted.Verify(later)
+	assert.True(t, errors.Is(err, ErrReplayed))
+	_, _, err = restarted.Verify(earlier)
+	assert.True(t, errors.Is(err, ErrReplayed))
+	_, _, err = restarted.Verify(signedMessage("kick_vm agent stop", now.Add(time.Second), "nonce-0003", testKey))
+	assert.NoError(t, err)
+}
+
+func TestSaveKickKeyTightensMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file mode is not applicable on Windows")
+	}
+	useKickKey(t, false)
+	keyPath, _ := getKickKeyPath()
+	assert.NoError(t, os.WriteFile(keyPath, []byte("stale"), 0644))
+	assert.NoError(t, SaveKickKey(base64.StdEncoding.EncodeToString(testKey)))
+	info, err := os.Stat(keyPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -29,11 +29,10 @@ func NewAgentHandle(action string, params []string) *AgentHandle{
 
 func (h *AgentHandle) DoAction() error{
 	if v, ok := agentRoute[h.action]; ok {
-		v(h.params)
+		return v(h.params)
 	} else {
 		return errors.New("no action found")
 	}
-	return nil
 }
 
 func (h *AgentHandle) CheckAction() bool{
@@ -22,10 +22,7 @@ func runFileTask(params []string) error {
 	if len(params) < 1 {
 		return errors.New("params error")
 	}
-	go func() {
-		taskengine.Fetch(true, params[0], taskengine.NormalTaskType)
-	}()
-	return nil
+	return fetchKickedTasks(params[0], taskengine.NormalTaskType)
 }
 
 func stopFileTask(params []string) error {
@@ -34,10 +31,7 @@ func stopFileTask(params []string) error {
 		return errors.New("params error")
 	}
 
-	go func() {
-		taskengine.Fetch(true, params[0], taskengine.NormalTaskType)
-	}()
-	return nil
+	return fetchKickedTasks(params[0], taskengine.NormalTaskType)
 }
 
 
@@ -55,11 +49,10 @@ func NewFileHandle(action string, params []string) *FileHandle{
 
 func (h *FileHandle) DoAction() error{
 	if v, ok := fileRoute[h.action]; ok {
-		v(h.params)
+		return v(h.params)
 	} else {
 		return errors.New("no action found")
 	}
-	return nil
 }
 
 func (h *FileHandle) CheckAction() bool{
@@ -6,6 +6,8 @@ import (
 
 // kick_vm kick_type action params...
 // for example: kick_vm  task  run  t-xxxxxxxx
+// NOTE: Handlers are called in goroutines of channels, thus they could block
+// until the action is done and report its result.
 type handleFunc func(params []string) error
 
 type KickHandle interface {
@@ -35,19 +37,29 @@ func ParseOption(input string) KickHandle {
     if len(arrays) < 2 {
     	return nil
 	}
+	action := ""
+	var params []string
+	if len(arrays) > 2 {
+		action = arrays[2]
+		params = arrays[3:]
+	}
+	return newHandle(arrays[1], action, params)
+}
+
+func newHandle(kickType string, action string, params []string) KickHandle {
 	var handle KickHandle = nil
-	if arrays[1] == "agent" {
-		handle = NewAgentHandle(arrays[2],arrays[3:])
-	} else if arrays[1] == "task" {
-		handle = NewTaskHandle(arrays[2],arrays[3:])
-	} else if arrays[1] == "session" {
-		handle = NewSessionHandle(arrays[2],arrays[3:])
-	} else if arrays[1] == "noop" {
+	if kickType == "agent" {
+		handle = NewAgentHandle(action, params)
+	} else if kickType == "task" {
+		handle = NewTaskHandle(action, params)
+	} else if kickType == "session" {
+		handle = NewSessionHandle(action, params)
+	} else if kickType == "noop" {
 		handle = NewHealthCheckHandle()
-	} else if arrays[1] == "file" {
-		handle = NewFileHandle(arrays[2],arrays[3:])
-	} else if arrays[1] == "status" {
-		handle = NewStatusHandle(arrays[2], arrays[3:])
+	} else if kickType == "file" {
+		handle = NewFileHandle(action, params)
+	} else if kickType == "status" {
+		handle = NewStatusHandle(action, params)
 	}
 
 	return handle
@@ -0,0 +1,151 @@
+package kickvmhandle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KickProtocolVersion is the highest version of JSON kick envelope supported
+const KickProtocolVersion = 1
+
+const (
+	// Kick is accepted and being executed, whose result would be acknowledged
+	// asynchronously
+	AckAccepted  = "accepted"
+	AckRejected  = "rejected"
+	AckExpired   = "expired"
+	AckSucceeded = "succeeded"
+	AckFailed    = "failed"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported kick protocol version")
+	ErrMissingRequestId   = errors.New("request id is required")
+	ErrUnknownAction      = errors.New("unknown kick type or action")
+	ErrMissingTaskId      = errors.New("taskId argument is required")
+)
+
+// KickArguments contains typed arguments of all kick actions, and only those
+// relevant to the action are used
+type KickArguments struct {
+	// For task run/stop, file create/stop and session start/stop
+	TaskId string `json:"taskId,omitempty"`
+	// For status network
+	Refresh bool `json:"refresh,omitempty"`
+	Vpc     bool `json:"vpc,omitempty"`
+	Classic bool `json:"classic,omitempty"`
+}
+
+// KickRequest is the versioned JSON envelope of kick, which is equivalent to
+// legacy `kick_vm <type> <action> <params...>` string with request id and
+// deadline
+type KickRequest struct {
+	Version   int           `json:"version"`
+	RequestId string        `json:"requestId"`
+	Type      string        `json:"type"`
+	Action    string        `json:"action"`
+	Arguments KickArguments `json:"arguments"`
+	// Unix timestamp in milliseconds after which the kick must not be
+	// executed. No deadline when zero
+	Deadline int64 `json:"deadline,omitempty"`
+}
+
+// KickAck acknowledges a kick request, synchronously as reply of the kick or
+// asynchronously when execution finishes
+type KickAck struct {
+	Version   int    `json:"version"`
+	RequestId string `json:"requestId"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	// Unix timestamp in milliseconds
+	Timestamp int64 `json:"timestamp"`
+}
+
+// ParseRequest parses and validates JSON kick envelope
+func ParseRequest(input string) (*KickRequest, error) {
+	request := &KickRequest{}
+	if err := json.Unmarshal([]byte(input), request); err != nil {
+		return nil, fmt.Errorf("invalid kick request: %w", err)
+	}
+	if request.Version < 1 || request.Version > KickProtocolVersion {
+		return request, fmt.Errorf("%w: %d", ErrUnsupportedVersion, request.Version)
+	}
+	if request.RequestId == "" {
+		return request, ErrMissingRequestId
+	}
+	return request, nil
+}
+
+// Expired reports whether the deadline of the request has passed
+func (r *KickRequest) Expired(now time.Time) bool {
+	return r.Deadline > 0 && now.UnixMilli() > r.Deadline
+}
+
+// Handle converts typed arguments to parameters of handlers shared with the
+// legacy string format
+func (r *KickRequest) Handle() (KickHandle, error) {
+	params, err := r.params()
+	if err != nil {
+		return nil, err
+	}
+	handle := newHandle(r.Type, r.Action, params)
+	if handle == nil || !handle.CheckAction() {
+		return nil, ErrUnknownAction
+	}
+	return handle, nil
+}
+
+// String returns the request in legacy string format for logging and auditing
+func (r *KickRequest) String() string {
+	params, _ := r.params()
+	fields := append([]string{"kick_vm", r.Type, r.Action}, params...)
+	return strings.TrimSpace(strings.Join(fields, " "))
+}
+
+func (r *KickRequest) params() ([]string, error) {
+	switch r.Type {
+	case "task", "file", "session":
+		if r.Type == "task" && r.Action == "fetch" {
+			return nil, nil
+		}
+		if r.Arguments.TaskId == "" {
+			return nil, ErrMissingTaskId
+		}
+		return []string{r.Arguments.TaskId}, nil
+	case "status":
+		var params []string
+		if r.Arguments.Refresh {
+			params = append(params, "--refresh")
+		}
+		if r.Arguments.Vpc {
+			params = append(params, "--vpc")
+		}
+		if r.Arguments.Classic {
+			params = append(params, "--classic")
+		}
+		return params, nil
+	default:
+		return nil, nil
+	}
+}
+
+func NewKickAck(requestId string, status string, err error) *KickAck {
+	ack := &KickAck{
+		Version:   KickProtocolVersion,
+		RequestId: requestId,
+		Status:    status,
+		Timestamp: time.Now().UnixMilli(),
+	}
+	if err != nil {
+		ack.Error = err.Error()
+	}
+	return ack
+}
+
+func (a *KickAck) String() string {
+	ackBytes, _ := json.Marshal(a)
+	return string(ackBytes)
+}
@@ -0,0 +1,63 @@
+package kickvmhandle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequest(t *testing.T) {
+	request, err := ParseRequest(`{"version":1,"requestId":"r-1","type":"task","action":"run","arguments":{"taskId":"t-xx"},"deadline":1700000000000}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "r-1", request.RequestId)
+	assert.Equal(t, "kick_vm task run t-xx", request.String())
+	assert.True(t, request.Expired(time.UnixMilli(1700000000001)))
+	assert.False(t, request.Expired(time.UnixMilli(1699999999999)))
+	handle, err := request.Handle()
+	assert.NoError(t, err)
+	assert.IsType(t, &TaskHandle{}, handle)
+
+	_, err = ParseRequest(`{"version":2,"requestId":"r-1","type":"noop"}`)
+	assert.True(t, errors.Is(err, ErrUnsupportedVersion))
+	_, err = ParseRequest(`{"version":1,"type":"noop"}`)
+	assert.True(t, errors.Is(err, ErrMissingRequestId))
+	_, err = ParseRequest(`{"version":1,"requestId":"r-1","type":"task","action":"run","arguments":{"taskId":1}}`)
+	assert.Error(t, err)
+}
+
+func TestRequestHandle(t *testing.T) {
+	request := &KickRequest{Version: 1, RequestId: "r-1", Type: "task", Action: "run"}
+	_, err := request.Handle()
+	assert.True(t, errors.Is(err, ErrMissingTaskId))
+
+	request = &KickRequest{Version: 1, RequestId: "r-1", Type: "agent", Action: "stop1"}
+	_, err = request.Handle()
+	assert.True(t, errors.Is(err, ErrUnknownAction))
+
+	// Deregistration is only accepted as legacy kick from websocket channel
+	request = &KickRequest{Version: 1, RequestId: "r-1", Type: "agent", Action: "deregister"}
+	_, err = request.Handle()
+	assert.True(t, errors.Is(err, ErrUnknownAction))
+
+	request = &KickRequest{Version: 1, RequestId: "r-1", Type: "agent", Action: "stop"}
+	handle, err := request.Handle()
+	assert.NoError(t, err)
+	assert.IsType(t, &AgentHandle{}, handle)
+	assert.Equal(t, "kick_vm agent stop", request.String())
+
+	request = &KickRequest{Version: 1, RequestId: "r-1", Type: "status", Action: "network",
+		Arguments: KickArguments{Refresh: true, Vpc: true}}
+	assert.Equal(t, "kick_vm status network --refresh --vpc", request.String())
+
+	request = &KickRequest{Version: 1, RequestId: "r-1", Type: "noop"}
+	handle, err = request.Handle()
+	assert.NoError(t, err)
+	assert.NoError(t, handle.DoAction())
+}
+
+func TestKickAck(t *testing.T) {
+	ack := NewKickAck("r-1", AckFailed, errors.New("params error"))
+	assert.Contains(t, ack.String(), `"requestId":"r-1","status":"failed","error":"params error"`)
+}
@@ -15,34 +15,30 @@ func init ()  {
 }
 
 func stopSession(params []string) error {
-	go func() {
-		if len(params) < 1 {
-			log.GetLogger().Errorln("params invalid", params)
-			return
-		}
-		ret := taskengine.GetSessionFactory().ContainsTask(params[0])
-		if ret == true {
-			log.GetLogger().Println("stop session ", params[0])
-			task,_ := taskengine.GetSessionFactory().GetTask(params[0])
-			task.StopTask()
-		} else {
-			log.GetLogger().Errorln("stop session failed")
-		}
-	}()
+	if len(params) < 1 {
+		log.GetLogger().Errorln("params invalid", params)
+		return errors.New("params error")
+	}
+	ret := taskengine.GetSessionFactory().ContainsTask(params[0])
+	if ret == true {
+		log.GetLogger().Println("stop session ", params[0])
+		task,_ := taskengine.GetSessionFactory().GetTask(params[0])
+		task.StopTask()
+	} else {
+		log.GetLogger().Errorln("stop session failed")
+		return errors.New("session not found")
+	}
 	return nil
 }
 
 func startSession(params []string) error {
-	go func() {
-		// kick_vm session  start task_id
-		// kick_vm session  stop task_id
-		if len(params) < 1 {
-			log.GetLogger().Errorln("params invalid", params)
-			return
-		}
-		taskengine.Fetch(true, params[0], taskengine.SessionTaskType)
-	}()
-	return nil
+	// kick_vm session  start task_id
+	// kick_vm session  stop task_id
+	if len(params) < 1 {
+		log.GetLogger().Errorln("params invalid", params)
+		return errors.New("params error")
+	}
+	return fetchKickedTasks(params[0], taskengine.SessionTaskType)
 }
 
 type SessionHandle struct {
@@ -59,11 +55,10 @@ func NewSessionHandle(action string, params []string) *SessionHandle{
 
 func (h *SessionHandle) DoAction() error{
 	if v, ok := sessionRoute[h.action]; ok {
-		v(h.params)
+		return v(h.params)
 	} else {
 		return errors.New("no action found")
 	}
-	return nil
 }
 
 func (h *SessionHandle) CheckAction() bool{
@@ -60,7 +60,7 @@ func requestNetworkStatus(params []string) error {
 
 	flags := pflag.NewFlagSet("network", pflag.ContinueOnError)
 	needToRefresh := flags.Bool("refresh", false, "Request to refresh the network diagnostic result")
-	isVPCNetwork := flags.Bool("vpc", false, "Declare the instance running in VPC network")
+	isVPCNetwork := flags.Bool("vpc", false, "Declare the instance running in VPC network, which enables metaserver check")
 	isClassicNetwork := flags.Bool("classic", false, "Declare the instance running in classic network")
 	// Disable unexpected usage printing when failing to parse kick_vm parameters
 	flags.Usage = func() {}
@@ -111,7 +111,8 @@ func requestNetworkStatus(params []string) error {
 	}
 	// Actions in kick_vm option handlers are all asynchronously called via new
 	// goroutine, thus synchronously calling checknet.RequestNetcheck() here is
-	// safe.
+	// safe. The diagnostic report is carried by reply of gshell channel, and
+	// also reported to server as metrics event.
 	checknet.RequestNetcheck(checknet.NetcheckRequestForceOnce)
 	return nil
 }
@@ -2,6 +2,7 @@ package kickvmhandle
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine"
@@ -14,17 +15,35 @@ func init ()  {
 	taskRoute = map[string]handleFunc{
 		"run": runTask,
 		"stop": stopTask,
+		"fetch": fetchTasks,
 	}
 }
 
+// fetchKickedTasks fetches tasks for kick and returns the real result, which
+// is acknowledged to server. Kick for specified task fails when the task is not
+// fetched.
+func fetchKickedTasks(taskId string, taskType int) error {
+	taskSize, err := taskengine.FetchWithError(true, taskId, taskType)
+	if err != nil {
+		return err
+	}
+	if taskId != "" && taskSize == 0 {
+		return fmt.Errorf("task %s not fetched", taskId)
+	}
+	return nil
+}
+
+func fetchTasks(params []string) error {
+	log.GetLogger().Println("fetchTasks")
+	return fetchKickedTasks("", taskengine.NormalTaskType)
+}
+
 func runTask(params []string) error {
 	log.GetLogger().Println("runTask")
 	if len(params) < 1 {
 		return errors.New("params error")
 	}
-	go func() {
-		taskengine.Fetch(true, params[0], taskengine.NormalTaskType)	}()
-	return nil
+	return fetchKickedTasks(params[0], taskengine.NormalTaskType)
 }
 
 func stopTask(params []string) error {
@@ -33,9 +52,7 @@ func stopTask(params []string) error {
 		return errors.New("params error")
 	}
 
-	go func() {
-		taskengine.Fetch(true, params[0], taskengine.NormalTaskType)	}()
-	return nil
+	return fetchKickedTasks(params[0], taskengine.NormalTaskType)
 }
 
 
@@ -53,11 +70,10 @@ func NewTaskHandle(action string, params []string) *TaskHandle{
 
 func (h *TaskHandle) DoAction() error{
 	if v, ok := taskRoute[h.action]; ok {
-		v(h.params)
+		return v(h.params)
 	} else {
 		return errors.New("no action found")
 	}
-	return nil
 }
 
 func (h *TaskHandle) CheckAction() bool{
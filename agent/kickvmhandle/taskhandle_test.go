@@ -0,0 +1,34 @@
+package kickvmhandle
+
+import (
+	"errors"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine"
+)
+
+func TestFetchKickedTasks(t *testing.T) {
+	var taskSize int
+	var fetchErr error
+	guard := monkey.Patch(taskengine.FetchWithError, func(fromKick bool, taskId string, taskType int) (int, error) {
+		return taskSize, fetchErr
+	})
+	defer guard.Unpatch()
+
+	taskSize = 1
+	assert.NoError(t, NewTaskHandle("run", []string{"t-1"}).DoAction())
+	assert.NoError(t, NewFileHandle("create", []string{"t-1"}).DoAction())
+
+	// Nothing to fetch is fine for fetching all tasks, but not for the
+	// specified task
+	taskSize = 0
+	assert.NoError(t, NewTaskHandle("fetch", nil).DoAction())
+	assert.Error(t, NewTaskHandle("stop", []string{"t-1"}).DoAction())
+
+	fetchErr = errors.New("network unreachable")
+	assert.ErrorIs(t, NewTaskHandle("fetch", nil).DoAction(), fetchErr)
+	assert.ErrorIs(t, NewSessionHandle("start", []string{"s-1"}).DoAction(), fetchErr)
+}
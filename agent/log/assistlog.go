@@ -43,11 +43,16 @@ func InitLog(filename string, logpath string, ignoreRotationError bool) {
 	}
 
 	Log = log.New()
-	Log.SetFormatter(&CustomLogrusTextFormatter{
-		CommonFields: DefaultCommonFields(),
+	Log.SetFormatter(&levelFilterFormatter{
+		Formatter: &CustomLogrusTextFormatter{
+			CommonFields: DefaultCommonFields(),
+		},
 	})
-	Log.SetOutput(writer)
-	Log.SetLevel(defaultLevel)
+	Log.SetOutput(&levelFilterWriter{Writer: writer})
+	// Module of entry is found by moduleHook before used by streamHook
+	Log.AddHook(moduleHook{})
+	Log.AddHook(streamHook{})
+	applyLoggerLevel()
 }
 
 func GetLogger() *log.Logger {
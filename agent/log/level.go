@@ -0,0 +1,270 @@
+package log
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+const (
+	// DefaultLevelTTL is used when no TTL is specified for module level
+	DefaultLevelTTL = 30 * time.Minute
+	// MaxLevelTTL bounds how long a changed level lasts before reverted
+	MaxLevelTTL = 24 * time.Hour
+
+	modulePrefix    = "github.com/aliyun/aliyun_assist_client/"
+	agentModuleRoot = "agent/"
+	maxCallerDepth  = 32
+	// Field holding module of entry, which is removed before formatted
+	moduleDataKey = "_module"
+)
+
+var (
+	ErrInvalidLevelTTL = errors.New("ttl of log level exceeds the limit")
+
+	_moduleLevels     = make(map[string]*moduleLevel)
+	_moduleLevelsLock sync.RWMutex
+
+	// Module of each logging call site
+	_callerModules sync.Map
+	// Frames of these packages are skipped when looking for caller
+	logrusPackage = packageOf(runtime.FuncForPC(reflect.ValueOf(log.New).Pointer()).Name())
+	logPackage    = packageOf(runtime.FuncForPC(reflect.ValueOf(InitLog).Pointer()).Name())
+
+	// Replaced in tests
+	levelNow = time.Now
+)
+
+type moduleLevel struct {
+	level      log.Level
+	expireTime time.Time
+	timer      *time.Timer
+}
+
+// ModuleLevel is the log level of a module changed at runtime
+type ModuleLevel struct {
+	// Package path like "taskengine" for agent/taskengine, which also covers
+	// its sub-packages. Empty module covers the whole process.
+	Module     string
+	Level      log.Level
+	ExpireTime time.Time
+}
+
+// SetModuleLevel changes log level of module until ttl elapses, and returns
+// the time when the level is reverted
+func SetModuleLevel(module string, level log.Level, ttl time.Duration) (time.Time, error) {
+	if ttl <= 0 {
+		ttl = DefaultLevelTTL
+	}
+	if ttl > MaxLevelTTL {
+		return time.Time{}, ErrInvalidLevelTTL
+	}
+	module = strings.Trim(module, "/")
+
+	_moduleLevelsLock.Lock()
+	if existing, ok := _moduleLevels[module]; ok {
+		existing.timer.Stop()
+	}
+	override := &moduleLevel{
+		level:      level,
+		expireTime: levelNow().Add(ttl),
+	}
+	override.timer = time.AfterFunc(ttl, func() {
+		_moduleLevelsLock.Lock()
+		if _moduleLevels[module] == override {
+			delete(_moduleLevels, module)
+		}
+		_moduleLevelsLock.Unlock()
+		GetLogger().WithField("module", module).Infoln("Reverted log level after ttl")
+		applyLoggerLevel()
+	})
+	_moduleLevels[module] = override
+	_moduleLevelsLock.Unlock()
+
+	applyLoggerLevel()
+	return override.expireTime, nil
+}
+
+// ResetModuleLevel reverts log level of module immediately
+func ResetModuleLevel(module string) bool {
+	module = strings.Trim(module, "/")
+	_moduleLevelsLock.Lock()
+	existing, ok := _moduleLevels[module]
+	if ok {
+		existing.timer.Stop()
+		delete(_moduleLevels, module)
+	}
+	_moduleLevelsLock.Unlock()
+
+	applyLoggerLevel()
+	return ok
+}
+
+// ListModuleLevels returns levels changed at runtime and not reverted yet
+func ListModuleLevels() []ModuleLevel {
+	_moduleLevelsLock.RLock()
+	levels := make([]ModuleLevel, 0, len(_moduleLevels))
+	for module, override := range _moduleLevels {
+		levels = append(levels, ModuleLevel{
+			Module:     module,
+			Level:      override.level,
+			ExpireTime: override.expireTime,
+		})
+	}
+	_moduleLevelsLock.RUnlock()
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].Module < levels[j].Module
+	})
+	return levels
+}
+
+// DefaultLevel returns log level of modules not changed at runtime
+func DefaultLevel() log.Level {
+	return defaultLevel
+}
+
+// effectiveLevel returns level of the longest changed module covering module
+func effectiveLevel(module string) log.Level {
+	_moduleLevelsLock.RLock()
+	defer _moduleLevelsLock.RUnlock()
+	level := defaultLevel
+	matched := -1
+	for prefix, override := range _moduleLevels {
+		if len(prefix) > matched && moduleCovers(prefix, module) {
+			level = override.level
+			matched = len(prefix)
+		}
+	}
+	return level
+}
+
+func hasModuleLevels() bool {
+	_moduleLevelsLock.RLock()
+	defer _moduleLevelsLock.RUnlock()
+	return len(_moduleLevels) > 0
+}
+
+func moduleCovers(prefix string, module string) bool {
+	return prefix == "" || module == prefix || strings.HasPrefix(module, prefix+"/")
+}
+
+// applyLoggerLevel lowers the threshold of logger to the most verbose level
+// required, and entries not wanted are filtered later
+func applyLoggerLevel() {
+	if Log == nil {
+		return
+	}
+	level := defaultLevel
+	_moduleLevelsLock.RLock()
+	for _, override := range _moduleLevels {
+		if override.level > level {
+			level = override.level
+		}
+	}
+	_moduleLevelsLock.RUnlock()
+	if subscriberLevel, ok := maxSubscriberLevel(); ok && subscriberLevel > level {
+		level = subscriberLevel
+	}
+	Log.SetLevel(level)
+}
+
+// callerModule returns module of the function calling logger, e.g.,
+// "taskengine/host" for package agent/taskengine/host
+func callerModule() string {
+	pcs := make([]uintptr, maxCallerDepth)
+	depth := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:depth])
+	for {
+		frame, more := frames.Next()
+		pkg := packageOf(frame.Function)
+		if pkg != "" && pkg != logrusPackage && pkg != logPackage {
+			if module, ok := _callerModules.Load(frame.PC); ok {
+				return module.(string)
+			}
+			module := moduleOfPackage(pkg)
+			_callerModules.Store(frame.PC, module)
+			return module
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func moduleOfPackage(pkg string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(pkg, modulePrefix), agentModuleRoot)
+}
+
+func packageOf(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[lastSlash+1:], "."); dot >= 0 {
+		return function[:lastSlash+1+dot]
+	}
+	return function
+}
+
+// moduleHook attributes entry to module of its caller, which is required by
+// module levels and subscribers. It must be added before other hooks so that
+// the caller is only looked up once for each entry.
+type moduleHook struct{}
+
+func (moduleHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (moduleHook) Fire(entry *log.Entry) error {
+	if hasModuleLevels() || hasSubscribers() {
+		entry.Data[moduleDataKey] = callerModule()
+	}
+	return nil
+}
+
+// entryModule returns module of entry found by moduleHook
+func entryModule(entry *log.Entry) string {
+	module, _ := entry.Data[moduleDataKey].(string)
+	return module
+}
+
+// levelFilterFormatter drops entries below the level of their module, since
+// logger only supports one level. Dropped entries are serialized as empty and
+// discarded by levelFilterWriter.
+type levelFilterFormatter struct {
+	Formatter log.Formatter
+}
+
+func (f *levelFilterFormatter) Format(entry *log.Entry) ([]byte, error) {
+	enabled := entryEnabled(entry)
+	delete(entry.Data, moduleDataKey)
+	if !enabled {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
+
+func entryEnabled(entry *log.Entry) bool {
+	if !hasModuleLevels() {
+		return entry.Level <= defaultLevel
+	}
+	return entry.Level <= effectiveLevel(entryModule(entry))
+}
+
+// levelFilterWriter keeps entries dropped by levelFilterFormatter from the
+// underlying writer, e.g., rotatelogs which locks and checks rotation on each
+// write
+type levelFilterWriter struct {
+	Writer io.Writer
+}
+
+func (w *levelFilterWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return w.Writer.Write(p)
+}
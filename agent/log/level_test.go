@@ -0,0 +1,109 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+func TestModuleOfPackage(t *testing.T) {
+	assert.Equal(t, "github.com/aliyun/aliyun_assist_client/agent/taskengine/host",
+		packageOf("github.com/aliyun/aliyun_assist_client/agent/taskengine/host.(*CommandProcessor).Prepare"))
+	assert.Equal(t, "main", packageOf("main.runStatusCmd"))
+	assert.Equal(t, "taskengine/host", moduleOfPackage("github.com/aliyun/aliyun_assist_client/agent/taskengine/host"))
+	assert.Equal(t, "common/update", moduleOfPackage("github.com/aliyun/aliyun_assist_client/common/update"))
+	assert.Equal(t, "main", moduleOfPackage("main"))
+}
+
+func TestSetModuleLevel(t *testing.T) {
+	InitLog("test", "", false)
+	defer func() {
+		for _, level := range ListModuleLevels() {
+			ResetModuleLevel(level.Module)
+		}
+	}()
+
+	_, err := SetModuleLevel("taskengine", logrus.DebugLevel, MaxLevelTTL+time.Second)
+	assert.Equal(t, ErrInvalidLevelTTL, err)
+
+	expireTime, err := SetModuleLevel("taskengine", logrus.DebugLevel, 0)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(DefaultLevelTTL), expireTime, time.Second)
+	_, err = SetModuleLevel("taskengine/host", logrus.ErrorLevel, time.Minute)
+	assert.NoError(t, err)
+
+	assert.Equal(t, logrus.DebugLevel, GetLogger().GetLevel())
+	assert.Equal(t, logrus.DebugLevel, effectiveLevel("taskengine"))
+	assert.Equal(t, logrus.DebugLevel, effectiveLevel("taskengine/timermanager"))
+	assert.Equal(t, logrus.ErrorLevel, effectiveLevel("taskengine/host"))
+	assert.Equal(t, defaultLevel, effectiveLevel("taskengineext"))
+	assert.Equal(t, defaultLevel, effectiveLevel("heartbeat"))
+
+	levels := ListModuleLevels()
+	assert.Len(t, levels, 2)
+	assert.Equal(t, "taskengine", levels[0].Module)
+	assert.Equal(t, "taskengine/host", levels[1].Module)
+
+	assert.True(t, ResetModuleLevel("taskengine"))
+	assert.False(t, ResetModuleLevel("taskengine"))
+	assert.Equal(t, defaultLevel, GetLogger().GetLevel())
+}
+
+func TestModuleLevelExpires(t *testing.T) {
+	InitLog("test", "", false)
+	_, err := SetModuleLevel("", logrus.TraceLevel, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, logrus.TraceLevel, GetLogger().GetLevel())
+	assert.Eventually(t, func() bool {
+		return len(ListModuleLevels()) == 0 && GetLogger().GetLevel() == defaultLevel
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestLevelFilterFormatter(t *testing.T) {
+	InitLog("test", "", false)
+	var buffer bytes.Buffer
+	GetLogger().SetOutput(&buffer)
+	defer ResetModuleLevel("")
+
+	// Entries of this package are attributed to the caller of test function
+	_, err := SetModuleLevel("", logrus.DebugLevel, time.Minute)
+	assert.NoError(t, err)
+	GetLogger().Debug("visible")
+	assert.Contains(t, buffer.String(), "visible")
+
+	_, err = SetModuleLevel("", logrus.WarnLevel, time.Minute)
+	assert.NoError(t, err)
+	GetLogger().Info("invisible")
+	assert.NotContains(t, buffer.String(), "invisible")
+	GetLogger().Warn("warning")
+	assert.Contains(t, buffer.String(), "warning")
+	// Module found for filtering is not written to log file
+	assert.NotContains(t, buffer.String(), moduleDataKey)
+}
+
+type countingWriter struct {
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return len(p), nil
+}
+
+func TestLevelFilterWriter(t *testing.T) {
+	InitLog("test", "", false)
+	var underlying countingWriter
+	GetLogger().SetOutput(&levelFilterWriter{Writer: &underlying})
+	defer ResetModuleLevel("")
+
+	_, err := SetModuleLevel("", logrus.WarnLevel, time.Minute)
+	assert.NoError(t, err)
+	GetLogger().Info("filtered")
+	assert.Equal(t, 0, underlying.writes)
+	GetLogger().Warn("written")
+	assert.Equal(t, 1, underlying.writes)
+}
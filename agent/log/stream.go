@@ -0,0 +1,165 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+// Entries are dropped instead of blocking logger when subscriber falls behind
+const subscriptionBufferSize = 1024
+
+var (
+	_subscriptions     = make(map[*Subscription]struct{})
+	_subscriptionsLock sync.RWMutex
+)
+
+// StreamFilter selects entries delivered to subscriber
+type StreamFilter struct {
+	// Most verbose level delivered
+	Level log.Level
+	// Module like "taskengine", which also covers its sub-packages. Empty
+	// module matches all.
+	Module string
+	// Only entries with the TaskId field are delivered when specified
+	TaskId string
+}
+
+// StreamEntry is a log entry delivered to subscriber
+type StreamEntry struct {
+	Time    time.Time
+	Level   log.Level
+	Module  string
+	Message string
+	Fields  map[string]string
+	// Entries dropped before this one since subscriber fell behind
+	Dropped int64
+}
+
+// Subscription receives live log entries of this process
+type Subscription struct {
+	C       <-chan StreamEntry
+	entries chan StreamEntry
+	filter  StreamFilter
+	dropped atomic.Int64
+	closed  bool
+}
+
+// Subscribe starts delivering live log entries matching filter. Entries below
+// logger level are delivered as well, without being written to log file.
+func Subscribe(filter StreamFilter) *Subscription {
+	entries := make(chan StreamEntry, subscriptionBufferSize)
+	s := &Subscription{
+		C:       entries,
+		entries: entries,
+		filter:  filter,
+	}
+	_subscriptionsLock.Lock()
+	_subscriptions[s] = struct{}{}
+	_subscriptionsLock.Unlock()
+
+	applyLoggerLevel()
+	return s
+}
+
+// Close stops delivering and closes channel C
+func (s *Subscription) Close() {
+	_subscriptionsLock.Lock()
+	if s.closed {
+		_subscriptionsLock.Unlock()
+		return
+	}
+	s.closed = true
+	delete(_subscriptions, s)
+	close(s.entries)
+	_subscriptionsLock.Unlock()
+
+	applyLoggerLevel()
+}
+
+func (s *Subscription) matches(level log.Level, module string, entry *log.Entry) bool {
+	if level > s.filter.Level {
+		return false
+	}
+	if s.filter.Module != "" && !moduleCovers(s.filter.Module, module) {
+		return false
+	}
+	if s.filter.TaskId != "" {
+		if taskId, ok := entry.Data["TaskId"]; !ok || fmt.Sprint(taskId) != s.filter.TaskId {
+			return false
+		}
+	}
+	return true
+}
+
+func hasSubscribers() bool {
+	_subscriptionsLock.RLock()
+	defer _subscriptionsLock.RUnlock()
+	return len(_subscriptions) > 0
+}
+
+func maxSubscriberLevel() (log.Level, bool) {
+	_subscriptionsLock.RLock()
+	defer _subscriptionsLock.RUnlock()
+	if len(_subscriptions) == 0 {
+		return 0, false
+	}
+	var level log.Level
+	for s := range _subscriptions {
+		if s.filter.Level > level {
+			level = s.filter.Level
+		}
+	}
+	return level, true
+}
+
+// streamHook delivers entries to subscribers
+type streamHook struct{}
+
+func (streamHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (streamHook) Fire(entry *log.Entry) error {
+	_subscriptionsLock.RLock()
+	defer _subscriptionsLock.RUnlock()
+	if len(_subscriptions) == 0 {
+		return nil
+	}
+
+	module := entryModule(entry)
+	var streamEntry *StreamEntry
+	for s := range _subscriptions {
+		if !s.matches(entry.Level, module, entry) {
+			continue
+		}
+		if streamEntry == nil {
+			fields := make(map[string]string, len(entry.Data))
+			for k, v := range entry.Data {
+				if k == moduleDataKey {
+					continue
+				}
+				fields[k] = fmt.Sprint(v)
+			}
+			streamEntry = &StreamEntry{
+				Time:    entry.Time,
+				Level:   entry.Level,
+				Module:  module,
+				Message: entry.Message,
+				Fields:  fields,
+			}
+		}
+		delivered := *streamEntry
+		delivered.Dropped = s.dropped.Load()
+		select {
+		case s.entries <- delivered:
+			s.dropped.Add(-delivered.Dropped)
+		default:
+			s.dropped.Add(1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+func TestSubscribe(t *testing.T) {
+	InitLog("test", "", false)
+	subscription := Subscribe(StreamFilter{
+		Level:  logrus.DebugLevel,
+		TaskId: "t-1",
+	})
+	// Subscriber receives entries below level of log file
+	assert.Equal(t, logrus.DebugLevel, GetLogger().GetLevel())
+
+	GetLogger().WithField("TaskId", "t-2").Info("other task")
+	GetLogger().WithField("TaskId", "t-1").Debug("this task")
+	GetLogger().WithField("TaskId", "t-1").Trace("too verbose")
+
+	entry := <-subscription.C
+	assert.Equal(t, "this task", entry.Message)
+	assert.Equal(t, logrus.DebugLevel, entry.Level)
+	assert.Equal(t, "t-1", entry.Fields["TaskId"])
+	assert.NotContains(t, entry.Fields, moduleDataKey)
+	assert.Len(t, subscription.C, 0)
+
+	subscription.Close()
+	subscription.Close()
+	_, ok := <-subscription.C
+	assert.False(t, ok)
+	assert.Equal(t, defaultLevel, GetLogger().GetLevel())
+}
+
+func TestSubscribeDropped(t *testing.T) {
+	InitLog("test", "", false)
+	subscription := Subscribe(StreamFilter{Level: logrus.InfoLevel})
+	defer subscription.Close()
+
+	for i := 0; i < subscriptionBufferSize+2; i++ {
+		GetLogger().Info("flood")
+	}
+	for i := 0; i < subscriptionBufferSize; i++ {
+		<-subscription.C
+	}
+	GetLogger().Info("after")
+	entry := <-subscription.C
+	assert.Equal(t, "after", entry.Message)
+	assert.Equal(t, int64(2), entry.Dropped)
+}
+
+func TestSubscribeModule(t *testing.T) {
+	subscription := &Subscription{filter: StreamFilter{Level: logrus.InfoLevel, Module: "taskengine"}}
+	entry := &logrus.Entry{Data: logrus.Fields{}}
+	assert.True(t, subscription.matches(logrus.InfoLevel, "taskengine/host", entry))
+	assert.False(t, subscription.matches(logrus.InfoLevel, "heartbeat", entry))
+	assert.False(t, subscription.matches(logrus.DebugLevel, "taskengine", entry))
+}
@@ -18,6 +18,9 @@ const (
 	// event id
 	EVENT_CHANNEL_FAILED                     MetricsEventID = "agent.channel.failed"
 	EVENT_CHANNEL_SWITCH                     MetricsEventID = "agent.channel.switch"
+	EVENT_CHANNEL_KICK_REJECTED              MetricsEventID = "agent.channel.kick.rejected"
+	EVENT_CHANNEL_KICK_THROTTLED             MetricsEventID = "agent.channel.kick.throttled"
+	EVENT_CHANNEL_NETWORK_DIAGNOSTIC         MetricsEventID = "agent.channel.network.diagnostic"
 	EVENT_UPDATE_FAILED                      MetricsEventID = "agent.update.failed"
 	EVENT_TASK_FAILED                        MetricsEventID = "agent.task.failed"
 	EVENT_TASK_WARN                          MetricsEventID = "agent.task.warn"
@@ -55,6 +58,7 @@ const (
 	// event subcategory
 	EVENT_SUBCATEGORY_CHANNEL_GSHELL    EventSubCategory = "gshell"
 	EVENT_SUBCATEGORY_CHANNEL_WS        EventSubCategory = "ws"
+	EVENT_SUBCATEGORY_CHANNEL_LONGPOLL  EventSubCategory = "longpoll"
 	EVENT_SUBCATEGORY_CHANNEL_MGR       EventSubCategory = "channelmgr"
 	EVENT_SUBCATEGORY_HYBRID_REGISTER   EventSubCategory = "register"
 	EVENT_SUBCATEGORY_HYBRID_UNREGISTER EventSubCategory = "unregister"
@@ -189,6 +193,40 @@ func GetChannelSwitchEvent(keywords ...string) *MetricsEvent {
 	}
 	return event
 }
+func GetKickRejectedEvent(keywords ...string) *MetricsEvent {
+	event := &MetricsEvent{
+		EventId:    EVENT_CHANNEL_KICK_REJECTED,
+		Category:   EVENT_CATEGORY_CHANNEL,
+		EventLevel: EVENT_LEVEL_WARN,
+		EventTime:  time.Now().UnixNano() / 1e6,
+		Common:     getCommonInfoStr(),
+		KeyWords:   genKeyWordsStr(keywords...),
+	}
+	return event
+}
+func GetKickThrottledEvent(keywords ...string) *MetricsEvent {
+	event := &MetricsEvent{
+		EventId:    EVENT_CHANNEL_KICK_THROTTLED,
+		Category:   EVENT_CATEGORY_CHANNEL,
+		EventLevel: EVENT_LEVEL_WARN,
+		EventTime:  time.Now().UnixNano() / 1e6,
+		Common:     getCommonInfoStr(),
+		KeyWords:   genKeyWordsStr(keywords...),
+	}
+	return event
+}
+
+func GetNetworkDiagnosticEvent(keywords ...string) *MetricsEvent {
+	event := &MetricsEvent{
+		EventId:    EVENT_CHANNEL_NETWORK_DIAGNOSTIC,
+		Category:   EVENT_CATEGORY_CHANNEL,
+		EventLevel: EVENT_LEVEL_INFO,
+		EventTime:  time.Now().UnixNano() / 1e6,
+		Common:     getCommonInfoStr(),
+		KeyWords:   genKeyWordsStr(keywords...),
+	}
+	return event
+}
 
 // 升级系统
 func GetUpdateFailedEvent(keywords ...string) *MetricsEvent {
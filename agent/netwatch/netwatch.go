@@ -0,0 +1,205 @@
+// Package netwatch watches network configuration changes of the host, e.g.,
+// ENI hot-plug, DHCP renewal and route changes, and notifies them after
+// debouncing so that the agent could recover connections quickly.
+package netwatch
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util/wrapgo"
+)
+
+const (
+	// Changes are notified after no more event arrives in quietPeriod...
+	quietPeriod = 2 * time.Second
+	// ...but no later than maxDelay since the first event
+	maxDelay = 10 * time.Second
+	// Minimum interval between notifications, thus hosts frequently changing
+	// network configuration, e.g., running containers, would not keep the
+	// agent busy
+	minInterval = 30 * time.Second
+)
+
+var (
+	ErrUnsupported = errors.New("watching network changes is not supported on this platform")
+)
+
+type EventType int
+
+const (
+	EventLink EventType = iota
+	EventAddress
+	EventRoute
+	// Some events have been dropped, e.g., due to overflowed buffer
+	EventOverflow
+)
+
+// Changes counts debounced events by type
+type Changes struct {
+	Link     int
+	Address  int
+	Route    int
+	Overflow int
+}
+
+func (c *Changes) add(eventType EventType) {
+	switch eventType {
+	case EventLink:
+		c.Link++
+	case EventAddress:
+		c.Address++
+	case EventRoute:
+		c.Route++
+	case EventOverflow:
+		c.Overflow++
+	}
+}
+
+func (c Changes) String() string {
+	return fmt.Sprintf("link=%d address=%d route=%d overflow=%d", c.Link, c.Address, c.Route, c.Overflow)
+}
+
+// Watcher notifies debounced network changes until stopped
+type Watcher struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	debounce *debouncer
+}
+
+// Start watches network changes and calls onChange in a new goroutine with
+// changes happened since last call
+func Start(onChange func(Changes)) (*Watcher, error) {
+	w := &Watcher{
+		stop:     make(chan struct{}),
+		debounce: newDebouncer(quietPeriod, maxDelay, minInterval, time.Now, onChange),
+	}
+	events, err := subscribe(w.stop)
+	if err != nil {
+		return nil, err
+	}
+	wrapgo.GoWithDefaultPanicHandler(func() {
+		for eventType := range events {
+			w.debounce.notify(eventType)
+		}
+		log.GetLogger().Infoln("Network change watcher stopped")
+	})
+	return w, nil
+}
+
+// Stop stops watching, and discards pending changes
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		w.debounce.stop()
+	})
+}
+
+// debouncer merges bursts of events into one notification
+type debouncer struct {
+	lock        sync.Mutex
+	quiet       time.Duration
+	maxDelay    time.Duration
+	minInterval time.Duration
+	now         func() time.Time
+	fire        func(Changes)
+
+	pending   Changes
+	hasEvents bool
+	first     time.Time
+	lastFired time.Time
+	running   bool
+	stopped   bool
+	timer     *time.Timer
+}
+
+func newDebouncer(quiet, maxDelay, minInterval time.Duration, now func() time.Time, fire func(Changes)) *debouncer {
+	return &debouncer{
+		quiet:       quiet,
+		maxDelay:    maxDelay,
+		minInterval: minInterval,
+		now:         now,
+		fire:        fire,
+	}
+}
+
+func (d *debouncer) notify(eventType EventType) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.stopped {
+		return
+	}
+	now := d.now()
+	if !d.hasEvents {
+		d.hasEvents = true
+		d.first = now
+	}
+	d.pending.add(eventType)
+	d.schedule(now)
+}
+
+// schedule MUST be called with lock held
+func (d *debouncer) schedule(now time.Time) {
+	due := now.Add(d.quiet)
+	if deadline := d.first.Add(d.maxDelay); due.After(deadline) {
+		due = deadline
+	}
+	if !d.lastFired.IsZero() {
+		if earliest := d.lastFired.Add(d.minInterval); due.Before(earliest) {
+			due = earliest
+		}
+	}
+	delay := due.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	if d.timer == nil {
+		d.timer = time.AfterFunc(delay, d.onTimer)
+	} else {
+		d.timer.Reset(delay)
+	}
+}
+
+func (d *debouncer) onTimer() {
+	d.lock.Lock()
+	if d.stopped || !d.hasEvents {
+		d.lock.Unlock()
+		return
+	}
+	now := d.now()
+	if d.running {
+		// Events arrived during last notification are notified after it
+		// finishes
+		d.schedule(now)
+		d.lock.Unlock()
+		return
+	}
+	changes := d.pending
+	d.pending = Changes{}
+	d.hasEvents = false
+	d.running = true
+	d.lock.Unlock()
+
+	log.GetLogger().Infoln("Network changes detected:", changes)
+	d.fire(changes)
+
+	d.lock.Lock()
+	d.running = false
+	d.lastFired = d.now()
+	if d.hasEvents && !d.stopped {
+		d.schedule(d.lastFired)
+	}
+	d.lock.Unlock()
+}
+
+func (d *debouncer) stop() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.stopped = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
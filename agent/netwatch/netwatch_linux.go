@@ -0,0 +1,101 @@
+//go:build linux
+// +build linux
+
+package netwatch
+
+import (
+	"errors"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util/wrapgo"
+)
+
+const (
+	netlinkGroups = unix.RTMGRP_LINK |
+		unix.RTMGRP_IPV4_IFADDR | unix.RTMGRP_IPV6_IFADDR |
+		unix.RTMGRP_IPV4_ROUTE | unix.RTMGRP_IPV6_ROUTE
+	// Receiving times out periodically to check whether watcher is stopped
+	receiveTimeoutSec = 1
+)
+
+// subscribe listens to rtnetlink multicast groups of link, address and route
+// changes until stop is closed
+func subscribe(stop <-chan struct{}) (<-chan EventType, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: netlinkGroups,
+	}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: receiveTimeoutSec}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	events := make(chan EventType, 64)
+	wrapgo.GoWithDefaultPanicHandler(func() {
+		defer close(events)
+		defer unix.Close(fd)
+		buf := make([]byte, unix.Getpagesize()*4)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			n, _, err := unix.Recvfrom(fd, buf, 0)
+			if err != nil {
+				if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+					continue
+				}
+				if errors.Is(err, unix.ENOBUFS) {
+					// Kernel dropped messages since socket buffer overflowed
+					events <- EventOverflow
+					continue
+				}
+				log.GetLogger().WithError(err).Errorln("Failed to receive netlink message")
+				return
+			}
+			for _, eventType := range parseEvents(buf[:n]) {
+				select {
+				case events <- eventType:
+				case <-stop:
+					return
+				}
+			}
+		}
+	})
+	return events, nil
+}
+
+// parseEvents extracts types of interesting events from netlink messages
+func parseEvents(data []byte) []EventType {
+	messages, err := syscall.ParseNetlinkMessage(data)
+	if err != nil {
+		return []EventType{EventOverflow}
+	}
+	eventTypes := make([]EventType, 0, len(messages))
+	for _, message := range messages {
+		switch message.Header.Type {
+		case unix.RTM_NEWLINK, unix.RTM_DELLINK:
+			eventTypes = append(eventTypes, EventLink)
+		case unix.RTM_NEWADDR, unix.RTM_DELADDR:
+			eventTypes = append(eventTypes, EventAddress)
+		case unix.RTM_NEWROUTE, unix.RTM_DELROUTE:
+			// Only routes in main table affect connections to the server,
+			// while local and cached routes change frequently
+			if len(message.Data) >= unix.SizeofRtMsg && message.Data[4] == unix.RT_TABLE_MAIN {
+				eventTypes = append(eventTypes, EventRoute)
+			}
+		}
+	}
+	return eventTypes
+}
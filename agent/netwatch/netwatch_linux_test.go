@@ -0,0 +1,40 @@
+package netwatch
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func buildNetlinkMessage(msgType uint16, data []byte) []byte {
+	length := unix.SizeofNlMsghdr + len(data)
+	aligned := (length + unix.NLMSG_ALIGNTO - 1) &^ (unix.NLMSG_ALIGNTO - 1)
+	message := make([]byte, aligned)
+	binary.LittleEndian.PutUint32(message[0:4], uint32(length))
+	binary.LittleEndian.PutUint16(message[4:6], msgType)
+	copy(message[unix.SizeofNlMsghdr:], data)
+	return message
+}
+
+func TestParseEvents(t *testing.T) {
+	mainRoute := make([]byte, unix.SizeofRtMsg)
+	mainRoute[4] = unix.RT_TABLE_MAIN
+	localRoute := make([]byte, unix.SizeofRtMsg)
+	localRoute[4] = unix.RT_TABLE_LOCAL
+
+	var data []byte
+	data = append(data, buildNetlinkMessage(unix.RTM_NEWLINK, make([]byte, unix.SizeofIfInfomsg))...)
+	data = append(data, buildNetlinkMessage(unix.RTM_DELADDR, make([]byte, unix.SizeofIfAddrmsg))...)
+	data = append(data, buildNetlinkMessage(unix.RTM_NEWROUTE, mainRoute)...)
+	data = append(data, buildNetlinkMessage(unix.RTM_NEWROUTE, localRoute)...)
+	data = append(data, buildNetlinkMessage(unix.RTM_NEWNEIGH, make([]byte, 12))...)
+
+	assert.Equal(t, []EventType{EventLink, EventAddress, EventRoute}, parseEvents(data))
+
+	// Truncated message
+	truncated := buildNetlinkMessage(unix.RTM_NEWLINK, nil)
+	binary.LittleEndian.PutUint32(truncated[0:4], 100)
+	assert.Equal(t, []EventType{EventOverflow}, parseEvents(truncated))
+}
@@ -0,0 +1,69 @@
+package netwatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type changesRecorder struct {
+	lock    sync.Mutex
+	changes []Changes
+	times   []time.Time
+}
+
+func (r *changesRecorder) record(changes Changes) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.changes = append(r.changes, changes)
+	r.times = append(r.times, time.Now())
+}
+
+func (r *changesRecorder) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.changes)
+}
+
+func TestDebouncerMergesBurst(t *testing.T) {
+	recorder := &changesRecorder{}
+	d := newDebouncer(50*time.Millisecond, time.Second, time.Second, time.Now, recorder.record)
+	defer d.stop()
+
+	for _, eventType := range []EventType{EventLink, EventAddress, EventAddress, EventRoute} {
+		d.notify(eventType)
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Eventually(t, func() bool { return recorder.count() == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, recorder.count())
+	assert.Equal(t, Changes{Link: 1, Address: 2, Route: 1}, recorder.changes[0])
+}
+
+func TestDebouncerMaxDelayAndMinInterval(t *testing.T) {
+	recorder := &changesRecorder{}
+	d := newDebouncer(50*time.Millisecond, 100*time.Millisecond, 300*time.Millisecond, time.Now, recorder.record)
+	defer d.stop()
+
+	// Continuous events are notified no later than maxDelay
+	start := time.Now()
+	for time.Since(start) < 200*time.Millisecond {
+		d.notify(EventRoute)
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.GreaterOrEqual(t, recorder.count(), 1)
+	assert.Eventually(t, func() bool { return recorder.count() == 2 }, time.Second, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, recorder.times[1].Sub(recorder.times[0]), 300*time.Millisecond)
+}
+
+func TestDebouncerStop(t *testing.T) {
+	recorder := &changesRecorder{}
+	d := newDebouncer(20*time.Millisecond, time.Second, time.Second, time.Now, recorder.record)
+	d.notify(EventLink)
+	d.stop()
+	d.notify(EventLink)
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, 0, recorder.count())
+}
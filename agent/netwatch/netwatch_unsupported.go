@@ -0,0 +1,10 @@
+//go:build !linux
+// +build !linux
+
+package netwatch
+
+// subscribe is currently not supported on this operating system. Connections
+// are recovered after failures of pings or heart-beats.
+func subscribe(stop <-chan struct{}) (<-chan EventType, error) {
+	return nil, ErrUnsupported
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/rodaine/table"
 
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
 	. "github.com/aliyun/aliyun_assist_client/agent/pluginmanager"
@@ -1048,6 +1049,25 @@ func installFromOnline(onlineInfo *PluginInfo, timeout time.Duration, localArch
 	}, nil
 }
 
+func recordPluginAudit(cmdPath string, paramList []string) {
+	event := audit.Event{
+		Action:  audit.ActionPluginExecute,
+		Trigger: audit.TriggerLocal,
+		Subject: filepath.Base(filepath.Dir(filepath.Dir(cmdPath))),
+		Details: map[string]string{
+			"entrypoint": cmdPath,
+			"params":     strings.Join(paramList, " "),
+			"parentPid":  strconv.Itoa(os.Getppid()),
+		},
+	}
+	if content, err := os.ReadFile(cmdPath); err == nil {
+		event.Digests = map[string]string{
+			"entrypoint": audit.Digest(content),
+		}
+	}
+	audit.Record(event)
+}
+
 func (pm *PluginManager) executePlugin(cmdPath string, paramList []string, timeout int, env []string, quiet bool, options... process.CmdOption) (exitCode int, errorCode string, err error) {
 	log.GetLogger().Infof("Enter executePlugin, cmdPath[%s] paramList[%v] paramCount[%d] timeout[%d]\n", cmdPath, paramList, len(paramList), timeout)
 	funcName := "ExecutePlugin"
@@ -1061,6 +1081,10 @@ func (pm *PluginManager) executePlugin(cmdPath string, paramList []string, timeo
 		fmt.Printf("Run cmd: %s, params: %v\n", cmdPath, paramList)
 	}
 
+	// Quiet executions are periodic status queries of persistent plugins
+	if !quiet {
+		recordPluginAudit(cmdPath, paramList)
+	}
 	processCmd := process.NewProcessCmd(options...)
 	// set environment variable
 	if env != nil && len(env) > 0 {
@@ -133,6 +133,7 @@ func pluginHealthCheckScan() {
 	}
 	persistPluginCount := 0
 	pluginInfoMap := make(map[string]*PluginInfo)
+	observedStatuses := make(map[string]string)
 	for _, pluginInfo := range pluginInfoList {
 		if pluginInfo.IsRemoved {
 			continue
@@ -144,6 +145,7 @@ func pluginHealthCheckScan() {
 				Status:  ONCE_INSTALLED,
 				Version: pluginInfo.Version,
 			}
+			observedStatuses[pluginInfo.Name] = ONCE_INSTALLED
 			// 太长的名称和版本号字段进行截断
 			if len(pluginStatus.Name) > PLUGIN_NAME_MAXLEN {
 				pluginStatus.Name = pluginStatus.Name[:PLUGIN_NAME_MAXLEN]
@@ -179,6 +181,7 @@ func pluginHealthCheckScan() {
 			if pluginInfo.Status == REMOVED {
 				continue
 			}
+			observedStatuses[pluginInfo.Name] = pluginInfo.Status
 			pluginStatus := PluginStatus{
 				Name:    pluginInfo.Name,
 				Version: pluginInfo.Version,
@@ -213,6 +216,7 @@ func pluginHealthCheckScan() {
 			}
 		}
 	}
+	recordPluginStatuses(observedStatuses)
 	if len(pluginStatusRequest.Plugin) == 0 {
 		log.GetLogger().Infof("pluginHealthCheckScan: there is no plugin need report status")
 		return
@@ -0,0 +1,68 @@
+package pluginmanager
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// PluginState describes an installed plugin and its status observed in last
+// health check scan
+type PluginState struct {
+	Name       string
+	Version    string
+	PluginType string
+	// Status is empty when the plugin has not been checked yet
+	Status    string
+	CheckTime time.Time
+}
+
+var (
+	_observedStatuses     map[string]string
+	_observedStatusesTime time.Time
+	_observedStatusesLock sync.Mutex
+)
+
+func recordPluginStatuses(statuses map[string]string) {
+	_observedStatusesLock.Lock()
+	defer _observedStatusesLock.Unlock()
+	_observedStatuses = statuses
+	_observedStatusesTime = time.Now()
+}
+
+// ListPluginStates returns installed plugins ordered by name, with statuses
+// observed in last health check scan
+func ListPluginStates() ([]PluginState, error) {
+	pluginInfoList, err := _findAllInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	_observedStatusesLock.Lock()
+	defer _observedStatusesLock.Unlock()
+	return mergePluginStates(pluginInfoList, _observedStatuses, _observedStatusesTime), nil
+}
+
+func mergePluginStates(pluginInfoList []PluginInfo, statuses map[string]string, checkTime time.Time) []PluginState {
+	states := make([]PluginState, 0, len(pluginInfoList))
+	for i := range pluginInfoList {
+		pluginInfo := &pluginInfoList[i]
+		if pluginInfo.IsRemoved {
+			continue
+		}
+		state := PluginState{
+			Name:       pluginInfo.Name,
+			Version:    pluginInfo.Version,
+			PluginType: pluginInfo.PluginType(),
+		}
+		if status, ok := statuses[pluginInfo.Name]; ok {
+			state.Status = status
+			state.CheckTime = checkTime
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
@@ -0,0 +1,29 @@
+package pluginmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePluginStates(t *testing.T) {
+	checkTime := time.Now()
+	pluginInfoList := []PluginInfo{
+		{Name: "persist_plugin", Version: "1.2", PluginType_: PLUGIN_PERSIST},
+		{Name: "once_plugin", Version: "1.0", PluginType_: PLUGIN_ONCE},
+		{Name: "removed_plugin", Version: "1.0", IsRemoved: true},
+		{Name: "new_plugin", Version: "2.0", PluginType_: PLUGIN_ONCE},
+	}
+	statuses := map[string]string{
+		"persist_plugin": PERSIST_FAIL,
+		"once_plugin":    ONCE_INSTALLED,
+	}
+
+	states := mergePluginStates(pluginInfoList, statuses, checkTime)
+	assert.Equal(t, []PluginState{
+		{Name: "new_plugin", Version: "2.0", PluginType: PLUGIN_ONCE},
+		{Name: "once_plugin", Version: "1.0", PluginType: PLUGIN_ONCE, Status: ONCE_INSTALLED, CheckTime: checkTime},
+		{Name: "persist_plugin", Version: "1.2", PluginType: PLUGIN_PERSIST, Status: PERSIST_FAIL, CheckTime: checkTime},
+	}, states)
+}
@@ -0,0 +1,474 @@
+// Package policy implements the locally managed execution policy, which lets
+// administrators of the instance restrict what commands and files sent from
+// server could be executed or written on this machine.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	PolicyFilename = "execution_policy.json"
+
+	// CommandTypeContainerSession is the command type of interactive shell
+	// sessions inside containers, whose content is the command started in
+	// the session
+	CommandTypeContainerSession = "ContainerSession"
+
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+
+	// Rule ids reported when the policy file exists but could not be trusted
+	// or parsed. Policy is enforced in fail-closed manner under such cases.
+	RuleIdInsecurePolicyFile = "policy-file-insecure"
+	RuleIdInvalidPolicyFile  = "policy-file-invalid"
+	// Rule id reported when no rule matches and default effect is deny
+	RuleIdDefault = "default"
+	// Rule id reported when host path to be mounted is not in MountSources
+	RuleIdMountSource = "mount-source"
+	// Rule id reported when network mode of ephemeral container is not in
+	// NetworkModes
+	RuleIdNetworkMode = "network-mode"
+	// Rule id reported when symbolic links in path of file to be written
+	// could not be resolved
+	RuleIdUnresolvedPath = "path-unresolved"
+)
+
+var (
+	// GetPolicyPath is replaced in tests, including those of callers
+	GetPolicyPath = defaultPolicyPath
+
+	// Network modes of ephemeral container isolated from the host, which are
+	// always allowed
+	isolatedNetworkModes = []string{"", "default", "bridge", "none"}
+)
+
+// Rule matches a request when all conditions specified in rule match, and
+// unspecified conditions are ignored. Conditions on command and send-file
+// requests are exclusive, i.e., rule specifying SendFileDestinations never
+// matches command requests and vice versa.
+type Rule struct {
+	Id     string `json:"id"`
+	Effect string `json:"effect"`
+
+	CommandTypes []string `json:"commandTypes,omitempty"`
+	// Empty username in request is normalized to root on Linux/FreeBSD and
+	// system on Windows before matching.
+	Usernames []string `json:"usernames,omitempty"`
+	// Path prefixes of working directory
+	WorkingDirectories []string `json:"workingDirectories,omitempty"`
+	// Glob patterns matched against container id, container name,
+	// <pod namespace>/<pod name> and image of ephemeral container
+	Containers []string `json:"containers,omitempty"`
+	// Regular expressions matched against decoded script content, with
+	// parameters in it resolved
+	ContentPatterns []string `json:"contentPatterns,omitempty"`
+
+	// Path prefixes of full path of file to be written
+	SendFileDestinations []string `json:"sendFileDestinations,omitempty"`
+
+	contentRegexps []*regexp.Regexp
+}
+
+// Policy is evaluated as an ordered rule list: the first matched rule decides
+// the effect, and DefaultEffect is applied when no rule matches.
+type Policy struct {
+	DefaultEffect string `json:"defaultEffect"`
+	Rules         []Rule `json:"rules"`
+	// Path prefixes on host which are allowed to be bind-mounted into
+	// ephemeral containers. Unlike rules, it is an allow-list: no host path
+	// could be mounted unless listed here, whatever DefaultEffect is.
+	MountSources []string `json:"mountSources,omitempty"`
+	// Network modes of ephemeral containers allowed besides isolated ones,
+	// e.g., "host" or "container:<name|id>". It is an allow-list like
+	// MountSources.
+	NetworkModes []string `json:"networkModes,omitempty"`
+}
+
+type CommandRequest struct {
+	TaskId           string
+	CommandType      string
+	Username         string
+	WorkingDirectory string
+	ContainerId      string
+	ContainerName    string
+	PodNamespace     string
+	PodName          string
+	ImageName        string
+	// Decoded script content
+	Content string
+}
+
+type SendFileRequest struct {
+	TaskId string
+	// Full path of file to be written
+	FilePath string
+}
+
+type MountRequest struct {
+	TaskId string
+	// Host path to be bind-mounted, whose symbolic links should have been
+	// resolved by caller
+	Source string
+}
+
+type NetworkModeRequest struct {
+	TaskId      string
+	NetworkMode string
+}
+
+// Violation describes which rule rejected the request
+type Violation struct {
+	RuleId string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("denied by execution policy rule %s: %s", v.RuleId, v.Reason)
+}
+
+func defaultPolicyPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, PolicyFilename), nil
+}
+
+// LoadPolicy reads and validates policy file. Nil policy without error is
+// returned when the policy file does not exist.
+func LoadPolicy(policyPath string) (*Policy, error) {
+	if _, err := os.Stat(policyPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := util.CheckFileProtected(policyPath); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicy(content)
+}
+
+// ParsePolicy parses and validates policy in JSON format
+func ParsePolicy(content []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := json.Unmarshal(content, policy); err != nil {
+		return nil, err
+	}
+
+	if policy.DefaultEffect == "" {
+		policy.DefaultEffect = EffectAllow
+	}
+	if policy.DefaultEffect != EffectAllow && policy.DefaultEffect != EffectDeny {
+		return nil, fmt.Errorf("invalid default effect %q", policy.DefaultEffect)
+	}
+	ruleIds := make(map[string]struct{}, len(policy.Rules))
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Id == "" {
+			return nil, fmt.Errorf("rule #%d has no id", i)
+		}
+		if _, ok := ruleIds[rule.Id]; ok {
+			return nil, fmt.Errorf("duplicated rule id %s", rule.Id)
+		}
+		ruleIds[rule.Id] = struct{}{}
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, fmt.Errorf("rule %s has invalid effect %q", rule.Id, rule.Effect)
+		}
+		if rule.hasCommandConditions() && len(rule.SendFileDestinations) > 0 {
+			return nil, fmt.Errorf("rule %s mixes command and send-file conditions", rule.Id)
+		}
+		for _, pattern := range rule.Containers {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("rule %s has invalid container pattern %q: %w", rule.Id, pattern, err)
+			}
+		}
+		for _, pattern := range rule.ContentPatterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s has invalid content pattern %q: %w", rule.Id, pattern, err)
+			}
+			rule.contentRegexps = append(rule.contentRegexps, re)
+		}
+	}
+	return policy, nil
+}
+
+// EvaluateCommand returns violation when the command request is denied
+func (p *Policy) EvaluateCommand(request *CommandRequest) *Violation {
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if len(rule.SendFileDestinations) > 0 || !rule.matchCommand(request) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return &Violation{
+				RuleId: rule.Id,
+				Reason: fmt.Sprintf("command of type %s denied", request.CommandType),
+			}
+		}
+		return nil
+	}
+	if p.DefaultEffect == EffectDeny {
+		return &Violation{
+			RuleId: RuleIdDefault,
+			Reason: "no rule allows the command",
+		}
+	}
+	return nil
+}
+
+// EvaluateSendFile returns violation when the send-file request is denied
+func (p *Policy) EvaluateSendFile(request *SendFileRequest) *Violation {
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.hasCommandConditions() || !matchPathPrefix(rule.SendFileDestinations, request.FilePath) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return &Violation{
+				RuleId: rule.Id,
+				Reason: fmt.Sprintf("writing file %s denied", request.FilePath),
+			}
+		}
+		return nil
+	}
+	if p.DefaultEffect == EffectDeny {
+		return &Violation{
+			RuleId: RuleIdDefault,
+			Reason: "no rule allows the file",
+		}
+	}
+	return nil
+}
+
+// EvaluateMount returns violation when the host path is not allowed to be
+// mounted into ephemeral container
+func (p *Policy) EvaluateMount(request *MountRequest) *Violation {
+	if len(p.MountSources) == 0 || !matchPathPrefix(p.MountSources, request.Source) {
+		return &Violation{
+			RuleId: RuleIdMountSource,
+			Reason: fmt.Sprintf("mounting host path %s denied", request.Source),
+		}
+	}
+	return nil
+}
+
+// EvaluateNetworkMode returns violation when ephemeral container is not
+// allowed to use the network mode
+func (p *Policy) EvaluateNetworkMode(request *NetworkModeRequest) *Violation {
+	if matchExact(isolatedNetworkModes, request.NetworkMode) || matchExact(p.NetworkModes, request.NetworkMode) {
+		return nil
+	}
+	return &Violation{
+		RuleId: RuleIdNetworkMode,
+		Reason: fmt.Sprintf("network mode %s denied", request.NetworkMode),
+	}
+}
+
+func (r *Rule) hasCommandConditions() bool {
+	return len(r.CommandTypes) > 0 || len(r.Usernames) > 0 ||
+		len(r.WorkingDirectories) > 0 || len(r.Containers) > 0 ||
+		len(r.ContentPatterns) > 0
+}
+
+func (r *Rule) matchCommand(request *CommandRequest) bool {
+	if len(r.CommandTypes) > 0 && !matchExact(r.CommandTypes, request.CommandType) {
+		return false
+	}
+	if len(r.Usernames) > 0 {
+		username := request.Username
+		if username == "" {
+			username = defaultUsername
+		}
+		if !matchUsername(r.Usernames, username) {
+			return false
+		}
+	}
+	if !matchPathPrefix(r.WorkingDirectories, request.WorkingDirectory) {
+		return false
+	}
+	if len(r.Containers) > 0 && !matchContainer(r.Containers, request) {
+		return false
+	}
+	if len(r.contentRegexps) > 0 {
+		matched := false
+		for _, re := range r.contentRegexps {
+			if re.MatchString(request.Content) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func matchExact(candidates []string, value string) bool {
+	for _, candidate := range candidates {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPrefix reports whether target is under any of prefixes. Empty
+// prefixes always match, while empty target never matches specified prefixes.
+func matchPathPrefix(prefixes []string, target string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	if target == "" {
+		return false
+	}
+	target = normalizePath(target)
+	for _, prefix := range prefixes {
+		prefix = normalizePath(prefix)
+		if target == prefix || strings.HasPrefix(target, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchContainer(patterns []string, request *CommandRequest) bool {
+	var targets []string
+	for _, target := range []string{request.ContainerId, request.ContainerName, request.ImageName} {
+		if target != "" {
+			targets = append(targets, target)
+		}
+	}
+	if request.PodName != "" || request.PodNamespace != "" {
+		targets = append(targets, request.PodNamespace+"/"+request.PodName)
+	}
+
+	for _, pattern := range patterns {
+		for _, target := range targets {
+			if matched, _ := path.Match(pattern, target); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckCommand evaluates command request against the local policy file.
+// Violation is only logged here, and recorded into audit log by caller along
+// with the rejected request.
+func CheckCommand(request *CommandRequest) *Violation {
+	policy, violation := currentPolicy()
+	if violation == nil && policy != nil {
+		violation = policy.EvaluateCommand(request)
+	}
+	if violation != nil {
+		logViolation("command", request.TaskId, violation)
+	}
+	return violation
+}
+
+// CheckSendFile evaluates send-file request against the local policy file,
+// and logs violation. Symbolic links in path of the file are
+// resolved before evaluation, since the file is written through them.
+func CheckSendFile(request *SendFileRequest) *Violation {
+	policy, violation := currentPolicy()
+	if violation == nil && policy != nil {
+		if resolvedPath, err := util.EvalSymlinksAllowMissing(request.FilePath); err != nil {
+			violation = &Violation{
+				RuleId: RuleIdUnresolvedPath,
+				Reason: err.Error(),
+			}
+		} else {
+			violation = policy.EvaluateSendFile(&SendFileRequest{
+				TaskId:   request.TaskId,
+				FilePath: resolvedPath,
+			})
+		}
+	}
+	if violation != nil {
+		logViolation("sendfile", request.TaskId, violation)
+	}
+	return violation
+}
+
+// CheckMount evaluates host path to be mounted into ephemeral container
+// against the local policy file, and logs violation. Host
+// paths are never allowed to be mounted without policy file.
+func CheckMount(request *MountRequest) *Violation {
+	policy, violation := currentPolicy()
+	if violation == nil {
+		if policy == nil {
+			policy = &Policy{}
+		}
+		violation = policy.EvaluateMount(request)
+	}
+	if violation != nil {
+		logViolation("mount", request.TaskId, violation)
+	}
+	return violation
+}
+
+// CheckNetworkMode evaluates network mode of ephemeral container against the
+// local policy file, and logs violation. Only isolated network modes are
+// allowed without policy file.
+func CheckNetworkMode(request *NetworkModeRequest) *Violation {
+	policy, violation := currentPolicy()
+	if violation == nil {
+		if policy == nil {
+			policy = &Policy{}
+		}
+		violation = policy.EvaluateNetworkMode(request)
+	}
+	if violation != nil {
+		logViolation("networkmode", request.TaskId, violation)
+	}
+	return violation
+}
+
+func currentPolicy() (*Policy, *Violation) {
+	policyPath, err := GetPolicyPath()
+	if err != nil {
+		log.GetLogger().WithError(err).Errorln("Failed to determine path of execution policy file")
+		return nil, &Violation{
+			RuleId: RuleIdInvalidPolicyFile,
+			Reason: err.Error(),
+		}
+	}
+
+	policy, err := LoadPolicy(policyPath)
+	if err != nil {
+		log.GetLogger().WithError(err).Errorf("Failed to load execution policy file %s", policyPath)
+		ruleId := RuleIdInvalidPolicyFile
+		if errors.Is(err, util.ErrFileNotProtected) {
+			ruleId = RuleIdInsecurePolicyFile
+		}
+		return nil, &Violation{
+			RuleId: ruleId,
+			Reason: err.Error(),
+		}
+	}
+	return policy, nil
+}
+
+func logViolation(request string, taskId string, violation *Violation) {
+	log.GetLogger().WithField("TaskId", taskId).Warnf("Request %s denied by execution policy rule %s: %s", request, violation.RuleId, violation.Reason)
+}
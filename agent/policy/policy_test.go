@@ -0,0 +1,212 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"empty", `{}`, false},
+		{"invalidDefaultEffect", `{"defaultEffect": "maybe"}`, true},
+		{"missingRuleId", `{"rules": [{"effect": "deny"}]}`, true},
+		{"duplicatedRuleId", `{"rules": [{"id": "r1", "effect": "deny"}, {"id": "r1", "effect": "allow"}]}`, true},
+		{"invalidEffect", `{"rules": [{"id": "r1", "effect": "block"}]}`, true},
+		{"mixedConditions", `{"rules": [{"id": "r1", "effect": "deny", "usernames": ["root"], "sendFileDestinations": ["/etc"]}]}`, true},
+		{"invalidRegexp", `{"rules": [{"id": "r1", "effect": "deny", "contentPatterns": ["(unclosed"]}]}`, true},
+		{"invalidGlob", `{"rules": [{"id": "r1", "effect": "deny", "containers": ["[unclosed"]}]}`, true},
+		{"valid", `{"defaultEffect": "deny", "rules": [{"id": "r1", "effect": "allow", "commandTypes": ["RunShellScript"]}]}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy([]byte(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, policy)
+			}
+		})
+	}
+}
+
+func TestEvaluateCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Paths in test cases are for unix-like systems")
+	}
+	policy, err := ParsePolicy([]byte(`{
+		"defaultEffect": "allow",
+		"rules": [
+			{"id": "no-rm-rf", "effect": "deny", "contentPatterns": ["rm\\s+-rf\\s+/(\\s|$)"]},
+			{"id": "no-bat", "effect": "deny", "commandTypes": ["RunBatScript"]},
+			{"id": "ops-in-opt", "effect": "allow", "usernames": ["ops"], "workingDirectories": ["/opt/app"]},
+			{"id": "no-ops", "effect": "deny", "usernames": ["ops"]},
+			{"id": "no-kube-system", "effect": "deny", "containers": ["kube-system/*"]},
+			{"id": "no-root-in-tmp", "effect": "deny", "usernames": ["root"], "workingDirectories": ["/tmp"]}
+		]
+	}`))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		request    CommandRequest
+		wantRuleId string
+	}{
+		{"plain", CommandRequest{CommandType: "RunShellScript", Content: "ls -l"}, ""},
+		{"content", CommandRequest{CommandType: "RunShellScript", Content: "echo 1\nrm -rf /\n"}, "no-rm-rf"},
+		{"commandType", CommandRequest{CommandType: "RunBatScript"}, "no-bat"},
+		{"allowedUserAndDir", CommandRequest{CommandType: "RunShellScript", Username: "ops", WorkingDirectory: "/opt/app/bin"}, ""},
+		{"deniedUserOutsideDir", CommandRequest{CommandType: "RunShellScript", Username: "ops", WorkingDirectory: "/opt/application"}, "no-ops"},
+		{"deniedUserWithoutDir", CommandRequest{CommandType: "RunShellScript", Username: "ops"}, "no-ops"},
+		{"pod", CommandRequest{CommandType: "RunShellScript", PodNamespace: "kube-system", PodName: "coredns"}, "no-kube-system"},
+		{"otherPod", CommandRequest{CommandType: "RunShellScript", PodNamespace: "default", PodName: "web"}, ""},
+		{"defaultUsername", CommandRequest{CommandType: "RunShellScript", WorkingDirectory: "/tmp/"}, "no-root-in-tmp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation := policy.EvaluateCommand(&tt.request)
+			if tt.wantRuleId == "" {
+				assert.Nil(t, violation)
+			} else if assert.NotNil(t, violation) {
+				assert.Equal(t, tt.wantRuleId, violation.RuleId)
+			}
+		})
+	}
+}
+
+func TestEvaluateSendFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Paths in test cases are for unix-like systems")
+	}
+	policy, err := ParsePolicy([]byte(`{
+		"defaultEffect": "deny",
+		"rules": [
+			{"id": "no-ssh", "effect": "deny", "sendFileDestinations": ["/root/.ssh"]},
+			{"id": "home", "effect": "allow", "sendFileDestinations": ["/root", "/home"]},
+			{"id": "shell-only", "effect": "allow", "commandTypes": ["RunShellScript"]}
+		]
+	}`))
+	assert.NoError(t, err)
+
+	assert.Nil(t, policy.EvaluateSendFile(&SendFileRequest{FilePath: "/home/ops/app.conf"}))
+	violation := policy.EvaluateSendFile(&SendFileRequest{FilePath: "/root/.ssh/authorized_keys"})
+	if assert.NotNil(t, violation) {
+		assert.Equal(t, "no-ssh", violation.RuleId)
+	}
+	// Command rules never apply to send-file requests
+	violation = policy.EvaluateSendFile(&SendFileRequest{FilePath: "/etc/passwd"})
+	if assert.NotNil(t, violation) {
+		assert.Equal(t, RuleIdDefault, violation.RuleId)
+	}
+}
+
+func TestEvaluateMount(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Paths in test cases are for unix-like systems")
+	}
+	// Nothing could be mounted without allow-list, even by default allowed
+	policy, err := ParsePolicy([]byte(`{}`))
+	assert.NoError(t, err)
+	violation := policy.EvaluateMount(&MountRequest{Source: "/data"})
+	if assert.NotNil(t, violation) {
+		assert.Equal(t, RuleIdMountSource, violation.RuleId)
+	}
+
+	policy, err = ParsePolicy([]byte(`{"mountSources": ["/data", "/opt/tools/"]}`))
+	assert.NoError(t, err)
+	assert.Nil(t, policy.EvaluateMount(&MountRequest{Source: "/data"}))
+	assert.Nil(t, policy.EvaluateMount(&MountRequest{Source: "/opt/tools/kubectl"}))
+	assert.NotNil(t, policy.EvaluateMount(&MountRequest{Source: "/database"}))
+	assert.NotNil(t, policy.EvaluateMount(&MountRequest{Source: "/data/../etc"}))
+	assert.NotNil(t, policy.EvaluateMount(&MountRequest{Source: "/"}))
+}
+
+func TestEvaluateNetworkMode(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{}`))
+	assert.NoError(t, err)
+	for _, mode := range []string{"", "default", "bridge", "none"} {
+		assert.Nil(t, policy.EvaluateNetworkMode(&NetworkModeRequest{NetworkMode: mode}))
+	}
+	violation := policy.EvaluateNetworkMode(&NetworkModeRequest{NetworkMode: "host"})
+	if assert.NotNil(t, violation) {
+		assert.Equal(t, RuleIdNetworkMode, violation.RuleId)
+	}
+	assert.NotNil(t, policy.EvaluateNetworkMode(&NetworkModeRequest{NetworkMode: "container:web"}))
+
+	policy, err = ParsePolicy([]byte(`{"networkModes": ["host"]}`))
+	assert.NoError(t, err)
+	assert.Nil(t, policy.EvaluateNetworkMode(&NetworkModeRequest{NetworkMode: "host"}))
+	assert.NotNil(t, policy.EvaluateNetworkMode(&NetworkModeRequest{NetworkMode: "container:web"}))
+}
+
+func TestCheckCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	policyPath := filepath.Join(tempDir, PolicyFilename)
+	originalGetPolicyPath := GetPolicyPath
+	GetPolicyPath = func() (string, error) { return policyPath, nil }
+	defer func() {
+		GetPolicyPath = originalGetPolicyPath
+	}()
+
+	request := &CommandRequest{TaskId: "t-1", CommandType: "RunShellScript"}
+	// No policy file means everything is allowed
+	assert.Nil(t, CheckCommand(request))
+
+	assert.NoError(t, os.WriteFile(policyPath, []byte(`{"defaultEffect": "deny"}`), 0644))
+	assert.NoError(t, os.Chmod(tempDir, 0755))
+	violation := CheckCommand(request)
+	if assert.NotNil(t, violation) {
+		if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+			assert.Equal(t, RuleIdInsecurePolicyFile, violation.RuleId)
+		} else {
+			assert.Equal(t, RuleIdDefault, violation.RuleId)
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		assert.NoError(t, os.Chmod(policyPath, 0666))
+		violation = CheckCommand(request)
+		if assert.NotNil(t, violation) {
+			assert.Equal(t, RuleIdInsecurePolicyFile, violation.RuleId)
+		}
+	}
+}
+
+func TestCheckSendFileResolvesSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() != 0 {
+		t.Skip("Requires trusted policy file and symbolic links on unix-like systems")
+	}
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chmod(tempDir, 0755))
+	policyPath := filepath.Join(tempDir, PolicyFilename)
+	originalGetPolicyPath := GetPolicyPath
+	GetPolicyPath = func() (string, error) { return policyPath, nil }
+	defer func() {
+		GetPolicyPath = originalGetPolicyPath
+	}()
+
+	protectedDir := filepath.Join(tempDir, "protected")
+	assert.NoError(t, os.Mkdir(protectedDir, 0755))
+	assert.NoError(t, os.Symlink(protectedDir, filepath.Join(tempDir, "innocent")))
+	assert.NoError(t, os.Symlink(filepath.Join(tempDir, "missing"), filepath.Join(tempDir, "dangling")))
+	assert.NoError(t, os.WriteFile(policyPath, []byte(`{"rules": [{"id": "protected", "effect": "deny", "sendFileDestinations": ["`+protectedDir+`"]}]}`), 0644))
+
+	assert.Nil(t, CheckSendFile(&SendFileRequest{TaskId: "t-1", FilePath: filepath.Join(tempDir, "other", "file")}))
+	violation := CheckSendFile(&SendFileRequest{TaskId: "t-1", FilePath: filepath.Join(tempDir, "innocent", "new", "file")})
+	if assert.NotNil(t, violation) {
+		assert.Equal(t, "protected", violation.RuleId)
+	}
+	violation = CheckSendFile(&SendFileRequest{TaskId: "t-1", FilePath: filepath.Join(tempDir, "dangling")})
+	if assert.NotNil(t, violation) {
+		assert.Equal(t, RuleIdUnresolvedPath, violation.RuleId)
+	}
+}
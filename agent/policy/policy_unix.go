@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package policy
+
+import (
+	"path/filepath"
+)
+
+const defaultUsername = "root"
+
+func matchUsername(candidates []string, username string) bool {
+	return matchExact(candidates, username)
+}
+
+func normalizePath(p string) string {
+	return filepath.Clean(p)
+}
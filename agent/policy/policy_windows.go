@@ -0,0 +1,22 @@
+package policy
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const defaultUsername = "system"
+
+func matchUsername(candidates []string, username string) bool {
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePath makes paths comparable on case-insensitive file system
+func normalizePath(p string) string {
+	return filepath.ToSlash(strings.ToLower(filepath.Clean(p)))
+}
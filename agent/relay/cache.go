@@ -0,0 +1,192 @@
+package relay
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+const (
+	tempFileSuffix = ".tmp"
+)
+
+// downloadCache keeps downloaded packages on disk, since the same plugin and
+// update packages are usually requested by all downstream agents
+type downloadCache struct {
+	dir      string
+	maxBytes int64
+	client   *http.Client
+	logger   logrus.FieldLogger
+
+	lock  sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newDownloadCache(logger logrus.FieldLogger, dir string, maxBytes int64, client *http.Client) (*downloadCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	// Remove temporary files left by interrupted downloading
+	if tempFiles, err := filepath.Glob(filepath.Join(dir, "*"+tempFileSuffix)); err == nil {
+		for _, tempFile := range tempFiles {
+			os.Remove(tempFile)
+		}
+	}
+	return &downloadCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		client:   client,
+		logger:   logger,
+		locks:    make(map[string]*keyLock),
+	}, nil
+}
+
+// open returns the opened cached file of url, which is downloaded first when
+// not cached yet. Concurrent requests for the same url are downloaded once.
+func (c *downloadCache) open(ctx context.Context, url string) (file *os.File, hit bool, err error) {
+	sum := sha256.Sum256([]byte(url))
+	key := hex.EncodeToString(sum[:])
+	cachedPath := filepath.Join(c.dir, key)
+
+	c.lockKey(key)
+	defer c.unlockKey(key)
+
+	if file, err = os.Open(cachedPath); err == nil {
+		// Modification time records the last usage for eviction
+		now := time.Now()
+		os.Chtimes(cachedPath, now, now)
+		return file, true, nil
+	}
+
+	if err = c.download(ctx, url, cachedPath); err != nil {
+		return nil, false, err
+	}
+	// Open before eviction, thus the file just downloaded is readable even if
+	// it exceeds the limit alone
+	if file, err = os.Open(cachedPath); err != nil {
+		return nil, false, err
+	}
+	c.evict(key)
+	return file, false, nil
+}
+
+func (c *downloadCache) download(ctx context.Context, url string, cachedPath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(requester.UserAgentHeader, requester.UserAgentValue)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return requester.NewHttpErrorCode(res.StatusCode)
+	}
+
+	tempFile, err := os.CreateTemp(c.dir, filepath.Base(cachedPath)+".*"+tempFileSuffix)
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	_, err = io.Copy(tempFile, res.Body)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempPath, cachedPath)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+// evict removes least recently used files until cached bytes are within the
+// limit, except the file of key just downloaded
+func (c *downloadCache) evict(key string) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		c.logger.WithError(err).Warningln("Failed to list relay cache directory")
+		return
+	}
+	type cachedFile struct {
+		name    string
+		size    int64
+		modTime time.Time
+	}
+	var files []cachedFile
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), tempFileSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		total += info.Size()
+		if entry.Name() != key {
+			files = append(files, cachedFile{entry.Name(), info.Size(), info.ModTime()})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		// Removing file being served would fail on Windows, which is retried
+		// in later eviction
+		if err := os.Remove(filepath.Join(c.dir, file.name)); err != nil {
+			c.logger.WithError(err).Warningln("Failed to evict cached file", file.name)
+			continue
+		}
+		total -= file.size
+	}
+}
+
+func (c *downloadCache) lockKey(key string) {
+	c.lock.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &keyLock{}
+		c.locks[key] = l
+	}
+	l.refs++
+	c.lock.Unlock()
+
+	l.Lock()
+}
+
+func (c *downloadCache) unlockKey(key string) {
+	c.lock.Lock()
+	l := c.locks[key]
+	l.refs--
+	if l.refs == 0 {
+		delete(c.locks, key)
+	}
+	c.lock.Unlock()
+
+	l.Unlock()
+}
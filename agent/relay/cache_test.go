@@ -0,0 +1,40 @@
+package relay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+func TestEvictLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := newDownloadCache(log.GetLogger(), dir, 10, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i, name := range []string{"oldest", "older", "latest"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("12345"), 0600))
+		modTime := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "downloading"+tempFileSuffix), []byte("12345"), 0600))
+
+	// Just downloaded file is kept even if it is the oldest one
+	cache.evict("oldest")
+	for name, exists := range map[string]bool{
+		"oldest":                       true,
+		"older":                        false,
+		"latest":                       true,
+		"downloading" + tempFileSuffix: true,
+	} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		assert.Equal(t, exists, err == nil, name)
+	}
+}
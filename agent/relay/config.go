@@ -0,0 +1,128 @@
+package relay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	// ConfigFilename is the file in cross-version config directory making the
+	// agent serve as relay for downstream agents
+	ConfigFilename = "relay.json"
+
+	defaultCacheMaxBytes = 1 << 30
+)
+
+var (
+	ErrNotConfigured = errors.New("relay is not configured")
+
+	defaultDownloadHosts = []string{".aliyuncs.com", ".aliyun.com"}
+
+	// getConfigPath is replaced in tests
+	getConfigPath = defaultConfigPath
+)
+
+// Config specifies how the relay serves downstream agents
+type Config struct {
+	// Address listened on private network, e.g., 10.0.0.5:8443
+	Listen   string `json:"listen"`
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// Downstream agents allowed, mapping agent id to its token
+	Agents map[string]AgentConfig `json:"agents"`
+	// Directory caching downloaded packages. Default: relay directory under
+	// cache path of the agent
+	CacheDir string `json:"cacheDir,omitempty"`
+	// Upper limit of cached bytes, and least recently used packages are evicted
+	// when exceeded. Default: 1GiB
+	CacheMaxBytes int64 `json:"cacheMaxBytes,omitempty"`
+	// Host suffixes allowed to download from through relay. Default:
+	// .aliyuncs.com and .aliyun.com
+	DownloadHosts []string `json:"downloadHosts,omitempty"`
+}
+
+// AgentConfig binds a downstream agent to its token
+type AgentConfig struct {
+	// Hex-encoded SHA-256 of token, thus tokens are never stored on relay
+	TokenSha256 string `json:"tokenSha256"`
+}
+
+// LoadConfig reads relay config file, and returns ErrNotConfigured when the
+// file does not exist. Config file which could be modified by non-root users
+// is refused.
+func LoadConfig() (*Config, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotConfigured
+		}
+		return nil, err
+	}
+	if err := pathutil.CheckFileProtected(configPath); err != nil {
+		return nil, fmt.Errorf("refuse relay config file: %w", err)
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid relay config file %s: %w", configPath, err)
+	}
+	if err := config.normalize(); err != nil {
+		return nil, fmt.Errorf("invalid relay config file %s: %w", configPath, err)
+	}
+	return config, nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of token used in Config.Agents
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Config) normalize() error {
+	if c.Listen == "" || c.CertFile == "" || c.KeyFile == "" {
+		return errors.New("listen, certFile and keyFile must be specified")
+	}
+	if len(c.Agents) == 0 {
+		return errors.New("no downstream agent is allowed")
+	}
+	for agentId, agent := range c.Agents {
+		if decoded, err := hex.DecodeString(agent.TokenSha256); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("token of agent %s is not hex-encoded SHA-256", agentId)
+		}
+	}
+	if c.CacheDir == "" {
+		cachePath, err := pathutil.GetCachePath()
+		if err != nil {
+			return err
+		}
+		c.CacheDir = filepath.Join(cachePath, "relay")
+	}
+	if c.CacheMaxBytes <= 0 {
+		c.CacheMaxBytes = defaultCacheMaxBytes
+	}
+	if len(c.DownloadHosts) == 0 {
+		c.DownloadHosts = defaultDownloadHosts
+	}
+	return nil
+}
+
+func defaultConfigPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, ConfigFilename), nil
+}
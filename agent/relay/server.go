@@ -0,0 +1,363 @@
+// Package relay makes the agent serve downstream agents without internet
+// access, by forwarding API requests, websocket channels and package downloads
+// to API server. Downstream agents access relay via apiserver.RelayProvider.
+package relay
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/apiserver"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+const (
+	downloadTimeout = 30 * time.Minute
+)
+
+var (
+	_serverLock sync.Mutex
+	_server     *Server
+
+	// forwardedPaths are paths of API server forwarded by relay, where paths
+	// ending with slash cover their sub-paths
+	forwardedPaths = []string{
+		"/luban/api/heart-beat",
+		"/luban/api/gshell",
+		"/luban/api/metrics",
+		"/luban/api/connection_detect",
+		"/luban/api/classic/region-id",
+		"/luban/api/instance/",
+		"/luban/api/v1/exception/",
+		"/luban/api/v1/notify/",
+		"/luban/api/v1/plugin/",
+		"/luban/api/v1/session/",
+		"/luban/api/v1/task/",
+		"/luban/api/v1/update/",
+		"/luban/api/v2/plugin/",
+		"/luban/notify_server",
+		"/luban/session/backend",
+	}
+)
+
+type agentIdKey struct{}
+
+// Server is the relay serving downstream agents over TLS
+type Server struct {
+	config *Config
+	logger logrus.FieldLogger
+	stats  *statsRecorder
+	cache  *downloadCache
+	proxy  *httputil.ReverseProxy
+
+	tlsConfig  *tls.Config
+	httpServer *http.Server
+
+	// Replaced in tests
+	upstreamHost func() string
+	regionId     func() string
+}
+
+// NewServer creates relay server from config, which does not listen yet
+func NewServer(logger logrus.FieldLogger, config *Config) (*Server, error) {
+	certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return newServer(logger, config, upstreamTransport{}, &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	})
+}
+
+func newServer(logger logrus.FieldLogger, config *Config, transport http.RoundTripper, tlsConfig *tls.Config) (*Server, error) {
+	cache, err := newDownloadCache(logger, config.CacheDir, config.CacheMaxBytes, &http.Client{
+		Transport: transport,
+		Timeout:   downloadTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		config:       config,
+		logger:       logger,
+		stats:        newStatsRecorder(),
+		cache:        cache,
+		tlsConfig:    tlsConfig,
+		upstreamHost: util.GetServerHost,
+		regionId:     util.GetRegionId,
+	}
+	s.proxy = &httputil.ReverseProxy{
+		Rewrite:        s.rewrite,
+		Transport:      transport,
+		ModifyResponse: s.modifyResponse,
+		ErrorHandler:   s.handleProxyError,
+	}
+	s.httpServer = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	return s, nil
+}
+
+// Start runs relay server in background when relay config file exists
+func Start() error {
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	server, err := NewServer(log.GetLogger().WithField("module", "relay"), config)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", config.Listen)
+	if err != nil {
+		return err
+	}
+
+	_serverLock.Lock()
+	defer _serverLock.Unlock()
+	if _server != nil {
+		listener.Close()
+		return errors.New("relay server has been started")
+	}
+	_server = server
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			server.logger.WithError(err).Errorln("Relay server stopped unexpectedly")
+		}
+	}()
+	server.logger.Infoln("Relay server started on", config.Listen)
+	return nil
+}
+
+// Stop closes relay server and all connections forwarded
+func Stop() {
+	_serverLock.Lock()
+	defer _serverLock.Unlock()
+	if _server != nil {
+		_server.httpServer.Close()
+		_server = nil
+	}
+}
+
+// Stats returns statistics of running relay server
+func Stats() (Statistics, bool) {
+	_serverLock.Lock()
+	defer _serverLock.Unlock()
+	if _server == nil {
+		return Statistics{}, false
+	}
+	return _server.stats.snapshot(), true
+}
+
+// Serve accepts TLS connections of downstream agents on listener
+func (s *Server) Serve(listener net.Listener) error {
+	return s.httpServer.Serve(tls.NewListener(listener, s.tlsConfig))
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	agentId, ok := s.authenticate(r)
+	if !ok {
+		s.stats.authFailure()
+		s.logger.WithFields(logrus.Fields{
+			"remote":  r.RemoteAddr,
+			"agentId": r.Header.Get(apiserver.RelayAgentIdHeader),
+		}).Warningln("Rejected unauthenticated request to relay")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.stats.request(agentId)
+	r = r.WithContext(context.WithValue(r.Context(), agentIdKey{}, agentId))
+
+	switch r.URL.Path {
+	case apiserver.RelayInfoPath:
+		s.serveInfo(w, r)
+	case apiserver.RelayDownloadPath:
+		s.serveDownload(w, r, agentId)
+	default:
+		if !forwardAllowed(r.URL.Path) {
+			s.stats.failure(agentId)
+			s.logger.WithField("agentId", agentId).Warningln("Rejected request to path not forwarded", r.URL.Path)
+			http.Error(w, "path is not forwarded", http.StatusForbidden)
+			return
+		}
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			s.stats.websocket(agentId, 1)
+			defer s.stats.websocket(agentId, -1)
+		}
+		s.proxy.ServeHTTP(w, r)
+	}
+}
+
+func (s *Server) authenticate(r *http.Request) (string, bool) {
+	agentId := r.Header.Get(apiserver.RelayAgentIdHeader)
+	token := r.Header.Get(apiserver.RelayTokenHeader)
+	agent, ok := s.config.Agents[agentId]
+	if agentId == "" || token == "" || !ok {
+		return "", false
+	}
+	actual := HashToken(token)
+	if subtle.ConstantTimeCompare([]byte(actual), []byte(strings.ToLower(agent.TokenSha256))) != 1 {
+		return "", false
+	}
+	return agentId, true
+}
+
+// forwardAllowed prevents relay from being used to access arbitrary API of
+// server. Path is checked in canonical form, which is also forwarded.
+func forwardAllowed(urlPath string) bool {
+	if urlPath == "" || path.Clean(urlPath) != urlPath {
+		return false
+	}
+	for _, allowed := range forwardedPaths {
+		if strings.HasSuffix(allowed, "/") {
+			if strings.HasPrefix(urlPath, allowed) {
+				return true
+			}
+		} else if urlPath == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveInfo(w http.ResponseWriter, r *http.Request) {
+	info := apiserver.RelayInfo{
+		RegionId:     s.regionId(),
+		ServerDomain: s.upstreamHost(),
+	}
+	if info.ServerDomain == "" {
+		s.stats.failure(agentIdOf(r))
+		http.Error(w, "API server is unavailable", http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
+
+func (s *Server) serveDownload(w http.ResponseWriter, r *http.Request, agentId string) {
+	logger := s.logger.WithField("agentId", agentId)
+	rawUrl := r.URL.Query().Get("url")
+	if !s.downloadAllowed(rawUrl) {
+		s.stats.failure(agentId)
+		logger.Warningln("Rejected download from disallowed URL", rawUrl)
+		http.Error(w, "download URL is not allowed", http.StatusForbidden)
+		return
+	}
+
+	file, hit, err := s.cache.open(r.Context(), rawUrl)
+	s.stats.cacheResult(hit)
+	if err != nil {
+		s.stats.failure(agentId)
+		logger.WithError(err).Errorln("Failed to download through relay", rawUrl)
+		code := http.StatusBadGateway
+		var httpErr *requester.HttpErrorCode
+		if errors.As(err, &httpErr) {
+			code = httpErr.GetCode()
+		}
+		http.Error(w, "failed to download", code)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		s.stats.failure(agentId)
+		http.Error(w, "failed to read cached file", http.StatusInternalServerError)
+		return
+	}
+	counter := &countingResponseWriter{ResponseWriter: w}
+	http.ServeContent(counter, r, "", info.ModTime(), file)
+	s.stats.download(agentId, counter.written)
+}
+
+// downloadAllowed prevents relay from being used to access arbitrary hosts
+func (s *Server) downloadAllowed(rawUrl string) bool {
+	// Packages are only downloaded over HTTPS, since they are cached and
+	// served to all downstream agents
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	hostname := strings.ToLower(u.Hostname())
+	for _, allowed := range s.config.DownloadHosts {
+		allowed = strings.ToLower(allowed)
+		if strings.HasPrefix(allowed, ".") {
+			if strings.HasSuffix(hostname, allowed) {
+				return true
+			}
+		} else if hostname == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) rewrite(pr *httputil.ProxyRequest) {
+	// Escaped form of path checked by forwardAllowed is not forwarded
+	pr.Out.URL.RawPath = ""
+	pr.SetURL(&url.URL{
+		Scheme: "https",
+		Host:   s.upstreamHost(),
+	})
+	pr.SetXForwarded()
+	// Credentials of downstream agents are never forwarded to API server
+	pr.Out.Header.Del(apiserver.RelayAgentIdHeader)
+	pr.Out.Header.Del(apiserver.RelayTokenHeader)
+}
+
+func (s *Server) modifyResponse(res *http.Response) error {
+	if res.StatusCode >= http.StatusInternalServerError {
+		s.stats.failure(agentIdOf(res.Request))
+	}
+	return nil
+}
+
+func (s *Server) handleProxyError(w http.ResponseWriter, r *http.Request, err error) {
+	agentId := agentIdOf(r)
+	s.stats.failure(agentId)
+	s.logger.WithField("agentId", agentId).WithError(err).Errorln("Failed to forward request", r.URL.Path)
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+func agentIdOf(r *http.Request) string {
+	agentId, _ := r.Context().Value(agentIdKey{}).(string)
+	return agentId
+}
+
+// upstreamTransport always uses current transport of the agent, which may be
+// refreshed with new CA certificates
+type upstreamTransport struct{}
+
+func (upstreamTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if transport := util.GetHTTPTransport(); transport != nil {
+		return transport.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+type countingResponseWriter struct {
+	http.ResponseWriter
+	written int64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,212 @@
+package relay
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/common/apiserver"
+)
+
+const (
+	testAgentId    = "agent-downstream"
+	testInstanceId = "i-downstream"
+	testToken      = "secret-token"
+)
+
+type relayFixture struct {
+	upstream      *httptest.Server
+	relay         *httptest.Server
+	server        *Server
+	downloadCount int32
+}
+
+func newRelayFixture(t *testing.T) *relayFixture {
+	f := &relayFixture{}
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/luban/api/heart-beat", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"instanceId": r.Header.Get("X-Client-Instance-ID"),
+			"agentId":    r.Header.Get(apiserver.RelayAgentIdHeader),
+			"token":      r.Header.Get(apiserver.RelayTokenHeader),
+		})
+	})
+	mux.HandleFunc("/luban/api/internal", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "internal")
+	})
+	mux.HandleFunc("/luban/notify_server", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(messageType, message)
+		}
+	})
+	mux.HandleFunc("/package.zip", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&f.downloadCount, 1)
+		io.WriteString(w, "package content")
+	})
+	f.upstream = httptest.NewTLSServer(mux)
+	t.Cleanup(f.upstream.Close)
+
+	config := &Config{
+		Agents: map[string]AgentConfig{
+			testAgentId: {TokenSha256: HashToken(testToken)},
+		},
+		CacheDir:      t.TempDir(),
+		CacheMaxBytes: defaultCacheMaxBytes,
+		DownloadHosts: []string{"127.0.0.1"},
+	}
+	server, err := newServer(log.GetLogger(), config, f.upstream.Client().Transport, nil)
+	require.NoError(t, err)
+	upstreamHost := f.upstream.Listener.Addr().String()
+	server.upstreamHost = func() string { return upstreamHost }
+	server.regionId = func() string { return "cn-test" }
+	f.server = server
+	f.relay = httptest.NewTLSServer(server)
+	t.Cleanup(f.relay.Close)
+	return f
+}
+
+func (f *relayFixture) get(t *testing.T, path string, token string) *http.Response {
+	request, err := http.NewRequest(http.MethodGet, f.relay.URL+path, nil)
+	require.NoError(t, err)
+	request.Header.Set(apiserver.RelayAgentIdHeader, testAgentId)
+	request.Header.Set(apiserver.RelayTokenHeader, token)
+	// Headers identifying downstream agent itself
+	request.Header.Set("X-Client-Instance-ID", testInstanceId)
+	response, err := f.relay.Client().Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func TestAuthentication(t *testing.T) {
+	f := newRelayFixture(t)
+
+	response := f.get(t, "/luban/api/heart-beat", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	response = f.get(t, apiserver.RelayInfoPath, testToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	info := apiserver.RelayInfo{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&info))
+	assert.Equal(t, "cn-test", info.RegionId)
+
+	stats := f.server.stats.snapshot()
+	assert.Equal(t, int64(1), stats.AuthFailures)
+	require.Len(t, stats.Agents, 1)
+	assert.Equal(t, int64(1), stats.Agents[0].Requests)
+}
+
+func TestForwardAPIRequest(t *testing.T) {
+	f := newRelayFixture(t)
+
+	response := f.get(t, "/luban/api/heart-beat", testToken)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	forwarded := map[string]string{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&forwarded))
+	// Headers of downstream agent are forwarded as they are, while relay
+	// credentials are not
+	assert.Equal(t, testInstanceId, forwarded["instanceId"])
+	assert.Empty(t, forwarded["agentId"])
+	assert.Empty(t, forwarded["token"])
+}
+
+func TestForwardDisallowedPath(t *testing.T) {
+	f := newRelayFixture(t)
+
+	for _, path := range []string{
+		"/luban/api/internal",
+		"/luban/api/v1/task/../../internal",
+		"/luban/api/v1/task/%2e%2e/%2e%2e/internal",
+		"/",
+	} {
+		response := f.get(t, path, testToken)
+		assert.Equal(t, http.StatusForbidden, response.StatusCode, path)
+	}
+}
+
+func TestForwardAllowed(t *testing.T) {
+	assert.True(t, forwardAllowed("/luban/api/heart-beat"))
+	assert.True(t, forwardAllowed("/luban/api/v1/task/finish"))
+	assert.True(t, forwardAllowed("/luban/session/backend"))
+	assert.False(t, forwardAllowed("/luban/api/heart-beat/extra"))
+	assert.False(t, forwardAllowed("/luban/api/v1/task/"+"../x"))
+	assert.False(t, forwardAllowed("//luban/api/heart-beat"))
+	assert.False(t, forwardAllowed("/relay/v1/other"))
+}
+
+func TestForwardWebsocket(t *testing.T) {
+	f := newRelayFixture(t)
+
+	dialer := websocket.Dialer{
+		TLSClientConfig: f.relay.Client().Transport.(*http.Transport).TLSClientConfig.Clone(),
+	}
+	header := http.Header{}
+	header.Set(apiserver.RelayAgentIdHeader, testAgentId)
+	header.Set(apiserver.RelayTokenHeader, testToken)
+	wsUrl := "wss://" + strings.TrimPrefix(f.relay.URL, "https://") + "/luban/notify_server"
+	conn, _, err := dialer.Dial(wsUrl, header)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("kick")))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "kick", string(message))
+	assert.Equal(t, int64(1), f.server.stats.snapshot().Agents[0].ActiveWebsockets)
+	conn.Close()
+}
+
+func TestDownloadCached(t *testing.T) {
+	f := newRelayFixture(t)
+	path := apiserver.RelayDownloadPath + "?" + url.Values{"url": []string{f.upstream.URL + "/package.zip"}}.Encode()
+
+	for i := 0; i < 2; i++ {
+		response := f.get(t, path, testToken)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		content, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "package content", string(content))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&f.downloadCount))
+
+	stats := f.server.stats.snapshot()
+	assert.Equal(t, int64(1), stats.CacheHits)
+	assert.Equal(t, int64(1), stats.CacheMisses)
+	assert.Equal(t, int64(2*len("package content")), stats.Agents[0].DownloadBytes)
+}
+
+func TestDownloadDisallowedHost(t *testing.T) {
+	f := newRelayFixture(t)
+	path := apiserver.RelayDownloadPath + "?" + url.Values{"url": []string{"https://example.com/package.zip"}}.Encode()
+
+	response := f.get(t, path, testToken)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestDownloadAllowed(t *testing.T) {
+	s := &Server{config: &Config{DownloadHosts: defaultDownloadHosts}}
+	assert.True(t, s.downloadAllowed("https://aliyun-client-assist.oss-cn-hangzhou-internal.aliyuncs.com/linux/update.zip"))
+	assert.True(t, s.downloadAllowed("https://repo.aliyun.com/package.rpm"))
+	assert.False(t, s.downloadAllowed("http://repo.aliyun.com/package.rpm"))
+	assert.False(t, s.downloadAllowed("https://aliyuncs.com.example.com/package.zip"))
+	assert.False(t, s.downloadAllowed("file:///etc/passwd"))
+}
@@ -0,0 +1,108 @@
+package relay
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// AgentStats is the connection statistics of a downstream agent
+type AgentStats struct {
+	AgentId          string    `json:"agentId"`
+	Requests         int64     `json:"requests"`
+	Failures         int64     `json:"failures"`
+	ActiveWebsockets int64     `json:"activeWebsockets"`
+	DownloadBytes    int64     `json:"downloadBytes"`
+	LastSeen         time.Time `json:"lastSeen"`
+}
+
+// Statistics is the snapshot of relay statistics
+type Statistics struct {
+	Agents       []AgentStats `json:"agents"`
+	AuthFailures int64        `json:"authFailures"`
+	CacheHits    int64        `json:"cacheHits"`
+	CacheMisses  int64        `json:"cacheMisses"`
+}
+
+type statsRecorder struct {
+	lock   sync.Mutex
+	agents map[string]*AgentStats
+
+	authFailures int64
+	cacheHits    int64
+	cacheMisses  int64
+
+	now func() time.Time
+}
+
+func newStatsRecorder() *statsRecorder {
+	return &statsRecorder{
+		agents: make(map[string]*AgentStats),
+		now:    time.Now,
+	}
+}
+
+// update modifies statistics of agent under protection of lock
+func (r *statsRecorder) update(agentId string, modify func(*AgentStats)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stats, ok := r.agents[agentId]
+	if !ok {
+		stats = &AgentStats{AgentId: agentId}
+		r.agents[agentId] = stats
+	}
+	modify(stats)
+}
+
+func (r *statsRecorder) request(agentId string) {
+	r.update(agentId, func(s *AgentStats) {
+		s.Requests++
+		s.LastSeen = r.now()
+	})
+}
+
+func (r *statsRecorder) failure(agentId string) {
+	r.update(agentId, func(s *AgentStats) { s.Failures++ })
+}
+
+func (r *statsRecorder) websocket(agentId string, delta int64) {
+	r.update(agentId, func(s *AgentStats) { s.ActiveWebsockets += delta })
+}
+
+func (r *statsRecorder) download(agentId string, bytes int64) {
+	r.update(agentId, func(s *AgentStats) { s.DownloadBytes += bytes })
+}
+
+func (r *statsRecorder) authFailure() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.authFailures++
+}
+
+func (r *statsRecorder) cacheResult(hit bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if hit {
+		r.cacheHits++
+	} else {
+		r.cacheMisses++
+	}
+}
+
+func (r *statsRecorder) snapshot() Statistics {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	snapshot := Statistics{
+		Agents:       make([]AgentStats, 0, len(r.agents)),
+		AuthFailures: r.authFailures,
+		CacheHits:    r.cacheHits,
+		CacheMisses:  r.cacheMisses,
+	}
+	for _, stats := range r.agents {
+		snapshot.Agents = append(snapshot.Agents, *stats)
+	}
+	sort.Slice(snapshot.Agents, func(i, j int) bool {
+		return snapshot.Agents[i].AgentId < snapshot.Agents[j].AgentId
+	})
+	return snapshot
+}
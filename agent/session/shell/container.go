@@ -0,0 +1,328 @@
+package shell
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/shlex"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
+	"github.com/aliyun/aliyun_assist_client/agent/session/channel"
+	"github.com/aliyun/aliyun_assist_client/agent/session/message"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+)
+
+const (
+	defaultContainerShell    = "/bin/sh"
+	containerTermEnvVariable = "TERM=xterm-256color"
+)
+
+// containerExec represents an interactive exec session with TTY inside the
+// container, whose output is read and input is written through it.
+type containerExec interface {
+	io.ReadWriteCloser
+
+	Resize(cols uint32, rows uint32) error
+}
+
+var (
+	// startContainerExec is replaced in tests
+	startContainerExec = (*ContainerShellPlugin).startExec
+)
+
+// ContainerShellPlugin opens an interactive shell inside the container, like
+// `docker exec -it` does, instead of a PTY on the host.
+type ContainerShellPlugin struct {
+	id            string
+	containerId   string
+	containerName string
+	cmdContent    string
+	username      string
+	dataChannel   channel.ISessionChannel
+
+	// Fields below are accessed by Execute and message handlers from
+	// different goroutines, thus guarded by lock
+	lock         sync.Mutex
+	exec         containerExec
+	first_ws_col uint32
+	first_ws_row uint32
+	sendInterval int
+}
+
+func NewContainerShellPlugin(id string, containerId string, containerName string, cmdContent string, username string, flowLimit int) *ContainerShellPlugin {
+	plugin := &ContainerShellPlugin{
+		id:            id,
+		containerId:   containerId,
+		containerName: containerName,
+		cmdContent:    cmdContent,
+		username:      username,
+		sendInterval:  defaultSendInterval,
+	}
+	if flowLimit > 0 {
+		plugin.sendInterval = 1000 / (flowLimit / 8 / sendPackageSize)
+	} else {
+		flowLimit = defaultSendSpeed * 1024
+	}
+	log.GetLogger().Infof("Init send speed, channelId[%s] speed[%d]bps sendInterval[%d]ms\n", id, flowLimit, plugin.sendInterval)
+	return plugin
+}
+
+func (p *ContainerShellPlugin) command() ([]string, error) {
+	if p.cmdContent == "" {
+		return []string{defaultContainerShell}, nil
+	}
+	cmdArgs, err := shlex.Split(p.cmdContent)
+	if err != nil {
+		return nil, fmt.Errorf("split command content failed: %v", err)
+	}
+	return cmdArgs, nil
+}
+
+// startExec detects the runtime of the container and starts an exec session
+// in it. Runtime can be specified explicitly by the prefix of container id in
+// format <runtime>://<container-id>, otherwise docker, podman and CRI runtimes
+// are tried in order.
+func (p *ContainerShellPlugin) startExec() (containerExec, error) {
+	cmd, err := p.command()
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeName := ""
+	containerId := p.containerId
+	if identifierSegments := strings.SplitN(p.containerId, "://", 2); len(identifierSegments) == 2 {
+		runtimeName = identifierSegments[0]
+		containerId = identifierSegments[1]
+	}
+
+	var errs []string
+	if runtimeName == "" || runtimeName == "docker" || runtimeName == "podman" {
+		exec, err := startDockerExec(runtimeName, containerId, p.containerName, p.username, cmd)
+		if err == nil {
+			return exec, nil
+		}
+		if runtimeName != "" {
+			return nil, err
+		}
+		errs = append(errs, err.Error())
+	}
+
+	if p.username != "" {
+		errs = append(errs, "username is not supported for containers via CRI")
+		return nil, fmt.Errorf("Failed to open shell in container: %s", strings.Join(errs, "; "))
+	}
+	exec, err := startCRIExec(runtimeName, containerId, p.containerName, cmd)
+	if err == nil {
+		return exec, nil
+	}
+	errs = append(errs, err.Error())
+	return nil, fmt.Errorf("Failed to open shell in container: %s", strings.Join(errs, "; "))
+}
+
+// checkPolicy evaluates the session against the local execution policy like
+// commands targeting containers
+func (p *ContainerShellPlugin) checkPolicy() error {
+	content := p.cmdContent
+	if content == "" {
+		content = defaultContainerShell
+	}
+	if violation := policy.CheckCommand(&policy.CommandRequest{
+		TaskId:        p.id,
+		CommandType:   policy.CommandTypeContainerSession,
+		Username:      p.username,
+		ContainerId:   p.containerId,
+		ContainerName: p.containerName,
+		Content:       content,
+	}); violation != nil {
+		return violation
+	}
+	return nil
+}
+
+func (p *ContainerShellPlugin) Execute(dataChannel channel.ISessionChannel, cancelFlag util.CancelFlag) (errorCode string, pluginErr error) {
+	p.dataChannel = dataChannel
+	errorCode = Ok
+
+	defer func() {
+		log.GetLogger().Infoln("stop in run ContainerShellPlugin")
+		if exec := p.getExec(); exec != nil {
+			if err := exec.Close(); err != nil {
+				log.GetLogger().Errorf("Error occurred while closing exec session in container: %v", err)
+			}
+		}
+
+		if err := recover(); err != nil {
+			log.GetLogger().Errorf("Error occurred while executing plugin %s: \n%v", p.id, err)
+			errorCode = Unknown_error
+			if v, ok := err.(error); ok {
+				pluginErr = v
+			} else {
+				pluginErr = fmt.Errorf(fmt.Sprint(err))
+			}
+		}
+	}()
+	log.GetLogger().Infoln("start exec in container", p.containerId, p.containerName)
+	if err := p.checkPolicy(); err != nil {
+		log.GetLogger().Errorf("Shell in container denied: %s", err)
+		return Open_pty_failed, err
+	}
+	exec, err := startContainerExec(p)
+	if err != nil {
+		log.GetLogger().Errorf("Unable to start shell in container: %s", err)
+		return Open_pty_failed, err
+	}
+	p.setExec(exec)
+	log.GetLogger().Infoln("start exec in container success")
+
+	cancelled := make(chan string, 1)
+	go func() {
+		cancelState := cancelFlag.Wait()
+		if cancelFlag.State() == util.Canceled {
+			cancelled <- Timeout
+		}
+		if cancelFlag.State() == util.Completed {
+			cancelled <- Notified
+		}
+		log.GetLogger().Debugf("Cancel flag set to %v in session", cancelState)
+	}()
+
+	done := make(chan string, 1)
+	go func() {
+		done <- p.writePump(exec)
+	}()
+	log.GetLogger().Infof("Plugin %s started", p.id)
+
+	select {
+	case errorCode = <-cancelled:
+		log.GetLogger().Info("The session was cancelled")
+	case exitCode := <-done:
+		log.GetLogger().Infoln("Plugin  done", p.id, exitCode)
+		errorCode = exitCode
+	}
+	return
+}
+
+// setExec records started exec session, and applies the size of TTY received
+// before the session started
+func (p *ContainerShellPlugin) setExec(exec containerExec) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.exec = exec
+	if p.first_ws_col != 0 {
+		if err := exec.Resize(p.first_ws_col, p.first_ws_row); err != nil {
+			log.GetLogger().Errorf("set container tty size failed: %s", err)
+		}
+	}
+}
+
+func (p *ContainerShellPlugin) getExec() containerExec {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.exec
+}
+
+func (p *ContainerShellPlugin) getSendInterval() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.sendInterval
+}
+
+func (p *ContainerShellPlugin) writePump(exec containerExec) (errorCode string) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.GetLogger().Println("WritePump thread crashed with message: \n", err)
+		}
+	}()
+
+	stdoutBytes := make([]byte, sendPackageSize)
+	reader := bufio.NewReader(exec)
+	var unprocessedBuf bytes.Buffer
+	for {
+		stdoutBytesLen, err := reader.Read(stdoutBytes)
+		if err != nil {
+			log.GetLogger().Debugf("Failed to read from exec session in container: %s", err)
+			return Ok
+		}
+
+		if unprocessedBuf, err = processStdoutData(p.dataChannel, stdoutBytes, stdoutBytesLen, unprocessedBuf); err != nil {
+			log.GetLogger().Errorf("Error processing stdout data, %v", err)
+			return Process_data_error
+		}
+		time.Sleep(time.Duration(p.getSendInterval()) * time.Millisecond)
+	}
+}
+
+func (p *ContainerShellPlugin) SetSize(ws_col, ws_row uint32) error {
+	// Resizing is also serialized by lock, otherwise TTY may be left in size
+	// of older message
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.exec == nil {
+		p.first_ws_col = ws_col
+		p.first_ws_row = ws_row
+		return nil
+	}
+	if err := p.exec.Resize(ws_col, ws_row); err != nil {
+		log.GetLogger().Errorf("set container tty size failed: %s", err)
+		return fmt.Errorf("set container tty size failed: %s", err)
+	}
+	return nil
+}
+
+func (p *ContainerShellPlugin) InputStreamMessageHandler(streamDataMessage message.Message) error {
+	switch streamDataMessage.MessageType {
+	case message.InputStreamDataMessage:
+		exec := p.getExec()
+		if exec == nil {
+			// Rejected packets would be resent by cli/console until exec
+			// session starts
+			log.GetLogger().Tracef("Exec session unavailable. Reject incoming message packet")
+			return nil
+		}
+		if _, err := exec.Write(streamDataMessage.Payload); err != nil {
+			log.GetLogger().Errorf("Unable to write to stdin, err: %v.", err)
+			return err
+		}
+	case message.SetSizeDataMessage:
+		var size SizeData
+		if err := json.Unmarshal(streamDataMessage.Payload, &size); err != nil {
+			log.GetLogger().Errorf("Invalid size message: %s", err)
+			return err
+		}
+		if err := p.SetSize(size.Cols, size.Rows); err != nil {
+			log.GetLogger().Errorf("Unable to set container tty size: %s", err)
+			return err
+		}
+	case message.StatusDataMessage:
+		if len(streamDataMessage.Payload) > 0 {
+			code, err := message.BytesToIntU(streamDataMessage.Payload[0:1])
+			if err != nil {
+				log.GetLogger().Errorf("Parse status code err: %s", err)
+				break
+			}
+			if code == 7 { // 设置agent的发送速率
+				speed, err := message.BytesToIntU(streamDataMessage.Payload[1:]) // speed 单位是 bps
+				if err != nil {
+					log.GetLogger().Errorf("Invalid flowLimit: %s", err)
+					return err
+				}
+				if speed == 0 {
+					break
+				}
+				sendInterval := 1000 / (speed / 8 / sendPackageSize)
+				p.lock.Lock()
+				p.sendInterval = sendInterval
+				p.lock.Unlock()
+				log.GetLogger().Infof("Set send speed, channelId[%s] speed[%d]bps sendInterval[%d]ms\n", p.id, speed, sendInterval)
+			}
+		}
+	}
+	return nil
+}
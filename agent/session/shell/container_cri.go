@@ -0,0 +1,175 @@
+package shell
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	runtimeapis "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	libcri "github.com/aliyun/aliyun_assist_client/agent/container/cri"
+)
+
+// Streaming server of CRI runtimes accepts websocket connections with the
+// channel protocol of Kubernetes, in which the first byte of each binary frame
+// indicates the stream.
+const (
+	criStreamProtocol = "v4.channel.k8s.io"
+
+	criStdinChannel  byte = 0
+	criStdoutChannel byte = 1
+	criStderrChannel byte = 2
+	criErrorChannel  byte = 3
+	criResizeChannel byte = 4
+)
+
+type criTerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+// criExec is an exec session with TTY via CRI Exec API and the streaming
+// server of container runtime.
+type criExec struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+
+	stdoutReader *io.PipeReader
+	stdoutWriter *io.PipeWriter
+}
+
+func startCRIExec(runtimeName string, containerId string, containerName string, cmd []string) (*criExec, error) {
+	runtimeContainersList, err := libcri.ListRuntimeContainers(containerRuntimeTimeout, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []libcri.RuntimeContainers
+	for _, runtimeContainers := range runtimeContainersList {
+		if runtimeName != "" && runtimeContainers.Endpoint.RuntimeName != runtimeName {
+			continue
+		}
+		for _, container := range runtimeContainers.Containers {
+			if containerId != "" && container.Id != containerId {
+				continue
+			}
+			if containerName != "" && container.Name != containerName {
+				continue
+			}
+			runtimeContainers.Containers = append(runtimeContainers.Containers[:0:0], container)
+			found = append(found, runtimeContainers)
+		}
+	}
+	if len(found) == 0 {
+		return nil, errors.New("no running container found via CRI")
+	}
+	if len(found) > 1 {
+		return nil, fmt.Errorf("%d running containers found via CRI", len(found))
+	}
+
+	response, err := found[0].Service.Exec(&runtimeapis.ExecRequest{
+		ContainerId: found[0].Containers[0].Id,
+		Cmd:         cmd,
+		Tty:         true,
+		Stdin:       true,
+		Stdout:      true,
+		// Stderr MUST be false when TTY is requested
+		Stderr: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	streamURL, err := url.Parse(response.Url)
+	if err != nil {
+		return nil, err
+	}
+	switch streamURL.Scheme {
+	case "https":
+		streamURL.Scheme = "wss"
+	default:
+		streamURL.Scheme = "ws"
+	}
+	dialer := websocket.Dialer{
+		Subprotocols:     []string{criStreamProtocol},
+		HandshakeTimeout: containerRuntimeTimeout,
+	}
+	conn, _, err := dialer.Dial(streamURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	exec := &criExec{
+		conn: conn,
+	}
+	exec.stdoutReader, exec.stdoutWriter = io.Pipe()
+	go exec.readLoop()
+	return exec, nil
+}
+
+func (e *criExec) readLoop() {
+	for {
+		_, data, err := e.conn.ReadMessage()
+		if err != nil {
+			e.stdoutWriter.CloseWithError(err)
+			return
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		switch data[0] {
+		case criStdoutChannel, criStderrChannel:
+			if _, err := e.stdoutWriter.Write(data[1:]); err != nil {
+				return
+			}
+		case criErrorChannel:
+			// Status of the exec process is sent on error channel when it
+			// exits, and the session ends
+			if message := strings.TrimSpace(string(data[1:])); message != "" && !strings.Contains(message, `"Success"`) {
+				e.stdoutWriter.CloseWithError(errors.New(message))
+			} else {
+				e.stdoutWriter.CloseWithError(io.EOF)
+			}
+			return
+		}
+	}
+}
+
+func (e *criExec) Read(p []byte) (int, error) {
+	return e.stdoutReader.Read(p)
+}
+
+func (e *criExec) writeChannel(channel byte, p []byte) error {
+	e.writeLock.Lock()
+	defer e.writeLock.Unlock()
+	return e.conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, p...))
+}
+
+func (e *criExec) Write(p []byte) (int, error) {
+	if err := e.writeChannel(criStdinChannel, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (e *criExec) Resize(cols uint32, rows uint32) error {
+	size, err := json.Marshal(criTerminalSize{
+		Width:  uint16(cols),
+		Height: uint16(rows),
+	})
+	if err != nil {
+		return err
+	}
+	return e.writeChannel(criResizeChannel, size)
+}
+
+func (e *criExec) Close() error {
+	e.stdoutReader.Close()
+	return e.conn.Close()
+}
@@ -0,0 +1,135 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	dockerclient "github.com/docker/docker/client"
+
+	"github.com/aliyun/aliyun_assist_client/agent/container/podman"
+)
+
+const (
+	containerRuntimeTimeout = 10 * time.Second
+)
+
+// dockerExec is an exec session with TTY through Docker Engine API, which is
+// also served by podman REST service.
+type dockerExec struct {
+	client   *dockerclient.Client
+	execId   string
+	hijacked types.HijackedResponse
+}
+
+func startDockerExec(runtimeName string, containerId string, containerName string, username string, cmd []string) (*dockerExec, error) {
+	clientOpts := []dockerclient.Opt{dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation()}
+	if runtimeName == "podman" {
+		endpoint, err := podman.FindEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		clientOpts = append(clientOpts, dockerclient.WithHost(endpoint))
+	}
+	client, err := dockerclient.NewClientWithOpts(clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), containerRuntimeTimeout)
+	defer cancel()
+	filterArgs := filters.NewArgs()
+	if containerId != "" {
+		filterArgs.Add("id", containerId)
+	}
+	if containerName != "" {
+		filterArgs.Add("name", containerName)
+	}
+	containers, err := client.ContainerList(ctx, types.ContainerListOptions{
+		Filters: filterArgs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Filter of name matches any part of container names, e.g., web also
+	// matches web-admin
+	if containerName != "" {
+		matched := containers[:0]
+		for _, container := range containers {
+			if hasContainerName(container.Names, containerName) {
+				matched = append(matched, container)
+			}
+		}
+		containers = matched
+	}
+	if len(containers) == 0 {
+		return nil, errors.New("no running container found")
+	}
+	if len(containers) > 1 {
+		return nil, fmt.Errorf("%d running containers found", len(containers))
+	}
+
+	execution, err := client.ContainerExecCreate(ctx, containers[0].ID, types.ExecConfig{
+		User:         username,
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          []string{containerTermEnvVariable},
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hijacked, err := client.ContainerExecAttach(ctx, execution.ID, types.ExecStartCheck{
+		Tty: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dockerExec{
+		client:   client,
+		execId:   execution.ID,
+		hijacked: hijacked,
+	}, nil
+}
+
+// hasContainerName reports whether name is one of names of container listed by
+// Docker Engine API, which are prefixed with "/"
+func hasContainerName(names []string, name string) bool {
+	name = strings.TrimPrefix(name, "/")
+	for _, containerName := range names {
+		if strings.TrimPrefix(containerName, "/") == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Output of exec session with TTY is not multiplexed, and can be read directly
+func (e *dockerExec) Read(p []byte) (int, error) {
+	return e.hijacked.Reader.Read(p)
+}
+
+func (e *dockerExec) Write(p []byte) (int, error) {
+	return e.hijacked.Conn.Write(p)
+}
+
+func (e *dockerExec) Resize(cols uint32, rows uint32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), containerRuntimeTimeout)
+	defer cancel()
+	return e.client.ContainerExecResize(ctx, e.execId, types.ResizeOptions{
+		Height: uint(rows),
+		Width:  uint(cols),
+	})
+}
+
+func (e *dockerExec) Close() error {
+	e.hijacked.Close()
+	return e.client.Close()
+}
@@ -0,0 +1,209 @@
+package shell
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
+	"github.com/aliyun/aliyun_assist_client/agent/session/message"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+)
+
+type fakeContainerExec struct {
+	*io.PipeReader
+	output *io.PipeWriter
+
+	lock    sync.Mutex
+	input   []byte
+	resizes [][2]uint32
+}
+
+func newFakeContainerExec() *fakeContainerExec {
+	reader, writer := io.Pipe()
+	return &fakeContainerExec{
+		PipeReader: reader,
+		output:     writer,
+	}
+}
+
+func (e *fakeContainerExec) Write(p []byte) (int, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.input = append(e.input, p...)
+	return len(p), nil
+}
+
+func (e *fakeContainerExec) Close() error {
+	return e.PipeReader.Close()
+}
+
+func (e *fakeContainerExec) Resize(cols uint32, rows uint32) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.resizes = append(e.resizes, [2]uint32{cols, rows})
+	return nil
+}
+
+func (e *fakeContainerExec) lastResize() [2]uint32 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.resizes) == 0 {
+		return [2]uint32{}
+	}
+	return e.resizes[len(e.resizes)-1]
+}
+
+type fakeSessionChannel struct {
+	lock sync.Mutex
+	sent []byte
+}
+
+func (c *fakeSessionChannel) Open() error      { return nil }
+func (c *fakeSessionChannel) Close() error     { return nil }
+func (c *fakeSessionChannel) Reconnect() error { return nil }
+func (c *fakeSessionChannel) SendStreamDataMessage(inputData []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.sent = append(c.sent, inputData...)
+	return nil
+}
+func (c *fakeSessionChannel) GetChannelId() string { return "c-test" }
+func (c *fakeSessionChannel) IsActive() bool       { return true }
+
+func (c *fakeSessionChannel) sentData() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return string(c.sent)
+}
+
+func replaceStartContainerExec(t *testing.T, exec containerExec) {
+	original := startContainerExec
+	startContainerExec = func(p *ContainerShellPlugin) (containerExec, error) {
+		return exec, nil
+	}
+	t.Cleanup(func() {
+		startContainerExec = original
+	})
+}
+
+func sizeMessage(t *testing.T, cols uint32, rows uint32) message.Message {
+	payload, err := json.Marshal(SizeData{Cols: cols, Rows: rows})
+	assert.NoError(t, err)
+	return message.Message{
+		MessageType: message.SetSizeDataMessage,
+		Payload:     payload,
+	}
+}
+
+func TestContainerShellPluginSizeBeforeStart(t *testing.T) {
+	exec := newFakeContainerExec()
+	replaceStartContainerExec(t, exec)
+	plugin := NewContainerShellPlugin("s-test", "docker://abc", "", "", "", 0)
+	dataChannel := &fakeSessionChannel{}
+
+	// Size received before exec session started is applied on starting
+	assert.NoError(t, plugin.InputStreamMessageHandler(sizeMessage(t, 120, 40)))
+	done := make(chan string, 1)
+	go func() {
+		errorCode, _ := plugin.Execute(dataChannel, util.NewChanneledCancelFlag())
+		done <- errorCode
+	}()
+
+	assert.Eventually(t, func() bool {
+		return exec.lastResize() == [2]uint32{120, 40}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	exec.output.Write([]byte("hello"))
+	assert.Eventually(t, func() bool {
+		return dataChannel.sentData() == "hello"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	exec.output.Close()
+	select {
+	case errorCode := <-done:
+		assert.Equal(t, Ok, errorCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Execute does not return after exec session ended")
+	}
+}
+
+func TestContainerShellPluginConcurrentMessages(t *testing.T) {
+	exec := newFakeContainerExec()
+	replaceStartContainerExec(t, exec)
+	plugin := NewContainerShellPlugin("s-test", "docker://abc", "", "", "", 0)
+	cancelFlag := util.NewChanneledCancelFlag()
+
+	done := make(chan string, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				plugin.InputStreamMessageHandler(sizeMessage(t, uint32(80+i), uint32(24+j)))
+				plugin.InputStreamMessageHandler(message.Message{
+					MessageType: message.InputStreamDataMessage,
+					Payload:     []byte("x"),
+				})
+			}
+		}(i)
+	}
+	go func() {
+		errorCode, _ := plugin.Execute(&fakeSessionChannel{}, cancelFlag)
+		done <- errorCode
+	}()
+	wg.Wait()
+
+	// The last size is always applied, whenever the exec session started
+	assert.NoError(t, plugin.SetSize(200, 50))
+	assert.Eventually(t, func() bool {
+		return exec.lastResize() == [2]uint32{200, 50}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancelFlag.Set(util.Completed)
+	select {
+	case errorCode := <-done:
+		assert.Equal(t, Notified, errorCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Execute does not return after canceled")
+	}
+}
+
+func TestContainerShellPluginDeniedByPolicy(t *testing.T) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chmod(tempDir, 0755))
+	policyPath := filepath.Join(tempDir, policy.PolicyFilename)
+	originalGetPolicyPath := policy.GetPolicyPath
+	policy.GetPolicyPath = func() (string, error) { return policyPath, nil }
+	defer func() { policy.GetPolicyPath = originalGetPolicyPath }()
+	assert.NoError(t, os.WriteFile(policyPath, []byte(`{"rules": [{"id": "no-db", "effect": "deny", "containers": ["db"]}]}`), 0644))
+
+	original := startContainerExec
+	defer func() { startContainerExec = original }()
+	startContainerExec = func(p *ContainerShellPlugin) (containerExec, error) {
+		t.Fatal("exec session started despite policy")
+		return nil, nil
+	}
+
+	plugin := NewContainerShellPlugin("s-test", "", "db", "", "", 0)
+	errorCode, err := plugin.Execute(&fakeSessionChannel{}, util.NewChanneledCancelFlag())
+	assert.Equal(t, Open_pty_failed, errorCode)
+	var violation *policy.Violation
+	assert.True(t, errors.As(err, &violation))
+}
+
+func TestHasContainerName(t *testing.T) {
+	assert.True(t, hasContainerName([]string{"/web"}, "web"))
+	assert.True(t, hasContainerName([]string{"/web-admin", "/web"}, "/web"))
+	assert.False(t, hasContainerName([]string{"/web-admin"}, "web"))
+	assert.False(t, hasContainerName(nil, "web"))
+}
@@ -161,6 +161,14 @@ func (p *ShellPlugin) processStdoutData(
 	stdoutBytes []byte,
 	stdoutBytesLen int,
 	unprocessedBuf bytes.Buffer) (bytes.Buffer, error) {
+	return processStdoutData(p.dataChannel, stdoutBytes, stdoutBytesLen, unprocessedBuf)
+}
+
+func processStdoutData(
+	dataChannel channel.ISessionChannel,
+	stdoutBytes []byte,
+	stdoutBytesLen int,
+	unprocessedBuf bytes.Buffer) (bytes.Buffer, error) {
 
 	// append stdoutBytes to unprocessedBytes and then read rune from appended bytes to send it over websocket channel
 	unprocessedBytes := unprocessedBuf.Bytes()
@@ -196,8 +204,8 @@ func (p *ShellPlugin) processStdoutData(
 		i += stdoutRuneLen
 	}
 
-	if p.dataChannel != nil {
-		if err := p.dataChannel.SendStreamDataMessage(processedBuf.Bytes()); err != nil {
+	if dataChannel != nil {
+		if err := dataChannel.SendStreamDataMessage(processedBuf.Bytes()); err != nil {
 			return processedBuf, fmt.Errorf("unable to send stream data message: %s", err)
 		}
 	}
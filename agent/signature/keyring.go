@@ -0,0 +1,241 @@
+// Package signature verifies detached signatures of content sent from server
+// against trusted public keys in the local keyring directory.
+package signature
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	KeyringDirname = "trusted_keys"
+	// Keys authenticating kick messages are kept apart from keys signing
+	// command content, since kicks could stop, remove or deregister the agent
+	KickKeyringDirname = "trusted_kick_keys"
+	keyFileExt         = ".pem"
+
+	// Optional PEM headers in key file to limit validity period of key in
+	// RFC3339 format, which allows new key to be deployed before rotation and
+	// old key to expire automatically after rotation.
+	headerNotBefore = "Not-Before"
+	headerNotAfter  = "Not-After"
+)
+
+// TrustedKey is a public key in keyring, identified by file name without the
+// .pem extension.
+type TrustedKey struct {
+	Id        string
+	PublicKey interface{}
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Keyring is the set of trusted keys loaded from keyring directory
+type Keyring struct {
+	keys map[string]*TrustedKey
+}
+
+// cachedKeyring is the keyring loaded last time from a directory, along with
+// fingerprint of key files it is loaded from
+type cachedKeyring struct {
+	fingerprint string
+	keyring     *Keyring
+}
+
+var (
+	_keyringCacheLock sync.Mutex
+	// Keyed by keyring directory, since keyrings of different purposes are
+	// used alternately
+	_keyringCache = make(map[string]*cachedKeyring)
+)
+
+// ValidAt reports whether the key is within its validity period
+func (k *TrustedKey) ValidAt(t time.Time) bool {
+	if !k.NotBefore.IsZero() && t.Before(k.NotBefore) {
+		return false
+	}
+	if !k.NotAfter.IsZero() && t.After(k.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// Algorithm returns name of signature algorithm the key is used with
+func (k *TrustedKey) Algorithm() string {
+	switch k.PublicKey.(type) {
+	case ed25519.PublicKey:
+		return "Ed25519"
+	case *rsa.PublicKey:
+		return "RSA-PSS-SHA256"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultKeyringDir returns the keyring directory shared across versions
+func DefaultKeyringDir() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, KeyringDirname), nil
+}
+
+// KickKeyringDir returns the directory of keys trusted for signing kick
+// messages, shared across versions
+func KickKeyringDir() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, KickKeyringDirname), nil
+}
+
+// LoadKeyring loads every *.pem file in the directory as a trusted key. Key
+// files that could be modified by non-root users are refused.
+func LoadKeyring(keyringDir string) (*Keyring, error) {
+	entries, err := os.ReadDir(keyringDir)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring := &Keyring{
+		keys: make(map[string]*TrustedKey),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExt {
+			continue
+		}
+		keyPath := filepath.Join(keyringDir, entry.Name())
+		if err := util.CheckFileProtected(keyPath); err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		keyId := strings.TrimSuffix(entry.Name(), keyFileExt)
+		key, err := ParseTrustedKey(keyId, content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key file %s: %w", keyPath, err)
+		}
+		keyring.keys[keyId] = key
+	}
+	log.GetLogger().Infof("Loaded %d trusted keys from %s", len(keyring.keys), keyringDir)
+	return keyring, nil
+}
+
+// LoadCachedKeyring returns keyring in the directory like LoadKeyring, but
+// reuses the keyring loaded last time unless any key file has been added,
+// removed, modified or changed in mode since then.
+func LoadCachedKeyring(keyringDir string) (*Keyring, error) {
+	fingerprint, err := keyringFingerprint(keyringDir)
+	if err != nil {
+		return nil, err
+	}
+
+	_keyringCacheLock.Lock()
+	defer _keyringCacheLock.Unlock()
+	if cached, ok := _keyringCache[keyringDir]; ok && cached.fingerprint == fingerprint {
+		return cached.keyring, nil
+	}
+	keyring, err := LoadKeyring(keyringDir)
+	if err != nil {
+		return nil, err
+	}
+	_keyringCache[keyringDir] = &cachedKeyring{
+		fingerprint: fingerprint,
+		keyring:     keyring,
+	}
+	return keyring, nil
+}
+
+// keyringFingerprint summarizes name, size, modification time and mode of
+// the directory and key files in it
+func keyringFingerprint(keyringDir string) (string, error) {
+	dirInfo, err := os.Stat(keyringDir)
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(keyringDir)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d %s;", dirInfo.ModTime().UnixNano(), dirInfo.Mode())
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExt {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&builder, "%s %d %d %s;", entry.Name(), info.Size(), info.ModTime().UnixNano(), info.Mode())
+	}
+	return builder.String(), nil
+}
+
+// ParseTrustedKey parses PEM-encoded PKIX public key of Ed25519 or RSA
+func ParseTrustedKey(keyId string, content []byte) (*TrustedKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("no PUBLIC KEY block found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch publicKey.(type) {
+	case ed25519.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	key := &TrustedKey{
+		Id:        keyId,
+		PublicKey: publicKey,
+	}
+	if value, ok := block.Headers[headerNotBefore]; ok {
+		if key.NotBefore, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, fmt.Errorf("invalid %s header: %w", headerNotBefore, err)
+		}
+	}
+	if value, ok := block.Headers[headerNotAfter]; ok {
+		if key.NotAfter, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, fmt.Errorf("invalid %s header: %w", headerNotAfter, err)
+		}
+	}
+	return key, nil
+}
+
+// Get returns trusted key of specified id
+func (k *Keyring) Get(keyId string) (*TrustedKey, bool) {
+	key, ok := k.keys[keyId]
+	return key, ok
+}
+
+// Keys returns all trusted keys sorted by id
+func (k *Keyring) Keys() []*TrustedKey {
+	keys := make([]*TrustedKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Id < keys[j].Id
+	})
+	return keys
+}
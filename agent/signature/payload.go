@@ -0,0 +1,100 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CommandPayloadVersion identifies the format of signed command payload
+const CommandPayloadVersion = "v2"
+
+var ErrSignatureExpired = errors.New("content signature has expired")
+
+// CommandPayload is what signature of command covers. Besides content, every
+// field of the task changing what is executed, how and where is covered too,
+// so that a valid signature could not be replayed by another user, in another
+// target or with other parameters. Nothing assigned at invocation time like
+// task id is covered, thus command could be signed offline, and replaying is
+// bounded by the required expire time instead.
+// Empty fields are kept in payload, and signer produces the same JSON encoding
+// of the payload: fields in the declared order, keys of map sorted, no
+// insignificant whitespace and no HTML escaping.
+type CommandPayload struct {
+	Version string `json:"version"`
+	// Unix time in seconds after which the signature is no longer accepted
+	ExpireTime  int64  `json:"expireTime"`
+	CommandType string `json:"commandType"`
+	// Hex-encoded SHA-256 digest of content, set by SetContent. For content
+	// url, it is the pinned digest of the referenced object instead
+	ContentSha256 string `json:"contentSha256"`
+	// Url and entrypoint inside bundle of content url, which are empty for
+	// inline content
+	ContentUrl        string `json:"contentUrl"`
+	ContentEntrypoint string `json:"contentEntrypoint"`
+	EnableParameter   bool   `json:"enableParameter"`
+	// Encoded as null when empty
+	BuiltinParameters map[string]string `json:"builtinParameters"`
+	Username          string            `json:"username"`
+	WindowsPassword   string            `json:"windowsPasswordName"`
+	WorkingDir        string            `json:"workingDir"`
+	LoginShell        bool              `json:"loginShell"`
+	Timeout           string            `json:"timeout"`
+	Repeat            string            `json:"repeat"`
+	Cron              string            `json:"cron"`
+	ContainerId       string            `json:"containerId"`
+	ContainerName     string            `json:"containerName"`
+	PodNamespace      string            `json:"podNamespace"`
+	PodName           string            `json:"podName"`
+	PodLabelSelector  string            `json:"podLabelSelector"`
+	// Sandbox options and ephemeral container of the task, encoded as they
+	// are in the task, or null when not specified
+	Sandbox            interface{} `json:"sandbox"`
+	EphemeralContainer interface{} `json:"ephemeralContainer"`
+}
+
+// SetContent records digest of content into payload
+func (p *CommandPayload) SetContent(content []byte) {
+	digest := sha256.Sum256(content)
+	p.ContentSha256 = hex.EncodeToString(digest[:])
+}
+
+// Bytes returns the canonical encoding of payload which is signed, or nil
+// when the payload could not be encoded
+func (p *CommandPayload) Bytes() []byte {
+	payload := *p
+	payload.Version = CommandPayloadVersion
+	if len(payload.BuiltinParameters) == 0 {
+		payload.BuiltinParameters = nil
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(&payload); err != nil {
+		return nil
+	}
+	// Trailing newline appended by encoder is not a part of payload
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))
+}
+
+// VerifyCommand loads the default keyring and verifies signature of command
+// payload, which must not have expired
+func VerifyCommand(payload *CommandPayload, encodedSignature string, keyId string) (string, error) {
+	if encodedSignature == "" {
+		return "", ErrSignatureMissing
+	}
+	if payload.ExpireTime <= 0 {
+		return "", fmt.Errorf("%w: expire time is required", ErrSignatureInvalid)
+	}
+	if timeNow().Unix() > payload.ExpireTime {
+		return "", ErrSignatureExpired
+	}
+	content := payload.Bytes()
+	if content == nil {
+		return "", fmt.Errorf("%w: payload could not be encoded", ErrSignatureInvalid)
+	}
+	return VerifyContent(content, encodedSignature, keyId)
+}
@@ -0,0 +1,245 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePublicKey(t *testing.T, publicKey interface{}, headers map[string]string) []byte {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    "PUBLIC KEY",
+		Headers: headers,
+		Bytes:   der,
+	})
+}
+
+func newTestKeyring(t *testing.T, keys map[string][]byte) *Keyring {
+	keyring := &Keyring{keys: make(map[string]*TrustedKey)}
+	for keyId, content := range keys {
+		key, err := ParseTrustedKey(keyId, content)
+		assert.NoError(t, err)
+		keyring.keys[keyId] = key
+	}
+	return keyring
+}
+
+func TestVerify(t *testing.T) {
+	content := []byte("#!/bin/bash\necho hello\n")
+
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	edSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivateKey, content))
+
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	digest := sha256.Sum256(content)
+	rsaSignatureBytes, err := rsa.SignPSS(rand.Reader, rsaPrivateKey, crypto.SHA256, digest[:], nil)
+	assert.NoError(t, err)
+	rsaSignature := base64.StdEncoding.EncodeToString(rsaSignatureBytes)
+
+	keyring := newTestKeyring(t, map[string][]byte{
+		"ci-2024": encodePublicKey(t, edPublicKey, map[string]string{
+			headerNotAfter: "2024-12-31T23:59:59Z",
+		}),
+		"ci-2025": encodePublicKey(t, edPublicKey, nil),
+		"ci-rsa":  encodePublicKey(t, &rsaPrivateKey.PublicKey, nil),
+	})
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = originalTimeNow }()
+
+	keyId, err := keyring.Verify(content, edSignature, "ci-2025")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-2025", keyId)
+
+	keyId, err = keyring.Verify(content, edSignature, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-2025", keyId)
+
+	keyId, err = keyring.Verify(content, rsaSignature, "ci-rsa")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-rsa", keyId)
+
+	_, err = keyring.Verify(content, "", "")
+	assert.True(t, errors.Is(err, ErrSignatureMissing))
+
+	_, err = keyring.Verify(content, edSignature, "ci-2024")
+	assert.True(t, errors.Is(err, ErrKeyNotTrusted))
+
+	_, err = keyring.Verify(content, edSignature, "unknown")
+	assert.True(t, errors.Is(err, ErrKeyNotTrusted))
+
+	_, err = keyring.Verify([]byte("tampered"), edSignature, "ci-2025")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+
+	_, err = keyring.Verify([]byte("tampered"), rsaSignature, "")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+
+	_, err = keyring.Verify(content, "not base64!", "")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+
+	_, err = (&Keyring{}).Verify(content, edSignature, "")
+	assert.True(t, errors.Is(err, ErrNoTrustedKey))
+}
+
+func TestLoadKeyring(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Key files must be owned by root")
+	}
+	keyringDir := t.TempDir()
+	assert.NoError(t, os.Chmod(keyringDir, 0755))
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "ci.pem"), encodePublicKey(t, edPublicKey, nil), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "README"), []byte("ignored"), 0644))
+
+	keyring, err := LoadKeyring(keyringDir)
+	assert.NoError(t, err)
+	if assert.Len(t, keyring.Keys(), 1) {
+		assert.Equal(t, "ci", keyring.Keys()[0].Id)
+		assert.Equal(t, "Ed25519", keyring.Keys()[0].Algorithm())
+	}
+
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "bad.pem"), []byte("garbage"), 0644))
+	_, err = LoadKeyring(keyringDir)
+	assert.Error(t, err)
+}
+
+func TestLoadCachedKeyring(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Key files must be owned by root")
+	}
+	keyringDir := t.TempDir()
+	assert.NoError(t, os.Chmod(keyringDir, 0755))
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "ci.pem"), encodePublicKey(t, edPublicKey, nil), 0644))
+
+	keyring, err := LoadCachedKeyring(keyringDir)
+	assert.NoError(t, err)
+	cached, err := LoadCachedKeyring(keyringDir)
+	assert.NoError(t, err)
+	assert.Same(t, keyring, cached)
+
+	// Added key file is picked up
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "ci-next.pem"), encodePublicKey(t, edPublicKey, nil), 0644))
+	reloaded, err := LoadCachedKeyring(keyringDir)
+	assert.NoError(t, err)
+	assert.NotSame(t, keyring, reloaded)
+	assert.Len(t, reloaded.Keys(), 2)
+
+	// Key file modifiable by others is refused again after mode changed
+	assert.NoError(t, os.Chmod(filepath.Join(keyringDir, "ci.pem"), 0666))
+	_, err = LoadCachedKeyring(keyringDir)
+	assert.Error(t, err)
+}
+
+func TestCommandPayload(t *testing.T) {
+	payload := &CommandPayload{
+		ExpireTime:        1748736000,
+		CommandType:       "RunShellScript",
+		EnableParameter:   true,
+		BuiltinParameters: map[string]string{"b": "2", "a": "1"},
+		Username:          "admin",
+	}
+	payload.SetContent([]byte("echo <hello>"))
+	digest := sha256.Sum256([]byte("echo <hello>"))
+	assert.Equal(t, hex.EncodeToString(digest[:]), payload.ContentSha256)
+	assert.Equal(t, `{"version":"v2","expireTime":1748736000,"commandType":"RunShellScript","contentSha256":"`+payload.ContentSha256+
+		`","contentUrl":"","contentEntrypoint":"","enableParameter":true,"builtinParameters":{"a":"1","b":"2"},"username":"admin","windowsPasswordName":"","workingDir":"",`+
+		`"loginShell":false,"timeout":"","repeat":"","cron":"","containerId":"","containerName":"","podNamespace":"","podName":"",`+
+		`"podLabelSelector":"","sandbox":null,"ephemeralContainer":null}`,
+		string(payload.Bytes()))
+
+	_, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	keyring := newTestKeyring(t, map[string][]byte{
+		"ci": encodePublicKey(t, edPrivateKey.Public(), nil),
+	})
+	encodedSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivateKey, payload.Bytes()))
+	_, err = keyring.Verify(payload.Bytes(), encodedSignature, "ci")
+	assert.NoError(t, err)
+
+	// Signature could not be replayed as another user, in another target or
+	// with other parameters
+	replayed := *payload
+	replayed.Username = "root"
+	_, err = keyring.Verify(replayed.Bytes(), encodedSignature, "ci")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+	replayed = *payload
+	replayed.ContainerId = "docker://abc"
+	_, err = keyring.Verify(replayed.Bytes(), encodedSignature, "ci")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+	replayed = *payload
+	replayed.BuiltinParameters = map[string]string{"a": "1", "b": "3"}
+	_, err = keyring.Verify(replayed.Bytes(), encodedSignature, "ci")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+
+	// Another script inside the signed bundle could not be executed
+	bundle := &CommandPayload{
+		ExpireTime:        1748736000,
+		CommandType:       "RunShellScript",
+		ContentSha256:     payload.ContentSha256,
+		ContentUrl:        "https://example.com/bundle.zip",
+		ContentEntrypoint: "install.sh",
+	}
+	encodedSignature = base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivateKey, bundle.Bytes()))
+	_, err = keyring.Verify(bundle.Bytes(), encodedSignature, "ci")
+	assert.NoError(t, err)
+	replayed = *bundle
+	replayed.ContentEntrypoint = "uninstall.sh"
+	_, err = keyring.Verify(replayed.Bytes(), encodedSignature, "ci")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+}
+
+func TestVerifyCommand(t *testing.T) {
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	keyringDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "ci.pem"), encodePublicKey(t, edPublicKey, nil), 0600))
+	originalGetKeyringDir := GetKeyringDir
+	GetKeyringDir = func() (string, error) { return keyringDir, nil }
+	defer func() { GetKeyringDir = originalGetKeyringDir }()
+	originalTimeNow := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = originalTimeNow }()
+
+	sign := func(payload *CommandPayload) string {
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivateKey, payload.Bytes()))
+	}
+	payload := &CommandPayload{
+		ExpireTime:  time.Date(2025, 6, 1, 1, 0, 0, 0, time.UTC).Unix(),
+		CommandType: "RunShellScript",
+	}
+	payload.SetContent([]byte("echo hello"))
+	keyId, err := VerifyCommand(payload, sign(payload), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", keyId)
+
+	expired := *payload
+	expired.ExpireTime = time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC).Unix()
+	_, err = VerifyCommand(&expired, sign(&expired), "")
+	assert.True(t, errors.Is(err, ErrSignatureExpired))
+
+	// Signature without expire time could be replayed forever
+	unbounded := *payload
+	unbounded.ExpireTime = 0
+	_, err = VerifyCommand(&unbounded, sign(&unbounded), "")
+	assert.True(t, errors.Is(err, ErrSignatureInvalid))
+}
@@ -0,0 +1,112 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrSignatureMissing = errors.New("content is not signed")
+	ErrSignatureInvalid = errors.New("content signature is invalid")
+	ErrKeyNotTrusted    = errors.New("signing key is not trusted")
+	ErrNoTrustedKey     = errors.New("no trusted key in keyring")
+
+	// timeNow is replaced in tests
+	timeNow = time.Now
+	// GetKeyringDir returns the directory of keys trusted for signing command
+	// content, which is replaced in tests
+	GetKeyringDir = DefaultKeyringDir
+)
+
+// Verify checks base64-encoded detached signature of content. When keyId is
+// specified only that key is used, otherwise any valid key in keyring could
+// produce the signature, which keeps signers without key id working during
+// key rotation. Id of the key verifying the signature is returned.
+func (k *Keyring) Verify(content []byte, encodedSignature string, keyId string) (string, error) {
+	if encodedSignature == "" {
+		return "", ErrSignatureMissing
+	}
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", fmt.Errorf("%w: decode error: %v", ErrSignatureInvalid, err)
+	}
+
+	now := timeNow()
+	if keyId != "" {
+		key, ok := k.Get(keyId)
+		if !ok {
+			return "", fmt.Errorf("%w: key %s not found in keyring", ErrKeyNotTrusted, keyId)
+		}
+		if !key.ValidAt(now) {
+			return "", fmt.Errorf("%w: key %s is out of its validity period", ErrKeyNotTrusted, keyId)
+		}
+		if err := verifyWithKey(key, content, signature); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+		}
+		return keyId, nil
+	}
+
+	candidates := 0
+	for _, key := range k.Keys() {
+		if !key.ValidAt(now) {
+			continue
+		}
+		candidates++
+		if verifyWithKey(key, content, signature) == nil {
+			return key.Id, nil
+		}
+	}
+	if candidates == 0 {
+		return "", ErrNoTrustedKey
+	}
+	return "", fmt.Errorf("%w: no trusted key matches", ErrSignatureInvalid)
+}
+
+func verifyWithKey(key *TrustedKey, content []byte, signature []byte) error {
+	switch publicKey := key.PublicKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, content, signature) {
+			return fmt.Errorf("%s verification failed with key %s", key.Algorithm(), key.Id)
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(content)
+		return rsa.VerifyPSS(publicKey, crypto.SHA256, digest[:], signature, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthAuto,
+		})
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// VerifyContent loads the default keyring and verifies signature of content
+func VerifyContent(content []byte, encodedSignature string, keyId string) (string, error) {
+	return verifyWithKeyringDir(GetKeyringDir, content, encodedSignature, keyId)
+}
+
+// VerifyKick loads the kick keyring and verifies signature of kick message.
+// Keys in the default keyring are not trusted for kicks.
+func VerifyKick(content []byte, encodedSignature string, keyId string) (string, error) {
+	return verifyWithKeyringDir(KickKeyringDir, content, encodedSignature, keyId)
+}
+
+func verifyWithKeyringDir(getKeyringDir func() (string, error), content []byte, encodedSignature string, keyId string) (string, error) {
+	if encodedSignature == "" {
+		return "", ErrSignatureMissing
+	}
+	keyringDir, err := getKeyringDir()
+	if err != nil {
+		return "", err
+	}
+	keyring, err := LoadCachedKeyring(keyringDir)
+	if err != nil {
+		return "", err
+	}
+	return keyring.Verify(content, encodedSignature, keyId)
+}
@@ -279,6 +279,7 @@ func getMode(config StateConfiguration) string {
 }
 
 func reportResult(config StateConfiguration, status, mode string, extraInfo map[string]interface{}) (err error) {
+	recordEnforceResult(config.StateConfigurationId, status)
 	var extraInfoStr string
 	if extraInfo != nil && len(extraInfo) > 0 {
 		data, _ := json.Marshal(extraInfo)
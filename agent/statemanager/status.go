@@ -0,0 +1,68 @@
+package statemanager
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StateConfigStatus describes a state configuration and its enforcement
+type StateConfigStatus struct {
+	StateConfiguration
+	// Result of last enforcement in this process, empty if never enforced
+	LastStatus      string
+	LastEnforceTime time.Time
+	NextRunTime     time.Time
+}
+
+type enforceResult struct {
+	status string
+	time   time.Time
+}
+
+var (
+	_enforceResults     = map[string]enforceResult{}
+	_enforceResultsLock sync.Mutex
+)
+
+func recordEnforceResult(stateConfigId string, status string) {
+	_enforceResultsLock.Lock()
+	defer _enforceResultsLock.Unlock()
+	_enforceResults[stateConfigId] = enforceResult{
+		status: status,
+		time:   time.Now(),
+	}
+}
+
+// ListStateConfigs returns state configurations ordered by id, with result of
+// last enforcement and next scheduled time
+func ListStateConfigs() []StateConfigStatus {
+	_stateConfigsLock.RLock()
+	statuses := make([]StateConfigStatus, 0, len(stateConfigs))
+	for _, config := range stateConfigs {
+		statuses = append(statuses, StateConfigStatus{StateConfiguration: config})
+	}
+	_stateConfigsLock.RUnlock()
+
+	_enforceResultsLock.Lock()
+	for i := range statuses {
+		if result, ok := _enforceResults[statuses[i].StateConfigurationId]; ok {
+			statuses[i].LastStatus = result.status
+			statuses[i].LastEnforceTime = result.time
+		}
+	}
+	_enforceResultsLock.Unlock()
+
+	stateConfigTimersLock.Lock()
+	for i := range statuses {
+		if stateConfigTimer, ok := stateConfigTimers[statuses[i].StateConfigurationId]; ok && stateConfigTimer.timer != nil {
+			statuses[i].NextRunTime = stateConfigTimer.timer.NextRunTime()
+		}
+	}
+	stateConfigTimersLock.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].StateConfigurationId < statuses[j].StateConfigurationId
+	})
+	return statuses
+}
@@ -0,0 +1,26 @@
+package statemanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListStateConfigs(t *testing.T) {
+	updateStateConfigs([]StateConfiguration{
+		{StateConfigurationId: "sc-2", TemplateName: "t2", ScheduleType: "rate", ScheduleExpression: "1 hour"},
+		{StateConfigurationId: "sc-1", TemplateName: "t1", ScheduleType: "cron", ScheduleExpression: "0 0 * * * ?"},
+	})
+	defer updateStateConfigs(nil)
+	recordEnforceResult("sc-2", NotCompliant)
+
+	statuses := ListStateConfigs()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "sc-1", statuses[0].StateConfigurationId)
+	assert.Equal(t, "t1", statuses[0].TemplateName)
+	assert.Empty(t, statuses[0].LastStatus)
+	assert.True(t, statuses[0].LastEnforceTime.IsZero())
+	assert.Equal(t, "sc-2", statuses[1].StateConfigurationId)
+	assert.Equal(t, NotCompliant, statuses[1].LastStatus)
+	assert.False(t, statuses[1].LastEnforceTime.IsZero())
+}
@@ -16,11 +16,16 @@ import (
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
 	"github.com/aliyun/aliyun_assist_client/agent/flagging"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
+	"github.com/aliyun/aliyun_assist_client/agent/signature"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/container"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/docker"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/host"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/remotescript"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/parameters"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/scriptmanager"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
@@ -35,6 +40,11 @@ const (
 	defaultQuotoPre = 6000
 )
 
+var (
+	// detectContentSignatureRequired is replaced in tests
+	detectContentSignatureRequired = flagging.DetectContentSignatureRequired
+)
+
 type FinishCallback func()
 
 type Task struct {
@@ -53,6 +63,24 @@ type Task struct {
 	cancelMut               sync.Mutex
 	output                  bytes.Buffer
 	data_sended             uint32
+	// Script, or entrypoint of bundle, downloaded from content url, which is
+	// evaluated by execution policy instead of the generated launcher
+	remoteScript          []byte
+	remoteContentResolved bool
+	// Content to be executed with parameters resolved, which is evaluated by
+	// execution policy in pre-checking phase, and whether it should be
+	// deleted after execution
+	preparedContent string
+	scriptToDelete  bool
+	// Whether content signature has been verified for the task, which is not
+	// verified again in following invocations of periodic task
+	contentSignatureVerified bool
+	// Unix milliseconds when current invocation started running, or zero when
+	// pending in the pool. Read concurrently for status reporting.
+	runningSince atomic.Int64
+	// Progress of invocation submitted locally is published here instead of
+	// reported to server
+	local *LocalInvocation
 }
 
 func NewTask(taskInfo models.RunTaskInfo, scheduleLocation *time.Location, onFinish FinishCallback) *Task {
@@ -62,7 +90,19 @@ func NewTask(taskInfo models.RunTaskInfo, scheduleLocation *time.Location, onFin
 	}
 
 	var processor models.TaskProcessor
-	if taskInfo.ContainerId != "" || taskInfo.ContainerName != "" {
+	if taskInfo.EphemeralContainer != nil {
+		processor = &docker.EphemeralProcessor{
+			TaskId:        taskInfo.TaskId,
+			InvokeVersion: taskInfo.InvokeVersion,
+			Container:     *taskInfo.EphemeralContainer,
+			CommandType:   taskInfo.CommandType,
+			Timeout:       timeout,
+
+			WorkingDirectory: taskInfo.WorkingDir,
+			Username:         taskInfo.Username,
+		}
+	} else if taskInfo.ContainerId != "" || taskInfo.ContainerName != "" ||
+		taskInfo.PodNamespace != "" || taskInfo.PodName != "" || taskInfo.PodLabelSelector != "" {
 		processor = container.DetectContainerProcessor(&container.ContainerCommandOptions{
 			TaskId:            taskInfo.TaskId,
 			InvokeVersion: taskInfo.InvokeVersion,
@@ -73,6 +113,9 @@ func NewTask(taskInfo models.RunTaskInfo, scheduleLocation *time.Location, onFin
 
 			WorkingDirectory: taskInfo.WorkingDir,
 			Username:         taskInfo.Username,
+			PodNamespace:     taskInfo.PodNamespace,
+			PodName:          taskInfo.PodName,
+			PodLabelSelector: taskInfo.PodLabelSelector,
 		})
 	} else {
 		processor = &host.HostProcessor{
@@ -86,6 +129,8 @@ func NewTask(taskInfo models.RunTaskInfo, scheduleLocation *time.Location, onFin
 			WorkingDirectory:    taskInfo.WorkingDir,
 			Username:            taskInfo.Username,
 			WindowsUserPassword: taskInfo.Password,
+			LoginShell:          taskInfo.LoginShell,
+			Sandbox:             taskInfo.Sandbox,
 		}
 	}
 
@@ -119,6 +164,13 @@ func tryReadAll(stdouterrWrite io.Reader, out *bytes.Buffer) {
 }
 
 func (task *Task) PreCheck(reportVerified bool) error {
+	_, err := task.preCheck(reportVerified)
+	return err
+}
+
+// preCheck verifies the task and prepares content to be executed, with error
+// code of failure in preparing content.
+func (task *Task) preCheck(reportVerified bool) (taskerrors.ErrorCode, error) {
 	// Reuse specified logger across whole task pre-checking phase
 	taskLogger := log.GetLogger().WithFields(logrus.Fields{
 		"TaskId": task.taskInfo.TaskId,
@@ -132,14 +184,89 @@ func (task *Task) PreCheck(reportVerified bool) error {
 		task.SendInvalidTask("TypeInvalid", fmt.Sprintf("TypeInvalid_%s", task.taskInfo.CommandType))
 		err := fmt.Errorf("Invalid command type: %s", task.taskInfo.CommandType)
 		taskLogger.Errorln("TypeInvalid", err.Error())
-		return err
+		return 0, err
 	}
 
-	if _, err := base64.StdEncoding.DecodeString(task.taskInfo.Content); err != nil {
+	var remoteRef *remotescript.Reference
+	if task.taskInfo.ContentUrl != "" {
+		var err error
+		if remoteRef, err = task.remoteContentRef(); err != nil {
+			taskLogger.WithError(err).Errorln("Invalid command content url")
+			return 0, err
+		}
+	}
+
+	decodedContent, err := base64.StdEncoding.DecodeString(task.taskInfo.Content)
+	if err != nil {
 		task.SendInvalidTask("CommandContentInvalid", err.Error())
 		wrapErr := fmt.Errorf("Invalid command content: decode error: %w", err)
 		taskLogger.Errorln("CommandContentInvalid", wrapErr.Error())
-		return wrapErr
+		return 0, wrapErr
+	}
+
+	// Pinned digest of the object referenced by content url is signed, thus
+	// nothing is downloaded before the signature is verified
+	if err := task.verifyContentSignature(decodedContent); err != nil {
+		taskLogger.WithError(err).Errorln("Content signature verification failed")
+		return 0, err
+	}
+
+	// Policy evaluates the downloaded script, or the entrypoint of bundle,
+	// instead of the launcher generated for it
+	policyContent := decodedContent
+	var remoteObject *remotescript.Object
+	if remoteRef != nil {
+		if task.remoteContentResolved {
+			policyContent = task.remoteScript
+		} else if remoteObject, policyContent, err = task.fetchRemoteContent(remoteRef); err != nil {
+			taskLogger.WithError(err).Errorln("Failed to fetch command content from url")
+			return 0, err
+		}
+	}
+
+	// Parameters are resolved before execution policy is evaluated, thus
+	// nothing could be hidden from the policy in parameter values. Entrypoint
+	// of bundle is executed as it is.
+	executedContent := string(policyContent)
+	scriptToDelete := false
+	if remoteRef == nil || !remoteRef.IsBundle() {
+		var errorCode taskerrors.ErrorCode
+		if executedContent, scriptToDelete, errorCode, err = task.resolveParameters(executedContent); err != nil {
+			taskLogger.WithError(err).Errorln("Failed to resolve parameters")
+			return errorCode, err
+		}
+	}
+
+	policyRequest := &policy.CommandRequest{
+		TaskId:           task.taskInfo.TaskId,
+		CommandType:      task.taskInfo.CommandType,
+		Username:         task.taskInfo.Username,
+		WorkingDirectory: task.taskInfo.WorkingDir,
+		ContainerId:      task.taskInfo.ContainerId,
+		ContainerName:    task.taskInfo.ContainerName,
+		PodNamespace:     task.taskInfo.PodNamespace,
+		PodName:          task.taskInfo.PodName,
+		Content:          executedContent,
+	}
+	if task.taskInfo.EphemeralContainer != nil {
+		policyRequest.ImageName = task.taskInfo.EphemeralContainer.Image
+	}
+	if violation := policy.CheckCommand(policyRequest); violation != nil {
+		task.SendInvalidTask("PolicyViolation", violation.RuleId)
+		taskLogger.WithError(violation).Errorln("PolicyViolation")
+		return 0, violation
+	}
+
+	if remoteObject != nil {
+		if err := task.installRemoteContent(remoteRef, remoteObject, policyContent); err != nil {
+			taskLogger.WithError(err).Errorln("Failed to install command content from url")
+			return 0, err
+		}
+	}
+	if remoteRef != nil && remoteRef.IsBundle() {
+		// Launcher of the entrypoint installed by installRemoteContent
+		launcher, _ := base64.StdEncoding.DecodeString(task.taskInfo.Content)
+		executedContent = string(launcher)
 	}
 
 	if invalidParameter, err := task.processer.PreCheck(); err != nil {
@@ -151,18 +278,199 @@ func (task *Task) PreCheck(reportVerified bool) error {
 			task.SendInvalidTask(invalidParameter, err.Error())
 		}
 		taskLogger.WithError(err).Errorf("Invalid parameter \"%s\" for invocation", invalidParameter)
-		return err
+		return 0, err
 	}
 
+	task.preparedContent = executedContent
+	task.scriptToDelete = scriptToDelete
 	if reportVerified == true {
 		task.sendTaskVerified()
 	}
+	return 0, nil
+}
+
+// resolveParameters resolves parameters in content to be executed, and
+// reports whether the script should be deleted after execution since secret
+// parameter is referenced.
+func (task *Task) resolveParameters(content string) (string, bool, taskerrors.ErrorCode, error) {
+	if !task.taskInfo.EnableParameter {
+		return content, false, 0, nil
+	}
+	content, err := parameters.ResolveBuiltinParameters(content, task.taskInfo.BuiltinParameters)
+	if err != nil {
+		if invalidErr, ok := err.(taskerrors.InvalidSettingError); ok {
+			task.SendInvalidTask("InvalidEnvironmentParameter", invalidErr.ShortMessage())
+		} else if taskErr, ok := err.(taskerrors.ExecutionError); ok {
+			task.SendError("", taskErr.Code(), taskErr.Error())
+			return "", false, taskErr.Code(), err
+		} else {
+			task.SendError("", taskerrors.WrapErrResolveEnvironmentParameterFailed, err.Error())
+		}
+
+		return "", false, taskerrors.WrapErrResolveEnvironmentParameterFailed, err
+	}
+
+	scriptToDelete := strings.Contains(content, "oos-secret")
+	content, err = util.ReplaceAllParameterStore(content)
+	if err != nil {
+		task.SendInvalidTask(err.Error(), content)
+		return "", false, 0, errors.New("ReplaceAllParameterStore error")
+	}
+	return content, scriptToDelete, 0, nil
+}
+
+// verifyContentSignature checks detached signature of decoded content, along
+// with every field of the task changing what is executed, against the local
+// keyring when signed content is required. Parameters are signed as the
+// template in content and the builtin parameter values. For content url, the
+// pinned digest of the referenced object is signed instead, along with the
+// url and the entrypoint executed inside bundle. The signature is verified
+// once for each task, thus periodic task keeps running after the signature
+// expires.
+func (task *Task) verifyContentSignature(decodedContent []byte) error {
+	if task.contentSignatureVerified {
+		return nil
+	}
+	required, err := detectContentSignatureRequired()
+	if err != nil {
+		log.GetLogger().WithError(err).Errorln("Failed to detect content signature flag")
+	}
+	if !required {
+		return nil
+	}
+
+	payload := &signature.CommandPayload{
+		ExpireTime:         task.taskInfo.ContentSignatureExpireTime,
+		CommandType:        task.taskInfo.CommandType,
+		EnableParameter:    task.taskInfo.EnableParameter,
+		BuiltinParameters:  task.taskInfo.BuiltinParameters,
+		Username:           task.taskInfo.Username,
+		WindowsPassword:    task.taskInfo.Password,
+		WorkingDir:         task.taskInfo.WorkingDir,
+		LoginShell:         task.taskInfo.LoginShell,
+		Timeout:            task.taskInfo.TimeOut,
+		Repeat:             string(task.taskInfo.Repeat),
+		Cron:               task.taskInfo.Cronat,
+		ContainerId:        task.taskInfo.ContainerId,
+		ContainerName:      task.taskInfo.ContainerName,
+		PodNamespace:       task.taskInfo.PodNamespace,
+		PodName:            task.taskInfo.PodName,
+		PodLabelSelector:   task.taskInfo.PodLabelSelector,
+		Sandbox:            task.taskInfo.Sandbox,
+		EphemeralContainer: task.taskInfo.EphemeralContainer,
+	}
+	if task.taskInfo.ContentUrl != "" {
+		payload.ContentSha256 = strings.ToLower(task.taskInfo.ContentSha256)
+		payload.ContentUrl = task.taskInfo.ContentUrl
+		payload.ContentEntrypoint = task.taskInfo.ContentEntrypoint
+	} else {
+		payload.SetContent(decodedContent)
+	}
+	keyId, err := signature.VerifyCommand(payload, task.taskInfo.ContentSignature, task.taskInfo.ContentSignatureKeyId)
+	if err != nil {
+		if errors.Is(err, signature.ErrSignatureMissing) {
+			task.SendInvalidTask("ContentSignatureMissing", err.Error())
+		} else if errors.Is(err, signature.ErrSignatureExpired) {
+			task.SendInvalidTask("ContentSignatureExpired", err.Error())
+		} else {
+			task.SendInvalidTask("ContentSignatureInvalid", err.Error())
+		}
+		return fmt.Errorf("Invalid command content: %w", err)
+	}
+	log.GetLogger().WithFields(logrus.Fields{
+		"TaskId": task.taskInfo.TaskId,
+		"KeyId":  keyId,
+	}).Infoln("Content signature verified")
+	task.contentSignatureVerified = true
 	return nil
 }
 
+// recordAudit records the invocation with digest of its decoded content before
+// parameters are resolved, thus the digest matches the signed content.
+func (task *Task) recordAudit(decodedContent []byte) {
+	digests := map[string]string{
+		"content": audit.Digest(decodedContent),
+	}
+	if objectDigest := task.remoteObjectDigest(); objectDigest != "" {
+		digests["contentObject"] = objectDigest
+	}
+	audit.Record(audit.Event{
+		Action:  audit.ActionRunTask,
+		Trigger: task.auditTrigger(),
+		Subject: task.taskInfo.TaskId,
+		Details: task.auditDetails(),
+		Digests: digests,
+	})
+}
+
+// recordRejection records the task refused with the reason reported to
+// server, e.g., due to invalid content, signature or execution policy. Content
+// which could not be decoded is digested as it is.
+func (task *Task) recordRejection(param string, value string) {
+	details := task.auditDetails()
+	details["param"] = param
+	details["value"] = value
+	digests := map[string]string{}
+	if decodedContent, err := base64.StdEncoding.DecodeString(task.taskInfo.Content); err == nil {
+		digests["content"] = audit.Digest(decodedContent)
+	} else {
+		digests["encodedContent"] = audit.Digest([]byte(task.taskInfo.Content))
+	}
+	if objectDigest := task.remoteObjectDigest(); objectDigest != "" {
+		digests["contentObject"] = objectDigest
+	}
+	audit.Record(audit.Event{
+		Action:  audit.ActionTaskRejected,
+		Trigger: task.auditTrigger(),
+		Subject: task.taskInfo.TaskId,
+		Details: details,
+		Digests: digests,
+	})
+}
+
+func (task *Task) auditTrigger() string {
+	if task.local != nil {
+		return audit.TriggerLocal
+	}
+	return audit.TriggerServer
+}
+
+func (task *Task) auditDetails() map[string]string {
+	details := map[string]string{
+		"commandId":     task.taskInfo.CommandId,
+		"commandType":   task.taskInfo.CommandType,
+		"invokeVersion": strconv.Itoa(task.taskInfo.InvokeVersion),
+		"repeat":        string(task.taskInfo.Repeat),
+		"username":      task.taskInfo.Username,
+		"workingDir":    task.taskInfo.WorkingDir,
+	}
+	if task.taskInfo.ContainerId != "" || task.taskInfo.ContainerName != "" {
+		details["containerId"] = task.taskInfo.ContainerId
+		details["containerName"] = task.taskInfo.ContainerName
+	}
+	if task.taskInfo.PodName != "" || task.taskInfo.PodLabelSelector != "" {
+		details["podNamespace"] = task.taskInfo.PodNamespace
+		details["podName"] = task.taskInfo.PodName
+		details["podLabelSelector"] = task.taskInfo.PodLabelSelector
+	}
+	if task.taskInfo.EphemeralContainer != nil {
+		details["ephemeralImage"] = task.taskInfo.EphemeralContainer.Image
+	}
+	if task.taskInfo.ContentUrl != "" {
+		details["contentUrl"] = task.taskInfo.ContentUrl
+		details["contentEntrypoint"] = task.taskInfo.ContentEntrypoint
+	}
+	if task.local != nil && task.local.Caller != nil {
+		details["callerUid"] = strconv.FormatUint(uint64(task.local.Caller.Uid), 10)
+		details["callerGid"] = strconv.FormatUint(uint64(task.local.Caller.Gid), 10)
+		details["callerPid"] = strconv.Itoa(int(task.local.Caller.Pid))
+	}
+	return details
+}
+
 func (task *Task) Run() (taskerrors.ErrorCode, error) {
-	if err := task.PreCheck(false); err != nil {
-		return 0, err
+	if errorCode, err := task.preCheck(false); err != nil {
+		return errorCode, err
 	}
 
 	// Reuse specified logger across whole task running phase
@@ -179,32 +487,8 @@ func (task *Task) Run() (taskerrors.ErrorCode, error) {
 		task.SendError("", taskerrors.WrapErrBase64DecodeFailed, fmt.Sprintf("Base64DecodeFailed: %s", err.Error()))
 		return taskerrors.WrapErrBase64DecodeFailed, errors.New("decode error")
 	}
-	ScriptToDelete := false
-	content := string(decodeBytes)
-	if task.taskInfo.EnableParameter {
-		content, err = parameters.ResolveBuiltinParameters(content, task.taskInfo.BuiltinParameters)
-		if err != nil {
-			if invalidErr, ok := err.(taskerrors.InvalidSettingError); ok {
-				task.SendInvalidTask("InvalidEnvironmentParameter", invalidErr.ShortMessage())
-			} else if taskErr, ok := err.(taskerrors.ExecutionError); ok {
-				task.SendError("", taskErr.Code(), taskErr.Error())
-				return taskErr.Code(), err
-			} else {
-				task.SendError("", taskerrors.WrapErrResolveEnvironmentParameterFailed, err.Error())
-			}
-
-			return taskerrors.WrapErrResolveEnvironmentParameterFailed, err
-		}
-
-		if strings.Contains(content, "oos-secret") {
-			ScriptToDelete = true
-		}
-		content, err = util.ReplaceAllParameterStore(content)
-		if err != nil {
-			task.SendInvalidTask(err.Error(), content)
-			return 0, errors.New("ReplaceAllParameterStore error")
-		}
-	}
+	task.recordAudit(decodeBytes)
+	content := task.preparedContent
 
 	switch task.taskInfo.CommandType {
 	case "RunBatScript":
@@ -240,6 +524,9 @@ func (task *Task) Run() (taskerrors.ErrorCode, error) {
 
 	task.startTime = time.Now()
 	task.monotonicStartTimestamp = timetool.ToAccurateTime(task.startTime.Local())
+	task.runningSince.Store(task.startTime.UnixMilli())
+	// Reusable invocation of periodic task is pending again in next schedule
+	defer task.runningSince.Store(0)
 	task.sendTaskStart()
 	taskLogger.Infof("Sent starting event")
 
@@ -276,7 +563,8 @@ func (task *Task) Run() (taskerrors.ErrorCode, error) {
 
 			select {
 			case <-ticker.C:
-				if atomic.LoadUint32(&task.data_sended) > defaultQuotoPre {
+				// Output of local invocation is not limited by quota
+				if task.local == nil && atomic.LoadUint32(&task.data_sended) > defaultQuotoPre {
 					tryRead(&stdouterrWrite, &task.output)
 					if reported := task.sendRunningOutput("", lastReportOutputTime); reported {
 						lastReportOutputTime = time.Now()
@@ -284,7 +572,11 @@ func (task *Task) Run() (taskerrors.ErrorCode, error) {
 					taskLogger.Infof("Running output sent: %d bytes, just report running no output sent", atomic.LoadUint32(&task.data_sended))
 				} else {
 					var running_output bytes.Buffer
-					tryRead(&stdouterrWrite, &running_output)
+					if task.local != nil {
+						tryReadAll(&stdouterrWrite, &running_output)
+					} else {
+						tryRead(&stdouterrWrite, &running_output)
+					}
 					if reported := task.sendRunningOutput(running_output.String(), lastReportOutputTime); reported {
 						lastReportOutputTime = time.Now()
 					}
@@ -355,7 +647,7 @@ func (task *Task) Run() (taskerrors.ErrorCode, error) {
 	task.output.Reset()
 	endTaskLogger.Info("Clean task output")
 	// Perform cleanup actions after task finished
-	if err := task.processer.Cleanup(ScriptToDelete); err != nil {
+	if err := task.processer.Cleanup(task.scriptToDelete); err != nil {
 		endTaskLogger.WithError(err).Errorln("Failed to cleanup after command finished")
 	}
 
@@ -375,6 +667,10 @@ func (task *Task) sendTaskVerified() {
 }
 
 func (task *Task) sendTaskStart() {
+	if task.local != nil {
+		task.local.start()
+		return
+	}
 	if task.taskInfo.Output.SendStart == false {
 		return
 	}
@@ -387,12 +683,34 @@ func (task *Task) sendTaskStart() {
 	util.HttpPost(url, "", "text")
 }
 
+// SendInvalidTask reports the task as invalid, and records the rejection into
+// audit log.
 func (task *Task) SendInvalidTask(param string, value string) {
+	task.recordRejection(param, value)
+	if task.local != nil {
+		task.local.finish(LocalEvent{
+			Status:       LocalStatusInvalid,
+			ErrorCode:    param,
+			ErrorMessage: value,
+		})
+		return
+	}
 	reportInvalidTask(task.taskInfo.TaskId, task.taskInfo.InvokeVersion, param, value)
 }
 
 func (task *Task) sendOutput(status string, output string) {
 	output = langutil.LocalToUTF8(output)
+	if task.local != nil {
+		task.local.output(output)
+		task.local.finish(LocalEvent{
+			Status:   status,
+			ExitCode: task.exit_code,
+		})
+		if task.onFinish != nil {
+			task.onFinish()
+		}
+		return
+	}
 
 	var url string
 	if status == "finished" {
@@ -429,6 +747,16 @@ func (task *Task) sendOutput(status string, output string) {
 }
 
 func (task *Task) SendError(output string, errCode fmt.Stringer, errDesc string) {
+	if task.local != nil {
+		task.local.output(langutil.LocalToUTF8(output))
+		task.local.finish(LocalEvent{
+			Status:       "failed",
+			ExitCode:     task.exit_code,
+			ErrorCode:    errCode.String(),
+			ErrorMessage: errDesc,
+		})
+		return
+	}
 	safelyTruncatedErrDesc := langutil.SafeTruncateStringInBytes(errDesc, 255)
 	escapedErrDesc := url.QueryEscape(safelyTruncatedErrDesc)
 	queryString := fmt.Sprintf("?taskId=%s&invokeVersion=%d&start=%d&end=%d&exitCode=%d&dropped=%d&errCode=%s&errDesc=%s",
@@ -473,6 +801,9 @@ func (task *Task) Cancel(quietly bool) {
 }
 
 func (task *Task) getReportString(output bytes.Buffer) string {
+	if task.local != nil {
+		return output.String()
+	}
 	var report_string string
 	quoto := task.taskInfo.Output.LogQuota
 	if quoto < defaultQuoto {
@@ -493,6 +824,10 @@ func (task *Task) sendRunningOutput(data string, lastReportTime time.Time) bool
 	if len(data) == 0 && task.taskInfo.Output.SkipEmpty && time.Since(lastReportTime) < time.Minute {
 		return false
 	}
+	if task.local != nil {
+		task.local.output(langutil.LocalToUTF8(data))
+		return true
+	}
 	url := util.GetRunningOutputService()
 	url += fmt.Sprintf("?taskId=%s&invokeVersion=%d&start=%s", 
 		task.taskInfo.TaskId, task.taskInfo.InvokeVersion, strconv.FormatInt(task.monotonicStartTimestamp, 10))
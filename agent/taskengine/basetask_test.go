@@ -1,14 +1,24 @@
 package taskengine
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
-	"math/rand"
+	mathrand "math/rand"
 	"runtime"
 	"strconv"
 	"testing"
 	"time"
+	"github.com/aliyun/aliyun_assist_client/agent/flagging"
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
+	"github.com/aliyun/aliyun_assist_client/agent/signature"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
 	"github.com/aliyun/aliyun_assist_client/agent/util/osutil"
 )
@@ -50,8 +60,8 @@ func TestRunTask(t *testing.T) {
 		workingDir = "C:\\Users"
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	rand_num := rand.Intn(10000000)
+	mathrand.Seed(time.Now().UnixNano())
+	rand_num := mathrand.Intn(10000000)
 	rand_str := strconv.Itoa(rand_num)
 
 	info := models.RunTaskInfo{
@@ -77,4 +87,118 @@ func TestRunTask(t *testing.T) {
 
 	assert.Equal(t, nil , err)
 	assert.Equal(t, 0 , int(errcode))
-}
\ No newline at end of file
+}
+
+// usePolicyFile writes execution policy into file under temporary directory,
+// from which policy is loaded
+func usePolicyFile(t *testing.T, content string) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chmod(tempDir, 0755))
+	policyPath := filepath.Join(tempDir, policy.PolicyFilename)
+	assert.NoError(t, os.WriteFile(policyPath, []byte(content), 0644))
+	originalGetPolicyPath := policy.GetPolicyPath
+	policy.GetPolicyPath = func() (string, error) { return policyPath, nil }
+	t.Cleanup(func() { policy.GetPolicyPath = originalGetPolicyPath })
+}
+
+// newLocalTestTask creates task whose result is published locally instead of
+// reported to server
+func newLocalTestTask(info models.RunTaskInfo) *Task {
+	task := NewTask(info, nil, nil)
+	task.local = newLocalInvocation(info.TaskId)
+	return task
+}
+
+func TestPreCheckEvaluatesResolvedContent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Shell script is not supported on Windows")
+	}
+	usePolicyFile(t, `{"defaultEffect":"allow","rules":[{"id":"no-rm","effect":"deny","contentPatterns":["rm\\s+-rf"]}]}`)
+
+	info := models.RunTaskInfo{
+		CommandType:       "RunShellScript",
+		TaskId:            "local-test",
+		Content:           base64.StdEncoding.EncodeToString([]byte("{{ACS::Cmd}} /tmp/test")),
+		EnableParameter:   true,
+		BuiltinParameters: map[string]string{"Cmd": "ls"},
+	}
+	task := newLocalTestTask(info)
+	assert.NoError(t, task.PreCheck(false))
+	assert.Equal(t, "ls /tmp/test", task.preparedContent)
+
+	// Denied command could not be hidden in parameter
+	info.BuiltinParameters = map[string]string{"Cmd": "rm -rf"}
+	task = newLocalTestTask(info)
+	var violation *policy.Violation
+	assert.True(t, errors.As(task.PreCheck(false), &violation))
+	assert.Equal(t, "no-rm", violation.RuleId)
+}
+
+// useSigningKey requires content signature and trusts the generated key only
+func useSigningKey(t *testing.T) ed25519.PrivateKey {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+	keyringDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(keyringDir, "ci.pem"),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	originalGetKeyringDir := signature.GetKeyringDir
+	signature.GetKeyringDir = func() (string, error) { return keyringDir, nil }
+	t.Cleanup(func() { signature.GetKeyringDir = originalGetKeyringDir })
+	detectContentSignatureRequired = func() (bool, error) { return true, nil }
+	t.Cleanup(func() { detectContentSignatureRequired = flagging.DetectContentSignatureRequired })
+	return privateKey
+}
+
+func signTaskContent(privateKey ed25519.PrivateKey, info *models.RunTaskInfo, content string) {
+	payload := &signature.CommandPayload{
+		ExpireTime:         info.ContentSignatureExpireTime,
+		CommandType:        info.CommandType,
+		EnableParameter:    info.EnableParameter,
+		BuiltinParameters:  info.BuiltinParameters,
+		Timeout:            info.TimeOut,
+		Repeat:             string(info.Repeat),
+		Sandbox:            info.Sandbox,
+		EphemeralContainer: info.EphemeralContainer,
+	}
+	payload.SetContent([]byte(content))
+	info.ContentSignature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, payload.Bytes()))
+}
+
+func TestPreCheckParameterizedSignedContent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Shell script is not supported on Windows")
+	}
+	usePolicyFile(t, `{"defaultEffect":"allow","rules":[{"id":"no-rm","effect":"deny","contentPatterns":["rm\\s+-rf"]}]}`)
+	privateKey := useSigningKey(t)
+
+	template := "{{ACS::Cmd}} /tmp/test"
+	info := models.RunTaskInfo{
+		CommandType:                "RunShellScript",
+		TaskId:                     "local-test",
+		Content:                    base64.StdEncoding.EncodeToString([]byte(template)),
+		EnableParameter:            true,
+		BuiltinParameters:          map[string]string{"Cmd": "ls"},
+		ContentSignatureExpireTime: time.Now().Add(time.Hour).Unix(),
+	}
+	signTaskContent(privateKey, &info, template)
+	task := newLocalTestTask(info)
+	assert.NoError(t, task.PreCheck(false))
+	assert.Equal(t, "ls /tmp/test", task.preparedContent)
+
+	// Parameter values are covered by the signature of template
+	tampered := info
+	tampered.BuiltinParameters = map[string]string{"Cmd": "rm -rf"}
+	task = newLocalTestTask(tampered)
+	assert.True(t, errors.Is(task.PreCheck(false), signature.ErrSignatureInvalid))
+
+	// Signed parameter values are still evaluated by execution policy
+	signTaskContent(privateKey, &tampered, template)
+	task = newLocalTestTask(tampered)
+	var violation *policy.Violation
+	assert.True(t, errors.As(task.PreCheck(false), &violation))
+	assert.Equal(t, "no-rm", violation.RuleId)
+}
@@ -2,13 +2,23 @@ package container
 
 import (
 	"strings"
+	"time"
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 
+	libcri "github.com/aliyun/aliyun_assist_client/agent/container/cri"
+	"github.com/aliyun/aliyun_assist_client/agent/container/model"
+	"github.com/aliyun/aliyun_assist_client/agent/container/podman"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/containerd"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/cri"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/docker"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
+	podmanprocessor "github.com/aliyun/aliyun_assist_client/agent/taskengine/podman"
+)
+
+const (
+	criCheckTimeout = 2 * time.Second
 )
 
 type ContainerCommandOptions struct {
@@ -23,12 +33,53 @@ type ContainerCommandOptions struct {
 	// Additional execution attributes supported by docker
 	WorkingDirectory string
 	Username         string
+	// Kubernetes pod selector, with ContainerName above
+	PodNamespace     string
+	PodName          string
+	PodLabelSelector string
 }
 
 func DetectContainerProcessor(options *ContainerCommandOptions) models.TaskProcessor {
+	// Containers selected by pod metadata can only be found via CRI
+	if model.IsPodSelectorSpecified(options.PodNamespace, options.PodName, options.PodLabelSelector) {
+		return &cri.PodProcessor{
+			TaskId: options.TaskId,
+			Selector: model.PodSelector{
+				Namespace:     options.PodNamespace,
+				PodName:       options.PodName,
+				LabelSelector: options.PodLabelSelector,
+				ContainerName: options.ContainerName,
+			},
+			CommandType: options.CommandType,
+			Timeout:     options.Timeout,
+		}
+	}
+
+	identifierSegments := strings.Split(options.ContainerId, "://")
+	// Runtime of specified container may be chosen explicitly by the prefix of
+	// container identifier in format <runtime>://<container-id>
+	if len(identifierSegments) > 1 {
+		switch identifierSegments[0] {
+		case "podman":
+			return podmanprocessor.NewPodmanProcessor(newDockerProcessor(options, identifierSegments[1]))
+		case "containerd":
+			// Containers in specified namespace can only be accessed via the
+			// native API of containerd. Otherwise CRI is preferred unless the
+			// CRI plugin of containerd is not enabled.
+			if strings.Contains(identifierSegments[1], "/") {
+				return newContainerdProcessor(options, identifierSegments[1])
+			}
+			if err := libcri.CheckRuntimeService("containerd", criCheckTimeout); err != nil {
+				log.GetLogger().WithFields(logrus.Fields{
+					"options": options,
+				}).WithError(err).Infoln("Fallback to native API of containerd since CRI of containerd is not available")
+				return newContainerdProcessor(options, identifierSegments[1])
+			}
+		}
+	}
+
 	// Detecting on Docker runtime (if available) first, but not when container
 	// runtime other than docker specified
-	identifierSegments := strings.Split(options.ContainerId, "://")
 	if len(identifierSegments) == 1 || identifierSegments[0] == "docker" {
 		// Maybe empty if options.ContainerId is just empty string
 		dockerContainerId := identifierSegments[0]
@@ -36,22 +87,28 @@ func DetectContainerProcessor(options *ContainerCommandOptions) models.TaskProce
 			dockerContainerId = identifierSegments[1]
 		}
 
-		dockerProcessor := &docker.DockerProcessor{
-			TaskId: options.TaskId,
-			// Fundamental properties of command process
-			ContainerId:      dockerContainerId,
-			ContainerName:    options.ContainerName,
-			CommandType:      options.CommandType,
-			Timeout:          options.Timeout,
-			WorkingDirectory: options.WorkingDirectory,
-			Username:         options.Username,
-		}
+		dockerProcessor := newDockerProcessor(options, dockerContainerId)
 		if err := docker.CheckDockerProcessor(dockerProcessor); err == nil {
 			return dockerProcessor
 		} else {
 			log.GetLogger().WithFields(logrus.Fields{
 				"options": options,
-			}).WithError(err).Infoln("Fallback to CRI since Docker runtime with API is not available")
+			}).WithError(err).Infoln("Docker runtime with API is not available or container not found")
+		}
+
+		// Then podman, whose REST service is detected cheaply, only when no
+		// runtime specified
+		if len(identifierSegments) == 1 {
+			if _, err := podman.FindEndpoint(); err == nil {
+				podmanProcessor := podmanprocessor.NewPodmanProcessor(newDockerProcessor(options, dockerContainerId))
+				if err := podmanprocessor.CheckPodmanProcessor(podmanProcessor); err == nil {
+					return podmanProcessor
+				} else {
+					log.GetLogger().WithFields(logrus.Fields{
+						"options": options,
+					}).WithError(err).Infoln("Fallback to CRI since podman runtime with API is not available or container not found")
+				}
+			}
 		}
 	}
 
@@ -65,3 +122,29 @@ func DetectContainerProcessor(options *ContainerCommandOptions) models.TaskProce
 		Timeout:             options.Timeout,
 	}
 }
+
+func newDockerProcessor(options *ContainerCommandOptions, containerId string) *docker.DockerProcessor {
+	return &docker.DockerProcessor{
+		TaskId: options.TaskId,
+		// Fundamental properties of command process
+		ContainerId:      containerId,
+		ContainerName:    options.ContainerName,
+		CommandType:      options.CommandType,
+		Timeout:          options.Timeout,
+		WorkingDirectory: options.WorkingDirectory,
+		Username:         options.Username,
+	}
+}
+
+func newContainerdProcessor(options *ContainerCommandOptions, containerIdentifier string) *containerd.ContainerdProcessor {
+	return &containerd.ContainerdProcessor{
+		TaskId: options.TaskId,
+		// Fundamental properties of command process
+		ContainerIdentifier: containerIdentifier,
+		ContainerName:       options.ContainerName,
+		CommandType:         options.CommandType,
+		Timeout:             options.Timeout,
+		WorkingDirectory:    options.WorkingDirectory,
+		Username:            options.Username,
+	}
+}
@@ -0,0 +1,31 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/containerd"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/podman"
+)
+
+func TestDetectContainerProcessorByRuntimePrefix(t *testing.T) {
+	processor := DetectContainerProcessor(&ContainerCommandOptions{
+		TaskId:      "t-podman",
+		ContainerId: "podman://abc",
+		Timeout:     60,
+	})
+	podmanProcessor, ok := processor.(*podman.PodmanProcessor)
+	assert.True(t, ok)
+	assert.Equal(t, "abc", podmanProcessor.ContainerId)
+
+	// Containers in namespace are only accessible via native API of containerd
+	processor = DetectContainerProcessor(&ContainerCommandOptions{
+		TaskId:      "t-containerd",
+		ContainerId: "containerd://k8s.io/abc",
+		Timeout:     60,
+	})
+	containerdProcessor, ok := processor.(*containerd.ContainerdProcessor)
+	assert.True(t, ok)
+	assert.Equal(t, "k8s.io/abc", containerdProcessor.ContainerIdentifier)
+}
@@ -0,0 +1,249 @@
+package containerd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/oci"
+	"github.com/google/uuid"
+
+	libcontainerd "github.com/aliyun/aliyun_assist_client/agent/container/containerd"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
+	"github.com/aliyun/aliyun_assist_client/agent/util/process"
+)
+
+const (
+	defaultTimeout time.Duration = 10 * time.Second
+)
+
+// ContainerdProcessor executes command in containers managed by containerd
+// via its native API, which is useful when CRI plugin of containerd is not
+// enabled or containers are not created by kubelet.
+type ContainerdProcessor struct {
+	TaskId string
+	// Fundamental properties of command process. ContainerIdentifier has been
+	// stripped off the runtime prefix, and would be in format
+	// [<namespace>/]<container-id>
+	ContainerIdentifier string
+	ContainerName       string
+	CommandType         string
+	CommandContent      string
+	Timeout             int
+	// Additional execution attributes supported by containerd
+	WorkingDirectory string
+	Username         string
+
+	// Extracted properties about target container
+	namespace   string
+	containerId string
+	execId      string
+
+	cancelLock sync.Mutex
+	canceled   bool
+	cancel     context.CancelFunc
+}
+
+func (p *ContainerdProcessor) PreCheck() (string, error) {
+	var err error
+	p.namespace, p.containerId, err = libcontainerd.ParseIdentifier(p.ContainerIdentifier)
+	if err != nil {
+		validationErr := taskerrors.NewInvalidContainerIdError()
+		return validationErr.Param(), validationErr
+	}
+
+	client, err := libcontainerd.NewClient(defaultTimeout)
+	if err != nil {
+		validationErr := taskerrors.NewContainerConnectError(err)
+		return validationErr.Param(), validationErr
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	containers, err := libcontainerd.FindContainers(ctx, client, p.namespace, p.containerId)
+	if err != nil {
+		validationErr := taskerrors.NewContainerConnectError(err)
+		return validationErr.Param(), validationErr
+	}
+	if len(containers) == 0 {
+		validationErr := taskerrors.NewContainerNotFoundError()
+		return validationErr.Param(), validationErr
+	}
+	// Container id is only unique in one namespace of containerd
+	if len(containers) > 1 {
+		validationErr := taskerrors.NewContainerNameDuplicatedError()
+		return validationErr.Param(), validationErr
+	}
+
+	p.namespace = containers[0].Namespace
+	if containers[0].State != libcontainerd.StateRunning {
+		validationErr := taskerrors.NewContainerStateAbnormalError(containers[0].State)
+		return validationErr.Param(), validationErr
+	}
+
+	return "", nil
+}
+
+func (p *ContainerdProcessor) Prepare(commandContent string) error {
+	p.CommandContent = commandContent
+	return nil
+}
+
+func (p *ContainerdProcessor) SyncRun(
+	stdoutWriter io.Writer,
+	stderrWriter io.Writer,
+	stdinReader io.Reader) (int, int, error) {
+	runCtx, cancel := context.WithTimeout(libcontainerd.WithNamespace(context.Background(), p.namespace), time.Duration(p.Timeout)*time.Second)
+	defer cancel()
+	if !p.setCancel(cancel) {
+		return 1, process.Success, nil
+	}
+
+	client, err := libcontainerd.NewClient(defaultTimeout)
+	if err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+	defer client.Close()
+
+	// Requests below are not bound to the timeout of command
+	ctx, cancelRequests := context.WithCancel(libcontainerd.WithNamespace(context.Background(), p.namespace))
+	defer cancelRequests()
+	proc, err := p.startExec(ctx, client, stdoutWriter, stderrWriter, stdinReader)
+	if err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+	defer func() {
+		deleteCtx, cancelDelete := context.WithTimeout(libcontainerd.WithNamespace(context.Background(), p.namespace), defaultTimeout)
+		defer cancelDelete()
+		if _, err := proc.Delete(deleteCtx, containerd.WithProcessKill); err != nil {
+			log.GetLogger().WithError(err).Warningf("Failed to delete exec process %s in container %s", p.execId, p.containerId)
+		}
+	}()
+
+	statusC, err := proc.Wait(ctx)
+	if err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+	if err := proc.Start(ctx); err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+
+	var exitStatus containerd.ExitStatus
+	select {
+	case exitStatus = <-statusC:
+	case <-runCtx.Done():
+		// Killing the exec process inside the container, which would not be
+		// terminated with the connection
+		killCtx, cancelKill := context.WithTimeout(libcontainerd.WithNamespace(context.Background(), p.namespace), defaultTimeout)
+		if err := proc.Kill(killCtx, syscall.SIGKILL); err != nil {
+			log.GetLogger().WithError(err).Warningf("Failed to kill exec process %s in container %s", p.execId, p.containerId)
+		}
+		cancelKill()
+		select {
+		case exitStatus = <-statusC:
+		case <-time.After(defaultTimeout):
+		}
+	}
+	exitCode, _, exitErr := exitStatus.Result()
+	return p.conclude(runCtx.Err(), int(exitCode), exitErr)
+}
+
+func (p *ContainerdProcessor) startExec(ctx context.Context, client *containerd.Client, stdoutWriter io.Writer, stderrWriter io.Writer, stdinReader io.Reader) (containerd.Process, error) {
+	container, err := client.LoadContainer(ctx, p.containerId)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.Username != "" {
+		info, err := container.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := oci.WithUser(p.Username)(ctx, client, &info, spec); err != nil {
+			return nil, fmt.Errorf("failed to resolve user %s in container: %w", p.Username, err)
+		}
+	}
+	processSpec := spec.Process
+	processSpec.Terminal = false
+	processSpec.Args = []string{"/bin/sh", "-c", p.CommandContent}
+	if p.WorkingDirectory != "" {
+		processSpec.Cwd = p.WorkingDirectory
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	p.execId = fmt.Sprintf("assist-%s", uuid.New().String())
+	return task.Exec(ctx, p.execId, processSpec, cio.NewCreator(cio.WithStreams(stdinReader, stdoutWriter, stderrWriter)))
+}
+
+// conclude maps result of exec process to that of processor. Canceled command
+// is reported as successful here, since final state has been reported when
+// canceling.
+func (p *ContainerdProcessor) conclude(ctxErr error, exitCode int, exitErr error) (int, int, error) {
+	if p.isCanceled() {
+		return exitCode, process.Success, nil
+	}
+	if ctxErr == context.DeadlineExceeded {
+		return 1, process.Timeout, errors.New("timeout")
+	}
+	if exitErr != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(exitErr)
+	}
+	return exitCode, process.Success, nil
+}
+
+// setCancel records cancel function of running command, and returns false if
+// the processor has been canceled
+func (p *ContainerdProcessor) setCancel(cancel context.CancelFunc) bool {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+	if p.canceled {
+		return false
+	}
+	p.cancel = cancel
+	return true
+}
+
+func (p *ContainerdProcessor) isCanceled() bool {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+	return p.canceled
+}
+
+func (p *ContainerdProcessor) Cancel() {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+	p.canceled = true
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *ContainerdProcessor) Cleanup(removeScriptFile bool) error {
+	return nil
+}
+
+func (p *ContainerdProcessor) SideEffect() error {
+	return nil
+}
+
+func (p *ContainerdProcessor) ExtraLubanParams() string {
+	if p.containerId == "" {
+		return fmt.Sprintf("&containerId=%s&containerName=%s", p.ContainerIdentifier, p.ContainerName)
+	}
+
+	return fmt.Sprintf("&containerId=%s&containerName=%s", p.containerId, p.ContainerName)
+}
@@ -0,0 +1,49 @@
+package containerd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/util/process"
+)
+
+func TestPreCheckInvalidIdentifier(t *testing.T) {
+	p := &ContainerdProcessor{ContainerIdentifier: "k8s.io/"}
+	param, err := p.PreCheck()
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidContainerId", param)
+}
+
+func TestConclude(t *testing.T) {
+	p := &ContainerdProcessor{}
+	exitCode, status, err := p.conclude(nil, 3, nil)
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, process.Success, status)
+	assert.NoError(t, err)
+
+	_, status, err = p.conclude(context.DeadlineExceeded, 137, nil)
+	assert.Equal(t, process.Timeout, status)
+	assert.Error(t, err)
+
+	_, status, err = p.conclude(nil, 0, errors.New("broken"))
+	assert.Equal(t, process.Fail, status)
+	assert.Error(t, err)
+
+	// Canceled command is not reported as failure
+	p.Cancel()
+	exitCode, status, err = p.conclude(context.Canceled, 137, nil)
+	assert.Equal(t, 137, exitCode)
+	assert.Equal(t, process.Success, status)
+	assert.NoError(t, err)
+}
+
+func TestSyncRunAfterCancel(t *testing.T) {
+	p := &ContainerdProcessor{ContainerIdentifier: "abc", Timeout: 10}
+	p.Cancel()
+	_, status, err := p.SyncRun(nil, nil, nil)
+	assert.Equal(t, process.Success, status)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,281 @@
+package cri
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/probe/exec"
+	utilexec "k8s.io/utils/exec"
+
+	libcri "github.com/aliyun/aliyun_assist_client/agent/container/cri"
+	"github.com/aliyun/aliyun_assist_client/agent/container/model"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
+	"github.com/aliyun/aliyun_assist_client/agent/util/process"
+)
+
+// Execution status of command in one container of pod, reported along with
+// exit code of each container
+const (
+	containerStatusFinished = "finished"
+	containerStatusFailed   = "failed"
+	containerStatusTimeout  = "timeout"
+	containerStatusCanceled = "canceled"
+)
+
+// containerResult is the execution result of command in one container
+type containerResult struct {
+	exitCode int
+	status   string
+	err      error
+}
+
+type execSyncResult struct {
+	stdout []byte
+	stderr []byte
+	err    error
+}
+
+// PodProcessor executes command in every container matching the pod selector
+// on Kubernetes nodes. Containers are resolved from pod metadata returned by
+// CRI, and executed one by one with results reported per container.
+type PodProcessor struct {
+	TaskId string
+	// Fundamental properties of command process
+	Selector       model.PodSelector
+	CommandType    string
+	CommandContent string
+	Timeout        int
+
+	// Containers matching the selector and their connections
+	connections []*containerConnection
+	matched     []model.Container
+	// Execution results of containers, in the same order as connections, and
+	// the channel closed on canceling. Both are guarded by lock since the
+	// processor is canceled and reported from other goroutines.
+	lock     sync.Mutex
+	results  []containerResult
+	canceled chan struct{}
+}
+
+func (p *PodProcessor) PreCheck() (string, error) {
+	if err := p.Selector.Validate(); err != nil {
+		validationErr := taskerrors.NewInvalidPodSelectorError(err)
+		return validationErr.Param(), validationErr
+	}
+	return "", nil
+}
+
+func (p *PodProcessor) Prepare(commandContent string) error {
+	runtimeContainersList, err := libcri.ListRuntimeContainers(10*time.Second, false)
+	if err != nil {
+		return taskerrors.NewContainerConnectError(err)
+	}
+	if len(runtimeContainersList) == 0 {
+		return taskerrors.NewContainerConnectError(fmt.Errorf("No available container runtime to find container for executing command"))
+	}
+
+	for _, runtimeContainers := range runtimeContainersList {
+		for _, container := range p.Selector.Select(runtimeContainers.Containers) {
+			p.connections = append(p.connections, &containerConnection{
+				runtimeService: runtimeContainers.Service,
+				containerId:    container.Id,
+				containerName:  container.Name,
+			})
+			p.matched = append(p.matched, container)
+		}
+	}
+	if len(p.connections) == 0 {
+		return taskerrors.NewPodContainerNotFoundError()
+	}
+	log.GetLogger().Infof("Found %d containers matching pod selector for task %s", len(p.connections), p.TaskId)
+
+	p.CommandContent = commandContent
+	return nil
+}
+
+func (p *PodProcessor) SyncRun(
+	stdoutWriter io.Writer,
+	stderrWriter io.Writer,
+	stdinReader io.Reader) (int, int, error) {
+	compiledCommand := []string{"/bin/sh", "-c", p.CommandContent}
+	deadline := time.Now().Add(time.Duration(p.Timeout) * time.Second)
+	canceled := p.canceledC()
+
+	p.lock.Lock()
+	p.results = make([]containerResult, len(p.connections))
+	p.lock.Unlock()
+	for i, connection := range p.connections {
+		container := p.matched[i]
+		select {
+		case <-canceled:
+			p.setResult(i, containerResult{exitCode: 1, status: containerStatusCanceled})
+			continue
+		default:
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			p.setResult(i, containerResult{exitCode: 1, status: containerStatusTimeout, err: errors.New("timeout")})
+			continue
+		}
+
+		fmt.Fprintf(stdoutWriter, "==> %s/%s/%s (%s) <==\n", container.PodNamespace, container.PodName, container.Name, container.Id)
+		// ExecSync of CRI provides no way to terminate the exec process, thus
+		// on canceling the processor stops waiting and leaves the process to
+		// be ended by the timeout passed to runtime.
+		execResultC := make(chan execSyncResult, 1)
+		go func(connection *containerConnection, timeout time.Duration) {
+			stdout, stderr, err := connection.runtimeService.ExecSync(connection.containerId, compiledCommand, timeout)
+			execResultC <- execSyncResult{stdout: stdout, stderr: stderr, err: err}
+		}(connection, remaining)
+
+		var execResult execSyncResult
+		select {
+		case execResult = <-execResultC:
+		case <-canceled:
+			p.setResult(i, containerResult{exitCode: 1, status: containerStatusCanceled})
+			fmt.Fprintf(stdoutWriter, "<== canceled in %s\n", container.Id)
+			continue
+		}
+		stdoutWriter.Write(execResult.stdout)
+		stderrWriter.Write(execResult.stderr)
+
+		result := concludeContainer(execResult.err)
+		p.setResult(i, result)
+		if result.status == containerStatusFinished {
+			fmt.Fprintf(stdoutWriter, "<== exit code of %s: %d\n", container.Id, result.exitCode)
+		} else {
+			fmt.Fprintf(stdoutWriter, "<== %s in %s: %v\n", result.status, container.Id, result.err)
+		}
+	}
+	return p.conclude()
+}
+
+// concludeContainer maps error returned by ExecSync to result of one container
+func concludeContainer(err error) containerResult {
+	if err == nil {
+		return containerResult{exitCode: 0, status: containerStatusFinished}
+	}
+	var exitcodeErr *utilexec.CodeExitError
+	var timeoutErr *exec.TimeoutError
+	if errors.As(err, &exitcodeErr) {
+		return containerResult{exitCode: exitcodeErr.Code, status: containerStatusFinished}
+	} else if errors.As(err, &timeoutErr) {
+		return containerResult{exitCode: 1, status: containerStatusTimeout, err: err}
+	}
+	return containerResult{exitCode: 1, status: containerStatusFailed, err: taskerrors.NewContainerRuntimeInternalError(err)}
+}
+
+// conclude summarizes results of all containers into that of processor.
+// Canceled command is reported as successful here, since final state has been
+// reported when canceling. Otherwise the first failure of containers is
+// reported, and exit code of each container is reported via ExtraLubanParams.
+func (p *PodProcessor) conclude() (int, int, error) {
+	if p.isCanceled() {
+		return 1, process.Success, nil
+	}
+	results := p.getResults()
+	for _, result := range results {
+		if result.status == containerStatusTimeout {
+			return 1, process.Timeout, result.err
+		}
+	}
+	for _, result := range results {
+		if result.status == containerStatusFailed {
+			return 1, process.Fail, result.err
+		}
+	}
+	// The first non-zero exit code is reported as the exit code of whole
+	// invocation
+	for _, result := range results {
+		if result.exitCode != 0 {
+			return result.exitCode, process.Success, nil
+		}
+	}
+	return 0, process.Success, nil
+}
+
+func (p *PodProcessor) setResult(index int, result containerResult) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.results[index] = result
+}
+
+func (p *PodProcessor) getResults() []containerResult {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]containerResult(nil), p.results...)
+}
+
+func (p *PodProcessor) canceledC() chan struct{} {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.canceledLocked()
+}
+
+func (p *PodProcessor) canceledLocked() chan struct{} {
+	if p.canceled == nil {
+		p.canceled = make(chan struct{})
+	}
+	return p.canceled
+}
+
+func (p *PodProcessor) isCanceled() bool {
+	select {
+	case <-p.canceledC():
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *PodProcessor) Cancel() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	canceled := p.canceledLocked()
+	select {
+	case <-canceled:
+	default:
+		close(canceled)
+	}
+}
+
+func (p *PodProcessor) Cleanup(removeScriptFile bool) error {
+	return nil
+}
+
+func (p *PodProcessor) SideEffect() error {
+	return nil
+}
+
+func (p *PodProcessor) ExtraLubanParams() string {
+	if len(p.connections) == 0 {
+		return fmt.Sprintf("&containerName=%s", p.Selector.ContainerName)
+	}
+
+	containerIds := make([]string, 0, len(p.connections))
+	containerNames := make([]string, 0, len(p.connections))
+	for _, connection := range p.connections {
+		containerIds = append(containerIds, connection.containerId)
+		containerNames = append(containerNames, connection.containerName)
+	}
+	params := fmt.Sprintf("&containerId=%s&containerName=%s", strings.Join(containerIds, ","), strings.Join(containerNames, ","))
+	results := p.getResults()
+	if len(results) == 0 {
+		return params
+	}
+
+	// Results of containers are listed in the same order as containerId
+	exitCodes := make([]string, 0, len(results))
+	statuses := make([]string, 0, len(results))
+	for _, result := range results {
+		exitCodes = append(exitCodes, strconv.Itoa(result.exitCode))
+		statuses = append(statuses, result.status)
+	}
+	return params + fmt.Sprintf("&containerExitCode=%s&containerStatus=%s", strings.Join(exitCodes, ","), strings.Join(statuses, ","))
+}
@@ -0,0 +1,122 @@
+package cri
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	criapis "k8s.io/cri-api/pkg/apis"
+	utilexec "k8s.io/utils/exec"
+
+	"github.com/aliyun/aliyun_assist_client/agent/container/model"
+	"github.com/aliyun/aliyun_assist_client/agent/util/process"
+)
+
+// fakeRuntimeService only implements ExecSync, which returns the result
+// registered for each container
+type fakeRuntimeService struct {
+	criapis.RuntimeService
+	results map[string]error
+	block   chan struct{}
+}
+
+func (s *fakeRuntimeService) ExecSync(containerID string, cmd []string, timeout time.Duration) ([]byte, []byte, error) {
+	if s.block != nil {
+		<-s.block
+	}
+	return []byte("out of " + containerID + "\n"), nil, s.results[containerID]
+}
+
+func newFakePodProcessor(service *fakeRuntimeService, containerIds ...string) *PodProcessor {
+	p := &PodProcessor{
+		TaskId:         "t-test",
+		CommandContent: "echo hello",
+		Timeout:        60,
+	}
+	for _, containerId := range containerIds {
+		p.connections = append(p.connections, &containerConnection{
+			runtimeService: service,
+			containerId:    containerId,
+			containerName:  "name-" + containerId,
+		})
+		p.matched = append(p.matched, model.Container{
+			Id:           containerId,
+			Name:         "name-" + containerId,
+			PodName:      "pod",
+			PodNamespace: "default",
+		})
+	}
+	return p
+}
+
+func TestPodProcessorReportsEachContainer(t *testing.T) {
+	service := &fakeRuntimeService{
+		results: map[string]error{
+			"c2": &utilexec.CodeExitError{Err: errors.New("exit 3"), Code: 3},
+			"c3": errors.New("connection reset"),
+		},
+	}
+	p := newFakePodProcessor(service, "c1", "c2", "c3", "c4")
+
+	var stdout, stderr bytes.Buffer
+	exitCode, status, err := p.SyncRun(&stdout, &stderr, nil)
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, process.Fail, status)
+	assert.Error(t, err)
+	// Containers after the failed one are still executed
+	assert.Contains(t, stdout.String(), "out of c4")
+	assert.Contains(t, stdout.String(), "<== exit code of c2: 3")
+	assert.Equal(t, "&containerId=c1,c2,c3,c4&containerName=name-c1,name-c2,name-c3,name-c4&containerExitCode=0,3,1,0&containerStatus=finished,finished,failed,finished",
+		p.ExtraLubanParams())
+}
+
+func TestPodProcessorFirstNonZeroExitCode(t *testing.T) {
+	service := &fakeRuntimeService{
+		results: map[string]error{
+			"c2": &utilexec.CodeExitError{Err: errors.New("exit 2"), Code: 2},
+			"c3": &utilexec.CodeExitError{Err: errors.New("exit 5"), Code: 5},
+		},
+	}
+	p := newFakePodProcessor(service, "c1", "c2", "c3")
+
+	var stdout, stderr bytes.Buffer
+	exitCode, status, err := p.SyncRun(&stdout, &stderr, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, process.Success, status)
+	assert.Equal(t, 2, exitCode)
+	assert.Contains(t, p.ExtraLubanParams(), "&containerExitCode=0,2,5&containerStatus=finished,finished,finished")
+}
+
+func TestPodProcessorCancel(t *testing.T) {
+	service := &fakeRuntimeService{
+		block: make(chan struct{}),
+	}
+	defer close(service.block)
+	p := newFakePodProcessor(service, "c1", "c2")
+
+	type syncRunResult struct {
+		exitCode int
+		status   int
+		err      error
+	}
+	resultC := make(chan syncRunResult, 1)
+	go func() {
+		var stdout, stderr bytes.Buffer
+		exitCode, status, err := p.SyncRun(&stdout, &stderr, nil)
+		resultC <- syncRunResult{exitCode, status, err}
+	}()
+
+	p.Cancel()
+	// Canceling twice must not panic
+	p.Cancel()
+	select {
+	case result := <-resultC:
+		assert.Equal(t, process.Success, result.status)
+		assert.NoError(t, result.err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("SyncRun does not return after canceled")
+	}
+	assert.Contains(t, p.ExtraLubanParams(), "&containerStatus=canceled,canceled")
+}
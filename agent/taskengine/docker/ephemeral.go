@@ -0,0 +1,314 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	dockerclient "github.com/docker/docker/client"
+	dockerstdcopy "github.com/docker/docker/pkg/stdcopy"
+	"github.com/google/uuid"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
+	"github.com/aliyun/aliyun_assist_client/agent/util/process"
+)
+
+const (
+	PullPolicyAlways       = "Always"
+	PullPolicyIfNotPresent = "IfNotPresent"
+	PullPolicyNever        = "Never"
+
+	defaultImagePullTimeout time.Duration = 10 * time.Minute
+	ephemeralLabelTaskId                  = "com.aliyun.assist.task-id"
+)
+
+// EphemeralProcessor executes command in a short-lived container created from
+// specified image, which is removed after command finished. Tooling needed by
+// the command is thus isolated from the host.
+type EphemeralProcessor struct {
+	TaskId        string
+	InvokeVersion int
+	// Fundamental properties of command process
+	Container      models.EphemeralContainerInfo
+	CommandType    string
+	CommandContent string
+	Timeout        int
+	// Additional execution attributes supported by docker
+	WorkingDirectory string
+	Username         string
+
+	client        *dockerclient.Client
+	mounts        []mount.Mount
+	containerId   string
+	containerName string
+}
+
+func (p *EphemeralProcessor) PreCheck() (string, error) {
+	if p.Container.Image == "" {
+		validationErr := taskerrors.NewInvalidEphemeralContainerError(errors.New("image must be specified"))
+		return validationErr.Param(), validationErr
+	}
+	switch p.Container.PullPolicy {
+	case "":
+		p.Container.PullPolicy = PullPolicyIfNotPresent
+	case PullPolicyAlways, PullPolicyIfNotPresent, PullPolicyNever:
+	default:
+		validationErr := taskerrors.NewInvalidEphemeralContainerError(fmt.Errorf("unknown pull policy %s", p.Container.PullPolicy))
+		return validationErr.Param(), validationErr
+	}
+	if p.Container.Cpus < 0 || p.Container.MemoryLimit < 0 {
+		validationErr := taskerrors.NewInvalidEphemeralContainerError(errors.New("resource limits must not be negative"))
+		return validationErr.Param(), validationErr
+	}
+
+	var err error
+	if p.mounts, err = resolveMounts(p.TaskId, p.Container.Mounts); err != nil {
+		validationErr := taskerrors.NewInvalidEphemeralContainerError(err)
+		return validationErr.Param(), validationErr
+	}
+	// Network of the host or other containers would be exposed to the command
+	// in modes other than isolated ones
+	if violation := policy.CheckNetworkMode(&policy.NetworkModeRequest{
+		TaskId:      p.TaskId,
+		NetworkMode: p.Container.NetworkMode,
+	}); violation != nil {
+		validationErr := taskerrors.NewInvalidEphemeralContainerError(violation)
+		return validationErr.Param(), validationErr
+	}
+
+	p.client, err = dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+	if err != nil {
+		validationErr := taskerrors.NewContainerConnectError(err)
+		return validationErr.Param(), validationErr
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	if _, err := p.client.Ping(ctx); err != nil {
+		validationErr := taskerrors.NewContainerConnectError(err)
+		return validationErr.Param(), validationErr
+	}
+
+	return "", nil
+}
+
+// resolveMounts validates host paths to be bind-mounted against mount sources
+// allowed by the local execution policy. Symbolic links in host paths are
+// resolved before checking, and resolved paths are mounted, so that a link
+// under allowed paths could not expose other paths into the container.
+func resolveMounts(taskId string, mounts []models.EphemeralMount) ([]mount.Mount, error) {
+	resolved := make([]mount.Mount, 0, len(mounts))
+	for _, m := range mounts {
+		if !path.IsAbs(m.Target) || !filepath.IsAbs(m.Source) {
+			return nil, fmt.Errorf("invalid mount from %s to %s", m.Source, m.Target)
+		}
+		source, err := filepath.EvalSymlinks(m.Source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mount from %s to %s: %w", m.Source, m.Target, err)
+		}
+		if violation := policy.CheckMount(&policy.MountRequest{
+			TaskId: taskId,
+			Source: source,
+		}); violation != nil {
+			return nil, violation
+		}
+		resolved = append(resolved, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+	return resolved, nil
+}
+
+// ephemeralContainerName generates name of ephemeral container with random
+// suffix, since the same invocation could be retried or repeated while the
+// container of last run has not been removed.
+func ephemeralContainerName(taskId string, invokeVersion int) string {
+	return fmt.Sprintf("assist-%s-%d-%s", taskId, invokeVersion, strings.ReplaceAll(uuid.New().String(), "-", "")[:8])
+}
+
+func (p *EphemeralProcessor) Prepare(commandContent string) error {
+	if err := p.ensureImage(); err != nil {
+		return err
+	}
+
+	p.CommandContent = commandContent
+	return nil
+}
+
+func (p *EphemeralProcessor) ensureImage() error {
+	if p.Container.PullPolicy != PullPolicyAlways {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+		_, _, err := p.client.ImageInspectWithRaw(ctx, p.Container.Image)
+		if err == nil {
+			return nil
+		}
+		if !dockerclient.IsErrNotFound(err) {
+			return taskerrors.NewContainerRuntimeInternalError(err)
+		}
+		if p.Container.PullPolicy == PullPolicyNever {
+			return taskerrors.NewContainerImagePullError(fmt.Errorf("image %s not present and pull policy is Never", p.Container.Image))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultImagePullTimeout)
+	defer cancel()
+	progress, err := p.client.ImagePull(ctx, p.Container.Image, types.ImagePullOptions{})
+	if err != nil {
+		return taskerrors.NewContainerImagePullError(err)
+	}
+	defer progress.Close()
+	// Pulling is only completed after the progress stream is drained
+	if _, err := io.Copy(io.Discard, progress); err != nil {
+		return taskerrors.NewContainerImagePullError(err)
+	}
+	return nil
+}
+
+func (p *EphemeralProcessor) SyncRun(
+	stdoutWriter io.Writer,
+	stderrWriter io.Writer,
+	stdinReader io.Reader) (int, int, error) {
+	env := make([]string, 0, len(p.Container.Env))
+	for key, value := range p.Container.Env {
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+	config := &container.Config{
+		Image:        p.Container.Image,
+		Cmd:          []string{"/bin/sh", "-c", p.CommandContent},
+		Env:          env,
+		User:         p.Username,
+		WorkingDir:   p.WorkingDirectory,
+		AttachStdout: true,
+		AttachStderr: true,
+		Labels: map[string]string{
+			ephemeralLabelTaskId: p.TaskId,
+		},
+	}
+	hostConfig := &container.HostConfig{
+		Mounts:      p.mounts,
+		NetworkMode: container.NetworkMode(p.Container.NetworkMode),
+		Resources: container.Resources{
+			NanoCPUs: int64(p.Container.Cpus * 1e9),
+			Memory:   p.Container.MemoryLimit,
+		},
+	}
+	p.containerName = ephemeralContainerName(p.TaskId, p.InvokeVersion)
+
+	createCtx, createCancel := context.WithTimeout(context.Background(), defaultTimeout)
+	created, err := p.client.ContainerCreate(createCtx, config, hostConfig, nil, nil, p.containerName)
+	createCancel()
+	if err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+	p.containerId = created.ID
+
+	// Attach before starting the container, otherwise output produced at the
+	// very beginning would be lost
+	attachCtx, attachCancel := context.WithTimeout(context.Background(), defaultTimeout)
+	hijackedResponse, err := p.client.ContainerAttach(attachCtx, p.containerId, types.ContainerAttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	attachCancel()
+	if err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+	defer hijackedResponse.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(p.Timeout)*time.Second)
+	defer cancel()
+	waitResponses, waitErrs := p.client.ContainerWait(ctx, p.containerId, container.WaitConditionNextExit)
+
+	startCtx, startCancel := context.WithTimeout(context.Background(), defaultTimeout)
+	err = p.client.ContainerStart(startCtx, p.containerId, types.ContainerStartOptions{})
+	startCancel()
+	if err != nil {
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+
+	if stdoutWriter == nil {
+		stdoutWriter = io.Discard
+	}
+	if stderrWriter == nil {
+		stderrWriter = io.Discard
+	}
+	streamed := make(chan error, 1)
+	go func() {
+		_, err := dockerstdcopy.StdCopy(stdoutWriter, stderrWriter, hijackedResponse.Reader)
+		streamed <- err
+	}()
+
+	select {
+	case waitResponse := <-waitResponses:
+		// Wait for remaining output to be copied
+		<-streamed
+		if waitResponse.Error != nil && waitResponse.Error.Message != "" {
+			return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(errors.New(waitResponse.Error.Message))
+		}
+		return int(waitResponse.StatusCode), process.Success, nil
+	case err := <-waitErrs:
+		if ctx.Err() == context.DeadlineExceeded {
+			p.kill()
+			return 1, process.Timeout, errors.New("timeout")
+		}
+		return 1, process.Fail, taskerrors.NewContainerRuntimeInternalError(err)
+	}
+}
+
+func (p *EphemeralProcessor) kill() {
+	if p.containerId == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	if err := p.client.ContainerKill(ctx, p.containerId, "SIGKILL"); err != nil {
+		log.GetLogger().WithFields(logrus.Fields{
+			"containerId": p.containerId,
+		}).WithError(err).Warningln("Failed to kill ephemeral container")
+	}
+}
+
+func (p *EphemeralProcessor) Cancel() {
+	p.kill()
+}
+
+// Cleanup removes the ephemeral container whatever the command finished.
+func (p *EphemeralProcessor) Cleanup(removeScriptFile bool) error {
+	if p.containerId == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	return p.client.ContainerRemove(ctx, p.containerId, types.ContainerRemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	})
+}
+
+func (p *EphemeralProcessor) SideEffect() error {
+	return nil
+}
+
+func (p *EphemeralProcessor) ExtraLubanParams() string {
+	if p.containerId == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("&containerId=%s&containerName=%s", p.containerId, strings.TrimPrefix(p.containerName, "/"))
+}
@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
+)
+
+func TestEphemeralContainerName(t *testing.T) {
+	name := ephemeralContainerName("t-123", 2)
+	assert.True(t, strings.HasPrefix(name, "assist-t-123-2-"))
+	assert.Len(t, strings.TrimPrefix(name, "assist-t-123-2-"), 8)
+	// Retried or repeated run must not collide with container of last run
+	assert.NotEqual(t, name, ephemeralContainerName("t-123", 2))
+}
+
+// usePolicyFile makes policy loaded from file under temporary directory, which
+// does not exist until written
+func usePolicyFile(t *testing.T) (string, string) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chmod(tempDir, 0755))
+	policyPath := filepath.Join(tempDir, policy.PolicyFilename)
+	originalGetPolicyPath := policy.GetPolicyPath
+	policy.GetPolicyPath = func() (string, error) { return policyPath, nil }
+	t.Cleanup(func() { policy.GetPolicyPath = originalGetPolicyPath })
+	return tempDir, policyPath
+}
+
+func TestResolveMounts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Ephemeral container is only supported on Linux")
+	}
+	tempDir, policyPath := usePolicyFile(t)
+
+	mounts, err := resolveMounts("t-1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, mounts)
+
+	_, err = resolveMounts("t-1", []models.EphemeralMount{{Source: "relative", Target: "/data"}})
+	assert.Error(t, err)
+	_, err = resolveMounts("t-1", []models.EphemeralMount{{Source: tempDir, Target: "relative"}})
+	assert.Error(t, err)
+	_, err = resolveMounts("t-1", []models.EphemeralMount{{Source: filepath.Join(tempDir, "missing"), Target: "/data"}})
+	assert.Error(t, err)
+
+	// Host paths could not be mounted without policy file
+	dataDir := filepath.Join(tempDir, "data")
+	assert.NoError(t, os.Mkdir(dataDir, 0755))
+	_, err = resolveMounts("t-1", []models.EphemeralMount{{Source: dataDir, Target: "/data"}})
+	var violation *policy.Violation
+	if assert.True(t, errors.As(err, &violation)) {
+		assert.Equal(t, policy.RuleIdMountSource, violation.RuleId)
+	}
+
+	if os.Geteuid() != 0 {
+		t.Skip("Policy file is only trusted when owned by root")
+	}
+	assert.NoError(t, os.WriteFile(policyPath, []byte(`{"mountSources": ["`+dataDir+`"]}`), 0644))
+	mounts, err = resolveMounts("t-1", []models.EphemeralMount{{Source: dataDir, Target: "/data", ReadOnly: true}})
+	assert.NoError(t, err)
+	if assert.Len(t, mounts, 1) {
+		assert.Equal(t, dataDir, mounts[0].Source)
+		assert.True(t, mounts[0].ReadOnly)
+	}
+
+	// Links under allowed paths are resolved before checking
+	link := filepath.Join(dataDir, "link")
+	assert.NoError(t, os.Symlink("/", link))
+	_, err = resolveMounts("t-1", []models.EphemeralMount{{Source: link, Target: "/host"}})
+	if assert.True(t, errors.As(err, &violation)) {
+		assert.Equal(t, policy.RuleIdMountSource, violation.RuleId)
+		assert.Contains(t, violation.Reason, "mounting host path / denied")
+	}
+}
+
+func TestPreCheckNetworkMode(t *testing.T) {
+	usePolicyFile(t)
+	p := &EphemeralProcessor{
+		TaskId: "t-1",
+		Container: models.EphemeralContainerInfo{
+			Image:       "alpine",
+			NetworkMode: "host",
+		},
+	}
+	_, err := p.PreCheck()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), policy.RuleIdNetworkMode)
+	}
+}
@@ -42,6 +42,9 @@ type DockerProcessor struct {
 	// Additional execution attributes supported by docker
 	WorkingDirectory string
 	Username         string
+	// Endpoint of service compatible with Docker Engine API. Docker daemon
+	// configured from environment variables is used when empty.
+	Endpoint string
 
 	client          *dockerclient.Client
 	foundContainers []types.Container
@@ -66,8 +69,12 @@ func CheckDockerProcessor(p *DockerProcessor) error {
 		}
 	}
 
+	clientOpts := []dockerclient.Opt{dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation()}
+	if p.Endpoint != "" {
+		clientOpts = append(clientOpts, dockerclient.WithHost(p.Endpoint))
+	}
 	var err error
-	p.client, err = dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+	p.client, err = dockerclient.NewClientWithOpts(clientOpts...)
 	if err != nil {
 		return taskerrors.NewContainerConnectError(err)
 	}
@@ -121,9 +121,11 @@ func parseTaskInfo(jsonStr string) (int, *taskCollection) {
 	return task_lists.Code, taskInfos
 }
 
-func FetchTaskList(reason FetchReason, taskId string, taskType int, isColdstart bool) *taskCollection {
+// FetchTaskList pulls tasks from server, and returns error when the server
+// could not be requested successfully
+func FetchTaskList(reason FetchReason, taskId string, taskType int, isColdstart bool) (*taskCollection, error) {
 	if util.GetServerHost() == "" {
-		return newTaskCollection()
+		return newTaskCollection(), ErrNoAvailableHost
 	}
 
 	url := util.GetFetchTaskListService()
@@ -136,7 +138,7 @@ func FetchTaskList(reason FetchReason, taskId string, taskType int, isColdstart
 		log.GetLogger().WithFields(logrus.Fields{
 			"reason": reason,
 		}).Errorln("Invalid reason for fetching tasks")
-		return newTaskCollection()
+		return newTaskCollection(), fmt.Errorf("invalid reason for fetching tasks: %s", reason)
 	}
 	if taskType == SessionTaskType {
 		url = util.GetFetchSessionTaskListService()
@@ -164,7 +166,7 @@ func FetchTaskList(reason FetchReason, taskId string, taskType int, isColdstart
 			response, err = util.HttpPostWithTimeout(url, "", "", 8, false)
 		}
 		if err != nil {
-			return newTaskCollection()
+			return newTaskCollection(), err
 		}
 		code, taskInfos = parseTaskInfo(response)
 		if code == 408 {
@@ -173,8 +175,11 @@ func FetchTaskList(reason FetchReason, taskId string, taskType int, isColdstart
 		}
 		break
 	}
+	if code == 408 {
+		return taskInfos, ErrFetchTimeout
+	}
 
-	return taskInfos
+	return taskInfos, nil
 }
 
 func (t *taskInfo) toRunTaskInfo(instanceId string) (models.RunTaskInfo, error) {
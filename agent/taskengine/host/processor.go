@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 	"github.com/hectane/go-acl"
@@ -35,10 +36,15 @@ type HostProcessor struct {
 	WorkingDirectory    string
 	Username            string
 	WindowsUserPassword string
+	// Run shell script within full login environment of the user
+	LoginShell bool
+	// Restrict privileges of command process running as root
+	Sandbox *process.SandboxOptions
 
 	// Detected properties for command process in host
 	envHomeDir     string
 	realWorkingDir string
+	loginCommand   string
 
 	// Generated variables to invoke command process
 	scriptFilePath    string
@@ -70,6 +76,27 @@ func (p *HostProcessor) PreCheck() (string, error) {
 	}
 
 	var err error
+	if p.Sandbox != nil {
+		if p.Username != "" || p.LoginShell {
+			return "sandbox", taskerrors.NewInvalidSandboxError(errors.New("sandbox could only be applied to command running as root without login shell"))
+		}
+		if err = process.ValidateSandbox(p.Sandbox); err != nil {
+			return "sandbox", taskerrors.NewInvalidSandboxError(err)
+		}
+	}
+
+	if p.LoginShell {
+		if !loginShellSupported {
+			return "loginShell", taskerrors.NewLoginShellNotSupportedError(runtime.GOOS)
+		}
+		if p.CommandType == "RunShellScript" {
+			p.loginCommand, err = findLoginCommand()
+			if err != nil {
+				return "loginShell", taskerrors.NewLoginShellNotAvailableError(err)
+			}
+		}
+	}
+
 	p.envHomeDir, err = p.checkHomeDirectory()
 	if err != nil {
 		taskLogger.WithError(err).Warningln("Invalid HOME directory for invocation")
@@ -195,6 +222,11 @@ func (p *HostProcessor) Prepare(commandContent string) error {
 		if _, err := executil.LookPath(p.invokeCommand); err != nil {
 			return taskerrors.NewSystemDefaultShellNotFoundError(err)
 		}
+
+		if p.LoginShell {
+			p.invokeCommand, p.invokeCommandArgs = p.loginShellCommand(p.invokeCommandArgs[1], useScriptFile)
+			taskLogger.Infof("Run script in login shell via %s", p.invokeCommand)
+		}
 	} else if p.CommandType == "RunPowerShellScript" {
 		p.invokeCommand = "powershell"
 		if useScriptFile {
@@ -226,16 +258,21 @@ func (p *HostProcessor) SyncRun(
 	stdoutWriter io.Writer,
 	stderrWriter io.Writer,
 	stdinReader io.Reader) (int, int, error) {
-	if p.Username != "" {
+	// Login shell switches user and sets up environment by itself
+	loginShell := p.LoginShell && p.CommandType == "RunShellScript"
+	if p.Username != "" && !loginShell {
 		p.processCmd.SetUserInfo(p.Username)
 	}
 	if p.WindowsUserPassword != "" {
 		p.processCmd.SetPasswordInfo(p.WindowsUserPassword)
 	}
 	// Fix $HOME environment variable undex *nix
-	if p.envHomeDir != "" {
+	if p.envHomeDir != "" && !loginShell {
 		p.processCmd.SetHomeDir(p.envHomeDir)
 	}
+	if p.Sandbox != nil {
+		p.processCmd.SetSandbox(p.Sandbox)
+	}
 
 	var err error
 	p.exitCode, p.resultStatus, err = p.processCmd.SyncRun(p.realWorkingDir, p.invokeCommand, p.invokeCommandArgs, stdoutWriter, stderrWriter, stdinReader, nil, p.Timeout)
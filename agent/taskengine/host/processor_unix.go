@@ -5,12 +5,14 @@ package host
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
 	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/common/executil"
 )
 
 var (
@@ -77,3 +79,44 @@ func (p *HostProcessor) checkWorkingDirectory() (string, error) {
 	taskLogger.Warningln("Failed to detect working directory and would use working directory of agent by default")
 	return "", nil
 }
+
+const loginShellSupported = true
+
+// findLoginCommand looks up the command to start login shell of specified
+// user. runuser is preferred since it never prompts for password under root,
+// while su is available on more systems like FreeBSD.
+func findLoginCommand() (string, error) {
+	var lastErr error
+	for _, candidate := range []string{"runuser", "su"} {
+		commandPath, err := executil.LookPath(candidate)
+		if err == nil {
+			return commandPath, nil
+		}
+		lastErr = err
+	}
+	return "", lastErr
+}
+
+// loginShellCommand wraps the script to be run like `su - <user> -c script`,
+// i.e., in the login shell of the user with environment cleared, PAM session
+// modules like pam_limits and pam_umask applied and profile sourced. Login
+// shell always starts at home directory, thus the working directory is
+// entered explicitly.
+func (p *HostProcessor) loginShellCommand(script string, isScriptFile bool) (string, []string) {
+	username := p.Username
+	if username == "" {
+		username = "root"
+	}
+	commandLine := script
+	if isScriptFile {
+		commandLine = quoteShellArgument(script)
+	}
+	if p.realWorkingDir != "" {
+		commandLine = fmt.Sprintf("cd %s || exit 1\n%s", quoteShellArgument(p.realWorkingDir), commandLine)
+	}
+	return p.loginCommand, []string{"-l", username, "-c", commandLine}
+}
+
+func quoteShellArgument(argument string) string {
+	return "'" + strings.ReplaceAll(argument, "'", `'\''`) + "'"
+}
@@ -0,0 +1,63 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindLoginCommand(t *testing.T) {
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	_, err := findLoginCommand()
+	assert.Error(t, err)
+
+	suPath := filepath.Join(binDir, "su")
+	assert.NoError(t, os.WriteFile(suPath, []byte("#!/bin/sh\n"), 0755))
+	commandPath, err := findLoginCommand()
+	assert.NoError(t, err)
+	assert.Equal(t, suPath, commandPath)
+
+	runuserPath := filepath.Join(binDir, "runuser")
+	assert.NoError(t, os.WriteFile(runuserPath, []byte("#!/bin/sh\n"), 0755))
+	commandPath, err = findLoginCommand()
+	assert.NoError(t, err)
+	assert.Equal(t, runuserPath, commandPath)
+}
+
+func TestPreCheckLoginShellNotAvailable(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	p := &HostProcessor{
+		CommandType: "RunShellScript",
+		LoginShell:  true,
+	}
+	param, err := p.PreCheck()
+	assert.Error(t, err)
+	assert.Equal(t, "loginShell", param)
+	assert.Contains(t, err.Error(), "LoginShellNotAvailable")
+}
+
+func TestLoginShellCommand(t *testing.T) {
+	p := &HostProcessor{loginCommand: "/usr/sbin/runuser"}
+	command, args := p.loginShellCommand("echo hello", false)
+	assert.Equal(t, "/usr/sbin/runuser", command)
+	assert.Equal(t, []string{"-l", "root", "-c", "echo hello"}, args)
+
+	p.Username = "admin"
+	p.realWorkingDir = "/home/admin/it's here"
+	command, args = p.loginShellCommand("/tmp/t-123.sh", true)
+	assert.Equal(t, "/usr/sbin/runuser", command)
+	assert.Equal(t, []string{"-l", "admin", "-c", "cd '/home/admin/it'\\''s here' || exit 1\n'/tmp/t-123.sh'"}, args)
+}
+
+func TestQuoteShellArgument(t *testing.T) {
+	assert.Equal(t, "''", quoteShellArgument(""))
+	assert.Equal(t, "'a b'", quoteShellArgument("a b"))
+	assert.Equal(t, `'$(id)'`, quoteShellArgument("$(id)"))
+	assert.Equal(t, `'a'\''b'`, quoteShellArgument("a'b"))
+}
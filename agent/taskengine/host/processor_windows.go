@@ -47,3 +47,16 @@ func (p *HostProcessor) checkWorkingDirectory() (string, error) {
 	}
 	return workingDir, nil
 }
+
+// Login shell is not supported on Windows, where the user profile has been
+// loaded when logging on the specified user. Tasks requesting it are rejected
+// in PreCheck instead of being run without it silently.
+const loginShellSupported = false
+
+func findLoginCommand() (string, error) {
+	return "", errors.New("login shell is not supported on Windows")
+}
+
+func (p *HostProcessor) loginShellCommand(script string, isScriptFile bool) (string, []string) {
+	return p.invokeCommand, p.invokeCommandArgs
+}
@@ -0,0 +1,25 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
+)
+
+func TestPreCheckLoginShellNotSupported(t *testing.T) {
+	for _, commandType := range []string{"RunBatScript", "RunPowerShellScript"} {
+		p := &HostProcessor{
+			CommandType: commandType,
+			LoginShell:  true,
+		}
+		param, err := p.PreCheck()
+		assert.Equal(t, "loginShell", param)
+		settingErr, ok := err.(taskerrors.InvalidSettingError)
+		if assert.True(t, ok) {
+			assert.Equal(t, "LoginShellNotSupported", settingErr.ShortMessage())
+			assert.EqualError(t, settingErr.Unwrap(), "login shell is not supported on windows")
+		}
+	}
+}
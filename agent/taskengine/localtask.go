@@ -0,0 +1,323 @@
+package taskengine
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
+)
+
+const (
+	// LocalTaskIdPrefix distinguishes invocations submitted locally from those
+	// dispatched by server
+	LocalTaskIdPrefix = "local-"
+
+	// Types of LocalEvent
+	LocalEventStarted  = "started"
+	LocalEventOutput   = "output"
+	LocalEventFinished = "finished"
+
+	// Final status of local invocation besides "finished", "timeout",
+	// "failed" and "canceled" used by server reporting
+	LocalStatusInvalid = "invalid"
+
+	// Output of local invocation is streamed at this interval
+	localOutputIntervalMs = 1000
+	// Output beyond the limit is dropped instead of kept for watchers
+	maxLocalOutputBytes = 4 * 1024 * 1024
+	// Finished local invocations are kept for late watchers
+	localInvocationRetention = 10 * time.Minute
+)
+
+var (
+	ErrLocalInvocationNotFound = errors.New("local invocation not found")
+	ErrLocalInvocationFinished = errors.New("local invocation has finished")
+
+	_localInvocations     = make(map[string]*LocalInvocation)
+	_localInvocationsLock sync.Mutex
+)
+
+// LocalCommand is a command submitted through local IPC instead of fetched
+// from server
+type LocalCommand struct {
+	CommandType string
+	CommandName string
+	// Plain text content of script
+	Content          string
+	WorkingDir       string
+	Username         string
+	LoginShell       bool
+	TimeoutSeconds   int
+	ContainerId      string
+	ContainerName    string
+	PodNamespace     string
+	PodName          string
+	PodLabelSelector string
+	// Required when content signature is enforced on the instance
+	ContentSignature           string
+	ContentSignatureKeyId      string
+	ContentSignatureExpireTime int64
+	// Process submitting the command, recorded in audit log. Nil when peer
+	// credentials are not available, e.g., via named pipe on Windows
+	Caller *LocalCaller
+}
+
+// LocalCaller identifies the local process submitting command
+type LocalCaller struct {
+	Uid uint32
+	Gid uint32
+	Pid int32
+}
+
+// LocalEvent is the progress of local invocation
+type LocalEvent struct {
+	Type   string
+	Time   time.Time
+	Output string
+	// Fields below are only set for LocalEventFinished
+	Status       string
+	ExitCode     int
+	ErrorCode    string
+	ErrorMessage string
+	// Bytes of output dropped due to the limit
+	Dropped int64
+}
+
+// LocalInvocation records events of an invocation submitted locally, which
+// are replayed to every watcher
+type LocalInvocation struct {
+	TaskId string
+	Caller *LocalCaller
+
+	lock        sync.Mutex
+	events      []LocalEvent
+	outputBytes int64
+	dropped     int64
+	finished    bool
+	// Closed and replaced when new event is published
+	updated chan struct{}
+}
+
+func newLocalInvocation(taskId string) *LocalInvocation {
+	return &LocalInvocation{
+		TaskId:  taskId,
+		updated: make(chan struct{}),
+	}
+}
+
+// Events returns events since index from, and the channel closed when more
+// events are published
+func (l *LocalInvocation) Events(from int) ([]LocalEvent, <-chan struct{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var events []LocalEvent
+	if from < len(l.events) {
+		events = append(events, l.events[from:]...)
+	}
+	return events, l.updated
+}
+
+// IsFinished returns whether the final event has been published
+func (l *LocalInvocation) IsFinished() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.finished
+}
+
+func (l *LocalInvocation) publishLocked(event LocalEvent) {
+	event.Time = time.Now()
+	l.events = append(l.events, event)
+	close(l.updated)
+	l.updated = make(chan struct{})
+}
+
+func (l *LocalInvocation) start() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.finished {
+		return
+	}
+	l.publishLocked(LocalEvent{Type: LocalEventStarted})
+}
+
+func (l *LocalInvocation) output(data string) {
+	if len(data) == 0 {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.finished {
+		return
+	}
+	if l.outputBytes+int64(len(data)) > maxLocalOutputBytes {
+		l.dropped += int64(len(data))
+		return
+	}
+	l.outputBytes += int64(len(data))
+	l.publishLocked(LocalEvent{Type: LocalEventOutput, Output: data})
+}
+
+func (l *LocalInvocation) finish(event LocalEvent) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.finished {
+		return
+	}
+	l.finished = true
+	event.Type = LocalEventFinished
+	event.Dropped = l.dropped
+	l.publishLocked(event)
+}
+
+// RunLocalCommand schedules command through the same pipeline as tasks from
+// server, while progress is published to returned LocalInvocation instead of
+// reported to server
+func RunLocalCommand(command LocalCommand) (*LocalInvocation, error) {
+	taskId, err := newLocalTaskId()
+	if err != nil {
+		return nil, err
+	}
+	taskInfo := models.RunTaskInfo{
+		CommandType:                command.CommandType,
+		TaskId:                     taskId,
+		TimeOut:                    strconv.Itoa(command.TimeoutSeconds),
+		CommandName:                command.CommandName,
+		InvokeVersion:              1,
+		Content:                    base64.StdEncoding.EncodeToString([]byte(command.Content)),
+		WorkingDir:                 command.WorkingDir,
+		Username:                   command.Username,
+		LoginShell:                 command.LoginShell,
+		ContainerId:                command.ContainerId,
+		ContainerName:              command.ContainerName,
+		PodNamespace:               command.PodNamespace,
+		PodName:                    command.PodName,
+		PodLabelSelector:           command.PodLabelSelector,
+		ContentSignature:           command.ContentSignature,
+		ContentSignatureKeyId:      command.ContentSignatureKeyId,
+		ContentSignatureExpireTime: command.ContentSignatureExpireTime,
+		Output: models.OutputInfo{
+			Interval:  localOutputIntervalMs,
+			SendStart: true,
+		},
+		Repeat: models.RunTaskOnce,
+	}
+	if command.TimeoutSeconds <= 0 {
+		taskInfo.TimeOut = ""
+	}
+
+	invocation := newLocalInvocation(taskId)
+	invocation.Caller = command.Caller
+	t := NewTask(taskInfo, nil, nil)
+	t.local = invocation
+
+	scheduleLogger := log.GetLogger().WithFields(logrus.Fields{
+		"TaskId": taskId,
+		"Phase":  "Scheduling",
+	})
+	taskFactory := GetTaskFactory()
+	if err := taskFactory.AddTask(t); err != nil {
+		return nil, err
+	}
+	_localInvocationsLock.Lock()
+	_localInvocations[taskId] = invocation
+	_localInvocationsLock.Unlock()
+
+	GetPool().RunTask(func() {
+		defer scheduleLocalInvocationRemoval(taskId)
+		defer GetTaskFactory().RemoveTaskByName(taskId)
+		// Local invocation may be stopped while pending
+		if t.IsCancled() {
+			return
+		}
+		code, err := t.Run()
+		// Not every failure is reported by Task, e.g., unexpected error when
+		// preparing process
+		if err != nil {
+			invocation.finish(LocalEvent{
+				Status:       "failed",
+				ErrorCode:    strconv.Itoa(int(code)),
+				ErrorMessage: err.Error(),
+			})
+		} else {
+			invocation.finish(LocalEvent{Status: "finished", ExitCode: t.exit_code})
+		}
+	})
+	scheduleLogger.Info("Scheduled local invocation for pending or running")
+	return invocation, nil
+}
+
+// AccessibleBy reports whether caller is allowed to watch or stop the
+// invocation. Root and callers without credentials, i.e., administrators via
+// named pipe on Windows, access all invocations, while others only access
+// those submitted by themselves.
+func (l *LocalInvocation) AccessibleBy(caller *LocalCaller) bool {
+	if caller == nil || caller.Uid == 0 {
+		return true
+	}
+	return l.Caller != nil && l.Caller.Uid == caller.Uid
+}
+
+// StopLocalCommand cancels a pending or running local invocation on behalf of
+// caller. Invocations not accessible by caller are reported as not found.
+func StopLocalCommand(taskId string, caller *LocalCaller) error {
+	invocation, ok := GetLocalInvocation(taskId)
+	if !ok || !invocation.AccessibleBy(caller) {
+		return ErrLocalInvocationNotFound
+	}
+	if invocation.IsFinished() {
+		return ErrLocalInvocationFinished
+	}
+	var details map[string]string
+	if caller != nil {
+		details = map[string]string{
+			"callerUid": strconv.FormatUint(uint64(caller.Uid), 10),
+			"callerGid": strconv.FormatUint(uint64(caller.Gid), 10),
+			"callerPid": strconv.Itoa(int(caller.Pid)),
+		}
+	}
+	audit.Record(audit.Event{
+		Action:  audit.ActionStopTask,
+		Trigger: audit.TriggerLocal,
+		Subject: taskId,
+		Details: details,
+	})
+	t, ok := GetTaskFactory().GetTask(taskId)
+	if !ok {
+		return ErrLocalInvocationFinished
+	}
+	t.Cancel(false)
+	return nil
+}
+
+// GetLocalInvocation returns local invocation running or recently finished
+func GetLocalInvocation(taskId string) (*LocalInvocation, bool) {
+	_localInvocationsLock.Lock()
+	defer _localInvocationsLock.Unlock()
+	invocation, ok := _localInvocations[taskId]
+	return invocation, ok
+}
+
+func scheduleLocalInvocationRemoval(taskId string) {
+	time.AfterFunc(localInvocationRetention, func() {
+		_localInvocationsLock.Lock()
+		defer _localInvocationsLock.Unlock()
+		delete(_localInvocations, taskId)
+	})
+}
+
+func newLocalTaskId() (string, error) {
+	randomBytes := make([]byte, 8)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return LocalTaskIdPrefix + hex.EncodeToString(randomBytes), nil
+}
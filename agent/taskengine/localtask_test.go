@@ -0,0 +1,166 @@
+package taskengine
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalInvocationEvents(t *testing.T) {
+	invocation := newLocalInvocation("local-test")
+	events, updated := invocation.Events(0)
+	assert.Empty(t, events)
+
+	invocation.start()
+	select {
+	case <-updated:
+	default:
+		t.Fatal("watcher not notified of new event")
+	}
+	invocation.output("hello ")
+	invocation.output("")
+	invocation.output("world")
+
+	events, _ = invocation.Events(0)
+	assert.Len(t, events, 3)
+	assert.Equal(t, LocalEventStarted, events[0].Type)
+	assert.Equal(t, LocalEventOutput, events[1].Type)
+	assert.Equal(t, "hello ", events[1].Output)
+	assert.Equal(t, "world", events[2].Output)
+
+	// Late watcher only receives events since the index
+	events, _ = invocation.Events(2)
+	assert.Len(t, events, 1)
+
+	invocation.finish(LocalEvent{Status: "finished", ExitCode: 3})
+	// Events after finished are ignored
+	invocation.output("ignored")
+	invocation.finish(LocalEvent{Status: "failed"})
+	assert.True(t, invocation.IsFinished())
+
+	events, _ = invocation.Events(3)
+	assert.Len(t, events, 1)
+	assert.Equal(t, LocalEventFinished, events[0].Type)
+	assert.Equal(t, "finished", events[0].Status)
+	assert.Equal(t, 3, events[0].ExitCode)
+}
+
+func TestLocalInvocationOutputLimit(t *testing.T) {
+	invocation := newLocalInvocation("local-test")
+	invocation.output(strings.Repeat("a", maxLocalOutputBytes))
+	invocation.output("dropped")
+	invocation.finish(LocalEvent{Status: "finished"})
+
+	events, _ := invocation.Events(0)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int64(len("dropped")), events[1].Dropped)
+}
+
+func TestStopLocalCommandNotFound(t *testing.T) {
+	assert.Equal(t, ErrLocalInvocationNotFound, StopLocalCommand("local-unknown", nil))
+
+	invocation := newLocalInvocation("local-finished")
+	invocation.finish(LocalEvent{Status: "finished"})
+	_localInvocationsLock.Lock()
+	_localInvocations[invocation.TaskId] = invocation
+	_localInvocationsLock.Unlock()
+	defer func() {
+		_localInvocationsLock.Lock()
+		delete(_localInvocations, invocation.TaskId)
+		_localInvocationsLock.Unlock()
+	}()
+	assert.Equal(t, ErrLocalInvocationFinished, StopLocalCommand(invocation.TaskId, nil))
+}
+
+func TestLocalTaskAuditCaller(t *testing.T) {
+	invocation := newLocalInvocation("local-test")
+	task := &Task{local: invocation}
+	assert.NotContains(t, task.auditDetails(), "callerUid")
+
+	invocation.Caller = &LocalCaller{Uid: 1000, Gid: 100, Pid: 4321}
+	details := task.auditDetails()
+	assert.Equal(t, "1000", details["callerUid"])
+	assert.Equal(t, "100", details["callerGid"])
+	assert.Equal(t, "4321", details["callerPid"])
+}
+
+func TestLocalInvocationAccessibleBy(t *testing.T) {
+	owned := newLocalInvocation("local-owned")
+	owned.Caller = &LocalCaller{Uid: 1000}
+	assert.True(t, owned.AccessibleBy(nil))
+	assert.True(t, owned.AccessibleBy(&LocalCaller{Uid: 0}))
+	assert.True(t, owned.AccessibleBy(&LocalCaller{Uid: 1000, Pid: 1}))
+	assert.False(t, owned.AccessibleBy(&LocalCaller{Uid: 1001}))
+
+	// Invocations submitted by administrators via named pipe
+	privileged := newLocalInvocation("local-privileged")
+	assert.True(t, privileged.AccessibleBy(&LocalCaller{Uid: 0}))
+	assert.False(t, privileged.AccessibleBy(&LocalCaller{Uid: 1000}))
+}
+
+func waitLocalInvocation(t *testing.T, invocation *LocalInvocation) LocalEvent {
+	next := 0
+	timeout := time.After(30 * time.Second)
+	for {
+		events, updated := invocation.Events(next)
+		for _, event := range events {
+			if event.Type == LocalEventFinished {
+				return event
+			}
+		}
+		next += len(events)
+		select {
+		case <-updated:
+		case <-timeout:
+			t.Fatal("local invocation not finished in time")
+		}
+	}
+}
+
+func TestRunLocalCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script is not supported on Windows")
+	}
+	invocation, err := RunLocalCommand(LocalCommand{
+		CommandType: "RunShellScript",
+		Content:     "echo hello",
+		Caller:      &LocalCaller{Uid: 1000},
+	})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(invocation.TaskId, LocalTaskIdPrefix))
+	found, ok := GetLocalInvocation(invocation.TaskId)
+	assert.True(t, ok)
+	assert.Same(t, invocation, found)
+
+	finished := waitLocalInvocation(t, invocation)
+	assert.Equal(t, "finished", finished.Status)
+	assert.Equal(t, 0, finished.ExitCode)
+	events, _ := invocation.Events(0)
+	var output strings.Builder
+	for _, event := range events {
+		output.WriteString(event.Output)
+	}
+	assert.Contains(t, output.String(), "hello")
+}
+
+func TestStopLocalCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script is not supported on Windows")
+	}
+	owner := &LocalCaller{Uid: 1000}
+	invocation, err := RunLocalCommand(LocalCommand{
+		CommandType: "RunShellScript",
+		Content:     "sleep 30",
+		Caller:      owner,
+	})
+	assert.NoError(t, err)
+
+	// Others could neither stop nor find the invocation
+	assert.Equal(t, ErrLocalInvocationNotFound, StopLocalCommand(invocation.TaskId, &LocalCaller{Uid: 1001}))
+	assert.NoError(t, StopLocalCommand(invocation.TaskId, owner))
+	finished := waitLocalInvocation(t, invocation)
+	assert.NotEqual(t, "finished", finished.Status)
+}
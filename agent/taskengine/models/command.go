@@ -1,5 +1,9 @@
 package models
 
+import (
+	"github.com/aliyun/aliyun_assist_client/agent/util/process"
+)
+
 type RunTaskRepeatType string
 
 const (
@@ -18,25 +22,67 @@ type OutputInfo struct {
 	SendStart bool `json:"sendStart"`
 }
 
+type EphemeralMount struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+// EphemeralContainerInfo describes the short-lived container in which command
+// is executed, and the container would be removed after command finished.
+type EphemeralContainerInfo struct {
+	Image string `json:"image"`
+	// PullPolicy is one of "Always", "IfNotPresent" and "Never", and defaults
+	// to "IfNotPresent"
+	PullPolicy  string            `json:"pullPolicy"`
+	Mounts      []EphemeralMount  `json:"mounts"`
+	Env         map[string]string `json:"env"`
+	Cpus        float64           `json:"cpus"`
+	MemoryLimit int64             `json:"memoryLimit"`
+	NetworkMode string            `json:"networkMode"`
+}
+
 type RunTaskInfo struct {
-	InstanceId        string            `json:"instanceId"`
-	CommandType       string            `json:"type"`
-	TaskId            string            `json:"taskID"`
-	CommandId         string            `json:"commandId"`
-	EnableParameter   bool              `json:"enableParameter"`
-	TimeOut           string            `json:"timeOut"`
-	CommandName       string            `json:"commandName"`
-	InvokeVersion     int               `json:"invokeVersion"`
-	Content           string            `json:"commandContent"`
-	WorkingDir        string            `json:"workingDirectory"`
-	Args              string            `json:"args"`
-	Cronat            string            `json:"cron"`
-	Username          string            `json:"username"`
-	Password          string            `json:"windowsPasswordName"`
-	CreationTime      int64             `json:"creationTime"`
-	ContainerId       string            `json:"containerId"`
-	ContainerName     string            `json:"containerName"`
-	BuiltinParameters map[string]string `json:"builtInParameter"`
+	InstanceId       string `json:"instanceId"`
+	CommandType      string `json:"type"`
+	TaskId           string `json:"taskID"`
+	CommandId        string `json:"commandId"`
+	EnableParameter  bool   `json:"enableParameter"`
+	TimeOut          string `json:"timeOut"`
+	CommandName      string `json:"commandName"`
+	InvokeVersion    int    `json:"invokeVersion"`
+	Content          string `json:"commandContent"`
+	WorkingDir       string `json:"workingDirectory"`
+	Args             string `json:"args"`
+	Cronat           string `json:"cron"`
+	Username         string `json:"username"`
+	Password         string `json:"windowsPasswordName"`
+	CreationTime     int64  `json:"creationTime"`
+	ContainerId      string `json:"containerId"`
+	ContainerName    string `json:"containerName"`
+	PodNamespace     string `json:"podNamespace"`
+	PodName          string `json:"podName"`
+	PodLabelSelector string `json:"podLabelSelector"`
+	// Base64-encoded detached signature of decoded content, optional id of
+	// the signing key in local keyring, and unix time in seconds after which
+	// the signature expires
+	ContentSignature           string `json:"commandContentSignature"`
+	ContentSignatureKeyId      string `json:"commandContentSignatureKeyId"`
+	ContentSignatureExpireTime int64  `json:"commandContentSignatureExpireTime"`
+	// Run shell script in login shell of the user like `su -`, which applies
+	// PAM session modules, profile, limits and umask of the user
+	LoginShell bool `json:"loginShell"`
+	// Restrict privileges of script running as root on Linux when specified
+	Sandbox *process.SandboxOptions `json:"sandbox,omitempty"`
+	// Content is downloaded from HTTP(S) or OSS url instead of inline
+	// commandContent when specified, pinned by required SHA-256 digest. The
+	// object is a zip bundle when entrypoint inside it is specified
+	ContentUrl        string `json:"commandContentUrl"`
+	ContentSha256     string `json:"commandContentSha256"`
+	ContentEntrypoint string `json:"commandContentEntrypoint"`
+	// Command is executed in ephemeral container when specified
+	EphemeralContainer *EphemeralContainerInfo `json:"ephemeralContainer,omitempty"`
+	BuiltinParameters  map[string]string       `json:"builtInParameter"`
 
 	Output OutputInfo
 	Repeat RunTaskRepeatType
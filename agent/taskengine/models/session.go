@@ -9,4 +9,7 @@ type SessionTaskInfo struct {
 	TargetHost   string `json:"targetHost"`
 	PortNumber   string `json:"portNumber"`
 	FlowLimit    int    `json:"flowLimit"` // 最大流量 单位 bps
+	// Shell is opened inside the container instead of the host if specified
+	ContainerId   string `json:"containerId"`
+	ContainerName string `json:"containerName"`
 }
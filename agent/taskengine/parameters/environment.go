@@ -2,18 +2,63 @@ package parameters
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 
+	"github.com/aliyun/aliyun_assist_client/agent/flagging"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
-	"github.com/aliyun/aliyun_assist_client/agent/util"
-	"github.com/aliyun/aliyun_assist_client/common/networkcategory"
+)
+
+const (
+	namespaceACS  = "ACS"
+	namespaceEnv  = "env"
+	namespaceFile = "file"
+
+	maxFileParameterSize = 64 * 1024
 )
 
 var (
-	//{{ACS::InstanceId}}
-	_environmentParameterPattern = regexp.MustCompile(`{{\s*((?U:ACS)\s*::\s*([\w-.]+))\s*}}`)
+	//{{ACS::InstanceId}}, {{env::HOME}}, {{file::/etc/hostname}}
+	_environmentParameterPattern = regexp.MustCompile(`{{\s*(ACS|env|file)\s*::\s*([^{}]+?)\s*}}`)
+	_builtinParameterNamePattern = regexp.MustCompile(`^[\w-.]+$`)
+	_envParameterNamePattern     = regexp.MustCompile(`^\w+$`)
+
+	// loadNamespacePolicy is replaceable for testing
+	loadNamespacePolicy = detectNamespacePolicy
 )
 
+// namespacePolicy describes which env and file parameters are allowed. Empty
+// allow-lists allow nothing even when the namespace is enabled.
+type namespacePolicy struct {
+	envEnabled          bool
+	envAllowedNames     []string
+	fileEnabled         bool
+	fileAllowedPrefixes []string
+}
+
+func detectNamespacePolicy() namespacePolicy {
+	policy := namespacePolicy{}
+	var err error
+	if policy.envEnabled, policy.envAllowedNames, err = flagging.DetectEnvParameterEnabled(); err != nil {
+		log.GetLogger().WithError(err).Warningln("Failed to detect policy of env parameter, which is considered disabled")
+	}
+	if policy.fileEnabled, policy.fileAllowedPrefixes, err = flagging.DetectFileParameterEnabled(); err != nil {
+		log.GetLogger().WithError(err).Warningln("Failed to detect policy of file parameter, which is considered disabled")
+	}
+	return policy
+}
+
+// resolution holds values resolved during one invocation, so that each
+// parameter is resolved only once however many times it appears.
+type resolution struct {
+	builtinParameters map[string]string
+	policy            namespacePolicy
+	cache             map[string]string
+}
+
 func ResolveBuiltinParameters(commandContent string, builtinParameters map[string]string) (string, error) {
 	// Special treatment when value for builtin parameter "InstanceName" is
 	// empty, i.e., some error encountered when luban reads instance name via
@@ -26,22 +71,32 @@ func ResolveBuiltinParameters(commandContent string, builtinParameters map[strin
 		}
 	}
 
+	r := &resolution{
+		builtinParameters: builtinParameters,
+		policy:            loadNamespacePolicy(),
+		cache:             make(map[string]string),
+	}
+
 	var thrown error = nil
 	resolvedContent := _environmentParameterPattern.ReplaceAllStringFunc(commandContent, func(matched string) string {
+		if thrown != nil {
+			return ""
+		}
 		match := _environmentParameterPattern.FindStringSubmatch(matched)
 		if len(match) != 3 {
-			if thrown == nil {
-				thrown = taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Invalid match %q when resolving environment parameter "%s"`, match, matched))
-			}
+			thrown = taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Invalid match %q when resolving environment parameter "%s"`, match, matched))
 			return ""
 		}
 
-		parameterName := match[2]
-		parameterValue, ok := builtinParameters[parameterName]
-		if !ok && thrown == nil {
-			thrown = taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The environment parameter %s is invalid`, parameterName))
+		value, resolved, err := r.resolve(match[1], match[2])
+		if err != nil {
+			thrown = err
+			return ""
+		}
+		if !resolved {
+			return matched
 		}
-		return parameterValue
+		return value
 	})
 	if thrown != nil {
 		return "", thrown
@@ -50,13 +105,136 @@ func ResolveBuiltinParameters(commandContent string, builtinParameters map[strin
 	return resolvedContent, nil
 }
 
-func retrieveInstanceName() (string, error) {
-	networkCategory := networkcategory.Get()
-	if networkCategory != networkcategory.NetworkVPC &&
-		networkCategory != networkcategory.NetworkWithMetaserver {
-		return "", fmt.Errorf("Agent is not able to retrieve instance name")
+// resolve returns value of the parameter, or resolved as false when the
+// parameter should be left untouched in command content.
+func (r *resolution) resolve(namespace string, name string) (value string, resolved bool, err error) {
+	cacheKey := namespace + "::" + name
+	if value, ok := r.cache[cacheKey]; ok {
+		return value, true, nil
+	}
+
+	switch namespace {
+	case namespaceACS:
+		// Names not conforming to the format have never been recognized as
+		// parameters
+		if !_builtinParameterNamePattern.MatchString(name) {
+			return "", false, nil
+		}
+		value, err = r.resolveBuiltin(name)
+	case namespaceEnv:
+		if !r.policy.envEnabled {
+			log.GetLogger().Warningf("Parameter {{%s}} is left unresolved since env parameter is not enabled", cacheKey)
+			return "", false, nil
+		}
+		value, err = resolveEnv(name, r.policy.envAllowedNames)
+	case namespaceFile:
+		if !r.policy.fileEnabled {
+			log.GetLogger().Warningf("Parameter {{%s}} is left unresolved since file parameter is not enabled", cacheKey)
+			return "", false, nil
+		}
+		value, err = resolveFile(name, r.policy.fileAllowedPrefixes)
+	default:
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	r.cache[cacheKey] = value
+	return value, true, nil
+}
+
+func (r *resolution) resolveBuiltin(name string) (string, error) {
+	// Values provided by server always take precedence
+	if value, ok := r.builtinParameters[name]; ok {
+		return value, nil
 	}
 
-	err, instanceName := util.HttpGet("http://100.100.100.200/latest/meta-data/instance/instance-name")
-	return instanceName, err
+	resolver, ok := getBuiltinResolver(name)
+	if !ok {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The environment parameter %s is invalid`, name))
+	}
+	value, err := resolver()
+	if err != nil {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Failed to resolve environment parameter ACS::%s: %s`, name, err.Error()))
+	}
+	return value, nil
+}
+
+func resolveEnv(name string, allowedNames []string) (string, error) {
+	if !_envParameterNamePattern.MatchString(name) {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The environment variable name %q is invalid`, name))
+	}
+	allowed := false
+	for _, allowedName := range allowedNames {
+		if name == allowedName {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Reading environment variable %s is not allowed by policy`, name))
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The environment variable %s is not set`, name))
+	}
+	return value, nil
+}
+
+func resolveFile(path string, allowedPrefixes []string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The file path %s must be absolute`, path))
+	}
+	cleanedPath := filepath.Clean(path)
+	if !isPathAllowed(cleanedPath, allowedPrefixes) {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Reading file %s is not allowed by policy`, cleanedPath))
+	}
+	// Symbolic links are resolved and checked again, otherwise a link under
+	// allowed prefixes could point to any file
+	resolvedPath, err := filepath.EvalSymlinks(cleanedPath)
+	if err != nil {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Failed to access file %s: %s`, cleanedPath, err.Error()))
+	}
+	if !isPathAllowed(resolvedPath, allowedPrefixes) {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Reading file %s linked to %s is not allowed by policy`, cleanedPath, resolvedPath))
+	}
+	cleanedPath = resolvedPath
+
+	fileInfo, err := os.Stat(cleanedPath)
+	if err != nil {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Failed to access file %s: %s`, cleanedPath, err.Error()))
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The file %s is not a regular file`, cleanedPath))
+	}
+	if fileInfo.Size() > maxFileParameterSize {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`The file %s is larger than %d bytes`, cleanedPath, maxFileParameterSize))
+	}
+	content, err := os.ReadFile(cleanedPath)
+	if err != nil {
+		return "", taskerrors.NewInvalidEnvironmentParameterError(fmt.Sprintf(`Failed to read file %s: %s`, cleanedPath, err.Error()))
+	}
+	// Like command substitution of shell, trailing newlines are removed
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// isPathAllowed reports whether cleaned path is under any of allowed prefixes.
+// No path is allowed when prefixes are empty.
+func isPathAllowed(cleanedPath string, allowedPrefixes []string) bool {
+	for _, prefix := range allowedPrefixes {
+		cleanedPrefix := filepath.Clean(prefix)
+		if isPathUnder(cleanedPath, cleanedPrefix) {
+			return true
+		}
+		// Allowed prefixes may also contain symbolic links, e.g., /var/run
+		if resolvedPrefix, err := filepath.EvalSymlinks(cleanedPrefix); err == nil && isPathUnder(cleanedPath, resolvedPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPathUnder(cleanedPath string, cleanedPrefix string) bool {
+	return cleanedPath == cleanedPrefix || strings.HasPrefix(cleanedPath, strings.TrimSuffix(cleanedPrefix, string(filepath.Separator))+string(filepath.Separator))
 }
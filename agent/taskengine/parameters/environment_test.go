@@ -0,0 +1,106 @@
+package parameters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
+)
+
+func withNamespacePolicy(policy namespacePolicy, f func()) {
+	original := loadNamespacePolicy
+	loadNamespacePolicy = func() namespacePolicy {
+		return policy
+	}
+	defer func() {
+		loadNamespacePolicy = original
+	}()
+	f()
+}
+
+func TestResolveBuiltinParameters(t *testing.T) {
+	resolvedTimes := 0
+	RegisterBuiltinResolver("TestCounter", func() (string, error) {
+		resolvedTimes++
+		return "counted", nil
+	})
+
+	withNamespacePolicy(namespacePolicy{}, func() {
+		content, err := ResolveBuiltinParameters("{{ACS::InstanceId}} {{ ACS::TestCounter }} {{ACS::TestCounter}}", map[string]string{
+			"InstanceId": "i-test",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "i-test counted counted", content)
+		assert.Equal(t, 1, resolvedTimes)
+
+		_, err = ResolveBuiltinParameters("{{ACS::NotExisted}}", map[string]string{})
+		assert.Error(t, err)
+		_, ok := err.(taskerrors.InvalidSettingError)
+		assert.True(t, ok)
+
+		// Parameters in namespaces not enabled are left untouched
+		content, err = ResolveBuiltinParameters("{{env::HOME}} {{file::/etc/hostname}}", map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, "{{env::HOME}} {{file::/etc/hostname}}", content)
+	})
+}
+
+func TestResolveEnvAndFileParameters(t *testing.T) {
+	os.Setenv("ASSIST_TEST_PARAMETER", "from-env")
+	defer os.Unsetenv("ASSIST_TEST_PARAMETER")
+	os.Setenv("ASSIST_TEST_SECRET", "secret")
+	defer os.Unsetenv("ASSIST_TEST_SECRET")
+
+	allowedDir := t.TempDir()
+	allowedFile := filepath.Join(allowedDir, "value")
+	assert.NoError(t, os.WriteFile(allowedFile, []byte("from-file\n"), 0600))
+	deniedFile := filepath.Join(t.TempDir(), "value")
+	assert.NoError(t, os.WriteFile(deniedFile, []byte("denied"), 0600))
+	linkToDenied := filepath.Join(allowedDir, "link")
+	symlinkErr := os.Symlink(deniedFile, linkToDenied)
+
+	withNamespacePolicy(namespacePolicy{
+		envEnabled:          true,
+		envAllowedNames:     []string{"ASSIST_TEST_PARAMETER", "ASSIST_TEST_NOT_SET"},
+		fileEnabled:         true,
+		fileAllowedPrefixes: []string{allowedDir},
+	}, func() {
+		content, err := ResolveBuiltinParameters("{{env::ASSIST_TEST_PARAMETER}} {{file::"+allowedFile+"}}", map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env from-file", content)
+
+		_, err = ResolveBuiltinParameters("{{env::ASSIST_TEST_NOT_SET}}", map[string]string{})
+		assert.Error(t, err)
+
+		// Variables not in allow-list are never exposed
+		_, err = ResolveBuiltinParameters("{{env::ASSIST_TEST_SECRET}}", map[string]string{})
+		assert.Error(t, err)
+
+		_, err = ResolveBuiltinParameters("{{file::"+deniedFile+"}}", map[string]string{})
+		assert.Error(t, err)
+
+		_, err = ResolveBuiltinParameters("{{file::relative/path}}", map[string]string{})
+		assert.Error(t, err)
+
+		// Symbolic link under allowed prefix is checked by its target
+		if symlinkErr == nil {
+			_, err = ResolveBuiltinParameters("{{file::"+linkToDenied+"}}", map[string]string{})
+			assert.Error(t, err)
+		}
+	})
+
+	// Empty allow-lists allow nothing
+	withNamespacePolicy(namespacePolicy{
+		envEnabled:  true,
+		fileEnabled: true,
+	}, func() {
+		_, err := ResolveBuiltinParameters("{{env::ASSIST_TEST_PARAMETER}}", map[string]string{})
+		assert.Error(t, err)
+
+		_, err = ResolveBuiltinParameters("{{file::"+allowedFile+"}}", map[string]string{})
+		assert.Error(t, err)
+	})
+}
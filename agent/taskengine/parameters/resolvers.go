@@ -0,0 +1,83 @@
+package parameters
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+	"github.com/aliyun/aliyun_assist_client/agent/util/osutil"
+	"github.com/aliyun/aliyun_assist_client/agent/version"
+	"github.com/aliyun/aliyun_assist_client/common/networkcategory"
+)
+
+// BuiltinResolver resolves value of {{ACS::Name}} parameter locally, when the
+// value is not provided by server.
+type BuiltinResolver func() (string, error)
+
+var (
+	_builtinResolvers = map[string]BuiltinResolver{
+		"InstanceName":     retrieveInstanceName,
+		"InstanceId":       metaserverResolver("instance-id"),
+		"RegionId":         metaserverResolver("region-id"),
+		"ZoneId":           metaserverResolver("zone-id"),
+		"PrivateIpAddress": metaserverResolver("private-ipv4"),
+		"Hostname":         os.Hostname,
+		"OSType":           resolveOSType,
+		"OSVersion":        resolveOSVersion,
+		"AgentVersion":     resolveAgentVersion,
+	}
+	_builtinResolversLock sync.RWMutex
+)
+
+// RegisterBuiltinResolver registers resolver for {{ACS::name}} parameter, and
+// replaces the existing one with the same name.
+func RegisterBuiltinResolver(name string, resolver BuiltinResolver) {
+	_builtinResolversLock.Lock()
+	defer _builtinResolversLock.Unlock()
+	_builtinResolvers[name] = resolver
+}
+
+func getBuiltinResolver(name string) (BuiltinResolver, bool) {
+	_builtinResolversLock.RLock()
+	defer _builtinResolversLock.RUnlock()
+	resolver, ok := _builtinResolvers[name]
+	return resolver, ok
+}
+
+func retrieveMetadata(path string) (string, error) {
+	networkCategory := networkcategory.Get()
+	if networkCategory != networkcategory.NetworkVPC &&
+		networkCategory != networkcategory.NetworkWithMetaserver {
+		return "", fmt.Errorf("Agent is not able to access metaserver")
+	}
+
+	err, value := util.HttpGet("http://100.100.100.200/latest/meta-data/" + path)
+	return value, err
+}
+
+func metaserverResolver(path string) BuiltinResolver {
+	return func() (string, error) {
+		return retrieveMetadata(path)
+	}
+}
+
+func retrieveInstanceName() (string, error) {
+	instanceName, err := retrieveMetadata("instance/instance-name")
+	if err != nil {
+		return "", fmt.Errorf("Agent is not able to retrieve instance name: %w", err)
+	}
+	return instanceName, nil
+}
+
+func resolveOSType() (string, error) {
+	return osutil.GetOsType(), nil
+}
+
+func resolveOSVersion() (string, error) {
+	return osutil.GetVersion(), nil
+}
+
+func resolveAgentVersion() (string, error) {
+	return version.AssistVersion, nil
+}
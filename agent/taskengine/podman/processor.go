@@ -0,0 +1,51 @@
+package podman
+
+import (
+	"github.com/aliyun/aliyun_assist_client/agent/container/podman"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/docker"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/taskerrors"
+)
+
+// PodmanProcessor executes command in containers managed by podman, through
+// the Docker-compatible API of podman REST service. Thus nearly everything is
+// delegated to DockerProcessor except how the service is found.
+type PodmanProcessor struct {
+	*docker.DockerProcessor
+
+	checkErr error
+}
+
+// NewPodmanProcessor creates processor for podman and performs pre-checking
+// logics in advance like CheckDockerProcessor() does. Error encountered is
+// kept and would be reported in PreCheck() method.
+func NewPodmanProcessor(dockerProcessor *docker.DockerProcessor) *PodmanProcessor {
+	p := &PodmanProcessor{
+		DockerProcessor: dockerProcessor,
+	}
+
+	endpoint, err := podman.FindEndpoint()
+	if err != nil {
+		p.checkErr = taskerrors.NewContainerConnectError(err)
+		return p
+	}
+	p.DockerProcessor.Endpoint = endpoint
+	p.checkErr = docker.CheckDockerProcessor(p.DockerProcessor)
+	return p
+}
+
+// CheckPodmanProcessor returns error encountered when creating the processor
+func CheckPodmanProcessor(p *PodmanProcessor) error {
+	return p.checkErr
+}
+
+func (p *PodmanProcessor) PreCheck() (string, error) {
+	if p.checkErr != nil {
+		if validationErr, ok := p.checkErr.(taskerrors.NormalizedValidationError); ok {
+			return validationErr.Param(), validationErr
+		}
+		validationErr := taskerrors.NewContainerConnectError(p.checkErr)
+		return validationErr.Param(), validationErr
+	}
+
+	return p.DockerProcessor.PreCheck()
+}
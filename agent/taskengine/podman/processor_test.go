@@ -0,0 +1,22 @@
+package podman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	libpodman "github.com/aliyun/aliyun_assist_client/agent/container/podman"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/docker"
+)
+
+func TestNewPodmanProcessorWithoutService(t *testing.T) {
+	originalEndpoints := libpodman.PrecedentEndpoints
+	defer func() { libpodman.PrecedentEndpoints = originalEndpoints }()
+	libpodman.PrecedentEndpoints = []string{"unix://" + t.TempDir() + "/missing.sock"}
+
+	p := NewPodmanProcessor(&docker.DockerProcessor{ContainerId: "abc"})
+	assert.Error(t, CheckPodmanProcessor(p))
+	param, err := p.PreCheck()
+	assert.Error(t, err)
+	assert.Equal(t, "ContainerConnectFailed", param)
+}
@@ -0,0 +1,118 @@
+package taskengine
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/host"
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/remotescript"
+)
+
+const remoteContentDownloadTimeout = 10 * time.Minute
+
+// remoteContentRef validates the reference of content url without downloading
+// anything, whose pinned digest is covered by content signature instead of
+// the downloaded object.
+func (task *Task) remoteContentRef() (*remotescript.Reference, error) {
+	ref := &remotescript.Reference{
+		Url:        task.taskInfo.ContentUrl,
+		Sha256:     task.taskInfo.ContentSha256,
+		Entrypoint: task.taskInfo.ContentEntrypoint,
+	}
+	if err := remotescript.Validate(ref); err != nil {
+		task.SendInvalidTask("CommandContentUrlInvalid", err.Error())
+		return nil, fmt.Errorf("Invalid command content url: %w", err)
+	}
+	// Extracted bundle only exists on the host
+	if _, onHost := task.processer.(*host.HostProcessor); ref.IsBundle() && !onHost {
+		task.SendInvalidTask("CommandContentUrlInvalid", "BundleNotSupportedInContainer")
+		return nil, errors.New("Invalid command content url: bundle is not supported in container")
+	}
+	return ref, nil
+}
+
+// fetchRemoteContent downloads the script or bundle referenced by content url
+// after the signature is verified, and returns the script, or the entrypoint
+// inside the bundle, to be evaluated by execution policy. Bundle is not
+// extracted until installRemoteContent.
+func (task *Task) fetchRemoteContent(ref *remotescript.Reference) (*remotescript.Object, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteContentDownloadTimeout)
+	defer cancel()
+	object, err := remotescript.Fetch(ctx, ref)
+	if err != nil {
+		if errors.Is(err, remotescript.ErrDigestMismatch) {
+			task.SendInvalidTask("CommandContentDigestMismatch", err.Error())
+		} else {
+			task.SendInvalidTask("CommandContentDownloadFailed", err.Error())
+		}
+		return nil, nil, fmt.Errorf("Failed to fetch command content: %w", err)
+	}
+
+	script, err := remotescript.EntryScript(ctx, ref, object)
+	if err != nil {
+		if errors.Is(err, remotescript.ErrEntrypointNotFound) {
+			task.SendInvalidTask("CommandContentUrlInvalid", err.Error())
+		} else {
+			task.SendInvalidTask("CommandContentDownloadFailed", err.Error())
+		}
+		return nil, nil, fmt.Errorf("Failed to read command content: %w", err)
+	}
+
+	log.GetLogger().WithFields(logrus.Fields{
+		"TaskId": task.taskInfo.TaskId,
+		"Url":    ref.Url,
+		"Sha256": ref.Sha256,
+	}).Infoln("Command content fetched from url")
+	return object, script, nil
+}
+
+// installRemoteContent extracts the bundle once the task has passed signature
+// and policy checks, and replaces inline content with the script or a
+// launcher of the entrypoint inside the bundle.
+func (task *Task) installRemoteContent(ref *remotescript.Reference, object *remotescript.Object, script []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteContentDownloadTimeout)
+	defer cancel()
+	if err := remotescript.Extract(ctx, ref, object); err != nil {
+		task.SendInvalidTask("CommandContentDownloadFailed", err.Error())
+		return fmt.Errorf("Failed to extract command content: %w", err)
+	}
+
+	content := script
+	if ref.IsBundle() {
+		content = []byte(bundleLauncher(task.taskInfo.CommandType, object.EntrypointPath))
+	}
+	task.taskInfo.Content = base64.StdEncoding.EncodeToString(content)
+	task.remoteScript = script
+	task.remoteContentResolved = true
+	return nil
+}
+
+// remoteObjectDigest returns digest of object referenced by content url in
+// the format of audit digests, or empty string without content url
+func (task *Task) remoteObjectDigest() string {
+	if task.taskInfo.ContentUrl == "" {
+		return ""
+	}
+	return "sha256:" + strings.ToLower(task.taskInfo.ContentSha256)
+}
+
+// bundleLauncher generates script invoking the entrypoint of extracted bundle,
+// which could locate other files in the bundle relative to itself
+func bundleLauncher(commandType string, entrypointPath string) string {
+	switch commandType {
+	case "RunBatScript":
+		return fmt.Sprintf("call \"%s\"\r\nexit /b %%ERRORLEVEL%%\r\n", entrypointPath)
+	case "RunPowerShellScript":
+		return fmt.Sprintf("& '%s'\r\nexit $LASTEXITCODE\r\n", strings.ReplaceAll(entrypointPath, "'", "''"))
+	default:
+		quoted := "'" + strings.ReplaceAll(entrypointPath, "'", `'\''`) + "'"
+		return fmt.Sprintf("if [ -x %[1]s ]; then exec %[1]s; else exec sh %[1]s; fi\n", quoted)
+	}
+}
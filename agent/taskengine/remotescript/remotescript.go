@@ -0,0 +1,332 @@
+package remotescript
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+	"github.com/aliyun/aliyun_assist_client/common/zipfile"
+
+	"github.com/aliyun/aliyun_assist_client/agent/util"
+)
+
+const (
+	// Downloaded scripts and extracted bundles are cached under the script
+	// directory, named by SHA-256 digest of downloaded object
+	CacheDirname = "remote"
+
+	bundleDirSuffix = ".d"
+)
+
+var (
+	ErrInvalidDigest      = errors.New("SHA-256 digest must be 64 hexadecimal characters")
+	ErrUnsupportedScheme  = errors.New("only http, https and oss schemes are supported")
+	ErrInvalidEntrypoint  = errors.New("entrypoint must be a relative path inside the bundle")
+	ErrRegionIdNotFound   = errors.New("region id is required to resolve oss url")
+	ErrDigestMismatch     = errors.New("digest of downloaded content mismatches")
+	ErrEntrypointNotFound = errors.New("entrypoint not found in the bundle")
+	ErrExtractedModified  = errors.New("extracted bundle has been modified")
+
+	// getCacheDir is replaced in tests
+	getCacheDir = defaultCacheDir
+	// getRegionId is replaced in tests
+	getRegionId = defaultRegionId
+)
+
+// Reference points to a script, or a zip bundle when Entrypoint is specified,
+// stored in HTTP(S) server or OSS-compatible object storage
+type Reference struct {
+	Url        string
+	Sha256     string
+	Entrypoint string
+}
+
+func (r *Reference) IsBundle() bool {
+	return r.Entrypoint != ""
+}
+
+// Validate checks the reference without downloading anything
+func Validate(ref *Reference) error {
+	digest, err := hex.DecodeString(ref.Sha256)
+	if err != nil || len(digest) != sha256.Size {
+		return ErrInvalidDigest
+	}
+	if _, err := resolveUrl(ref.Url); err != nil {
+		return err
+	}
+	if ref.IsBundle() {
+		entrypoint := filepath.ToSlash(ref.Entrypoint)
+		if path.IsAbs(entrypoint) || filepath.IsAbs(ref.Entrypoint) ||
+			path.Clean(entrypoint) == ".." || strings.HasPrefix(path.Clean(entrypoint), "../") {
+			return ErrInvalidEntrypoint
+		}
+	}
+	return nil
+}
+
+// Object is the downloaded object in the cache
+type Object struct {
+	// Path of the downloaded script or bundle archive
+	Path string
+	// Path of the entrypoint inside extracted bundle, only available after
+	// Extract
+	EntrypointPath string
+}
+
+// Fetch downloads referenced object into the cache unless an object with the
+// same digest has been cached. Bundle is not extracted here, thus nothing in it
+// is written out before the task passes all checks.
+func Fetch(ctx context.Context, ref *Reference) (*Object, error) {
+	if err := Validate(ref); err != nil {
+		return nil, err
+	}
+	downloadUrl, _ := resolveUrl(ref.Url)
+	digest := strings.ToLower(ref.Sha256)
+
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, err
+	}
+	cachedPath := filepath.Join(cacheDir, digest)
+
+	// Cached file is verified again before reuse, in case it was modified
+	// after downloaded
+	if actual, err := fileDigest(cachedPath); err != nil || actual != digest {
+		if err := download(ctx, downloadUrl, cachedPath, digest); err != nil {
+			return nil, err
+		}
+	}
+	return &Object{
+		Path: cachedPath,
+	}, nil
+}
+
+// EntryScript returns content of the downloaded script, or of the entrypoint
+// inside the bundle read without extracting the bundle
+func EntryScript(ctx context.Context, ref *Reference, object *Object) ([]byte, error) {
+	if !ref.IsBundle() {
+		return os.ReadFile(object.Path)
+	}
+	content, err := zipfile.PeekFileContext(ctx, object.Path, filepath.FromSlash(ref.Entrypoint))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrEntrypointNotFound
+	}
+	return content, err
+}
+
+// Extract unzips the downloaded bundle beside it, or reuses the extracted
+// directory when its content still matches the bundle. Directory failing the
+// verification is evicted and extracted again.
+func Extract(ctx context.Context, ref *Reference, object *Object) error {
+	if !ref.IsBundle() {
+		return nil
+	}
+
+	bundleDir := object.Path + bundleDirSuffix
+	if _, err := os.Stat(bundleDir); err == nil {
+		if err := verifyExtracted(ctx, object.Path, bundleDir); err != nil {
+			if err := os.RemoveAll(bundleDir); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := os.Stat(bundleDir); err != nil {
+		if err := extract(ctx, object.Path, bundleDir); err != nil {
+			return err
+		}
+	}
+	object.EntrypointPath = filepath.Join(bundleDir, filepath.FromSlash(ref.Entrypoint))
+	if info, err := os.Lstat(object.EntrypointPath); err != nil || !info.Mode().IsRegular() {
+		return ErrEntrypointNotFound
+	}
+	return nil
+}
+
+// resolveUrl converts oss://bucket/object to URL of the internal endpoint of
+// the bucket in current region, which is accessible for public-read objects
+// or buckets authorizing the VPC
+func resolveUrl(rawUrl string) (string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host == "" {
+			return "", fmt.Errorf("host is missing in url %s", rawUrl)
+		}
+		return rawUrl, nil
+	case "oss":
+		if u.Host == "" || strings.TrimPrefix(u.Path, "/") == "" {
+			return "", fmt.Errorf("bucket or object is missing in url %s", rawUrl)
+		}
+		regionId := getRegionId()
+		if regionId == "" {
+			return "", ErrRegionIdNotFound
+		}
+		return fmt.Sprintf("https://%s.oss-%s-internal.aliyuncs.com/%s", u.Host, regionId, strings.TrimPrefix(u.EscapedPath(), "/")), nil
+	default:
+		return "", ErrUnsupportedScheme
+	}
+}
+
+func download(ctx context.Context, downloadUrl string, cachedPath string, digest string) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(cachedPath), filepath.Base(cachedPath)+".download-*")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	tempFile.Close()
+	defer os.Remove(tempPath)
+
+	if err := util.HttpDownloadContextStrict(ctx, downloadUrl, tempPath); err != nil {
+		return err
+	}
+	actual, err := fileDigest(tempPath)
+	if err != nil {
+		return err
+	}
+	if actual != digest {
+		return fmt.Errorf("%w: expected %s, actual %s", ErrDigestMismatch, digest, actual)
+	}
+	return os.Rename(tempPath, cachedPath)
+}
+
+// extract unzips the bundle into a temporary directory first, then renames
+// it to the final location, thus an existing bundle directory is always
+// complete
+func extract(ctx context.Context, bundlePath string, bundleDir string) error {
+	tempDir, err := os.MkdirTemp(filepath.Dir(bundleDir), filepath.Base(bundleDir)+".extract-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+	if err := os.Chmod(tempDir, 0755); err != nil {
+		return err
+	}
+
+	if err := zipfile.UnzipContext(ctx, bundlePath, tempDir); err != nil {
+		return err
+	}
+	if err := os.Rename(tempDir, bundleDir); err != nil {
+		// Another task has extracted the same bundle concurrently
+		if _, statErr := os.Stat(bundleDir); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// verifyExtracted checks that bundleDir contains exactly the files in the
+// bundle, i.e., no file is added, removed, replaced by symbolic link or
+// modified after extracted
+func verifyExtracted(ctx context.Context, bundlePath string, bundleDir string) error {
+	zipReader, err := zip.OpenReader(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	expected := make(map[string]*zip.File, len(zipReader.File))
+	for _, f := range zipReader.File {
+		entryPath, err := zipfile.EntryPath(bundleDir, f.Name)
+		if err != nil {
+			return err
+		}
+		expected[entryPath] = f
+	}
+
+	err = filepath.Walk(bundleDir, func(walkedPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if walkedPath == bundleDir {
+			return nil
+		}
+		f, ok := expected[walkedPath]
+		if info.IsDir() {
+			// Parent directories are created implicitly without entries
+			if ok && !f.FileInfo().IsDir() {
+				return fmt.Errorf("%w: %s", ErrExtractedModified, walkedPath)
+			}
+			return nil
+		}
+		if !ok || !info.Mode().IsRegular() || f.FileInfo().IsDir() || info.Size() != int64(f.UncompressedSize64) {
+			return fmt.Errorf("%w: %s", ErrExtractedModified, walkedPath)
+		}
+		delete(expected, walkedPath)
+		return compareEntry(f, walkedPath)
+	})
+	if err != nil {
+		return err
+	}
+	for entryPath, f := range expected {
+		if !f.FileInfo().IsDir() {
+			return fmt.Errorf("%w: %s is missing", ErrExtractedModified, entryPath)
+		}
+	}
+	return nil
+}
+
+func compareEntry(f *zip.File, extractedPath string) error {
+	compressed, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer compressed.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, compressed); err != nil {
+		return err
+	}
+	actual, err := fileDigest(extractedPath)
+	if err != nil {
+		return err
+	}
+	if actual != hex.EncodeToString(h.Sum(nil)) {
+		return fmt.Errorf("%w: %s", ErrExtractedModified, extractedPath)
+	}
+	return nil
+}
+
+func fileDigest(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func defaultCacheDir() (string, error) {
+	scriptDir, err := pathutil.GetScriptPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(scriptDir, CacheDirname), nil
+}
+
+func defaultRegionId() string {
+	return util.GetRegionId()
+}
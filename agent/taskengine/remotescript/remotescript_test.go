@@ -0,0 +1,166 @@
+package remotescript
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveObject(t *testing.T, content []byte) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/script" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func sha256Hex(content []byte) string {
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:])
+}
+
+func useCacheDir(t *testing.T) string {
+	cacheDir := filepath.Join(t.TempDir(), CacheDirname)
+	getCacheDir = func() (string, error) { return cacheDir, nil }
+	t.Cleanup(func() { getCacheDir = defaultCacheDir })
+	return cacheDir
+}
+
+func TestValidate(t *testing.T) {
+	digest := sha256Hex([]byte("echo 1"))
+	assert.NoError(t, Validate(&Reference{Url: "https://example.com/a.sh", Sha256: digest}))
+	assert.ErrorIs(t, Validate(&Reference{Url: "https://example.com/a.sh", Sha256: "abc"}), ErrInvalidDigest)
+	assert.ErrorIs(t, Validate(&Reference{Url: "ftp://example.com/a.sh", Sha256: digest}), ErrUnsupportedScheme)
+	assert.ErrorIs(t, Validate(&Reference{Url: "https://example.com/a.zip", Sha256: digest, Entrypoint: "../a.sh"}), ErrInvalidEntrypoint)
+	assert.ErrorIs(t, Validate(&Reference{Url: "https://example.com/a.zip", Sha256: digest, Entrypoint: "/a.sh"}), ErrInvalidEntrypoint)
+
+	getRegionId = func() string { return "cn-hangzhou" }
+	defer func() { getRegionId = defaultRegionId }()
+	resolved, err := resolveUrl("oss://bucket/dir/a.sh")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bucket.oss-cn-hangzhou-internal.aliyuncs.com/dir/a.sh", resolved)
+}
+
+func TestFetchScript(t *testing.T) {
+	cacheDir := useCacheDir(t)
+	content := []byte("#!/bin/sh\necho remote\n")
+	server, requests := serveObject(t, content)
+	ref := &Reference{Url: server.URL + "/script", Sha256: sha256Hex(content)}
+
+	object, err := Fetch(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, sha256Hex(content)), object.Path)
+	cached, _ := os.ReadFile(object.Path)
+	assert.Equal(t, content, cached)
+
+	// Cache hit
+	_, err = Fetch(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	// Modified cache is downloaded again
+	assert.NoError(t, os.WriteFile(object.Path, []byte("echo modified"), 0600))
+	_, err = Fetch(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+	cached, _ = os.ReadFile(object.Path)
+	assert.Equal(t, content, cached)
+}
+
+func TestFetchDigestMismatch(t *testing.T) {
+	cacheDir := useCacheDir(t)
+	server, _ := serveObject(t, []byte("echo tampered"))
+	_, err := Fetch(context.Background(), &Reference{Url: server.URL + "/script", Sha256: sha256Hex([]byte("echo 1"))})
+	assert.True(t, errors.Is(err, ErrDigestMismatch))
+	entries, _ := os.ReadDir(cacheDir)
+	assert.Len(t, entries, 0)
+
+	_, err = Fetch(context.Background(), &Reference{Url: server.URL + "/missing", Sha256: sha256Hex([]byte("echo 1"))})
+	assert.Error(t, err)
+}
+
+func TestFetchBundle(t *testing.T) {
+	cacheDir := useCacheDir(t)
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	w, _ := zipWriter.Create("bin/main.sh")
+	w.Write([]byte("echo bundle\n"))
+	w, _ = zipWriter.Create("lib/common.sh")
+	w.Write([]byte("echo common\n"))
+	assert.NoError(t, zipWriter.Close())
+	server, _ := serveObject(t, buf.Bytes())
+
+	ref := &Reference{Url: server.URL + "/script", Sha256: sha256Hex(buf.Bytes()), Entrypoint: "bin/main.sh"}
+	object, err := Fetch(context.Background(), ref)
+	assert.NoError(t, err)
+	script, err := EntryScript(context.Background(), ref, object)
+	assert.NoError(t, err)
+	assert.Equal(t, "echo bundle\n", string(script))
+	// Nothing is extracted before Extract
+	_, err = os.Stat(object.Path + bundleDirSuffix)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, Extract(context.Background(), ref, object))
+	entrypoint, err := os.ReadFile(object.EntrypointPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "echo bundle\n", string(entrypoint))
+
+	missingRef := *ref
+	missingRef.Entrypoint = "main.sh"
+	_, err = EntryScript(context.Background(), &missingRef, object)
+	assert.ErrorIs(t, err, ErrEntrypointNotFound)
+	assert.ErrorIs(t, Extract(context.Background(), &missingRef, object), ErrEntrypointNotFound)
+
+	// Modified, added or removed files in extracted directory are evicted
+	bundleDir := filepath.Join(cacheDir, sha256Hex(buf.Bytes())+bundleDirSuffix)
+	for _, tamper := range []func(){
+		func() { os.WriteFile(filepath.Join(bundleDir, "bin", "main.sh"), []byte("echo evil!!\n"), 0755) },
+		func() { os.WriteFile(filepath.Join(bundleDir, "bin", "extra.sh"), []byte("echo extra\n"), 0755) },
+		func() { os.Remove(filepath.Join(bundleDir, "lib", "common.sh")) },
+	} {
+		tamper()
+		assert.Error(t, verifyExtracted(context.Background(), object.Path, bundleDir))
+		assert.NoError(t, Extract(context.Background(), ref, object))
+		assert.NoError(t, verifyExtracted(context.Background(), object.Path, bundleDir))
+		entrypoint, _ = os.ReadFile(object.EntrypointPath)
+		assert.Equal(t, "echo bundle\n", string(entrypoint))
+	}
+}
+
+func TestExtractZipSlip(t *testing.T) {
+	cacheDir := useCacheDir(t)
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	w, _ := zipWriter.Create("main.sh")
+	w.Write([]byte("echo bundle\n"))
+	w, _ = zipWriter.Create("../escaped.sh")
+	w.Write([]byte("echo escaped\n"))
+	assert.NoError(t, zipWriter.Close())
+	server, _ := serveObject(t, buf.Bytes())
+
+	ref := &Reference{Url: server.URL + "/script", Sha256: sha256Hex(buf.Bytes()), Entrypoint: "main.sh"}
+	object, err := Fetch(context.Background(), ref)
+	assert.NoError(t, err)
+	assert.Error(t, Extract(context.Background(), ref, object))
+	_, err = os.Stat(filepath.Join(cacheDir, "escaped.sh"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(object.Path + bundleDirSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
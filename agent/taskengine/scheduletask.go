@@ -10,6 +10,7 @@ import (
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 	heavylock "github.com/viney-shih/go-lock"
 
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
@@ -22,6 +23,13 @@ const (
 	ErrUpdatingProcedureRunning = -7
 )
 
+var (
+	ErrFetchingDisabled = errors.New("fetching tasks is disabled due to network is not ready")
+	ErrFetchingBusy     = errors.New("fetching tasks is canceled due to another running fetching or updating process")
+	ErrNoAvailableHost  = errors.New("no available host to fetch tasks")
+	ErrFetchTimeout     = errors.New("server timed out when fetching tasks")
+)
+
 const (
 	NormalTaskType  = 0
 	SessionTaskType = 1
@@ -71,6 +79,13 @@ func IsStartupFetched() bool {
 }
 
 func Fetch(from_kick bool, taskId string, taskType int) int {
+	task_size, _ := FetchWithError(from_kick, taskId, taskType)
+	return task_size
+}
+
+// FetchWithError works like Fetch, and also returns the error why tasks could
+// not be fetched, e.g., for acknowledging kicks with the real result
+func FetchWithError(from_kick bool, taskId string, taskType int) (int, error) {
 	// Fetching task should be allowed before all core components of agent have
 	// been correctly initialized. This critical indicator would be set at the
 	// end of program.run method
@@ -78,7 +93,7 @@ func Fetch(from_kick bool, taskId string, taskType int) int {
 		log.GetLogger().WithFields(logrus.Fields{
 			"from_kick": from_kick,
 		}).Infoln("Fetching tasks is disabled due to network is not ready")
-		return 0
+		return 0, ErrFetchingDisabled
 	}
 
 	// NOTE: sync.Mutex from Go standard library does not support try-lock
@@ -94,7 +109,7 @@ func Fetch(from_kick bool, taskId string, taskType int) int {
 		log.GetLogger().WithFields(logrus.Fields{
 			"from_kick": from_kick,
 		}).Infoln("Fetching tasks is canceled due to another running fetching or updating process.")
-		return ErrUpdatingProcedureRunning
+		return ErrUpdatingProcedureRunning, ErrFetchingBusy
 	}
 	// Immediately release fetchingTaskLock to let other goroutine fetching
 	// tasks go, but keep updating safe
@@ -106,7 +121,6 @@ func Fetch(from_kick bool, taskId string, taskType int) int {
 	FetchingTaskCounter.Add(1)
 	defer FetchingTaskCounter.Add(-1)
 
-	var task_size int
 	var isColdstart bool
 	fetchReason := FetchOnKickoff
 	if taskType == NormalTaskType && taskId == "" && !_startupFetched.Swap(true) {
@@ -119,18 +133,18 @@ func Fetch(from_kick bool, taskId string, taskType int) int {
 			}).Infoln("Merge the fetch operations for the kick_off task and the startup task.")
 		}
 	}
-	task_size = fetchTasks(fetchReason, taskId, taskType, isColdstart)
+	task_size, err := fetchTasks(fetchReason, taskId, taskType, isColdstart)
 
 	for i := 0; i < 1 && from_kick && task_size == 0; i++ {
 		time.Sleep(time.Duration(3) * time.Second)
-		task_size = fetchTasks(FetchOnKickoff, taskId, taskType, false)
+		task_size, err = fetchTasks(FetchOnKickoff, taskId, taskType, false)
 	}
 
-	return task_size
+	return task_size, err
 }
 
-func fetchTasks(reason FetchReason, taskId string, taskType int, isColdstart bool) int {
-	taskInfos := FetchTaskList(reason, taskId, taskType, isColdstart)
+func fetchTasks(reason FetchReason, taskId string, taskType int, isColdstart bool) (int, error) {
+	taskInfos, err := FetchTaskList(reason, taskId, taskType, isColdstart)
 	SendFiles(taskInfos.sendFiles)
 	DoSessionTask(taskInfos.sessionInfos)
 	for _, v := range taskInfos.runInfos {
@@ -145,7 +159,7 @@ func fetchTasks(reason FetchReason, taskId string, taskType int, isColdstart boo
 		dispatchTestTask(v)
 	}
 
-	return len(taskInfos.runInfos) + len(taskInfos.stopInfos) + len(taskInfos.sessionInfos) + len(taskInfos.sendFiles)
+	return len(taskInfos.runInfos) + len(taskInfos.stopInfos) + len(taskInfos.sessionInfos) + len(taskInfos.sendFiles), err
 }
 
 func dispatchRunTask(taskInfo models.RunTaskInfo) {
@@ -235,6 +249,15 @@ func dispatchStopTask(taskInfo models.RunTaskInfo) {
 		"InvokeVersion": taskInfo.InvokeVersion,
 		"Phase":         "Fetched",
 	}).Info("Fetched to be canceled")
+	audit.Record(audit.Event{
+		Action:  audit.ActionStopTask,
+		Trigger: audit.TriggerServer,
+		Subject: taskInfo.TaskId,
+		Details: map[string]string{
+			"invokeVersion": strconv.Itoa(taskInfo.InvokeVersion),
+			"repeat":        string(taskInfo.Repeat),
+		},
+	})
 
 	cancelLogger := log.GetLogger().WithFields(logrus.Fields{
 		"TaskId":        taskInfo.TaskId,
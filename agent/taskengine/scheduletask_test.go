@@ -92,8 +92,8 @@ func TestFetch(t *testing.T) {
 				defer FetchingTaskLock.Unlock()
 			}
 			if tt.name == "from_kick" {
-				guard := monkey.Patch(fetchTasks, func(reason FetchReason, taskId string, taskType int, isColdstart bool) int {
-					return 10
+				guard := monkey.Patch(fetchTasks, func(reason FetchReason, taskId string, taskType int, isColdstart bool) (int, error) {
+					return 10, nil
 				})
 				defer guard.Unpatch()
 			}
@@ -128,17 +128,17 @@ func Test_fetchTasks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.name == "normal" {
-				monkey.Patch(FetchTaskList, func(reason FetchReason, taskId string, taskType int, isColdstart bool) *taskCollection {
+				monkey.Patch(FetchTaskList, func(reason FetchReason, taskId string, taskType int, isColdstart bool) (*taskCollection, error) {
 					return &taskCollection{
 						runInfos:     []models.RunTaskInfo{models.RunTaskInfo{}},
 						stopInfos:    []models.RunTaskInfo{models.RunTaskInfo{}},
 						testInfos:    []models.RunTaskInfo{models.RunTaskInfo{}},
 						sendFiles:    []models.SendFileTaskInfo{models.SendFileTaskInfo{}},
 						sessionInfos: []models.SessionTaskInfo{models.SessionTaskInfo{}},
-					}
+					}, nil
 				})
 			}
-			if got := fetchTasks(tt.args.reason, tt.args.taskId, tt.args.taskType, tt.args.isColdstart); got != tt.want {
+			if got, _ := fetchTasks(tt.args.reason, tt.args.taskId, tt.args.taskType, tt.args.isColdstart); got != tt.want {
 				t.Errorf("fetchTasks() = %v, want %v", got, tt.want)
 			}
 		})
@@ -273,7 +273,7 @@ func Test_dispatchStopTask(t *testing.T) {
 				defer taskFactory.RemoveTaskByName(tt.args.taskInfo.TaskId)
 			} else if tt.name == "taskRepeatOnce" {
 				var t *Task
-				guard := monkey.PatchInstanceMethod(reflect.TypeOf(t), "Cancel", func(*Task) {})
+				guard := monkey.PatchInstanceMethod(reflect.TypeOf(t), "Cancel", func(*Task, bool) {})
 				defer guard.Unpatch()
 			}
 			dispatchStopTask(tt.args.taskInfo)
@@ -540,7 +540,7 @@ func Test_cancelPeriodicTask(t *testing.T) {
 				})
 				defer GetTaskFactory().RemoveTaskByName(tt.args.taskInfo.TaskId)
 				var t *Task
-				guard := monkey.PatchInstanceMethod(reflect.TypeOf(t), "Cancel", func(*Task) {})
+				guard := monkey.PatchInstanceMethod(reflect.TypeOf(t), "Cancel", func(*Task, bool) {})
 				defer guard.Unpatch()
 			} else if tt.name == "noNeedCancelTask" {
 				timermanager.InitTimerManager()
@@ -562,7 +562,7 @@ func Test_cancelPeriodicTask(t *testing.T) {
 				guard := monkey.Patch(util.HttpPost, func(string, string, string) (string, error) { return "", nil })
 				defer guard.Unpatch()
 			}
-			if err := cancelPeriodicTask(tt.args.taskInfo); (err != nil) != tt.wantErr {
+			if err := cancelPeriodicTask(tt.args.taskInfo, false); (err != nil) != tt.wantErr {
 				t.Errorf("cancelPeriodicTask() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
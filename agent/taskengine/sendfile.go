@@ -6,14 +6,15 @@ import (
 	"io/ioutil"
 	"os"
 	"os/user"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
+	"github.com/aliyun/aliyun_assist_client/agent/policy"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
 	"github.com/aliyun/aliyun_assist_client/agent/util"
 )
@@ -34,6 +35,7 @@ const (
 	EInalidFileMode     = 17
 	EInalidGID          = 18
 	EInalidUID          = 19
+	EPolicyViolation    = 20
 )
 
 var G_IsWindows bool = false
@@ -81,9 +83,12 @@ func SendFileFinished(sendFile models.SendFileTaskInfo, status int) {
 }
 
 func SendFileInvalid(sendFile models.SendFileTaskInfo, status int) {
+	sendFileInvalidWithValue(sendFile, status, "")
+}
+
+func sendFileInvalidWithValue(sendFile models.SendFileTaskInfo, status int, value string) {
 	url := util.GetInvalidTaskService()
 	key := ""
-	value := ""
 	if status == EInvalidFilePath {
 		key = "FileNameInvalid"
 		value = sendFile.Name
@@ -106,6 +111,8 @@ func SendFileInvalid(sendFile models.SendFileTaskInfo, status int) {
 	} else if status == EInalidUID {
 		key = "FileOwnerNotExist"
 		value = sendFile.Owner
+	} else if status == EPolicyViolation {
+		key = "PolicyViolation"
 	}
 	metrics.GetTaskFailedEvent(
 		"taskid", sendFile.TaskID,
@@ -120,7 +127,15 @@ func SendFileInvalid(sendFile models.SendFileTaskInfo, status int) {
 }
 
 func doSendFile(task models.SendFileTaskInfo) {
-	ret := sendFile(task)
+	// The file is written to exactly the path evaluated by execution policy
+	filePath := resolveSendFilePath(task)
+	if violation := checkSendFilePolicy(task, filePath); violation != nil {
+		recordSendFileAudit(task, EPolicyViolation, violation.RuleId)
+		sendFileInvalidWithValue(task, EPolicyViolation, violation.RuleId)
+		return
+	}
+	ret := sendFile(task, filePath)
+	recordSendFileAudit(task, ret, "")
 	log.GetLogger().Println("sendFile ret: ", ret)
 	if ret <= ECreateDirFailed {
 		SendFileFinished(task, ret)
@@ -129,28 +144,17 @@ func doSendFile(task models.SendFileTaskInfo) {
 	}
 }
 
-func sendFile(sendFile models.SendFileTaskInfo) int {
+func sendFile(sendFile models.SendFileTaskInfo, filePath string) int {
 	if sendFile.Name == "" {
 		return EInvalidFilePath
 	}
 	if sendFile.Content == "" {
 		return EEmptyContent
 	}
-	fileDir := ""
-	if sendFile.Destination == "" {
-		if G_IsWindows {
-			currentpath, _ := os.Executable()
-			fileDir, _ = filepath.Abs(filepath.Dir(currentpath))
-
-		} else {
-			fileDir = "/root"
-		}
-	} else {
-		fileDir = sendFile.Destination
-	}
+	fileDir := filepath.Dir(filePath)
 
 	if sendFile.Destination != "" {
-		err := os.MkdirAll(sendFile.Destination, os.ModePerm)
+		err := os.MkdirAll(fileDir, os.ModePerm)
 		if err != nil {
 			log.GetLogger().Errorln("MkdirAll error: ", err)
 			return ECreateDirFailed
@@ -158,11 +162,11 @@ func sendFile(sendFile models.SendFileTaskInfo) int {
 	}
 	if G_IsLinux || G_IsFreebsd {
 		//文件下发时，如果root目录有一个test的文件，又创建了一个/root/test下的文件，则会报错。报错应通过invalid接口上报
-		if util.IsFile(sendFile.Destination) {
+		if util.IsFile(fileDir) {
 			return EInvalidFilePath
 		}
 	}
-	file_path := path.Join(fileDir, sendFile.Name)
+	file_path := filePath
 	fileContent, err := base64.StdEncoding.DecodeString(sendFile.Content)
 	if err != nil {
 		log.GetLogger().Errorln("base64 decode error: ", err)
@@ -192,6 +196,67 @@ func sendFile(sendFile models.SendFileTaskInfo) int {
 	return changeFileOwner(file_path, sendFile.Owner, sendFile.Group)
 }
 
+// recordSendFileAudit records result of send-file request, along with id of
+// the execution policy rule when denied by policy
+func recordSendFileAudit(sendFile models.SendFileTaskInfo, ret int, policyRuleId string) {
+	content, err := base64.StdEncoding.DecodeString(sendFile.Content)
+	if err != nil {
+		content = []byte(sendFile.Content)
+	}
+	details := map[string]string{
+		"filePath":  filepath.Join(sendFileDirectory(sendFile), sendFile.Name),
+		"owner":     sendFile.Owner,
+		"group":     sendFile.Group,
+		"mode":      sendFile.Mode,
+		"overwrite": strconv.FormatBool(sendFile.Overwrite),
+		"result":    strconv.Itoa(ret),
+	}
+	if policyRuleId != "" {
+		details["policyRuleId"] = policyRuleId
+	}
+	audit.Record(audit.Event{
+		Action:  audit.ActionSendFile,
+		Trigger: audit.TriggerServer,
+		Subject: sendFile.TaskID,
+		Details: details,
+		Digests: map[string]string{
+			"content": audit.Digest(content),
+		},
+	})
+}
+
+func sendFileDirectory(sendFile models.SendFileTaskInfo) string {
+	if sendFile.Destination != "" {
+		return sendFile.Destination
+	}
+	if G_IsWindows {
+		currentpath, _ := os.Executable()
+		fileDir, _ := filepath.Abs(filepath.Dir(currentpath))
+		return fileDir
+	}
+	return "/root"
+}
+
+// resolveSendFilePath returns full path of file to be written, with symbolic
+// links in it resolved. The cleaned path is returned when it could not be
+// resolved, which is denied when execution policy exists.
+func resolveSendFilePath(sendFile models.SendFileTaskInfo) string {
+	filePath := filepath.Join(sendFileDirectory(sendFile), sendFile.Name)
+	if resolvedPath, err := util.EvalSymlinksAllowMissing(filePath); err == nil {
+		return resolvedPath
+	}
+	return filePath
+}
+
+// checkSendFilePolicy evaluates full path of file to be written against the
+// local execution policy before anything is written to disk.
+func checkSendFilePolicy(sendFile models.SendFileTaskInfo, filePath string) *policy.Violation {
+	return policy.CheckSendFile(&policy.SendFileRequest{
+		TaskId:   sendFile.TaskID,
+		FilePath: filePath,
+	})
+}
+
 func changeFileOwner(filePath string, User string, Group string) int {
 	if G_IsWindows {
 		return ESuccess
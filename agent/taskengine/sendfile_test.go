@@ -1,13 +1,18 @@
 package taskengine
 
 import (
+	"encoding/base64"
 	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"bou.ke/monkey"
 	"github.com/aliyun/aliyun_assist_client/agent/util"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSendFileFinished(t *testing.T) {
@@ -101,3 +106,30 @@ func TestSendFileInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestSendFileResolvedPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Creating symbolic link requires privilege on Windows")
+	}
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	realDir := filepath.Join(tempDir, "real")
+	assert.NoError(t, os.Mkdir(realDir, 0755))
+	assert.NoError(t, os.Symlink(realDir, filepath.Join(tempDir, "link")))
+
+	content := base64.StdEncoding.EncodeToString([]byte("hello"))
+	task := models.SendFileTaskInfo{
+		TaskID:      "t-test",
+		Name:        "hello.txt",
+		Destination: filepath.Join(tempDir, "link", "sub"),
+		Content:     content,
+		Signature:   util.ComputeStrMd5(content),
+	}
+	// Directory is created along the same resolved path evaluated by policy
+	filePath := resolveSendFilePath(task)
+	assert.Equal(t, filepath.Join(realDir, "sub", "hello.txt"), filePath)
+	assert.Equal(t, ESuccess, sendFile(task, filePath))
+	written, err := os.ReadFile(filepath.Join(realDir, "sub", "hello.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(written))
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
 	"github.com/aliyun/aliyun_assist_client/agent/log"
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
 	"github.com/aliyun/aliyun_assist_client/agent/session/channel"
@@ -27,15 +28,21 @@ type SessionTask struct {
 	portNumber   string
 	flowLimit    int
 
-	sessionChannel *channel.SessionChannel
-	shellPlugin    *shell.ShellPlugin
-	portPlugin     *port.PortPlugin
+	containerId   string
+	containerName string
+
+	sessionChannel       *channel.SessionChannel
+	shellPlugin          *shell.ShellPlugin
+	containerShellPlugin *shell.ContainerShellPlugin
+	portPlugin           *port.PortPlugin
 	cancelFlag     util.CancelFlag
+
+	startTime time.Time
 }
 
 func NewSessionTask(sessionId string, websocketUrl string, taskId string,
 	cmdContent string, username string, passwordName string, targetHost string,
-	portNumber string, flowLimit int) *SessionTask {
+	portNumber string, flowLimit int, containerId string, containerName string) *SessionTask {
 	task := &SessionTask{
 		sessionId:    sessionId,
 		taskId:       taskId,
@@ -48,7 +55,11 @@ func NewSessionTask(sessionId string, websocketUrl string, taskId string,
 		portNumber:   portNumber,
 		flowLimit:    flowLimit,
 
+		containerId:   containerId,
+		containerName: containerName,
+
 		cancelFlag: util.NewChanneledCancelFlag(),
+		startTime:  time.Now(),
 	}
 	return task
 }
@@ -79,6 +90,10 @@ func (sessionTask *SessionTask) isPortForwardTask() bool {
 	return sessionTask.portNumber != "" 
 }
 
+func (sessionTask *SessionTask) isContainerShellTask() bool {
+	return sessionTask.containerId != "" || sessionTask.containerName != ""
+}
+
 func (sessionTask *SessionTask) runTask() (string, error) {
 	ret := GetSessionFactory().ContainsTask(sessionTask.sessionId)
 	if ret == true {
@@ -88,6 +103,8 @@ func (sessionTask *SessionTask) runTask() (string, error) {
 	if sessionTask.isPortForwardTask() {
 		port_num, _ := strconv.Atoi(sessionTask.portNumber)
 		sessionTask.portPlugin = port.NewPortPlugin(sessionTask.sessionId, sessionTask.targetHost, port_num, sessionTask.flowLimit)
+	} else if sessionTask.isContainerShellTask() {
+		sessionTask.containerShellPlugin = shell.NewContainerShellPlugin(sessionTask.sessionId, sessionTask.containerId, sessionTask.containerName, sessionTask.cmdContent, sessionTask.username, sessionTask.flowLimit)
 	} else {
 		sessionTask.shellPlugin = shell.NewShellPlugin(sessionTask.sessionId, sessionTask.cmdContent, sessionTask.username, sessionTask.passwordName, sessionTask.flowLimit)
 	}
@@ -105,6 +122,8 @@ func (sessionTask *SessionTask) runTask() (string, error) {
 	if sessionTask.isPortForwardTask() {
 		session_channel, err = channel.NewSessionChannel(websocketUrl, sessionTask.sessionId, sessionTask.portPlugin.InputStreamMessageHandler, sessionTask.cancelFlag)
 
+	} else if sessionTask.isContainerShellTask() {
+		session_channel, err = channel.NewSessionChannel(websocketUrl, sessionTask.sessionId, sessionTask.containerShellPlugin.InputStreamMessageHandler, sessionTask.cancelFlag)
 	} else {
 		session_channel, err = channel.NewSessionChannel(websocketUrl, sessionTask.sessionId, sessionTask.shellPlugin.InputStreamMessageHandler, sessionTask.cancelFlag)
 		sessionTask.sessionChannel = session_channel
@@ -130,6 +149,9 @@ func (sessionTask *SessionTask) runTask() (string, error) {
 		if sessionTask.isPortForwardTask() {
 			log.GetLogger().Infoln("run portPlugin")
 			error_code, err = sessionTask.portPlugin.Execute(session_channel, sessionTask.cancelFlag)
+		} else if sessionTask.isContainerShellTask() {
+			log.GetLogger().Infoln("run containerShellPlugin")
+			error_code, err = sessionTask.containerShellPlugin.Execute(session_channel, sessionTask.cancelFlag)
 		} else {
 			log.GetLogger().Infoln("run shellPlugin")
 			error_code, err = sessionTask.shellPlugin.Execute(session_channel, sessionTask.cancelFlag)
@@ -160,7 +182,9 @@ func DoSessionTask(tasks []models.SessionTaskInfo) {
 				s.Password,
 				s.TargetHost,
 				s.PortNumber,
-				s.FlowLimit)
+				s.FlowLimit,
+				s.ContainerId,
+				s.ContainerName)
 			session.RunTask(s.SessionId)
 		}
 	}()
@@ -168,7 +192,9 @@ func DoSessionTask(tasks []models.SessionTaskInfo) {
 
 func (sessionTask *SessionTask) RunTask(taskid string) error {
 	log.GetLogger().Infoln("run task", taskid, sessionTask.sessionId)
+	sessionTask.recordAudit(true, "")
 	code, err := sessionTask.runTask()
+	sessionTask.recordAudit(false, code)
 	ReportSessionResult(taskid, code)
 	if sessionTask.sessionChannel != nil {
 		sessionTask.sessionChannel.Close()
@@ -193,9 +219,46 @@ func (sessionTask *SessionTask) RunTask(taskid string) error {
 	return err
 }
 
+func (sessionTask *SessionTask) recordAudit(start bool, code string) {
+	event := audit.Event{
+		Trigger: audit.TriggerServer,
+		Subject: sessionTask.sessionId,
+		Details: map[string]string{
+			"taskId": sessionTask.taskId,
+		},
+	}
+	if sessionTask.isPortForwardTask() {
+		event.Action = audit.ActionPortForwardStart
+		if !start {
+			event.Action = audit.ActionPortForwardEnd
+		}
+		event.Details["targetHost"] = sessionTask.targetHost
+		event.Details["portNumber"] = sessionTask.portNumber
+	} else {
+		event.Action = audit.ActionSessionStart
+		if !start {
+			event.Action = audit.ActionSessionEnd
+		}
+		event.Details["username"] = sessionTask.username
+		if sessionTask.isContainerShellTask() {
+			event.Details["containerId"] = sessionTask.containerId
+			event.Details["containerName"] = sessionTask.containerName
+		}
+		if sessionTask.cmdContent != "" {
+			event.Digests = map[string]string{
+				"content": audit.Digest([]byte(sessionTask.cmdContent)),
+			}
+		}
+	}
+	if !start {
+		event.Details["code"] = code
+	}
+	audit.Record(event)
+}
+
 func (sessionTask *SessionTask) StopTask() error {
 	log.GetLogger().Infoln("stop task", sessionTask.taskId)
-	if sessionTask.shellPlugin != nil || sessionTask.portPlugin != nil {
+	if sessionTask.shellPlugin != nil || sessionTask.containerShellPlugin != nil || sessionTask.portPlugin != nil {
 		sessionTask.cancelFlag.Set(util.Completed)
 	} else {
 		log.GetLogger().Errorln("sesison plugin is invalid")
@@ -0,0 +1,127 @@
+package taskengine
+
+import (
+	"sort"
+	"time"
+)
+
+// InvocationStatus describes an invocation registered in TaskFactory, which
+// is either running or pending in the task pool
+type InvocationStatus struct {
+	TaskId        string
+	InvokeVersion int
+	CommandName   string
+	Repeat        string
+	Running       bool
+	// StartTime is zero when the invocation is still pending
+	StartTime time.Time
+}
+
+// PeriodicTaskStatus describes the schedule of a periodic task
+type PeriodicTaskStatus struct {
+	TaskId        string
+	InvokeVersion int
+	CommandName   string
+	Repeat        string
+	Cronat        string
+	CreationTime  int64
+	Running       bool
+	LastRunTime   time.Time
+	NextRunTime   time.Time
+}
+
+// SessionStatus describes a session task being served
+type SessionStatus struct {
+	SessionId     string
+	TaskId        string
+	Username      string
+	TargetHost    string
+	PortNumber    string
+	ContainerId   string
+	ContainerName string
+	StartTime     time.Time
+}
+
+// ListInvocations returns snapshot of invocations in TaskFactory ordered by
+// task id
+func (t *TaskFactory) ListInvocations() []InvocationStatus {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	invocations := make([]InvocationStatus, 0, len(t.tasks))
+	for _, task := range t.tasks {
+		invocation := InvocationStatus{
+			TaskId:        task.taskInfo.TaskId,
+			InvokeVersion: task.taskInfo.InvokeVersion,
+			CommandName:   task.taskInfo.CommandName,
+			Repeat:        string(task.taskInfo.Repeat),
+		}
+		if runningSince := task.runningSince.Load(); runningSince != 0 {
+			invocation.Running = true
+			invocation.StartTime = time.UnixMilli(runningSince)
+		}
+		invocations = append(invocations, invocation)
+	}
+	sort.Slice(invocations, func(i, j int) bool {
+		return invocations[i].TaskId < invocations[j].TaskId
+	})
+	return invocations
+}
+
+// ListSessions returns snapshot of session tasks ordered by session id
+func (t *SessionFactory) ListSessions() []SessionStatus {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	sessions := make([]SessionStatus, 0, len(t.tasks))
+	for _, task := range t.tasks {
+		sessions = append(sessions, SessionStatus{
+			SessionId:     task.sessionId,
+			TaskId:        task.taskId,
+			Username:      task.username,
+			TargetHost:    task.targetHost,
+			PortNumber:    task.portNumber,
+			ContainerId:   task.containerId,
+			ContainerName: task.containerName,
+			StartTime:     task.startTime,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].SessionId < sessions[j].SessionId
+	})
+	return sessions
+}
+
+// ListPeriodicTasks returns snapshot of periodic task schedules ordered by
+// task id
+func ListPeriodicTasks() []PeriodicTaskStatus {
+	_periodicTaskSchedulesLock.Lock()
+	defer _periodicTaskSchedulesLock.Unlock()
+
+	taskFactory := GetTaskFactory()
+	periodicTasks := make([]PeriodicTaskStatus, 0, len(_periodicTaskSchedules))
+	for _, schedule := range _periodicTaskSchedules {
+		if schedule.reusableInvocation == nil {
+			continue
+		}
+		taskInfo := schedule.reusableInvocation.taskInfo
+		periodicTask := PeriodicTaskStatus{
+			TaskId:        taskInfo.TaskId,
+			InvokeVersion: taskInfo.InvokeVersion,
+			CommandName:   taskInfo.CommandName,
+			Repeat:        string(taskInfo.Repeat),
+			Cronat:        taskInfo.Cronat,
+			CreationTime:  taskInfo.CreationTime,
+			Running:       taskFactory.ContainsTaskByName(taskInfo.TaskId),
+		}
+		if schedule.timer != nil {
+			periodicTask.LastRunTime = schedule.timer.LastRunTime()
+			periodicTask.NextRunTime = schedule.timer.NextRunTime()
+		}
+		periodicTasks = append(periodicTasks, periodicTask)
+	}
+	sort.Slice(periodicTasks, func(i, j int) bool {
+		return periodicTasks[i].TaskId < periodicTasks[j].TaskId
+	})
+	return periodicTasks
+}
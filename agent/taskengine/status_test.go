@@ -0,0 +1,61 @@
+package taskengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/agent/taskengine/models"
+)
+
+func TestListInvocations(t *testing.T) {
+	factory := &TaskFactory{
+		tasks: make(map[string]*Task),
+	}
+	running := &Task{
+		taskInfo: models.RunTaskInfo{
+			TaskId:      "t-running",
+			CommandName: "cmd-running",
+			Repeat:      models.RunTaskOnce,
+		},
+	}
+	startTime := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	running.runningSince.Store(startTime.UnixMilli())
+	pending := &Task{
+		taskInfo: models.RunTaskInfo{
+			TaskId:      "t-pending",
+			CommandName: "cmd-pending",
+			Repeat:      models.RunTaskCron,
+		},
+	}
+	assert.NoError(t, factory.AddTask(running))
+	assert.NoError(t, factory.AddTask(pending))
+
+	invocations := factory.ListInvocations()
+	assert.Len(t, invocations, 2)
+	assert.Equal(t, "t-pending", invocations[0].TaskId)
+	assert.False(t, invocations[0].Running)
+	assert.True(t, invocations[0].StartTime.IsZero())
+	assert.Equal(t, string(models.RunTaskCron), invocations[0].Repeat)
+	assert.Equal(t, "t-running", invocations[1].TaskId)
+	assert.True(t, invocations[1].Running)
+	assert.True(t, startTime.Equal(invocations[1].StartTime))
+}
+
+func TestListSessions(t *testing.T) {
+	factory := &SessionFactory{
+		tasks: make(map[string]*SessionTask),
+	}
+	factory.AddSessionTask(NewSessionTask("s-2", "", "t-2", "", "root", "", "", "", 0, "", ""))
+	factory.AddSessionTask(NewSessionTask("s-1", "", "t-1", "", "", "", "localhost", "22", 0, "", ""))
+
+	sessions := factory.ListSessions()
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "s-1", sessions[0].SessionId)
+	assert.Equal(t, "localhost", sessions[0].TargetHost)
+	assert.Equal(t, "22", sessions[0].PortNumber)
+	assert.Equal(t, "s-2", sessions[1].SessionId)
+	assert.Equal(t, "root", sessions[1].Username)
+	assert.False(t, sessions[1].StartTime.IsZero())
+}
@@ -25,6 +25,20 @@ func NewUnsupportedContainerRuntimeError() NormalizedValidationError {
 	}
 }
 
+func NewInvalidPodSelectorError(cause error) NormalizedValidationError {
+	return &normalizedValidationErrorImpl{
+		category: "InvalidPodSelector",
+		cause: fmt.Errorf("The specified pod selector is not valid. %w", cause),
+	}
+}
+
+func NewPodContainerNotFoundError() NormalizedValidationError {
+	return &normalizedValidationErrorImpl{
+		category: "ContainerNotFound",
+		cause: fmt.Errorf("No running container matches the specified pod selector."),
+	}
+}
+
 func NewContainerNameAndIdNotMatchError(containerId string, expectedName string) NormalizedValidationError {
 	return &normalizedValidationErrorImpl{
 		category: "ContainerNameAndIdNotMatch",
@@ -60,6 +74,20 @@ func NewContainerConnectError(cause error) NormalizedValidationError {
 	}
 }
 
+func NewInvalidEphemeralContainerError(cause error) NormalizedValidationError {
+	return &normalizedValidationErrorImpl{
+		category: "InvalidEphemeralContainer",
+		cause: fmt.Errorf("The specified ephemeral container is not valid. %w", cause),
+	}
+}
+
+func NewContainerImagePullError(cause error) NormalizedExecutionError {
+	return &normalizedExecutionErrorImpl{
+		code: "ContainerImagePullFailed",
+		cause: cause,
+	}
+}
+
 func NewContainerRuntimeInternalError(cause error) NormalizedExecutionError {
 	return &normalizedExecutionErrorImpl{
 		code: "ContainerRuntimeInternalError",
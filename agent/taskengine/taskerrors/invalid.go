@@ -63,6 +63,34 @@ func NewDefaultWorkingDirectoryNotAvailableError(message string) InvalidSettingE
 	}
 }
 
+func NewLoginShellNotAvailableError(cause error) InvalidSettingError {
+	return &settingError{
+		name: "loginShell",
+		shortMessage: "LoginShellNotAvailable",
+		message: fmt.Sprintf("LoginShellNotAvailable: Neither runuser nor su is available to start login shell: %s", cause.Error()),
+		cause: cause,
+	}
+}
+
+func NewLoginShellNotSupportedError(osName string) InvalidSettingError {
+	cause := fmt.Errorf("login shell is not supported on %s", osName)
+	return &settingError{
+		name: "loginShell",
+		shortMessage: "LoginShellNotSupported",
+		message: fmt.Sprintf("LoginShellNotSupported: %s", cause.Error()),
+		cause: cause,
+	}
+}
+
+func NewInvalidSandboxError(cause error) InvalidSettingError {
+	return &settingError{
+		name: "sandbox",
+		shortMessage: "InvalidSandbox",
+		message: fmt.Sprintf("InvalidSandbox: %s", cause.Error()),
+		cause: cause,
+	}
+}
+
 func NewInvalidEnvironmentParameterError(message string) InvalidSettingError {
 	return &settingError{
 		name: "InvalidEnvironmentParameter",
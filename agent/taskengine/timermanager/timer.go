@@ -25,6 +25,9 @@ type Timer struct {
 	rwLock sync.RWMutex
 	isRunning bool
 	err error
+	// Time of next and last invocation of callback, for reporting only
+	nextRunTime time.Time
+	lastRunTime time.Time
 }
 
 var (
@@ -51,6 +54,22 @@ func (t *Timer) IsRunning() bool {
 	return t.isRunning
 }
 
+// NextRunTime returns when callback would be invoked next time, or zero time
+// when the timer is not scheduled anymore
+func (t *Timer) NextRunTime() time.Time {
+	t.rwLock.RLock()
+	defer t.rwLock.RUnlock()
+	return t.nextRunTime
+}
+
+// LastRunTime returns when callback was invoked last time, or zero time if
+// never invoked
+func (t *Timer) LastRunTime() time.Time {
+	t.rwLock.RLock()
+	defer t.rwLock.RUnlock()
+	return t.lastRunTime
+}
+
 func (t *Timer) Run() (*Timer, error) {
 	if t.err != nil {
 		return nil, t.err
@@ -64,10 +83,12 @@ func (t *Timer) Run() (*Timer, error) {
 		return nil, ErrNoNextRun
 	}
 	wrapgo.GoWithDefaultPanicHandler(func() {
+		defer t.setNextRunTime(time.Time{})
 		for shouldContinue := true; shouldContinue; {
 			if durationToWait < 0 {
 				return
 			}
+			t.setNextRunTime(time.Now().Add(durationToWait))
 
 			shouldContinue = func () bool {
 				timer := time.NewTimer(durationToWait)
@@ -112,6 +133,15 @@ func (t *Timer) setRunning(state bool) {
 	t.rwLock.Lock()
 	defer t.rwLock.Unlock()
 	t.isRunning = state
+	if state {
+		t.lastRunTime = time.Now()
+	}
+}
+
+func (t *Timer) setNextRunTime(nextRunTime time.Time) {
+	t.rwLock.Lock()
+	defer t.rwLock.Unlock()
+	t.nextRunTime = nextRunTime
 }
 
 func runTimer(t *Timer) {
@@ -2,6 +2,7 @@ package timermanager
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -93,3 +94,30 @@ func TestRunTimerNoop(t *testing.T) {
 }
 
 // TODO: Concurrent runTimer invocation, detect race condition
+
+func TestTimerRunTimes(t *testing.T) {
+	called := make(chan struct{}, 1)
+	timer := NewTimer(NewMutableScheduled(time.Hour).NotImmediately(), func() {
+		called <- struct{}{}
+	})
+	assert.True(t, timer.NextRunTime().IsZero(), "NextRunTime should be zero before timer runs")
+	assert.True(t, timer.LastRunTime().IsZero(), "LastRunTime should be zero before callback invoked")
+
+	_, err := timer.Run()
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return !timer.NextRunTime().IsZero()
+	}, time.Second, 10*time.Millisecond, "NextRunTime should be set when timer is waiting")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), timer.NextRunTime(), time.Minute)
+
+	timer.SkipWaiting()
+	<-called
+	assert.Eventually(t, func() bool {
+		return !timer.LastRunTime().IsZero()
+	}, time.Second, 10*time.Millisecond, "LastRunTime should be set after callback invoked")
+
+	timer.Stop()
+	assert.Eventually(t, func() bool {
+		return timer.NextRunTime().IsZero()
+	}, time.Second, 10*time.Millisecond, "NextRunTime should be reset after timer stopped")
+}
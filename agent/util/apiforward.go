@@ -0,0 +1,102 @@
+package util
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+var (
+	// ErrForwarderUnavailable is returned by forwarder when the request could
+	// not be forwarded, and the request falls back to HTTP
+	ErrForwarderUnavailable = errors.New("API request forwarder is unavailable")
+
+	_apiForwarder     APIForwarder
+	_apiForwarderLock sync.RWMutex
+
+	// Only frequent requests to API server are forwarded
+	forwardablePaths = map[string]bool{
+		"/luban/api/v1/task/list":     true,
+		"/luban/api/v1/session/list":  true,
+		"/luban/api/v1/task/running":  true,
+		"/luban/api/v1/task/finish":   true,
+		"/luban/api/v1/task/stopped":  true,
+		"/luban/api/v1/task/timeout":  true,
+		"/luban/api/v1/task/error":    true,
+		"/luban/api/v1/task/invalid":  true,
+		"/luban/api/v1/task/verified": true,
+		"/luban/api/heart-beat":       true,
+		"/luban/api/metrics":          true,
+	}
+)
+
+// APIForwarder sends requests to API server through alternative transport,
+// e.g., the persistent websocket connection, instead of new HTTPS request
+type APIForwarder interface {
+	// Forward returns response body and HTTP status code, or
+	// ErrForwarderUnavailable to fall back to HTTP
+	Forward(method string, path string, header map[string]string, body string, timeout time.Duration) (string, int, error)
+}
+
+// SetAPIForwarder sets or clears (nil) the forwarder used by HttpGet and
+// HttpPost series functions
+func SetAPIForwarder(forwarder APIForwarder) {
+	_apiForwarderLock.Lock()
+	defer _apiForwarderLock.Unlock()
+	_apiForwarder = forwarder
+}
+
+// forwardAPIRequest tries to forward request to API server through the
+// forwarder, and reports whether the request is handled. timeout is in
+// seconds like those passed to HttpGetWithTimeout.
+func forwardAPIRequest(method string, rawUrl string, header map[string]string, body string, timeout time.Duration) (string, error, bool) {
+	_apiForwarderLock.RLock()
+	forwarder := _apiForwarder
+	_apiForwarderLock.RUnlock()
+	if forwarder == nil {
+		return "", nil, false
+	}
+	u, err := url.Parse(rawUrl)
+	if err != nil || !forwardablePaths[u.Path] || u.Host != GetServerHost() {
+		return "", nil, false
+	}
+
+	// Forwarded request carries the same headers as HTTP request, e.g., the
+	// signature and instance headers of hybrid instances authenticating it
+	forwardHeader := apiRequestHeaders()
+	for k, v := range header {
+		forwardHeader[k] = v
+	}
+	content, status, err := forwarder.Forward(method, u.RequestURI(), forwardHeader, body, timeout*time.Second)
+	if err != nil {
+		if !errors.Is(err, ErrForwarderUnavailable) {
+			log.GetLogger().WithError(err).Warningln("Failed to forward API request, fall back to HTTP:", rawUrl)
+		}
+		return "", nil, false
+	}
+	if status > 400 {
+		err = requester.NewHttpErrorCode(status)
+	}
+	log.GetLogger().Debugln("forwarded", rawUrl, content, err)
+	return content, err, true
+}
+
+// apiRequestHeaders returns headers sent with every request to API server,
+// i.e., User-Agent and extra headers of the selected API server provider
+func apiRequestHeaders() map[string]string {
+	headers := map[string]string{
+		requester.UserAgentHeader: requester.UserAgentValue,
+	}
+	if extraHeaders, err := requester.GetExtraHTTPHeaders(log.GetLogger()); extraHeaders != nil {
+		for k, v := range extraHeaders {
+			headers[k] = v
+		}
+	} else if err != nil {
+		log.GetLogger().WithError(err).Error("Failed to construct extra HTTP headers")
+	}
+	return headers
+}
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	gomonkey "github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+type fakeForwarder struct {
+	method  string
+	path    string
+	header  map[string]string
+	body    string
+	timeout time.Duration
+
+	content string
+	status  int
+	err     error
+}
+
+func (f *fakeForwarder) Forward(method string, path string, header map[string]string, body string, timeout time.Duration) (string, int, error) {
+	f.method, f.path, f.header, f.body, f.timeout = method, path, header, body, timeout
+	return f.content, f.status, f.err
+}
+
+func TestForwardAPIRequest(t *testing.T) {
+	guard := gomonkey.ApplyFunc(GetServerHost, func() string { return "cn-test.axt.aliyun.com" })
+	defer guard.Reset()
+	defer SetAPIForwarder(nil)
+
+	extraHeadersGuard := gomonkey.ApplyFunc(requester.GetExtraHTTPHeaders, func(logrus.FieldLogger) (map[string]string, error) {
+		return map[string]string{"x-acs-signature": "sig"}, nil
+	})
+	defer extraHeadersGuard.Reset()
+
+	forwarder := &fakeForwarder{content: "ok", status: 200}
+	SetAPIForwarder(forwarder)
+
+	content, err, ok := forwardAPIRequest(http.MethodPost, "https://cn-test.axt.aliyun.com/luban/api/v1/task/finish?taskId=t-1",
+		map[string]string{"Content-Type": "text/plain; charset=utf-8"}, "output", 8)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", content)
+	assert.Equal(t, http.MethodPost, forwarder.method)
+	assert.Equal(t, "/luban/api/v1/task/finish?taskId=t-1", forwarder.path)
+	assert.Equal(t, "output", forwarder.body)
+	assert.Equal(t, 8*time.Second, forwarder.timeout)
+	assert.Equal(t, "text/plain; charset=utf-8", forwarder.header["Content-Type"])
+	assert.Equal(t, requester.UserAgentValue, forwarder.header[requester.UserAgentHeader])
+	assert.Equal(t, "sig", forwarder.header["x-acs-signature"])
+
+	// Error status is converted like HTTP requests
+	forwarder.status = 503
+	_, err, ok = forwardAPIRequest(http.MethodGet, "https://cn-test.axt.aliyun.com/luban/api/heart-beat", nil, "", 5)
+	assert.True(t, ok)
+	assert.Equal(t, requester.NewHttpErrorCode(503).Error(), err.Error())
+
+	// Requests not in allowlist or to other hosts are not forwarded
+	_, _, ok = forwardAPIRequest(http.MethodGet, "https://cn-test.axt.aliyun.com/luban/api/instance/register", nil, "", 5)
+	assert.False(t, ok)
+	_, _, ok = forwardAPIRequest(http.MethodGet, "https://example.com/luban/api/heart-beat", nil, "", 5)
+	assert.False(t, ok)
+
+	// Fall back to HTTP when forwarding failed
+	forwarder.err = ErrForwarderUnavailable
+	_, _, ok = forwardAPIRequest(http.MethodGet, "https://cn-test.axt.aliyun.com/luban/api/heart-beat", nil, "", 5)
+	assert.False(t, ok)
+
+	SetAPIForwarder(nil)
+	_, _, ok = forwardAPIRequest(http.MethodGet, "https://cn-test.axt.aliyun.com/luban/api/heart-beat", nil, "", 5)
+	assert.False(t, ok)
+}
@@ -2,10 +2,13 @@ package util
 
 import (
 	"errors"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
 )
 
 var (
 	ErrRoleNameFailed = errors.New("RoleNameFailed")
 	ErrParameterStoreNotAccessible = errors.New("ParameterStoreNotAccessible")
 	ErrParameterFailed = errors.New("ParameterFailed")
+	ErrFileNotProtected = pathutil.ErrFileNotProtected
 )
@@ -20,6 +20,42 @@ func CheckFileIsExist(filename string) bool {
 	return exist
 }
 
+// CheckFileProtected ensures the file and its parent directory could only be
+// modified by root, or administrators on Windows
+func CheckFileProtected(path string) error {
+	return pathutil.CheckFileProtected(path)
+}
+
+// EvalSymlinksAllowMissing resolves symbolic links in path like
+// filepath.EvalSymlinks, but trailing components not existing yet, e.g., file
+// to be written into directories to be created, are kept as they are. Dangling
+// symbolic link is reported as error, since writing to it creates its target.
+func EvalSymlinksAllowMissing(path string) (string, error) {
+	current := filepath.Clean(path)
+	var missing []string
+	for {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			for i := len(missing) - 1; i >= 0; i-- {
+				resolved = filepath.Join(resolved, missing[i])
+			}
+			return resolved, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if _, lstatErr := os.Lstat(current); lstatErr == nil {
+			return "", fmt.Errorf("%s is a dangling symbolic link: %w", current, err)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", err
+		}
+		missing = append(missing, filepath.Base(current))
+		current = parent
+	}
+}
+
 func WriteStringToFile(path string, content string) error {
 	var d1 = []byte(content)
 	err := ioutil.WriteFile(path, d1, 0666) //写入文件(字节数组)
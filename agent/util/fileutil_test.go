@@ -0,0 +1,36 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalSymlinksAllowMissing(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Creating symbolic links requires privilege on Windows")
+	}
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	targetDir := filepath.Join(tempDir, "target")
+	assert.NoError(t, os.Mkdir(targetDir, 0755))
+	assert.NoError(t, os.Symlink(targetDir, filepath.Join(tempDir, "link")))
+	assert.NoError(t, os.Symlink(filepath.Join(tempDir, "missing"), filepath.Join(tempDir, "dangling")))
+
+	resolved, err := EvalSymlinksAllowMissing(filepath.Join(tempDir, "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, targetDir, resolved)
+
+	// Components not existing yet are kept after resolved parent
+	resolved, err = EvalSymlinksAllowMissing(filepath.Join(tempDir, "link", "sub", "file"))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(targetDir, "sub", "file"), resolved)
+
+	_, err = EvalSymlinksAllowMissing(filepath.Join(tempDir, "dangling"))
+	assert.Error(t, err)
+	_, err = EvalSymlinksAllowMissing(filepath.Join(tempDir, "dangling", "file"))
+	assert.Error(t, err)
+}
@@ -81,3 +81,19 @@ func GetServerHost() string {
 
 	return g_domainId
 }
+
+// RedetectServerHost determines API server domain again, e.g., after network
+// configuration changed, and reports whether the domain changed. Previously
+// determined domain is kept when detection fails.
+func RedetectServerHost() (string, bool, error) {
+	domainId, err := requester.GetServerDomain(log.GetLogger())
+
+	g_domainIdInitLock.Lock()
+	defer g_domainIdInitLock.Unlock()
+	if err != nil || domainId == "" {
+		return g_domainId, false, err
+	}
+	changed := g_domainId != domainId
+	g_domainId = domainId
+	return domainId, changed, nil
+}
@@ -2,7 +2,6 @@ package util
 
 import (
 	"context"
-	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
@@ -48,6 +47,9 @@ func HttpGet(url string) (error, string) {
 }
 
 func HttpGetWithTimeout(url string, timeout time.Duration, noLog bool) (error, string) {
+	if content, err, ok := forwardAPIRequest(http.MethodGet, url, nil, "", timeout); ok {
+		return err, content
+	}
 	req := HttpRequest.Transport(GetHTTPTransport())
 	logger := log.GetLogger().WithFields(logrus.Fields{
 		"url": url,
@@ -56,15 +58,8 @@ func HttpGetWithTimeout(url string, timeout time.Duration, noLog bool) (error, s
 	// 设置超时时间，不设置时，默认30s
 	req.SetTimeout(timeout)
 
-	// Add user-agent header
-	req.SetHeaders(map[string]string{
-		requester.UserAgentHeader: requester.UserAgentValue,
-	})
-	if extraHeaders, err := requester.GetExtraHTTPHeaders(log.GetLogger()); extraHeaders != nil {
-		req.SetHeaders(extraHeaders)
-	} else if err != nil {
-		log.GetLogger().WithError(err).Error("Failed to construct extra HTTP headers")
-	}
+	// Add user-agent header and extra headers
+	req.SetHeaders(apiRequestHeaders())
 
 	res, err := req.Get(url)
 	if err != nil {
@@ -75,9 +70,7 @@ func HttpGetWithTimeout(url string, timeout time.Duration, noLog bool) (error, s
 			// requester._httpTransport being modified
 			req.Transport(requester.PeekHTTPTransport(logger))
 			certPool := requester.PeekRefreshedRootCAs(logger)
-			req.SetTLSClient(&tls.Config{
-				RootCAs: certPool,
-			})
+			req.SetTLSClient(requester.NewTLSConfig(logger, certPool))
 			if res, err = req.Get(url); err == nil {
 				logger.Info("certificate updated")
 				requester.RefreshHTTPCas(logger, certPool)
@@ -109,6 +102,15 @@ func HttpPost(url string, data string, contentType string) (string, error) {
 }
 
 func HttpPostWithTimeout(url string, data string, contentType string, timeout time.Duration, noLog bool) (string, error) {
+	forwardContentType := "application/json; charset=utf-8"
+	if contentType == "text" {
+		forwardContentType = "text/plain; charset=utf-8"
+	}
+	if content, err, ok := forwardAPIRequest(http.MethodPost, url, map[string]string{
+		"Content-Type": forwardContentType,
+	}, data, timeout); ok {
+		return content, err
+	}
 	req := HttpRequest.Transport(GetHTTPTransport())
 	logger := log.GetLogger().WithFields(logrus.Fields{
 		"url": url,
@@ -117,14 +119,7 @@ func HttpPostWithTimeout(url string, data string, contentType string, timeout ti
 	// 设置超时时间，不设置时，默认30s
 	req.SetTimeout(timeout)
 
-	req.SetHeaders(map[string]string{
-		requester.UserAgentHeader: requester.UserAgentValue,
-	})
-	if extraHeaders, err := requester.GetExtraHTTPHeaders(log.GetLogger()); extraHeaders != nil {
-		req.SetHeaders(extraHeaders)
-	} else if err != nil {
-		log.GetLogger().WithError(err).Error("Failed to construct extra HTTP headers")
-	}
+	req.SetHeaders(apiRequestHeaders())
 
 	// 设置Headers
 	if contentType == "text" {
@@ -147,9 +142,7 @@ func HttpPostWithTimeout(url string, data string, contentType string, timeout ti
 			// requester._httpTransport being modified
 			req.Transport(requester.PeekHTTPTransport(logger))
 			certPool := requester.PeekRefreshedRootCAs(logger)
-			req.SetTLSClient(&tls.Config{
-				RootCAs: certPool,
-			})
+			req.SetTLSClient(requester.NewTLSConfig(logger, certPool))
 			if res, err = req.Get(url); err == nil {
 				logger.Info("certificate updated")
 				requester.RefreshHTTPCas(logger, certPool)
@@ -186,7 +179,11 @@ func HttpDownlod(url string, FilePath string) error {
 		// reference `transport` variable.
 		Transport: GetHTTPTransport(),
 	}
-	res, err := client.Get(url)
+	req, err := newDownloadRequest(context.Background(), url)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -200,7 +197,7 @@ func HttpDownlod(url string, FilePath string) error {
 }
 
 func HttpDownloadContext(ctx context.Context, url string, FilePath string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := newDownloadRequest(ctx, url)
 	if err != nil {
 		return err
 	}
@@ -226,6 +223,37 @@ func HttpDownloadContext(ctx context.Context, url string, FilePath string) error
 	return err
 }
 
+// HttpDownloadContextStrict works like HttpDownloadContext, but fails without
+// creating the file when the response status is not 200, instead of saving
+// the error page.
+func HttpDownloadContextStrict(ctx context.Context, url string, filePath string) error {
+	req, err := newDownloadRequest(ctx, url)
+	if err != nil {
+		return err
+	}
+
+	client := http.Client{
+		Transport: GetHTTPTransport(),
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return requester.NewHttpErrorCode(res.StatusCode)
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, res.Body)
+	return err
+}
+
 // HttpDownloadWithTimeout downloads a file from url to filePath with specified
 // timeout. Check if returned error is of type *url.Error and whether url.Error.Timeout
 // method returns true for timeout request.
@@ -237,7 +265,11 @@ func HttpDownloadWithTimeout(url string, filePath string, timeout time.Duration)
 		Transport: GetHTTPTransport(),
 		Timeout:   timeout,
 	}
-	res, err := client.Get(url)
+	req, err := newDownloadRequest(context.Background(), url)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -255,6 +287,20 @@ func HttpDownloadWithTimeout(url string, filePath string, timeout time.Duration)
 	return err
 }
 
+// newDownloadRequest builds the request for downloading packages, which may be
+// redirected to relay agent by selected API server provider
+func newDownloadRequest(ctx context.Context, url string) (*http.Request, error) {
+	url, extraHeaders := requester.RewriteDownloadURL(log.GetLogger(), url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range extraHeaders {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
 func CallApi(httpMethod, url string, parameters map[string]interface{}, respObj interface{}, apiTimeout time.Duration, noLog bool) error {
 	var response string
 	var err error
@@ -37,6 +37,7 @@ type ProcessCmd struct {
 	password     string
 	homeDir      string
 	env          []string
+	sandbox      *SandboxOptions
 
 	commandOptions []CmdOption
 }
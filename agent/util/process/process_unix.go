@@ -53,6 +53,11 @@ func (p *ProcessCmd) prepareProcess() error {
 		}
 	}
 
+	// Sandbox wraps the final command thus must be applied at last
+	if err := p.applySandbox(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,37 @@
+package process
+
+import (
+	"errors"
+)
+
+var ErrSandboxNotSupported = errors.New("sandbox is not supported on this platform")
+
+// SandboxOptions restricts privileges of the command process, which is
+// currently only supported for processes running as root on Linux.
+type SandboxOptions struct {
+	// Names of capabilities kept in bounding set, e.g., CAP_NET_RAW. Other
+	// capabilities are dropped from bounding and inheritable sets, ambient set
+	// is cleared and no_new_privs bit is set, so that dropped capabilities
+	// could not be regained via execve(2). Capabilities are not modified when
+	// nil.
+	KeepCapabilities []string `json:"keepCapabilities"`
+	// Set no_new_privs bit, which prevents privilege escalation via setuid
+	// binaries and file capabilities
+	NoNewPrivileges bool `json:"noNewPrivileges"`
+	// Paths bind-mounted read-only in a private mount namespace, including
+	// mounts under them
+	ReadOnlyPaths []string `json:"readOnlyPaths"`
+	// Mount an empty tmpfs on /tmp in a private mount namespace
+	PrivateTmp bool `json:"privateTmp"`
+	// Run in a new network namespace with only loopback interface
+	NoNetwork bool `json:"noNetwork"`
+}
+
+// SetSandbox sets sandbox options applied when starting the command process
+func (p *ProcessCmd) SetSandbox(sandbox *SandboxOptions) {
+	p.sandbox = sandbox
+}
+
+func (s *SandboxOptions) needMountNamespace() bool {
+	return s.PrivateTmp || len(s.ReadOnlyPaths) > 0
+}
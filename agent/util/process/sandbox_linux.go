@@ -0,0 +1,351 @@
+package process
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Sandbox restrictions like dropping bounding capabilities and setting
+// no_new_privs bit could not be expressed by syscall.SysProcAttr, so the
+// command process is started by re-executing agent itself with special
+// argv[0]. The re-executed process applies restrictions in its init stage and
+// then replaces itself with the real command via execve(2).
+const (
+	sandboxInitArg0   = "aliyun-assist-sandbox-init"
+	sandboxSpecEnvKey = "ALIYUN_ASSIST_SANDBOX_SPEC"
+
+	sandboxInitExitCode = 125
+)
+
+var capabilityNames = map[string]int{
+	"CAP_CHOWN":              unix.CAP_CHOWN,
+	"CAP_DAC_OVERRIDE":       unix.CAP_DAC_OVERRIDE,
+	"CAP_DAC_READ_SEARCH":    unix.CAP_DAC_READ_SEARCH,
+	"CAP_FOWNER":             unix.CAP_FOWNER,
+	"CAP_FSETID":             unix.CAP_FSETID,
+	"CAP_KILL":               unix.CAP_KILL,
+	"CAP_SETGID":             unix.CAP_SETGID,
+	"CAP_SETUID":             unix.CAP_SETUID,
+	"CAP_SETPCAP":            unix.CAP_SETPCAP,
+	"CAP_LINUX_IMMUTABLE":    unix.CAP_LINUX_IMMUTABLE,
+	"CAP_NET_BIND_SERVICE":   unix.CAP_NET_BIND_SERVICE,
+	"CAP_NET_BROADCAST":      unix.CAP_NET_BROADCAST,
+	"CAP_NET_ADMIN":          unix.CAP_NET_ADMIN,
+	"CAP_NET_RAW":            unix.CAP_NET_RAW,
+	"CAP_IPC_LOCK":           unix.CAP_IPC_LOCK,
+	"CAP_IPC_OWNER":          unix.CAP_IPC_OWNER,
+	"CAP_SYS_MODULE":         unix.CAP_SYS_MODULE,
+	"CAP_SYS_RAWIO":          unix.CAP_SYS_RAWIO,
+	"CAP_SYS_CHROOT":         unix.CAP_SYS_CHROOT,
+	"CAP_SYS_PTRACE":         unix.CAP_SYS_PTRACE,
+	"CAP_SYS_PACCT":          unix.CAP_SYS_PACCT,
+	"CAP_SYS_ADMIN":          unix.CAP_SYS_ADMIN,
+	"CAP_SYS_BOOT":           unix.CAP_SYS_BOOT,
+	"CAP_SYS_NICE":           unix.CAP_SYS_NICE,
+	"CAP_SYS_RESOURCE":       unix.CAP_SYS_RESOURCE,
+	"CAP_SYS_TIME":           unix.CAP_SYS_TIME,
+	"CAP_SYS_TTY_CONFIG":     unix.CAP_SYS_TTY_CONFIG,
+	"CAP_MKNOD":              unix.CAP_MKNOD,
+	"CAP_LEASE":              unix.CAP_LEASE,
+	"CAP_AUDIT_WRITE":        unix.CAP_AUDIT_WRITE,
+	"CAP_AUDIT_CONTROL":      unix.CAP_AUDIT_CONTROL,
+	"CAP_SETFCAP":            unix.CAP_SETFCAP,
+	"CAP_MAC_OVERRIDE":       unix.CAP_MAC_OVERRIDE,
+	"CAP_MAC_ADMIN":          unix.CAP_MAC_ADMIN,
+	"CAP_SYSLOG":             unix.CAP_SYSLOG,
+	"CAP_WAKE_ALARM":         unix.CAP_WAKE_ALARM,
+	"CAP_BLOCK_SUSPEND":      unix.CAP_BLOCK_SUSPEND,
+	"CAP_AUDIT_READ":         unix.CAP_AUDIT_READ,
+	"CAP_PERFMON":            unix.CAP_PERFMON,
+	"CAP_BPF":                unix.CAP_BPF,
+	"CAP_CHECKPOINT_RESTORE": unix.CAP_CHECKPOINT_RESTORE,
+}
+
+func init() {
+	if len(os.Args) > 0 && os.Args[0] == sandboxInitArg0 {
+		if err := runSandboxInit(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set up sandbox: %v\n", err)
+			os.Exit(sandboxInitExitCode)
+		}
+	}
+}
+
+// ValidateSandbox checks sandbox options could be applied
+func ValidateSandbox(sandbox *SandboxOptions) error {
+	for _, name := range sandbox.KeepCapabilities {
+		if _, ok := capabilityNames[strings.ToUpper(name)]; !ok {
+			return fmt.Errorf("unknown capability %s", name)
+		}
+	}
+	for _, path := range sandbox.ReadOnlyPaths {
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("read-only path %s is not absolute", path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("read-only path %s is not accessible: %w", path, err)
+		}
+	}
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("sandbox requires agent running as root")
+	}
+	return nil
+}
+
+// applySandbox replaces the command with re-executed agent applying sandbox
+// restrictions, and requests new namespaces for the command process.
+func (p *ProcessCmd) applySandbox() error {
+	if p.sandbox == nil {
+		return nil
+	}
+	if p.user_name != "" {
+		return fmt.Errorf("sandbox could not be applied to command running as user %s", p.user_name)
+	}
+	spec, err := json.Marshal(p.sandbox)
+	if err != nil {
+		return err
+	}
+
+	if p.command.SysProcAttr == nil {
+		p.command.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	if p.sandbox.needMountNamespace() {
+		p.command.SysProcAttr.Cloneflags |= syscall.CLONE_NEWNS
+	}
+	if p.sandbox.NoNetwork {
+		p.command.SysProcAttr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+
+	p.command.Args = append([]string{sandboxInitArg0, p.command.Path}, p.command.Args[1:]...)
+	p.command.Path = "/proc/self/exe"
+	p.command.Env = append(p.command.Env, fmt.Sprintf("%s=%s", sandboxSpecEnvKey, spec))
+	return nil
+}
+
+// runSandboxInit is executed in the re-executed process and never returns on
+// success.
+func runSandboxInit() error {
+	// Capabilities and no_new_privs bit are attributes of thread, thus all
+	// work must be done in the thread calling execve(2).
+	runtime.LockOSThread()
+
+	if len(os.Args) < 2 {
+		return fmt.Errorf("no command specified")
+	}
+	var sandbox SandboxOptions
+	if err := json.Unmarshal([]byte(os.Getenv(sandboxSpecEnvKey)), &sandbox); err != nil {
+		return fmt.Errorf("invalid sandbox spec: %w", err)
+	}
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, sandboxSpecEnvKey+"=") {
+			env = append(env, kv)
+		}
+	}
+
+	if sandbox.needMountNamespace() {
+		if err := setupMounts(&sandbox); err != nil {
+			return err
+		}
+	}
+	if sandbox.NoNetwork {
+		if err := setLoopbackUp(); err != nil {
+			return fmt.Errorf("failed to set up loopback interface: %w", err)
+		}
+	}
+	if sandbox.KeepCapabilities != nil {
+		if err := dropCapabilities(sandbox.KeepCapabilities); err != nil {
+			return err
+		}
+	}
+	// Capabilities dropped from bounding set could still be gained via file
+	// capabilities without no_new_privs bit
+	if sandbox.NoNewPrivileges || sandbox.KeepCapabilities != nil {
+		if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+			return fmt.Errorf("failed to set no_new_privs: %w", err)
+		}
+	}
+
+	return syscall.Exec(os.Args[1], os.Args[1:], env)
+}
+
+func setupMounts(sandbox *SandboxOptions) error {
+	// Stop mount events propagating back to the host mount namespace
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+	if sandbox.PrivateTmp {
+		if err := unix.Mount("tmpfs", "/tmp", "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777"); err != nil {
+			return fmt.Errorf("failed to mount private /tmp: %w", err)
+		}
+	}
+	for _, path := range sandbox.ReadOnlyPaths {
+		if err := bindMountReadOnly(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindMountReadOnly bind-mounts path onto itself recursively, and remounts
+// the bind mount and every mount under it read-only, since read-only flag of
+// bind remount is applied to a single mount even with MS_REC.
+func bindMountReadOnly(path string) error {
+	// Mount points in mountinfo are resolved paths
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve read-only path %s: %w", path, err)
+	}
+	if err := unix.Mount(resolvedPath, resolvedPath, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to bind mount %s: %w", path, err)
+	}
+	mountPoints, err := mountPointsUnder(resolvedPath)
+	if err != nil {
+		return fmt.Errorf("failed to list mounts under %s: %w", path, err)
+	}
+	for _, mountPoint := range mountPoints {
+		var statfs unix.Statfs_t
+		if err := unix.Statfs(mountPoint, &statfs); err != nil {
+			return fmt.Errorf("failed to get flags of mount %s: %w", mountPoint, err)
+		}
+		// Flags like nosuid and noexec must be kept when remounting bind mount,
+		// and ST_* flags share values with MS_* flags
+		keptFlags := uintptr(statfs.Flags) & (unix.MS_NOSUID | unix.MS_NODEV | unix.MS_NOEXEC | unix.MS_NOATIME | unix.MS_NODIRATIME | unix.MS_RELATIME)
+		if err := unix.Mount("", mountPoint, "", unix.MS_BIND|unix.MS_REMOUNT|unix.MS_REC|unix.MS_RDONLY|keptFlags, ""); err != nil {
+			return fmt.Errorf("failed to remount %s read-only: %w", mountPoint, err)
+		}
+	}
+	return nil
+}
+
+// mountPointsUnder returns mount points at or under path in current mount
+// namespace, parents before children
+func mountPointsUnder(path string) ([]string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	var mountPoints []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Mount point is the 5th field, see proc(5)
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mountPoint := unescapeMountInfo(fields[4])
+		if mountPoint != path && !strings.HasPrefix(mountPoint, prefix) {
+			continue
+		}
+		if !seen[mountPoint] {
+			seen[mountPoint] = true
+			mountPoints = append(mountPoints, mountPoint)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mountPoints, nil
+}
+
+// unescapeMountInfo decodes octal escapes like \040 for space in mountinfo
+func unescapeMountInfo(field string) string {
+	if !strings.Contains(field, "\\") {
+		return field
+	}
+	var builder strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if value, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				builder.WriteByte(byte(value))
+				i += 3
+				continue
+			}
+		}
+		builder.WriteByte(field[i])
+	}
+	return builder.String()
+}
+
+// setLoopbackUp brings up the loopback interface in new network namespace,
+// which is down by default.
+func setLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	ifreq, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifreq); err != nil {
+		return err
+	}
+	ifreq.SetUint16(ifreq.Uint16() | unix.IFF_UP)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifreq)
+}
+
+// dropCapabilities drops capabilities not kept from bounding and inheritable
+// sets, and clears ambient set. Capabilities in inheritable and ambient sets
+// are added to permitted set of the command via execve(2) regardless of
+// bounding set.
+func dropCapabilities(keepCapabilities []string) error {
+	keep := make(map[int]bool, len(keepCapabilities))
+	for _, name := range keepCapabilities {
+		capability, ok := capabilityNames[strings.ToUpper(name)]
+		if !ok {
+			return fmt.Errorf("unknown capability %s", name)
+		}
+		keep[capability] = true
+	}
+
+	for capability := 0; capability <= unix.CAP_LAST_CAP; capability++ {
+		if keep[capability] {
+			continue
+		}
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(capability), 0, 0, 0); err != nil {
+			// Capabilities unknown to running kernel
+			if err == unix.EINVAL {
+				continue
+			}
+			return fmt.Errorf("failed to drop capability %d from bounding set: %w", capability, err)
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil && err != unix.EINVAL {
+		return fmt.Errorf("failed to clear ambient capabilities: %w", err)
+	}
+
+	header := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	if err := unix.Capget(&header, &data[0]); err != nil {
+		return fmt.Errorf("failed to get capabilities: %w", err)
+	}
+	for i := range data {
+		var keepMask uint32
+		for capability := range keep {
+			if capability/32 == i {
+				keepMask |= 1 << uint(capability%32)
+			}
+		}
+		data[i].Inheritable &= keepMask
+	}
+	if err := unix.Capset(&header, &data[0]); err != nil {
+		return fmt.Errorf("failed to drop inheritable capabilities: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package process
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestSandboxCapabilities(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Sandbox requires root")
+	}
+	var stdoutWrite bytes.Buffer
+	var stderrWrite bytes.Buffer
+	processer := ProcessCmd{}
+	// no_new_privs bit is set whenever capabilities are dropped
+	processer.SetSandbox(&SandboxOptions{
+		KeepCapabilities: []string{},
+	})
+
+	exitCode, status, err := processer.SyncRun("/tmp",
+		"cat", []string{"/proc/self/status"}, &stdoutWrite, &stderrWrite, nil, nil, 30)
+	assert.NoError(t, err, stderrWrite.String())
+	assert.Equal(t, Success, status)
+	assert.Equal(t, 0, exitCode)
+	assert.Contains(t, stdoutWrite.String(), "CapInh:\t0000000000000000")
+	assert.Contains(t, stdoutWrite.String(), "CapBnd:\t0000000000000000")
+	assert.Contains(t, stdoutWrite.String(), "CapAmb:\t0000000000000000")
+	assert.Contains(t, stdoutWrite.String(), "NoNewPrivs:\t1")
+}
+
+func TestSandboxPrivateTmp(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Sandbox requires root")
+	}
+	marker, err := os.CreateTemp("/tmp", "sandbox-test-")
+	assert.NoError(t, err)
+	marker.Close()
+	defer os.Remove(marker.Name())
+
+	var stdoutWrite bytes.Buffer
+	var stderrWrite bytes.Buffer
+	processer := ProcessCmd{}
+	processer.SetSandbox(&SandboxOptions{
+		PrivateTmp:    true,
+		ReadOnlyPaths: []string{"/etc"},
+	})
+	exitCode, _, _ := processer.SyncRun("/",
+		"sh", []string{"-c", "ls /tmp; touch /etc/sandbox-test 2>&1 || echo readonly"}, &stdoutWrite, &stderrWrite, nil, nil, 30)
+	if exitCode == sandboxInitExitCode {
+		t.Skipf("Mount namespace is not available: %s", stderrWrite.String())
+	}
+	assert.NotContains(t, stdoutWrite.String(), strings.TrimPrefix(marker.Name(), "/tmp/"))
+	assert.Contains(t, stdoutWrite.String(), "readonly")
+	_, err = os.Stat("/etc/sandbox-test")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSandboxReadOnlySubmount(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Sandbox requires root")
+	}
+	dir := t.TempDir()
+	submount := filepath.Join(dir, "sub")
+	assert.NoError(t, os.Mkdir(submount, 0755))
+	if err := unix.Mount("tmpfs", submount, "tmpfs", 0, ""); err != nil {
+		t.Skipf("Could not mount tmpfs: %v", err)
+	}
+	defer unix.Unmount(submount, unix.MNT_DETACH)
+
+	var stdoutWrite bytes.Buffer
+	var stderrWrite bytes.Buffer
+	processer := ProcessCmd{}
+	processer.SetSandbox(&SandboxOptions{
+		ReadOnlyPaths: []string{dir},
+	})
+	exitCode, _, _ := processer.SyncRun("/",
+		"sh", []string{"-c", "touch " + submount + "/sandbox-test 2>&1 || echo readonly"}, &stdoutWrite, &stderrWrite, nil, nil, 30)
+	if exitCode == sandboxInitExitCode {
+		t.Skipf("Mount namespace is not available: %s", stderrWrite.String())
+	}
+	assert.Contains(t, stdoutWrite.String(), "readonly")
+	_, err := os.Stat(filepath.Join(submount, "sandbox-test"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUnescapeMountInfo(t *testing.T) {
+	assert.Equal(t, "/mnt/a b", unescapeMountInfo(`/mnt/a\040b`))
+	assert.Equal(t, "/mnt/a\\", unescapeMountInfo(`/mnt/a\`))
+	assert.Equal(t, "/mnt/plain", unescapeMountInfo("/mnt/plain"))
+}
+
+func TestValidateSandbox(t *testing.T) {
+	assert.Error(t, ValidateSandbox(&SandboxOptions{KeepCapabilities: []string{"CAP_UNKNOWN"}}))
+	assert.Error(t, ValidateSandbox(&SandboxOptions{ReadOnlyPaths: []string{"relative/path"}}))
+}
+
+func TestSandboxNoNetwork(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Sandbox requires root")
+	}
+	var stdoutWrite bytes.Buffer
+	var stderrWrite bytes.Buffer
+	processer := ProcessCmd{}
+	processer.SetSandbox(&SandboxOptions{
+		NoNetwork: true,
+	})
+	exitCode, _, _ := processer.SyncRun("/",
+		"cat", []string{"/proc/net/dev"}, &stdoutWrite, &stderrWrite, nil, nil, 30)
+	if exitCode == sandboxInitExitCode {
+		t.Skipf("Network namespace is not available: %s", stderrWrite.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdoutWrite.String()), "\n")
+	// Two header lines followed by the only loopback interface
+	if assert.Len(t, lines, 3) {
+		assert.Contains(t, lines[2], "lo:")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+// ValidateSandbox reports sandbox is not supported
+func ValidateSandbox(sandbox *SandboxOptions) error {
+	return ErrSandboxNotSupported
+}
+
+func (p *ProcessCmd) applySandbox() error {
+	if p.sandbox == nil {
+		return nil
+	}
+	return ErrSandboxNotSupported
+}
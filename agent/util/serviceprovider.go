@@ -10,6 +10,20 @@ func GetUpdateService() string {
 	return url
 }
 
+// GetLongPollService returns the notify endpoint polled by longpoll channel,
+// which is only served by API servers explicitly providing it
+func GetLongPollService() string {
+	url := "https://" + GetServerHost()
+	url += "/luban/api/v1/notify/poll"
+	return url
+}
+
+func GetLongPollReplyService() string {
+	url := "https://" + GetServerHost()
+	url += "/luban/api/v1/notify/reply"
+	return url
+}
+
 func GetConnectDetectService() string {
 	url := "https://" + GetServerHost()
 	url += "/luban/api/connection_detect"
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/cli"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/i18n"
+
+	"github.com/aliyun/aliyun_assist_client/agent/audit"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+const (
+	AuditVerifyFlagName = "verify"
+	AuditExportFlagName = "export"
+	AuditFileFlagName   = "file"
+)
+
+var (
+	auditLogFlags = []cli.Flag{
+		{
+			Name:         AuditVerifyFlagName,
+			Short:        i18n.T(`verify hash chain of the audit log`, `校验审计日志的哈希链`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+		{
+			Name:         AuditExportFlagName,
+			Short:        i18n.T(`verify and export entries of the audit log as a JSON array`, `校验并以JSON数组格式导出审计日志条目`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+		{
+			Name:         AuditFileFlagName,
+			Shorthand:    'f',
+			Short:        i18n.T(`path of the audit log file, whose rotated files <path>.N are read too. Default: audit log of the installed agent`, `指定审计日志文件路径，其轮转文件<path>.N也会被读取，默认为已安装云助手的审计日志`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+	}
+
+	auditLogCmd = cli.Command{
+		Name:              "audit-log",
+		Short:             i18n.T("Verify or export the local audit log", "校验或导出本地审计日志"),
+		Usage:             "audit-log --verify|--export [flags]",
+		Sample:            "",
+		EnableUnknownFlag: false,
+		Run:               runAuditLogCmd,
+	}
+)
+
+func init() {
+	for j := range auditLogFlags {
+		auditLogCmd.Flags().Add(&auditLogFlags[j])
+	}
+}
+
+func runAuditLogCmd(ctx *cli.Context, args []string) error {
+	// Extract value of persistent flags
+	logPath, _ := ctx.Flags().Get(LogPathFlagName).GetValue()
+	// Extract value of flags just for the command
+	verify := ctx.Flags().Get(AuditVerifyFlagName).IsAssigned()
+	export := ctx.Flags().Get(AuditExportFlagName).IsAssigned()
+	if verify == export {
+		return fmt.Errorf("Exactly one of --%s and --%s must be specified", AuditVerifyFlagName, AuditExportFlagName)
+	}
+
+	log.InitLog("aliyun_assist_main.log", logPath, true)
+
+	auditLogPath, assigned := ctx.Flags().Get(AuditFileFlagName).GetValue()
+	if !assigned {
+		var err error
+		auditLogPath, err = audit.DefaultAuditLogPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	if export {
+		// Export entries verified even if the chain is broken afterwards, and
+		// report the breakage on stderr. Entries are streamed as a JSON array
+		// instead of being held in memory.
+		exported := 0
+		fmt.Print("[")
+		_, err := audit.Verify(auditLogPath, func(entry audit.Entry) error {
+			jsonBytes, err := json.MarshalIndent(entry, "  ", "  ")
+			if err != nil {
+				return err
+			}
+			if exported > 0 {
+				fmt.Print(",")
+			}
+			fmt.Print("\n  ", string(jsonBytes))
+			exported++
+			return nil
+		})
+		if exported > 0 {
+			fmt.Print("\n")
+		}
+		fmt.Println("]")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	verified, err := audit.Verify(auditLogPath, nil)
+	if err != nil {
+		fmt.Printf("Verified %d entries before the chain breaks: %v\n", verified, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Verified %d entries: hash chain is intact\n", verified)
+	return nil
+}
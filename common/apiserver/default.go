@@ -9,20 +9,24 @@ var (
 	_externalExecutableProvider = &ExternalExecutableProvider{}
 	_hybridModeProvider = &HybridModeProvider{}
 	_generalProvider = &GeneralProvider{}
+	_relayProvider = &RelayProvider{}
 
 	defaultRootCAProviders = []requester.CACertificateProvider{
+		_relayProvider,
 		_envProvider,
 		_externalExecutableProvider,
 		_generalProvider,
 	}
 
 	defaultAPIServerProviders = []requester.APIServerProvider{
+		_relayProvider,
 		_externalExecutableProvider,
 		_hybridModeProvider,
 		_generalProvider,
 	}
 
 	defaultRegionIdProviders = []requester.RegionIdProvider{
+		_relayProvider,
 		_externalExecutableProvider,
 		_hybridModeProvider,
 		_generalProvider,
@@ -9,7 +9,6 @@ import (
 	"strings"
 	"sync"
 	"crypto/x509"
-	"crypto/tls"
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 	"github.com/kirinlabs/HttpRequest"
@@ -234,9 +233,7 @@ func httpGetWithoutExtraHeader(logger logrus.FieldLogger, url string) (string, e
 			logger.Info("certificate error, reload certificates and retry")
 			request.Transport(requester.PeekHTTPTransport(logger))
 			certPool := requester.PeekRefreshedRootCAs(logger)
-			request.SetTLSClient(&tls.Config{
-				RootCAs: certPool,
-			})
+			request.SetTLSClient(requester.NewTLSConfig(logger, certPool))
 			if response, err = request.Get(url); err == nil {
 				logger.Info("certificated updated")
 				requester.RefreshHTTPCas(logger, certPool)
@@ -0,0 +1,239 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+const (
+	// RelayClientConfigFilename is the file in cross-version config directory
+	// making the agent access API server through a relay agent
+	RelayClientConfigFilename = "relay-client.json"
+
+	// Headers authenticating downstream agents, which are stripped by relay
+	RelayAgentIdHeader = "X-Assist-Relay-Agent-Id"
+	RelayTokenHeader   = "X-Assist-Relay-Token"
+
+	RelayInfoPath     = "/relay/v1/info"
+	RelayDownloadPath = "/relay/v1/download"
+)
+
+var (
+	// getRelayClientConfigPath is replaced in tests
+	getRelayClientConfigPath = defaultRelayClientConfigPath
+)
+
+// RelayClientConfig specifies the relay agent accessed by this agent
+type RelayClientConfig struct {
+	// host:port of the relay agent
+	Address string `json:"address"`
+	AgentId string `json:"agentId"`
+	Token   string `json:"token"`
+	// CA certificate file verifying the relay agent
+	CACertFile string `json:"caCertFile,omitempty"`
+}
+
+// RelayInfo is served by relay agent for downstream agents
+type RelayInfo struct {
+	RegionId     string `json:"regionId"`
+	ServerDomain string `json:"serverDomain"`
+}
+
+// RelayProvider makes agents without access to API server work through a
+// relay agent, which forwards API requests, websocket channels and package
+// downloads. Relay client config file is loaded once in the process.
+type RelayProvider struct {
+	lock     sync.Mutex
+	regionId string
+
+	loadConfigOnce sync.Once
+	config         *RelayClientConfig
+	configErr      error
+}
+
+func (*RelayProvider) Name() string {
+	return "RelayProvider"
+}
+
+func (p *RelayProvider) CACertificate(logger logrus.FieldLogger, refresh bool) ([]byte, error) {
+	config, err := p.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config.CACertFile == "" {
+		return nil, requester.ErrNotProvided
+	}
+	// CA certificate of relay agent is trusted for all API traffic
+	if err := pathutil.CheckFileProtected(config.CACertFile); err != nil {
+		return nil, fmt.Errorf("refuse CA certificate file of relay agent: %w", err)
+	}
+	pemCerts, err := os.ReadFile(config.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate file of relay agent: %w", err)
+	}
+	return pemCerts, nil
+}
+
+func (p *RelayProvider) ServerDomain(logger logrus.FieldLogger) (string, error) {
+	config, err := p.clientConfig()
+	if err != nil {
+		return "", err
+	}
+	// Relay agent is regarded as available only when it authenticates this
+	// agent successfully
+	info, err := p.fetchInfo(logger, config)
+	if err != nil {
+		logger.WithError(err).Errorln("Failed to access relay agent", config.Address)
+		return "", err
+	}
+	p.lock.Lock()
+	p.regionId = info.RegionId
+	p.lock.Unlock()
+	return config.Address, nil
+}
+
+func (p *RelayProvider) ExtraHTTPHeaders(logger logrus.FieldLogger) (map[string]string, error) {
+	config, err := p.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Headers identifying the instance, e.g., signature of hybrid instance,
+	// are forwarded by relay agent as they are
+	extraHeaders := map[string]string{}
+	if IsHybrid() {
+		if extraHeaders, err = _hybridModeProvider.ExtraHTTPHeaders(logger); err != nil {
+			return nil, err
+		}
+	}
+	extraHeaders[RelayAgentIdHeader] = config.AgentId
+	extraHeaders[RelayTokenHeader] = config.Token
+	return extraHeaders, nil
+}
+
+func (p *RelayProvider) RegionId(logger logrus.FieldLogger) (string, error) {
+	p.lock.Lock()
+	regionId := p.regionId
+	p.lock.Unlock()
+	if regionId != "" {
+		return regionId, nil
+	}
+	if _, err := p.ServerDomain(logger); err != nil {
+		return "", requester.ErrNotProvided
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.regionId == "" {
+		return "", requester.ErrNotProvided
+	}
+	return p.regionId, nil
+}
+
+// RewriteDownloadURL makes packages downloaded through relay agent, which
+// caches them for other downstream agents. Relay agent only downloads over
+// HTTPS, thus plain HTTP URL is upgraded.
+func (p *RelayProvider) RewriteDownloadURL(logger logrus.FieldLogger, rawUrl string) (string, map[string]string, error) {
+	config, err := p.clientConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	if u, err := url.Parse(rawUrl); err == nil && u.Scheme == "http" {
+		u.Scheme = "https"
+		rawUrl = u.String()
+	}
+	relayUrl := "https://" + config.Address + RelayDownloadPath + "?" + url.Values{"url": []string{rawUrl}}.Encode()
+	return relayUrl, map[string]string{
+		RelayAgentIdHeader: config.AgentId,
+		RelayTokenHeader:   config.Token,
+	}, nil
+}
+
+func (p *RelayProvider) fetchInfo(logger logrus.FieldLogger, config *RelayClientConfig) (*RelayInfo, error) {
+	request, err := http.NewRequest(http.MethodGet, "https://"+config.Address+RelayInfoPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set(requester.UserAgentHeader, requester.UserAgentValue)
+	request.Header.Set(RelayAgentIdHeader, config.AgentId)
+	request.Header.Set(RelayTokenHeader, config.Token)
+	client := &http.Client{
+		Transport: requester.GetHTTPTransport(logger),
+		Timeout:   5 * time.Second,
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, requester.NewHttpErrorCode(response.StatusCode)
+	}
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	info := &RelayInfo{}
+	if err := json.Unmarshal(content, info); err != nil {
+		return nil, fmt.Errorf("invalid response of relay agent: %w", err)
+	}
+	return info, nil
+}
+
+func (p *RelayProvider) clientConfig() (*RelayClientConfig, error) {
+	p.loadConfigOnce.Do(func() {
+		p.config, p.configErr = loadRelayClientConfig()
+	})
+	return p.config, p.configErr
+}
+
+// loadRelayClientConfig returns requester.ErrNotProvided when relay is not
+// configured. Config file which could be modified by non-root users is
+// refused, since it redirects all API traffic.
+func loadRelayClientConfig() (*RelayClientConfig, error) {
+	configPath, err := getRelayClientConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, requester.ErrNotProvided
+		}
+		return nil, err
+	}
+	if err := pathutil.CheckFileProtected(configPath); err != nil {
+		return nil, fmt.Errorf("refuse relay client config file: %w", err)
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &RelayClientConfig{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid relay client config file %s: %w", configPath, err)
+	}
+	if config.Address == "" || config.AgentId == "" || config.Token == "" {
+		return nil, fmt.Errorf("address, agentId and token must be specified in relay client config file %s", configPath)
+	}
+	return config, nil
+}
+
+func defaultRelayClientConfigPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, RelayClientConfigFilename), nil
+}
@@ -0,0 +1,95 @@
+package apiserver
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+func setRelayClientConfig(t *testing.T, content string) {
+	configPath := filepath.Join(t.TempDir(), RelayClientConfigFilename)
+	if content != "" {
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	}
+	getRelayClientConfigPath = func() (string, error) { return configPath, nil }
+	t.Cleanup(func() { getRelayClientConfigPath = defaultRelayClientConfigPath })
+}
+
+func TestRelayNotConfigured(t *testing.T) {
+	setRelayClientConfig(t, "")
+	p := &RelayProvider{}
+
+	_, err := p.ServerDomain(logrus.New())
+	assert.ErrorIs(t, err, requester.ErrNotProvided)
+	_, err = p.CACertificate(logrus.New(), false)
+	assert.ErrorIs(t, err, requester.ErrNotProvided)
+}
+
+func TestRelayInvalidConfig(t *testing.T) {
+	setRelayClientConfig(t, `{"address": "10.0.0.5:8443"}`)
+	p := &RelayProvider{}
+
+	_, err := p.ServerDomain(logrus.New())
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, requester.ErrNotProvided)
+}
+
+func TestRelayConfigNotProtected(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("File mode is not checked on Windows")
+	}
+	setRelayClientConfig(t, `{"address": "10.0.0.5:8443", "agentId": "i-test", "token": "secret"}`)
+	configPath, _ := getRelayClientConfigPath()
+	require.NoError(t, os.Chmod(configPath, 0666))
+	p := &RelayProvider{}
+
+	// Relay is not silently skipped, thus API traffic is never sent to
+	// server chosen by non-root users
+	_, err := p.ServerDomain(logrus.New())
+	assert.ErrorIs(t, err, pathutil.ErrFileNotProtected)
+	_, err = p.CACertificate(logrus.New(), false)
+	assert.ErrorIs(t, err, pathutil.ErrFileNotProtected)
+}
+
+func TestRelayRewriteDownloadURL(t *testing.T) {
+	setRelayClientConfig(t, `{"address": "10.0.0.5:8443", "agentId": "i-test", "token": "secret"}`)
+	p := &RelayProvider{}
+	rawUrl := "https://aliyun-client-assist.oss-cn-hangzhou-internal.aliyuncs.com/linux/update.zip?a=1&b=2"
+
+	relayUrl, headers, err := p.RewriteDownloadURL(logrus.New(), rawUrl)
+	require.NoError(t, err)
+	u, err := url.Parse(relayUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5:8443", u.Host)
+	assert.Equal(t, RelayDownloadPath, u.Path)
+	assert.Equal(t, rawUrl, u.Query().Get("url"))
+	assert.Equal(t, map[string]string{
+		RelayAgentIdHeader: "i-test",
+		RelayTokenHeader:   "secret",
+	}, headers)
+
+	// Config file is loaded only once
+	setRelayClientConfig(t, "")
+	_, _, err = p.RewriteDownloadURL(logrus.New(), rawUrl)
+	assert.NoError(t, err)
+}
+
+func TestRelayRewriteHTTPDownloadURL(t *testing.T) {
+	setRelayClientConfig(t, `{"address": "10.0.0.5:8443", "agentId": "i-test", "token": "secret"}`)
+	p := &RelayProvider{}
+
+	relayUrl, _, err := p.RewriteDownloadURL(logrus.New(), "http://repo.aliyun.com/package.rpm")
+	require.NoError(t, err)
+	u, err := url.Parse(relayUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "https://repo.aliyun.com/package.rpm", u.Query().Get("url"))
+}
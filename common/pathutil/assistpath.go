@@ -113,6 +113,22 @@ func GetCrossVersionConfigPath() (string, error) {
 	return crossVersionConfigDir, nil
 }
 
+// GetCrossVersionAuditPath returns directory of audit log shared across
+// versions, so that the hash chain in audit log is not broken by updating.
+func GetCrossVersionAuditPath() (string, error) {
+	crossVersionDir, err := getCrossVersionDir()
+	if err != nil {
+		return "", err
+	}
+
+	crossVersionAuditDir := filepath.Join(crossVersionDir, "audit")
+	if err := MakeSurePath(crossVersionAuditDir); err != nil {
+		return "", err
+	}
+
+	return crossVersionAuditDir, nil
+}
+
 func GetTempPath() (string, error) {
 	goTempDir := os.TempDir()
 
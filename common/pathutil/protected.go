@@ -0,0 +1,9 @@
+package pathutil
+
+import (
+	"errors"
+)
+
+// ErrFileNotProtected is returned by CheckFileProtected for files which could
+// be modified by non-root users
+var ErrFileNotProtected = errors.New("file could be modified by non-root users")
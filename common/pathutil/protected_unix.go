@@ -0,0 +1,29 @@
+//go:build !windows
+
+package pathutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// CheckFileProtected ensures the file and its parent directory are owned by
+// root and not writable by group or others, i.e., could only be modified by
+// root.
+func CheckFileProtected(path string) error {
+	for _, p := range []string{path, filepath.Dir(path)} {
+		fileInfo, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if stat, ok := fileInfo.Sys().(*syscall.Stat_t); ok && stat.Uid != 0 {
+			return fmt.Errorf("%w: %s is owned by uid %d", ErrFileNotProtected, p, stat.Uid)
+		}
+		if fileInfo.Mode().Perm()&0022 != 0 {
+			return fmt.Errorf("%w: %s has mode %s", ErrFileNotProtected, p, fileInfo.Mode().Perm())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+//go:build windows
+
+package pathutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// Access rights on file or directory allowing its content, children, ACL
+	// or owner to be changed
+	fileWriteData       = 0x0002 // FILE_WRITE_DATA, FILE_ADD_FILE
+	fileAppendData      = 0x0004 // FILE_APPEND_DATA, FILE_ADD_SUBDIRECTORY
+	fileDeleteChild     = 0x0040 // FILE_DELETE_CHILD
+	fileModifyingRights = fileWriteData | fileAppendData | fileDeleteChild |
+		windows.DELETE | windows.WRITE_DAC | windows.WRITE_OWNER |
+		windows.GENERIC_WRITE | windows.GENERIC_ALL
+
+	accessAllowedAceType               = 0x0 // ACCESS_ALLOWED_ACE_TYPE
+	accessAllowedObjectAceType         = 0x5 // ACCESS_ALLOWED_OBJECT_ACE_TYPE
+	accessAllowedCallbackAceType       = 0x9 // ACCESS_ALLOWED_CALLBACK_ACE_TYPE
+	accessAllowedCallbackObjectAceType = 0xB // ACCESS_ALLOWED_CALLBACK_OBJECT_ACE_TYPE
+
+	trustedInstallerSid = "S-1-5-80-956008885-3418522649-1831038044-1853292631-2271478464"
+)
+
+// aclHeader and aceHeader are layouts of ACL and ACE headers. Access allowed
+// ACE is followed by its access mask and SID.
+type aclHeader struct {
+	AclRevision byte
+	Sbz1        byte
+	AclSize     uint16
+	AceCount    uint16
+	Sbz2        uint16
+}
+
+type aceHeader struct {
+	AceType  byte
+	AceFlags byte
+	AceSize  uint16
+}
+
+type accessAllowedAce struct {
+	Header   aceHeader
+	Mask     uint32
+	SidStart uint32
+}
+
+// CheckFileProtected ensures the file and its parent directory are owned by
+// SYSTEM, Administrators or TrustedInstaller, and no other account is granted
+// rights to modify them by DACL. Ordinary users could create subdirectories
+// under C:\ProgramData, thus default ACL could not be relied on.
+func CheckFileProtected(path string) error {
+	for _, p := range []string{path, filepath.Dir(path)} {
+		if err := checkSecurityProtected(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSecurityProtected(path string) error {
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.OWNER_SECURITY_INFORMATION|windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return err
+	}
+	owner, _, err := sd.Owner()
+	if err != nil {
+		return err
+	}
+	if !isTrustedSid(owner) {
+		return fmt.Errorf("%w: %s is owned by %s", ErrFileNotProtected, path, owner.String())
+	}
+
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return err
+	}
+	// NULL DACL grants full access to everyone
+	if dacl == nil {
+		return fmt.Errorf("%w: %s has no DACL", ErrFileNotProtected, path)
+	}
+	header := (*aclHeader)(unsafe.Pointer(dacl))
+	offset := uintptr(unsafe.Sizeof(aclHeader{}))
+	for i := 0; i < int(header.AceCount); i++ {
+		if offset+unsafe.Sizeof(aceHeader{}) > uintptr(header.AclSize) {
+			return fmt.Errorf("%w: %s has malformed DACL", ErrFileNotProtected, path)
+		}
+		ace := (*aceHeader)(unsafe.Pointer(uintptr(unsafe.Pointer(dacl)) + offset))
+		offset += uintptr(ace.AceSize)
+		// Inherit-only ACE only applies to children, e.g., CREATOR OWNER
+		if ace.AceFlags&windows.INHERIT_ONLY_ACE != 0 {
+			continue
+		}
+		switch ace.AceType {
+		case accessAllowedAceType, accessAllowedCallbackAceType:
+			allowed := (*accessAllowedAce)(unsafe.Pointer(ace))
+			if allowed.Mask&fileModifyingRights == 0 {
+				continue
+			}
+			sid := (*windows.SID)(unsafe.Pointer(&allowed.SidStart))
+			if !isTrustedSid(sid) {
+				return fmt.Errorf("%w: %s could be modified by %s", ErrFileNotProtected, path, sid.String())
+			}
+		case accessAllowedObjectAceType, accessAllowedCallbackObjectAceType:
+			return fmt.Errorf("%w: %s has unsupported object ACE", ErrFileNotProtected, path)
+		}
+	}
+	return nil
+}
+
+func isTrustedSid(sid *windows.SID) bool {
+	return sid.IsWellKnown(windows.WinLocalSystemSid) ||
+		sid.IsWellKnown(windows.WinBuiltinAdministratorsSid) ||
+		sid.String() == trustedInstallerSid
+}
@@ -67,6 +67,25 @@ func GetExtraHTTPHeaders(logger logrus.FieldLogger) (map[string]string, error) {
 	return extraHeaders, err
 }
 
+// RewriteDownloadURL returns the URL and extra HTTP headers for downloading
+// packages through selected API server provider. Original URL is returned
+// without extra headers when the provider does not rewrite download URLs.
+func RewriteDownloadURL(logger logrus.FieldLogger, url string) (string, map[string]string) {
+	_apiServerProviderLock.RLock()
+	defer _apiServerProviderLock.RUnlock()
+	rewriter, ok := _selectedAPIServerProvider.(DownloadURLRewriter)
+	if !ok {
+		return url, nil
+	}
+
+	rewrittenUrl, extraHeaders, err := rewriter.RewriteDownloadURL(logger, url)
+	if err != nil {
+		logger.WithError(err).Warningf("Selected API server provider %s does not work for rewriting download URL", _selectedAPIServerProvider.Name())
+		return url, nil
+	}
+	return rewrittenUrl, extraHeaders
+}
+
 // unsafeSelectProviderForServerDomain MUST be called with protection under
 // correct locking
 func unsafeSelectProviderForServerDomain(logger logrus.FieldLogger) (string, error) {
@@ -0,0 +1,84 @@
+package requester
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	// CABundleDirname is the directory in cross-version config directory,
+	// where PEM-encoded CA bundles like those of TLS-inspecting proxies are
+	// trusted in addition to preferred Root CA certificate
+	CABundleDirname = "certs.d"
+)
+
+var (
+	caBundleExtensions = []string{".crt", ".pem", ".cer"}
+
+	// getCABundleDir is replaced in tests
+	getCABundleDir = defaultCABundleDir
+)
+
+// loadCABundles reads all CA bundle files in CA bundle directory, which is
+// reloaded every time for refreshing
+func loadCABundles(logger logrus.FieldLogger) [][]byte {
+	dir, err := getCABundleDir()
+	if err != nil {
+		logger.WithError(err).Warning("Failed to locate CA bundle directory")
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.WithError(err).Warningf("Failed to list CA bundle directory %s", dir)
+		}
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		for _, allowed := range caBundleExtensions {
+			if ext == allowed {
+				names = append(names, entry.Name())
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var bundles [][]byte
+	for _, name := range names {
+		bundlePath := filepath.Join(dir, name)
+		pemCerts, err := os.ReadFile(bundlePath)
+		if err != nil {
+			logger.WithError(err).Warningf("Failed to read CA bundle file %s", bundlePath)
+			continue
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(pemCerts) {
+			logger.Warningf("No certificate is found in CA bundle file %s", bundlePath)
+			continue
+		}
+		logger.Infof("Loaded CA bundle file %s", bundlePath)
+		bundles = append(bundles, pemCerts)
+	}
+	return bundles
+}
+
+func defaultCABundleDir() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, CABundleDirname), nil
+}
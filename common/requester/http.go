@@ -1,7 +1,6 @@
 package requester
 
 import (
-	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net"
@@ -40,9 +39,7 @@ func GetHTTPTransport(logger logrus.FieldLogger) *http.Transport {
 			}).DialContext,
 			// TLSClientConfig specifies the TLS configuration, which uses custom
 			// Root CA for assist server
-			TLSClientConfig: &tls.Config{
-				RootCAs: GetRootCAs(logger),
-			},
+			TLSClientConfig: NewTLSConfig(logger, GetRootCAs(logger)),
 			// Enabled HTTP/2 protocol when `TLSClientConfig` is not nil
 			ForceAttemptHTTP2:     true,
 			MaxIdleConns:          100,
@@ -58,9 +55,7 @@ func GetHTTPTransport(logger logrus.FieldLogger) *http.Transport {
 func RefreshHTTPCas(logger logrus.FieldLogger, certPool *x509.CertPool) {
 	_httpTransportLock.Lock()
 	defer _httpTransportLock.Unlock()
-	_httpTransport.TLSClientConfig = &tls.Config{
-		RootCAs: certPool,
-	}
+	_httpTransport.TLSClientConfig = NewTLSConfig(logger, certPool)
 	UpdateRootCAs(logger, certPool)
 }
 
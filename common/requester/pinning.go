@@ -0,0 +1,246 @@
+package requester
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	// PinningConfigFilename is the file in cross-version config directory
+	// enabling SPKI pinning of API server certificates
+	PinningConfigFilename = "pinning.json"
+
+	spkiPinPrefix = "sha256/"
+
+	pinMismatchReportInterval = 10 * time.Minute
+)
+
+var (
+	// Domains of API server are all under these suffixes, e.g.,
+	// cn-hangzhou.axt.aliyun.com and cn-hangzhou-axt.aliyuncs.com
+	defaultPinnedHosts = []string{"axt.aliyun.com", "axt.aliyuncs.com"}
+
+	_pinningOnce sync.Once
+	_pinning     *pinning
+
+	_pinMismatchLock     sync.Mutex
+	_pinMismatchHandler  func(*PinMismatchError)
+	_pinMismatchReported = make(map[string]time.Time)
+
+	// Replaced in tests
+	getPinningConfigPath = defaultPinningConfigPath
+	pinningNow           = time.Now
+)
+
+// PinningConfig specifies public keys pinned for API server connections
+type PinningConfig struct {
+	// Base64-encoded SHA-256 of SubjectPublicKeyInfo, optionally prefixed by
+	// "sha256/". Connection is accepted when any certificate in the verified
+	// chain matches one of pins, thus intermediate or root CA can be pinned.
+	Pins []string `json:"pins"`
+	// Server names pinned, covering their subdomains. Default: axt.aliyun.com
+	// and axt.aliyuncs.com
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// PinMismatchError is returned by TLS handshake when none of certificates
+// presented by pinned host matches configured pins
+type PinMismatchError struct {
+	Host string
+	// SPKI fingerprints of certificates presented by server
+	Fingerprints []string
+}
+
+func (e *PinMismatchError) Error() string {
+	return fmt.Sprintf("certificate pin mismatch for %s: none of presented public keys [%s] is pinned",
+		e.Host, strings.Join(e.Fingerprints, ", "))
+}
+
+type pinning struct {
+	pins  map[string]struct{}
+	hosts []string
+	// Invalid config makes connections to pinned hosts rejected instead of
+	// silently unpinned
+	configErr error
+}
+
+// NewTLSConfig returns TLS client config trusting rootCAs for connections to
+// API server, which enforces certificate pinning when configured
+func NewTLSConfig(logger logrus.FieldLogger, rootCAs *x509.CertPool) *tls.Config {
+	config := &tls.Config{
+		RootCAs: rootCAs,
+	}
+	if p := getPinning(logger); p != nil {
+		config.VerifyConnection = p.verifyConnection
+	}
+	return config
+}
+
+// SetPinMismatchHandler registers the function notified of pin mismatches,
+// at most once per host in 10 minutes
+func SetPinMismatchHandler(handler func(*PinMismatchError)) {
+	_pinMismatchLock.Lock()
+	defer _pinMismatchLock.Unlock()
+	_pinMismatchHandler = handler
+}
+
+// SPKIFingerprint returns the pin of certificate's public key
+func SPKIFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// LoadPinningConfig reads pinning config file, and returns empty config when
+// the file does not exist
+func LoadPinningConfig() (*PinningConfig, error) {
+	configPath, err := getPinningConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &PinningConfig{}, nil
+		}
+		return nil, err
+	}
+	config := &PinningConfig{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid pinning config file %s: %w", configPath, err)
+	}
+	return config, nil
+}
+
+// getPinning loads pinning config once in the process, and returns nil when
+// pinning is not configured
+func getPinning(logger logrus.FieldLogger) *pinning {
+	_pinningOnce.Do(func() {
+		config, err := LoadPinningConfig()
+		if err == nil && len(config.Pins) == 0 {
+			return
+		}
+		_pinning = &pinning{
+			pins:  make(map[string]struct{}),
+			hosts: defaultPinnedHosts,
+		}
+		if err == nil {
+			err = _pinning.apply(config)
+		}
+		if err != nil {
+			logger.WithError(err).Errorln("Invalid certificate pinning config, connections to pinned hosts are rejected")
+			_pinning.configErr = err
+			return
+		}
+		logger.WithFields(logrus.Fields{
+			"pins":  len(_pinning.pins),
+			"hosts": _pinning.hosts,
+		}).Infoln("Certificate pinning enabled")
+	})
+	return _pinning
+}
+
+func (p *pinning) apply(config *PinningConfig) error {
+	if len(config.Pins) == 0 {
+		return errors.New("no pin is specified")
+	}
+	for _, pin := range config.Pins {
+		pin = strings.TrimPrefix(strings.TrimSpace(pin), spkiPinPrefix)
+		if decoded, err := base64.StdEncoding.DecodeString(pin); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("pin %q is not base64-encoded SHA-256", pin)
+		}
+		p.pins[pin] = struct{}{}
+	}
+	if len(config.Hosts) > 0 {
+		p.hosts = nil
+		for _, host := range config.Hosts {
+			host = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(host)), ".")
+			if host == "" {
+				return errors.New("empty host is specified")
+			}
+			p.hosts = append(p.hosts, host)
+		}
+	}
+	return nil
+}
+
+func (p *pinning) pinnedHost(host string) bool {
+	for _, pinned := range p.hosts {
+		if host == pinned || strings.HasSuffix(host, "."+pinned) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pinning) verifyConnection(cs tls.ConnectionState) error {
+	host := strings.ToLower(strings.TrimSuffix(cs.ServerName, "."))
+	if !p.pinnedHost(host) {
+		return nil
+	}
+	if p.configErr != nil {
+		return fmt.Errorf("certificate pinning config is invalid: %w", p.configErr)
+	}
+
+	chains := cs.VerifiedChains
+	if len(chains) == 0 {
+		chains = [][]*x509.Certificate{cs.PeerCertificates}
+	}
+	var fingerprints []string
+	seen := make(map[string]struct{})
+	for _, chain := range chains {
+		for _, cert := range chain {
+			fingerprint := SPKIFingerprint(cert)
+			if _, ok := p.pins[fingerprint]; ok {
+				return nil
+			}
+			if _, ok := seen[fingerprint]; !ok {
+				seen[fingerprint] = struct{}{}
+				fingerprints = append(fingerprints, fingerprint)
+			}
+		}
+	}
+
+	err := &PinMismatchError{
+		Host:         host,
+		Fingerprints: fingerprints,
+	}
+	notifyPinMismatch(err)
+	return err
+}
+
+func notifyPinMismatch(err *PinMismatchError) {
+	_pinMismatchLock.Lock()
+	defer _pinMismatchLock.Unlock()
+	if _pinMismatchHandler == nil {
+		return
+	}
+	now := pinningNow()
+	if last, ok := _pinMismatchReported[err.Host]; ok && now.Sub(last) < pinMismatchReportInterval {
+		return
+	}
+	_pinMismatchReported[err.Host] = now
+	// Handler may send requests, which must not block TLS handshake
+	go _pinMismatchHandler(err)
+}
+
+func defaultPinningConfigPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, PinningConfigFilename), nil
+}
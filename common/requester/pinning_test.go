@@ -0,0 +1,123 @@
+package requester
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+)
+
+// pinnedGet requests TLS test server as example.com, for which its certificate
+// is issued
+func pinnedGet(t *testing.T, server *httptest.Server, p *pinning) error {
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.ServerName = "example.com"
+	transport.TLSClientConfig.VerifyConnection = p.verifyConnection
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err == nil {
+		response.Body.Close()
+	}
+	return err
+}
+
+func mockPinMismatchHandler(t *testing.T) <-chan *PinMismatchError {
+	mismatches := make(chan *PinMismatchError, 10)
+	SetPinMismatchHandler(func(err *PinMismatchError) { mismatches <- err })
+	_pinMismatchReported = make(map[string]time.Time)
+	t.Cleanup(func() { SetPinMismatchHandler(nil) })
+	return mismatches
+}
+
+func TestPinnedConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverPin := SPKIFingerprint(server.Certificate())
+	mismatches := mockPinMismatchHandler(t)
+
+	p := &pinning{pins: make(map[string]struct{}), hosts: defaultPinnedHosts}
+	require.NoError(t, p.apply(&PinningConfig{
+		Pins:  []string{spkiPinPrefix + serverPin},
+		Hosts: []string{"example.com"},
+	}))
+	assert.NoError(t, pinnedGet(t, server, p))
+
+	p = &pinning{pins: make(map[string]struct{}), hosts: defaultPinnedHosts}
+	require.NoError(t, p.apply(&PinningConfig{
+		Pins:  []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="},
+		Hosts: []string{"example.com"},
+	}))
+	for i := 0; i < 2; i++ {
+		err := pinnedGet(t, server, p)
+		var mismatch *PinMismatchError
+		require.True(t, errors.As(err, &mismatch), "unexpected error: %v", err)
+		assert.Equal(t, "example.com", mismatch.Host)
+		assert.Contains(t, mismatch.Fingerprints, serverPin)
+	}
+	// Mismatches of the same host are reported once in an interval
+	select {
+	case mismatch := <-mismatches:
+		assert.Equal(t, "example.com", mismatch.Host)
+	case <-time.After(time.Second):
+		t.Fatal("pin mismatch is not reported")
+	}
+	select {
+	case <-mismatches:
+		t.Fatal("pin mismatch is reported repeatedly")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Hosts not pinned are unaffected
+	p.hosts = []string{"axt.aliyuncs.com"}
+	assert.NoError(t, pinnedGet(t, server, p))
+}
+
+func TestPinnedHost(t *testing.T) {
+	p := &pinning{pins: make(map[string]struct{}), hosts: defaultPinnedHosts}
+	assert.True(t, p.pinnedHost("axt.aliyun.com"))
+	assert.True(t, p.pinnedHost("cn-hangzhou.axt.aliyun.com"))
+	assert.False(t, p.pinnedHost("evilaxt.aliyun.com"))
+	assert.False(t, p.pinnedHost("axt.aliyun.com.example.com"))
+
+	require.NoError(t, p.apply(&PinningConfig{
+		Pins:  []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="},
+		Hosts: []string{".Example.com"},
+	}))
+	assert.True(t, p.pinnedHost("api.example.com"))
+	assert.False(t, p.pinnedHost("badexample.com"))
+}
+
+func TestInvalidPinningConfig(t *testing.T) {
+	p := &pinning{pins: make(map[string]struct{}), hosts: defaultPinnedHosts}
+	assert.Error(t, p.apply(&PinningConfig{Pins: []string{"not-a-pin"}}))
+	assert.Error(t, p.apply(&PinningConfig{}))
+
+	p.configErr = errors.New("invalid")
+	assert.Error(t, p.verifyConnection(tls.ConnectionState{ServerName: "cn-hangzhou.axt.aliyun.com"}))
+	assert.NoError(t, p.verifyConnection(tls.ConnectionState{ServerName: "example.com"}))
+}
+
+func TestLoadCABundles(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	dir := t.TempDir()
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "proxy.crt"), certPem, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("broken"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), certPem, 0644))
+	original := getCABundleDir
+	getCABundleDir = func() (string, error) { return dir, nil }
+	defer func() { getCABundleDir = original }()
+
+	bundles := loadCABundles(logrus.New())
+	assert.Equal(t, [][]byte{certPem}, bundles)
+}
@@ -14,6 +14,12 @@ type APIServerProvider interface {
 	ExtraHTTPHeaders(logger logrus.FieldLogger) (map[string]string, error)
 }
 
+// DownloadURLRewriter is optionally implemented by APIServerProvider, which
+// makes packages downloaded from rewritten URL with extra HTTP headers
+type DownloadURLRewriter interface {
+	RewriteDownloadURL(logger logrus.FieldLogger, url string) (string, map[string]string, error)
+}
+
 type CACertificateProvider interface {
 	Name() string
 
@@ -1,41 +1,264 @@
 package requester
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
 	"golang.org/x/net/http/httpproxy"
+
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	// ProxyConfigFilename is the file in cross-version config directory
+	ProxyConfigFilename = "proxy.json"
+
+	// Legacy environment variable for both HTTP and HTTPS requests
+	proxyEnv      = "ALIYUN_ASSIST_PROXY"
+	httpProxyEnv  = "ALIYUN_ASSIST_HTTP_PROXY"
+	httpsProxyEnv = "ALIYUN_ASSIST_HTTPS_PROXY"
+	noProxyEnv    = "ALIYUN_ASSIST_NO_PROXY"
+
+	// Metaserver is only accessible directly. Requests to localhost and
+	// loopback addresses are never proxied either.
+	defaultNoProxy = "100.100.100.200"
+	// Placeholder of proxy which could not be applied
+	unavailableProxy = "http://proxy.unavailable"
 )
 
 var (
+	ErrProxyUnavailable = errors.New("proxy is configured but unavailable")
+
 	_obtainAssistProxyEnvOnce sync.Once
 	_requestAssistProxyFunc   func(*http.Request) (*url.URL, error)
+
+	// getProxyConfigPath is replaced in tests
+	getProxyConfigPath = defaultProxyConfigPath
 )
 
+// ProxyConfig specifies proxies used by the agent. Settings in config file are
+// overridden by environment variables field by field.
+type ProxyConfig struct {
+	// Proxy URLs for HTTP and HTTPS (including websocket) requests
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// Comma-separated hosts, domain suffixes, IP addresses and CIDRs which are
+	// accessed directly
+	NoProxy string `json:"noProxy,omitempty"`
+	// Basic authentication credential for proxies without userinfo in URL
+	Username string `json:"username,omitempty"`
+	// Password obfuscated by ObfuscateProxyPassword. It is kept confidential
+	// only by permission of the config file.
+	ObfuscatedPassword string `json:"obfuscatedPassword,omitempty"`
+}
+
+// GetProxyFunc returns the function selecting proxy for requests, or nil when
+// no proxy is configured. Configuration is loaded once in the process.
 func GetProxyFunc(logger logrus.FieldLogger) func(*http.Request) (*url.URL, error) {
 	_obtainAssistProxyEnvOnce.Do(func() {
-		assistProxyEnv := os.Getenv("ALIYUN_ASSIST_PROXY")
-		if assistProxyEnv == "" {
-			return
+		_requestAssistProxyFunc = loadProxyFunc(logger)
+	})
+
+	return _requestAssistProxyFunc
+}
+
+func loadProxyFunc(logger logrus.FieldLogger) func(*http.Request) (*url.URL, error) {
+	config, err := LoadProxyConfigFile()
+	if err != nil {
+		logger.WithError(err).Errorln("Failed to load proxy config file, requests via proxy are rejected")
+		return unavailableProxyFunc(err)
+	}
+	config.applyEnv(logger)
+	proxyFunc, err := config.ProxyFunc()
+	if err != nil {
+		// Proxy credential could not be recovered when machine id changed,
+		// e.g., in instance created from a cloned image
+		logger.WithError(err).Errorln("Invalid proxy setting, requests via proxy are rejected")
+		return unavailableProxyFunc(err)
+	}
+	if proxyFunc != nil {
+		logger.WithFields(logrus.Fields{
+			"httpProxy":  redactProxyUrl(config.HTTPProxy),
+			"httpsProxy": redactProxyUrl(config.HTTPSProxy),
+			"noProxy":    config.NoProxy,
+		}).Infoln("Proxy setting applied")
+	}
+	return proxyFunc
+}
+
+// unavailableProxyFunc fails requests which would be sent via proxy rather than
+// sending them directly, since proxy is configured but could not be applied.
+// Requests never proxied, e.g., to metaserver, are still sent directly.
+func unavailableProxyFunc(cause error) func(*http.Request) (*url.URL, error) {
+	err := fmt.Errorf("%w: %v", ErrProxyUnavailable, cause)
+	urlProxyFunc := (&httpproxy.Config{
+		HTTPProxy:  unavailableProxy,
+		HTTPSProxy: unavailableProxy,
+		NoProxy:    defaultNoProxy,
+	}).ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		if proxyUrl, _ := urlProxyFunc(r.URL); proxyUrl == nil {
+			return nil, nil
 		}
+		return nil, err
+	}
+}
 
+// LoadProxyConfigFile reads proxy config file, and returns empty config when
+// the file does not exist
+func LoadProxyConfigFile() (*ProxyConfig, error) {
+	configPath, err := getProxyConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		if os.IsNotExist(err) {
+			return &ProxyConfig{}, nil
+		}
+		return nil, err
+	}
+	// Proxy config redirects all requests and holds proxy credential, thus it
+	// must not be modified or replaced by non-root users
+	if err := pathutil.CheckFileProtected(configPath); err != nil {
+		return nil, fmt.Errorf("refuse proxy config file: %w", err)
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &ProxyConfig{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("invalid proxy config file %s: %w", configPath, err)
+	}
+	return config, nil
+}
+
+// SaveProxyConfigFile writes proxy config file readable only by the owner,
+// or removes it when config is empty. The file is removed again when it could
+// be modified by non-root users, since proxy credential in it is obfuscated
+// rather than encrypted. Running agent applies it after restarted.
+func SaveProxyConfigFile(config *ProxyConfig) error {
+	configPath, err := getProxyConfigPath()
+	if err != nil {
+		return err
+	}
+	if *config == (ProxyConfig{}) {
+		if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if _, err := config.ProxyFunc(); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(configPath, content, 0600); err != nil {
+		return err
+	}
+	if err := pathutil.CheckFileProtected(configPath); err != nil {
+		os.Remove(configPath)
+		return fmt.Errorf("refuse to save proxy config file: %w", err)
+	}
+	return nil
+}
+
+func (c *ProxyConfig) applyEnv(logger logrus.FieldLogger) {
+	if assistProxyEnv := os.Getenv(proxyEnv); assistProxyEnv != "" {
 		logger.WithFields(logrus.Fields{
-			"ALIYUN_ASSIST_PROXY": assistProxyEnv,
+			proxyEnv: redactProxyUrl(assistProxyEnv),
 		}).Infoln("Detected environment variable ALIYUN_ASSIST_PROXY for proxy setting")
-		proxyConfig := &httpproxy.Config{
-			HTTPProxy:  assistProxyEnv,
-			HTTPSProxy: assistProxyEnv,
-			NoProxy:    "",
-			CGI:        false,
+		c.HTTPProxy = assistProxyEnv
+		c.HTTPSProxy = assistProxyEnv
+	}
+	if value := os.Getenv(httpProxyEnv); value != "" {
+		c.HTTPProxy = value
+	}
+	if value := os.Getenv(httpsProxyEnv); value != "" {
+		c.HTTPSProxy = value
+	}
+	if value := os.Getenv(noProxyEnv); value != "" {
+		c.NoProxy = value
+	}
+}
+
+// ProxyFunc builds the function selecting proxy for requests, or returns nil
+// when no proxy is configured
+func (c *ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if c.HTTPProxy == "" && c.HTTPSProxy == "" {
+		return nil, nil
+	}
+	for _, proxy := range []string{c.HTTPProxy, c.HTTPSProxy} {
+		if proxy == "" {
+			continue
 		}
-		urlProxyFunc := proxyConfig.ProxyFunc()
-		_requestAssistProxyFunc = func(r *http.Request) (*url.URL, error) {
-			return urlProxyFunc(r.URL)
+		if _, err := url.Parse(proxy); err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
 		}
-	})
+	}
+	var credential *url.Userinfo
+	if c.Username != "" {
+		password := ""
+		if c.ObfuscatedPassword != "" {
+			var err error
+			if password, err = DeobfuscateProxyPassword(c.ObfuscatedPassword); err != nil {
+				return nil, err
+			}
+		}
+		credential = url.UserPassword(c.Username, password)
+	} else if c.ObfuscatedPassword != "" {
+		return nil, errors.New("proxy password is specified without username")
+	}
 
-	return _requestAssistProxyFunc
+	noProxy := defaultNoProxy
+	if c.NoProxy != "" {
+		noProxy += "," + c.NoProxy
+	}
+	urlProxyFunc := (&httpproxy.Config{
+		HTTPProxy:  c.HTTPProxy,
+		HTTPSProxy: c.HTTPSProxy,
+		NoProxy:    noProxy,
+		CGI:        false,
+	}).ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		proxyUrl, err := urlProxyFunc(r.URL)
+		if proxyUrl == nil || err != nil {
+			return proxyUrl, err
+		}
+		if credential != nil && proxyUrl.User == nil {
+			withCredential := *proxyUrl
+			withCredential.User = credential
+			return &withCredential, nil
+		}
+		return proxyUrl, nil
+	}, nil
+}
+
+func redactProxyUrl(proxy string) string {
+	if u, err := url.Parse(proxy); err == nil {
+		return u.Redacted()
+	}
+	// Proxy without scheme, e.g., proxy.example.com:3128
+	if i := strings.LastIndex(proxy, "@"); i >= 0 {
+		return "xxxxx" + proxy[i:]
+	}
+	return proxy
+}
+
+func defaultProxyConfigPath() (string, error) {
+	configDir, err := pathutil.GetCrossVersionConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, ProxyConfigFilename), nil
 }
@@ -0,0 +1,81 @@
+package requester
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+
+	"github.com/aliyun/aliyun_assist_client/common/machineid"
+)
+
+const (
+	proxyCredentialKeyContext = "aliyun-assist proxy credential v1\n"
+)
+
+var (
+	ErrInvalidObfuscatedPassword = errors.New("invalid obfuscated proxy password")
+
+	// getMachineID is replaced in tests
+	getMachineID = machineid.GetMachineID
+)
+
+// ObfuscateProxyPassword obfuscates proxy password with AES-GCM by a key
+// derived from machine id, which keeps the password from being read at a
+// glance or copied to other machines. It is obfuscation, NOT encryption:
+// machine id is readable by every local user, so the password is protected
+// only by proxy config file being readable by root only, which is ensured by
+// SaveProxyConfigFile and LoadProxyConfigFile.
+func ObfuscateProxyPassword(password string) (string, error) {
+	aead, err := proxyCredentialCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(password), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DeobfuscateProxyPassword recovers password obfuscated by
+// ObfuscateProxyPassword on this machine
+func DeobfuscateProxyPassword(obfuscated string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(obfuscated)
+	if err != nil {
+		return "", ErrInvalidObfuscatedPassword
+	}
+	aead, err := proxyCredentialCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidObfuscatedPassword
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	password, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrInvalidObfuscatedPassword
+	}
+	return string(password), nil
+}
+
+func proxyCredentialCipher() (cipher.AEAD, error) {
+	id, err := getMachineID()
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, errors.New("machine id is empty")
+	}
+	key := sha256.Sum256([]byte(proxyCredentialKeyContext + id))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
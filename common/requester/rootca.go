@@ -47,7 +47,8 @@ func GetRootCAs(logger logrus.FieldLogger) *x509.CertPool {
 			break
 		}
 	}
-	if pemCerts == nil {
+	bundles := loadCABundles(logger)
+	if pemCerts == nil && len(bundles) == 0 {
 		logger.Warning("No preferred Root CA certificate is provided. Only system CAs would be certified.")
 		_rootCAs = nil
 		return nil
@@ -59,6 +60,9 @@ func GetRootCAs(logger logrus.FieldLogger) *x509.CertPool {
 		certPool = x509.NewCertPool()
 	}
 	certPool.AppendCertsFromPEM(pemCerts)
+	for _, bundle := range bundles {
+		certPool.AppendCertsFromPEM(bundle)
+	}
 
 	_rootCAs = certPool
 	return _rootCAs
@@ -80,7 +84,8 @@ func PeekRefreshedRootCAs(logger logrus.FieldLogger) *x509.CertPool {
 			break
 		}
 	}
-	if pemCerts == nil {
+	bundles := loadCABundles(logger)
+	if pemCerts == nil && len(bundles) == 0 {
 		logger.Warning("No preferred Root CA certificate is provided. Only system CAs would be certified.")
 		return nil
 	}
@@ -93,6 +98,9 @@ func PeekRefreshedRootCAs(logger logrus.FieldLogger) *x509.CertPool {
 		certPool = certPool.Clone()
 	}
 	certPool.AppendCertsFromPEM(pemCerts)
+	for _, bundle := range bundles {
+		certPool.AppendCertsFromPEM(bundle)
+	}
 
 	return certPool
 }
@@ -3,11 +3,16 @@ package zipfile
 import (
 	"archive/zip"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
+var ErrIllegalPath = errors.New("illegal file path in zip archive")
+
 func PeekFile(zipFile string, target string) ([]byte, error) {
 	return PeekFileContext(context.Background(), zipFile, target)
 }
@@ -63,7 +68,10 @@ func UnzipContext(ctx context.Context, zipFile string, destDir string) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			fpath := filepath.Join(destDir, f.Name)
+			fpath, err := EntryPath(destDir, f.Name)
+			if err != nil {
+				return err
+			}
 			if f.FileInfo().IsDir() {
 				os.MkdirAll(fpath, os.ModePerm)
 			} else {
@@ -95,3 +103,20 @@ func UnzipContext(ctx context.Context, zipFile string, destDir string) error {
 	}
 	return nil
 }
+
+// EntryPath returns the path where entry of the name is extracted under
+// destDir, and rejects absolute names or names escaping destDir via "..",
+// which would overwrite arbitrary files when extracted.
+func EntryPath(destDir string, name string) (string, error) {
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	if name == "" || strings.HasPrefix(slashed, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %s", ErrIllegalPath, name)
+	}
+	cleanedDest := filepath.Clean(destDir)
+	fpath := filepath.Join(cleanedDest, name)
+	rel, err := filepath.Rel(cleanedDest, fpath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrIllegalPath, name)
+	}
+	return fpath, nil
+}
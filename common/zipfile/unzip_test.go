@@ -0,0 +1,56 @@
+package zipfile
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeZip(t *testing.T, names ...string) string {
+	zipPath := filepath.Join(t.TempDir(), "test.zip")
+	f, err := os.Create(zipPath)
+	assert.NoError(t, err)
+	defer f.Close()
+	zipWriter := zip.NewWriter(f)
+	for _, name := range names {
+		w, err := zipWriter.Create(name)
+		assert.NoError(t, err)
+		w.Write([]byte(name))
+	}
+	assert.NoError(t, zipWriter.Close())
+	return zipPath
+}
+
+func TestEntryPath(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "dest")
+	entryPath, err := EntryPath(destDir, "a/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(destDir, "a", "b.txt"), entryPath)
+	entryPath, err = EntryPath(destDir, "a/../b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(destDir, "b.txt"), entryPath)
+
+	for _, name := range []string{"", "../b.txt", "a/../../b.txt", "/etc/passwd", "..", `\evil.txt`} {
+		_, err := EntryPath(destDir, name)
+		assert.True(t, errors.Is(err, ErrIllegalPath), name)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "dest")
+	assert.NoError(t, Unzip(writeZip(t, "a/b.txt", "c.txt"), destDir))
+	content, err := os.ReadFile(filepath.Join(destDir, "a", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a/b.txt", string(content))
+
+	parentDir := t.TempDir()
+	destDir = filepath.Join(parentDir, "dest")
+	err = Unzip(writeZip(t, "../escaped.txt"), destDir)
+	assert.True(t, errors.Is(err, ErrIllegalPath))
+	_, err = os.Stat(filepath.Join(parentDir, "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
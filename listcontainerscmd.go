@@ -26,6 +26,10 @@ const (
 	JsonFlagName    = "json"
 	SourceFlagName  = "source"
 	TimeoutFlagName = "timeout"
+
+	PodNamespaceFlagName = "namespace"
+	PodNameFlagName      = "pod"
+	SelectorFlagName     = "selector"
 )
 
 var (
@@ -49,7 +53,7 @@ var (
 		{
 			Name:         SourceFlagName,
 			Shorthand:    's',
-			Short:        i18n.T(`set source interface from which containers are listed. Possibles are "cri", "docker", or "all". Default: "all"`, `指定列出容器的数据来源，可选的值有 "cri"、"docker" 或者全部列出 "all"。默认为 "all"`),
+			Short:        i18n.T(`set source interface from which containers are listed. Possibles are "cri", "docker", "podman", "containerd", or "all". Default: "all"`, `指定列出容器的数据来源，可选的值有 "cri"、"docker"、"podman"、"containerd" 或者全部列出 "all"。默认为 "all"`),
 			AssignedMode: cli.AssignedOnce,
 			Category:     "caller",
 		},
@@ -61,6 +65,26 @@ var (
 			AssignedMode: cli.AssignedOnce,
 			Category:     "caller",
 		},
+		{
+			Name:         PodNamespaceFlagName,
+			Shorthand:    'n',
+			Short:        i18n.T(`only list containers in pods of the Kubernetes namespace`, `只列出指定 Kubernetes 命名空间中 Pod 的容器`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         PodNameFlagName,
+			Shorthand:    'p',
+			Short:        i18n.T(`only list containers in the Kubernetes pod, which is in "default" namespace unless specified`, `只列出指定 Kubernetes Pod 中的容器，未指定命名空间时为 "default" 命名空间`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         SelectorFlagName,
+			Short:        i18n.T(`only list containers in Kubernetes pods matching the label selector, e.g., app=nginx,tier!=cache`, `只列出标签匹配指定选择器的 Kubernetes Pod 中的容器，如 app=nginx,tier!=cache`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
 	}
 
 	listContainersCmd = cli.Command{
@@ -97,12 +121,27 @@ func runListContainersCmd(ctx *cli.Context, args []string) error {
 	dataSourceName := defaultDataSourceName
 	if value, assigned := ctx.Flags().Get(SourceFlagName).GetValue(); assigned {
 		switch value {
-		case "all", "cri", "docker":
+		case "all", "cri", "docker", "podman", "containerd":
 			dataSourceName = value
 		case "":
-			return fmt.Errorf(`Specified source must not be empty and should be one of "all", "cri" or "docker"`)
+			return fmt.Errorf(`Specified source must not be empty and should be one of "all", "cri", "docker", "podman" or "containerd"`)
 		default:
-			return fmt.Errorf(`Specified source is neither of "all", "cri" or "docker": %s`, value)
+			return fmt.Errorf(`Specified source is neither of "all", "cri", "docker", "podman" or "containerd": %s`, value)
+		}
+	}
+
+	var podSelector *model.PodSelector
+	podNamespace, _ := ctx.Flags().Get(PodNamespaceFlagName).GetValue()
+	podName, _ := ctx.Flags().Get(PodNameFlagName).GetValue()
+	labelSelector, _ := ctx.Flags().Get(SelectorFlagName).GetValue()
+	if model.IsPodSelectorSpecified(podNamespace, podName, labelSelector) {
+		podSelector = &model.PodSelector{
+			Namespace:     podNamespace,
+			PodName:       podName,
+			LabelSelector: labelSelector,
+		}
+		if err := podSelector.Validate(); err != nil {
+			return printErrorOrReturn(err, useJsonFormat)
 		}
 	}
 
@@ -120,6 +159,7 @@ func runListContainersCmd(ctx *cli.Context, args []string) error {
 		ConnectTimeout:    connectTimeout,
 		DataSourceName:    dataSourceName,
 		ShowAllContainers: showAllContainers,
+		PodSelector:       podSelector,
 	})
 	if len(containers) == 0 && err != nil {
 		return printErrorOrReturn(err, useJsonFormat)
@@ -156,9 +196,9 @@ func printErrorOrReturn(err error, useJsonFormat bool) error {
 }
 
 func printContainerListText(containers []model.Container) {
-	tbl := table.New("Container Id", "Container Name", "Pod Name", "Runtime", "State", "Data Source")
+	tbl := table.New("Container Id", "Container Name", "Namespace", "Pod Namespace", "Pod Name", "Runtime", "State", "Data Source")
 	for _, c := range containers {
-		tbl.AddRow(c.Id, c.Name, c.PodName, c.RuntimeName, c.State, c.DataSource)
+		tbl.AddRow(c.Id, c.Name, c.Namespace, c.PodNamespace, c.PodName, c.RuntimeName, c.State, c.DataSource)
 	}
 	tbl.Print()
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/rodaine/table"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/cli"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/i18n"
+
+	"github.com/aliyun/aliyun_assist_client/agent/ipc/client"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+)
+
+const (
+	LogLevelTTLFlagName   = "ttl"
+	LogLevelResetFlagName = "reset"
+)
+
+var (
+	logLevelFlags = []cli.Flag{
+		{
+			Name:         LogLevelFlagName,
+			Short:        i18n.T(`log level to set: panic, fatal, error, warning, info, debug or trace`, `要设置的日志级别：panic、fatal、error、warning、info、debug或trace`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         LogModuleFlagName,
+			Short:        i18n.T(`module whose log level is set or reset, covering its sub-modules, e.g., taskengine. Default: the whole agent`, `设置或重置日志级别的模块，包括其子模块，如taskengine。默认为整个Agent`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         LogLevelTTLFlagName,
+			Short:        i18n.T(`seconds before the log level is reverted automatically, at most 86400. Default: 1800`, `日志级别自动恢复前的秒数，最大为86400，默认为1800`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         LogLevelResetFlagName,
+			Short:        i18n.T(`revert the log level of the module immediately`, `立即恢复模块的日志级别`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+	}
+
+	logLevelCmd = cli.Command{
+		Name:              "loglevel",
+		Short:             i18n.T("Show, change or reset log levels of the running agent", "查看、修改或重置正在运行的Agent的日志级别"),
+		Usage:             "loglevel [--level <level> [--ttl <seconds>] | --reset] [--module <module>]",
+		Sample:            "",
+		EnableUnknownFlag: false,
+		Run:               runLogLevelCmd,
+	}
+)
+
+func init() {
+	for j := range logLevelFlags {
+		logLevelCmd.Flags().Add(&logLevelFlags[j])
+	}
+}
+
+func runLogLevelCmd(ctx *cli.Context, args []string) error {
+	// Extract value of persistent flags
+	logPath, _ := ctx.Flags().Get(LogPathFlagName).GetValue()
+	// Extract value of flags just for the command
+	level, setLevel := ctx.Flags().Get(LogLevelFlagName).GetValue()
+	module, _ := ctx.Flags().Get(LogModuleFlagName).GetValue()
+	reset := ctx.Flags().Get(LogLevelResetFlagName).IsAssigned()
+	ttlSeconds := 0
+	if value, assigned := ctx.Flags().Get(LogLevelTTLFlagName).GetValue(); assigned {
+		var err error
+		if ttlSeconds, err = strconv.Atoi(value); err != nil || ttlSeconds <= 0 {
+			return fmt.Errorf("Invalid ttl: %s", value)
+		}
+	}
+	if setLevel && reset {
+		return fmt.Errorf("--%s and --%s must not be specified together", LogLevelFlagName, LogLevelResetFlagName)
+	}
+
+	log.InitLog(agentLogFilename, logPath, true)
+
+	if setLevel {
+		expireTimestamp, err := client.SetLogLevel(module, level, ttlSeconds)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to set log level of agent:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Log level of %s is set to %s until %s\n", logLevelModuleName(module), level, formatTimestamp(expireTimestamp))
+		return nil
+	}
+	if reset {
+		if err := client.ResetLogLevel(module); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to reset log level of agent:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Log level of %s is reset\n", logLevelModuleName(module))
+		return nil
+	}
+
+	levels, err := client.ListLogLevels()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to get log levels of agent:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Default level: %s\n\n", levels.DefaultLevel)
+	tbl := table.New("Module", "Level", "Expire")
+	for _, moduleLevel := range levels.Levels {
+		tbl.AddRow(logLevelModuleName(moduleLevel.Module), moduleLevel.Level, formatTimestamp(moduleLevel.ExpireTimestamp))
+	}
+	tbl.Print()
+	return nil
+}
+
+func logLevelModuleName(module string) string {
+	if module == "" {
+		return "*"
+	}
+	return module
+}
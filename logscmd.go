@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/cli"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/i18n"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/sirupsen/logrus"
+
+	pb "github.com/aliyun/aliyun_assist_client/agent/ipc/agrpc"
+	"github.com/aliyun/aliyun_assist_client/agent/ipc/client"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+)
+
+const (
+	FollowFlagName    = "follow"
+	LinesFlagName     = "lines"
+	LogLevelFlagName  = "level"
+	LogModuleFlagName = "module"
+	TaskIdFlagName    = "task-id"
+
+	agentLogFilename = "aliyun_assist_main.log"
+	defaultLogLines  = 100
+	// Long lines in log file, e.g., task output, are allowed up to the size
+	maxLogLineBytes = 1024 * 1024
+)
+
+var (
+	logsFlags = []cli.Flag{
+		{
+			Name:         FollowFlagName,
+			Shorthand:    'f',
+			Short:        i18n.T(`stream live log entries from the running agent until interrupted`, `持续输出正在运行的Agent的实时日志，直到被中断`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+		{
+			Name:         LinesFlagName,
+			Shorthand:    'n',
+			Short:        i18n.T(`number of last lines printed from the log file. Default: 100`, `从日志文件输出的最后行数，默认为100`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         LogLevelFlagName,
+			Short:        i18n.T(`most verbose level printed: panic, fatal, error, warning, info, debug or trace. Default: info`, `输出的最详细日志级别：panic、fatal、error、warning、info、debug或trace，默认为info`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         LogModuleFlagName,
+			Short:        i18n.T(`only print entries of the module and its sub-modules, e.g., taskengine. Requires --follow`, `仅输出指定模块及其子模块的日志，如taskengine。需要与--follow同时使用`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         TaskIdFlagName,
+			Short:        i18n.T(`only print entries of the task`, `仅输出指定任务的日志`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         JsonFlagName,
+			Short:        i18n.T(`print each live log entry as a line of JSON. Requires --follow`, `以每行一个JSON的格式输出实时日志。需要与--follow同时使用`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+	}
+
+	logsCmd = cli.Command{
+		Name:              "logs",
+		Short:             i18n.T("Print recent logs of the agent, or stream live logs with --follow", "输出云助手的最近日志，或通过--follow持续输出实时日志"),
+		Usage:             "logs [--follow] [flags]",
+		Sample:            "",
+		EnableUnknownFlag: false,
+		Run:               runLogsCmd,
+	}
+
+	logLineLevelPattern  = regexp.MustCompile(`\blevel=(\w+)`)
+	logLineTaskIdPattern = regexp.MustCompile(`\bTaskId="?([^\s"]+)`)
+)
+
+func init() {
+	for j := range logsFlags {
+		logsCmd.Flags().Add(&logsFlags[j])
+	}
+}
+
+func runLogsCmd(ctx *cli.Context, args []string) error {
+	// Extract value of persistent flags
+	logPath, _ := ctx.Flags().Get(LogPathFlagName).GetValue()
+	// Extract value of flags just for the command
+	follow := ctx.Flags().Get(FollowFlagName).IsAssigned()
+	useJsonFormat := ctx.Flags().Get(JsonFlagName).IsAssigned()
+	module, _ := ctx.Flags().Get(LogModuleFlagName).GetValue()
+	taskId, _ := ctx.Flags().Get(TaskIdFlagName).GetValue()
+	levelName, _ := ctx.Flags().Get(LogLevelFlagName).GetValue()
+	level := logrus.InfoLevel
+	if levelName != "" {
+		var err error
+		if level, err = logrus.ParseLevel(strings.ToLower(levelName)); err != nil {
+			return err
+		}
+	}
+	lines := defaultLogLines
+	if value, assigned := ctx.Flags().Get(LinesFlagName).GetValue(); assigned {
+		var err error
+		if lines, err = strconv.Atoi(value); err != nil || lines < 0 {
+			return fmt.Errorf("Invalid number of lines: %s", value)
+		}
+	}
+	if !follow && (module != "" || useJsonFormat) {
+		return fmt.Errorf("--%s and --%s require --%s", LogModuleFlagName, JsonFlagName, FollowFlagName)
+	}
+
+	log.InitLog(agentLogFilename, logPath, true)
+
+	if !follow {
+		return printLogFileTail(lines, level, taskId)
+	}
+
+	streamCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := client.StreamLogs(streamCtx, &pb.StreamLogsReq{
+		Level:  level.String(),
+		Module: module,
+		TaskId: taskId,
+	}, func(entry *pb.LogEntry) {
+		if entry.Dropped > 0 {
+			fmt.Fprintf(os.Stderr, "%d entries dropped\n", entry.Dropped)
+		}
+		if useJsonFormat {
+			jsonBytes, _ := json.Marshal(entry)
+			fmt.Println(string(jsonBytes))
+			return
+		}
+		fmt.Println(formatLogEntry(entry))
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to stream logs of agent:", err)
+		os.Exit(1)
+	}
+	return nil
+}
+
+func formatLogEntry(entry *pb.LogEntry) string {
+	var builder strings.Builder
+	builder.WriteString(time.UnixMilli(entry.Timestamp).Format("2006-01-02 15:04:05.000"))
+	fmt.Fprintf(&builder, " %-7s %s: %s", strings.ToUpper(entry.Level), entry.Module, entry.Message)
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&builder, " %s=%q", k, entry.Fields[k])
+	}
+	return builder.String()
+}
+
+// printLogFileTail prints last lines of current agent log file which match
+// level and taskId
+func printLogFileTail(lines int, level logrus.Level, taskId string) error {
+	logDir, err := pathutil.GetLogPath()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(filepath.Join(logDir, agentLogFilename))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tail := make([]string, 0, lines)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !logLineMatches(line, level, taskId) {
+			continue
+		}
+		if lines == 0 {
+			continue
+		}
+		if len(tail) == lines {
+			tail = tail[1:]
+		}
+		tail = append(tail, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, line := range tail {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func logLineMatches(line string, level logrus.Level, taskId string) bool {
+	if match := logLineLevelPattern.FindStringSubmatch(line); match != nil {
+		if lineLevel, err := logrus.ParseLevel(match[1]); err == nil && lineLevel > level {
+			return false
+		}
+	}
+	if taskId != "" {
+		match := logLineTaskIdPattern.FindStringSubmatch(line)
+		if match == nil || match[1] != taskId {
+			return false
+		}
+	}
+	return true
+}
@@ -22,6 +22,12 @@ func main() {
 
 	rootCmd.AddSubCommand(&listContainersCmd)
 	rootCmd.AddSubCommand(&dataEncryptionCmd)
+	rootCmd.AddSubCommand(&auditLogCmd)
+	rootCmd.AddSubCommand(&networkCheckCmd)
+	rootCmd.AddSubCommand(&proxyConfigCmd)
+	rootCmd.AddSubCommand(&statusCmd)
+	rootCmd.AddSubCommand(&logsCmd)
+	rootCmd.AddSubCommand(&logLevelCmd)
 
 	rootCmd.Execute(ctx, os.Args[1:])
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rodaine/table"
+
+	"github.com/aliyun/aliyun_assist_client/agent/checknet"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/cli"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/i18n"
+)
+
+var (
+	networkCheckFlags = []cli.Flag{
+		{
+			Name:         JsonFlagName,
+			Short:        i18n.T(`print diagnostic report in JSON format`, `以JSON格式打印诊断报告`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+		{
+			Name:      TimeoutFlagName,
+			Shorthand: 't',
+			Short: i18n.T(`timeout of the whole diagnostic, e.g., 30s or 2m. Default: 3m`,
+				`指定整个诊断过程的超时时间，如 30s 或者 2m。默认为 3m`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+	}
+
+	networkCheckCmd = cli.Command{
+		Name:              "network-check",
+		Short:             i18n.T("Diagnose network connectivity to the Cloud Assistant server", "诊断到云助手服务端的网络连通性"),
+		Usage:             "network-check [flags]",
+		Sample:            "",
+		EnableUnknownFlag: false,
+		Run:               runNetworkCheckCmd,
+	}
+)
+
+func init() {
+	for j := range networkCheckFlags {
+		networkCheckCmd.Flags().Add(&networkCheckFlags[j])
+	}
+}
+
+func runNetworkCheckCmd(ctx *cli.Context, args []string) error {
+	// Extract value of persistent flags
+	logPath, _ := ctx.Flags().Get(LogPathFlagName).GetValue()
+	// Extract value of flags just for the command
+	useJsonFormat := ctx.Flags().Get(JsonFlagName).IsAssigned()
+	timeout := 3 * time.Minute
+	if value, assigned := ctx.Flags().Get(TimeoutFlagName).GetValue(); assigned {
+		var err error
+		timeout, err = time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.InitLog("aliyun_assist_main.log", logPath, true)
+
+	diagnoseCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	report := checknet.Diagnose(diagnoseCtx)
+
+	if useJsonFormat {
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonBytes))
+	} else {
+		tbl := table.New("Step", "Status", "Elapsed", "Detail")
+		for _, step := range report.Steps {
+			detail := step.Detail
+			if step.Error != "" {
+				detail = step.Error
+			}
+			tbl.AddRow(step.Name, step.Status, fmt.Sprintf("%dms", step.ElapsedMs), detail)
+		}
+		tbl.Print()
+	}
+
+	if report.Result != checknet.ResultOK {
+		if !useJsonFormat {
+			fmt.Printf("Network diagnostic failed at step %s, diagnostic code %d\n", report.FailedStep(), report.DiagnosticCode)
+		}
+		os.Exit(1)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/cli"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/i18n"
+
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
+)
+
+const (
+	HTTPProxyFlagName     = "http-proxy"
+	HTTPSProxyFlagName    = "https-proxy"
+	NoProxyFlagName       = "no-proxy"
+	ProxyUsernameFlagName = "username"
+	PasswordStdinFlagName = "password-stdin"
+	ClearFlagName         = "clear"
+)
+
+var (
+	proxyConfigFlags = []cli.Flag{
+		{
+			Name:         HTTPProxyFlagName,
+			Short:        i18n.T(`proxy URL for HTTP requests, e.g., http://proxy.example.com:3128`, `HTTP 请求使用的代理地址，如 http://proxy.example.com:3128`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         HTTPSProxyFlagName,
+			Short:        i18n.T(`proxy URL for HTTPS and websocket requests`, `HTTPS 及 websocket 请求使用的代理地址`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         NoProxyFlagName,
+			Short:        i18n.T(`comma-separated hosts, domain suffixes, IP addresses and CIDRs accessed directly, e.g., .internal,10.0.0.0/8`, `以逗号分隔的直接访问的主机、域名后缀、IP 地址及 CIDR，如 .internal,10.0.0.0/8`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         ProxyUsernameFlagName,
+			Short:        i18n.T(`username for basic authentication of proxies`, `代理 Basic 认证的用户名`),
+			AssignedMode: cli.AssignedOnce,
+			Category:     "caller",
+		},
+		{
+			Name:         PasswordStdinFlagName,
+			Short:        i18n.T(`read password for basic authentication of proxies from stdin, which is stored obfuscated in a file readable only by the owner`, `从标准输入读取代理 Basic 认证的密码，密码将被混淆后保存在仅所有者可读的文件中`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+		{
+			Name:         ClearFlagName,
+			Short:        i18n.T(`remove the proxy config file`, `删除代理配置文件`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+	}
+
+	proxyConfigCmd = cli.Command{
+		Name:              "proxy-config",
+		Short:             i18n.T("Show or set proxies used by the agent, which take effect after restarting the agent", "查看或设置云助手使用的代理，重启云助手后生效"),
+		Usage:             "proxy-config [flags]",
+		Sample:            "",
+		EnableUnknownFlag: false,
+		Run:               runProxyConfigCmd,
+	}
+)
+
+func init() {
+	for j := range proxyConfigFlags {
+		proxyConfigCmd.Flags().Add(&proxyConfigFlags[j])
+	}
+}
+
+func runProxyConfigCmd(ctx *cli.Context, args []string) error {
+	// Extract value of persistent flags
+	logPath, _ := ctx.Flags().Get(LogPathFlagName).GetValue()
+	log.InitLog("aliyun_assist_main.log", logPath, true)
+
+	if ctx.Flags().Get(ClearFlagName).IsAssigned() {
+		return requester.SaveProxyConfigFile(&requester.ProxyConfig{})
+	}
+
+	config, err := requester.LoadProxyConfigFile()
+	if err != nil {
+		return err
+	}
+	modified := false
+	for flagName, field := range map[string]*string{
+		HTTPProxyFlagName:     &config.HTTPProxy,
+		HTTPSProxyFlagName:    &config.HTTPSProxy,
+		NoProxyFlagName:       &config.NoProxy,
+		ProxyUsernameFlagName: &config.Username,
+	} {
+		if value, assigned := ctx.Flags().Get(flagName).GetValue(); assigned {
+			*field = value
+			modified = true
+		}
+	}
+	if ctx.Flags().Get(PasswordStdinFlagName).IsAssigned() {
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && password == "" {
+			return fmt.Errorf("Failed to read password from stdin: %w", err)
+		}
+		config.ObfuscatedPassword, err = requester.ObfuscateProxyPassword(strings.TrimRight(password, "\r\n"))
+		if err != nil {
+			return err
+		}
+		modified = true
+	}
+	if modified {
+		return requester.SaveProxyConfigFile(config)
+	}
+
+	// Show config file without obfuscated password
+	if config.ObfuscatedPassword != "" {
+		config.ObfuscatedPassword = "******"
+	}
+	jsonBytes, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsonBytes))
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -18,6 +19,7 @@ import (
 	"github.com/aliyun/aliyun_assist_client/agent/channel"
 	"github.com/aliyun/aliyun_assist_client/agent/checkagentpanic"
 	"github.com/aliyun/aliyun_assist_client/agent/checkkdump"
+	"github.com/aliyun/aliyun_assist_client/agent/checknet"
 	"github.com/aliyun/aliyun_assist_client/agent/checkospanic"
 	"github.com/aliyun/aliyun_assist_client/agent/checkvirt"
 	"github.com/aliyun/aliyun_assist_client/agent/clientreport"
@@ -31,6 +33,7 @@ import (
 	"github.com/aliyun/aliyun_assist_client/agent/metrics"
 	"github.com/aliyun/aliyun_assist_client/agent/perfmon"
 	"github.com/aliyun/aliyun_assist_client/agent/pluginmanager"
+	"github.com/aliyun/aliyun_assist_client/agent/relay"
 	"github.com/aliyun/aliyun_assist_client/agent/statemanager"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine"
 	"github.com/aliyun/aliyun_assist_client/agent/taskengine/timermanager"
@@ -41,6 +44,7 @@ import (
 	"github.com/aliyun/aliyun_assist_client/agent/util/wrapgo"
 	"github.com/aliyun/aliyun_assist_client/agent/version"
 	"github.com/aliyun/aliyun_assist_client/common/pathutil"
+	"github.com/aliyun/aliyun_assist_client/common/requester"
 )
 
 type Options struct {
@@ -279,6 +283,16 @@ func (p *program) run() {
 		log.GetLogger().WithError(err).Errorln("Failed to obtain current working directory")
 	}
 
+	// Certificate pin mismatch is reported explicitly instead of being
+	// regarded as generic connection failure. Metrics could never reach the
+	// mismatched API server, thus the mismatch is carried by network
+	// diagnostic report in reply of gshell channel, which does not depend on
+	// the network.
+	requester.SetPinMismatchHandler(func(err *requester.PinMismatchError) {
+		log.GetLogger().WithError(err).Errorln("Certificate of API server does not match pinned public keys")
+		checknet.RequestNetcheck(checknet.NetcheckRequestForceOnce)
+	})
+
 	sleep_internals_seconds := 3
 	for {
 		host := util.GetServerHost()
@@ -339,6 +353,11 @@ func (p *program) run() {
 
 	channel.StartChannelMgr()
 
+	// Serve downstream agents without internet access when configured as relay
+	if err := relay.Start(); err != nil && !errors.Is(err, relay.ErrNotConfigured) {
+		log.GetLogger().WithError(err).Errorln("Failed to start relay server")
+	}
+
 	if err := heartbeat.InitHeartbeatTimer(); err != nil {
 		log.GetLogger().Fatalln("Failed to initialize heartbeat: " + err.Error())
 		return
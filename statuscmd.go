@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rodaine/table"
+
+	"github.com/aliyun/aliyun_assist_client/agent/ipc/client"
+	"github.com/aliyun/aliyun_assist_client/agent/log"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/cli"
+	"github.com/aliyun/aliyun_assist_client/thirdparty/aliyun-cli/i18n"
+)
+
+var (
+	statusFlags = []cli.Flag{
+		{
+			Name:         JsonFlagName,
+			Short:        i18n.T(`print agent status in JSON format`, `以JSON格式打印Agent状态`),
+			AssignedMode: cli.AssignedNone,
+			Category:     "caller",
+		},
+	}
+
+	statusCmd = cli.Command{
+		Name:              "status",
+		Short:             i18n.T("Show runtime status of the running agent", "显示正在运行的Agent的运行时状态"),
+		Usage:             "status [flags]",
+		Sample:            "",
+		EnableUnknownFlag: false,
+		Run:               runStatusCmd,
+	}
+)
+
+func init() {
+	for j := range statusFlags {
+		statusCmd.Flags().Add(&statusFlags[j])
+	}
+}
+
+func formatTimestamp(timestamp int64) string {
+	if timestamp == 0 {
+		return "-"
+	}
+	return time.Unix(timestamp, 0).Format("2006-01-02 15:04:05")
+}
+
+func formatSeconds(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func runStatusCmd(ctx *cli.Context, args []string) error {
+	// Extract value of persistent flags
+	logPath, _ := ctx.Flags().Get(LogPathFlagName).GetValue()
+	// Extract value of flags just for the command
+	useJsonFormat := ctx.Flags().Get(JsonFlagName).IsAssigned()
+
+	log.InitLog("aliyun_assist_main.log", logPath, true)
+
+	status, err := client.GetAgentStatus()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to get status of agent:", err)
+		os.Exit(1)
+	}
+
+	if useJsonFormat {
+		jsonBytes, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	heartbeatResult := "-"
+	if status.Heartbeat.LastTimestamp != 0 {
+		heartbeatResult = "succeeded"
+		if !status.Heartbeat.Succeeded {
+			heartbeatResult = "failed: " + status.Heartbeat.ErrMessage
+		}
+	}
+	channelHealth := "working"
+	if !status.Channel.Working {
+		channelHealth = "not working"
+	}
+	fmt.Printf("Version:        %s\n", status.Version)
+	fmt.Printf("PID:            %d\n", status.Pid)
+	fmt.Printf("Started:        %s (up %s)\n", formatTimestamp(status.StartTimestamp), formatSeconds(status.UptimeSeconds))
+	fmt.Printf("Channel:        %s (%s)\n", status.Channel.ChannelType, channelHealth)
+	fmt.Printf("Last heartbeat: %s %s\n", formatTimestamp(status.Heartbeat.LastTimestamp), heartbeatResult)
+
+	fmt.Println("\nInvocations:")
+	tbl := table.New("TaskId", "CommandName", "Repeat", "State", "Elapsed")
+	for _, invocation := range status.Invocations {
+		elapsed := "-"
+		if invocation.StartTimestamp != 0 {
+			elapsed = formatSeconds(invocation.ElapsedSeconds)
+		}
+		tbl.AddRow(invocation.TaskId, invocation.CommandName, invocation.Repeat, invocation.State, elapsed)
+	}
+	tbl.Print()
+
+	fmt.Println("\nPeriodic tasks:")
+	tbl = table.New("TaskId", "CommandName", "Repeat", "Expression", "Running", "LastRun", "NextRun")
+	for _, periodicTask := range status.PeriodicTasks {
+		tbl.AddRow(periodicTask.TaskId, periodicTask.CommandName, periodicTask.Repeat, periodicTask.Expression,
+			periodicTask.Running, formatTimestamp(periodicTask.LastRunTimestamp), formatTimestamp(periodicTask.NextRunTimestamp))
+	}
+	tbl.Print()
+
+	fmt.Println("\nSessions:")
+	tbl = table.New("SessionId", "TaskId", "Username", "Target", "Elapsed")
+	for _, session := range status.Sessions {
+		target := session.ContainerId + session.ContainerName
+		if session.PortNumber != "" {
+			target = fmt.Sprintf("%s:%s", session.TargetHost, session.PortNumber)
+		}
+		tbl.AddRow(session.SessionId, session.TaskId, session.Username, target, formatSeconds(session.ElapsedSeconds))
+	}
+	tbl.Print()
+
+	fmt.Println("\nPlugins:")
+	tbl = table.New("Name", "Version", "Type", "Status", "CheckTime")
+	for _, plugin := range status.Plugins {
+		pluginStatus := plugin.Status
+		if pluginStatus == "" {
+			pluginStatus = "-"
+		}
+		tbl.AddRow(plugin.Name, plugin.Version, plugin.PluginType, pluginStatus, formatTimestamp(plugin.CheckTimestamp))
+	}
+	tbl.Print()
+
+	fmt.Println("\nState configurations:")
+	tbl = table.New("StateConfigurationId", "Template", "Mode", "Schedule", "LastStatus", "LastEnforce", "NextRun")
+	for _, stateConfig := range status.StateConfigs {
+		lastStatus := stateConfig.LastStatus
+		if lastStatus == "" {
+			lastStatus = "-"
+		}
+		tbl.AddRow(stateConfig.StateConfigurationId,
+			fmt.Sprintf("%s:%s", stateConfig.TemplateName, stateConfig.TemplateVersion),
+			stateConfig.ConfigureMode,
+			fmt.Sprintf("%s(%s)", stateConfig.ScheduleType, stateConfig.ScheduleExpression),
+			lastStatus, formatTimestamp(stateConfig.LastEnforceTimestamp), formatTimestamp(stateConfig.NextRunTimestamp))
+	}
+	tbl.Print()
+	return nil
+}